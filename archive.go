@@ -0,0 +1,185 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/Golem-Base/bridgette/pkg/archive"
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/urfave/cli/v2"
+)
+
+// archiveFlag selects the archive directory, holding one archive per chain
+// in its l1 and l2 subdirectories
+func archiveFlag(dir *string) cli.Flag {
+	return &cli.StringFlag{
+		Name:        "archive",
+		Usage:       "The archive directory, holding the recorded logs and headers of each chain in its l1 and l2 subdirectories",
+		EnvVars:     []string{"ARCHIVE"},
+		Required:    true,
+		Destination: dir,
+	}
+}
+
+// recordCommand dumps the logs and headers of a block range to an archive
+func recordCommand(log *slog.Logger) *cli.Command {
+	cfg := &config{}
+	var dir, chainName string
+	var fromBlock, toBlock uint64
+
+	return &cli.Command{
+		Name:  "record",
+		Usage: "Record the bridge and source events of a block range and the headers of their blocks to an archive",
+		Flags: append(flags(cfg.chainFlags()),
+			cfg.backfillingBatchSizeFlag(),
+			cfg.eventsConfigFlag(),
+			archiveFlag(&dir),
+			&cli.StringFlag{
+				Name:        "chain",
+				Usage:       "The chain to record: l1 or l2",
+				Required:    true,
+				Destination: &chainName,
+			},
+			&cli.Uint64Flag{
+				Name:        "from",
+				Usage:       "The first block to record",
+				Required:    true,
+				Destination: &fromBlock,
+			},
+			&cli.Uint64Flag{
+				Name:        "to",
+				Usage:       "The last block to record",
+				Required:    true,
+				Destination: &toBlock,
+			},
+		),
+		Action: func(c *cli.Context) error {
+			if chainName != registry.L1 && chainName != registry.L2 {
+				return fmt.Errorf("unknown chain %q, expected l1 or l2", chainName)
+			}
+			if fromBlock > toBlock {
+				return fmt.Errorf("--from must not be after --to")
+			}
+			if cfg.backfillingBatchSize == 0 {
+				return fmt.Errorf("--backfilling-batch-size must be positive")
+			}
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			// Only the contracts of the indexer are used, nothing is stored
+			ix, err := newBridgeIndexer(cfg, nil, nil, nil, events.NewBus(), log)
+			if err != nil {
+				return err
+			}
+			var contracts []registry.History
+			for _, ch := range ix.chainsOn(chainName) {
+				contracts = append(contracts, ch.Contracts)
+			}
+
+			var client *tracing.EthClient
+			var closeClient func()
+			if chainName == registry.L1 {
+				client, closeClient, err = cfg.dialL1()
+			} else {
+				client, closeClient, err = cfg.dialL2()
+			}
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			recorded, err := archive.Record(ctx, client, filepath.Join(dir, chainName), contracts, fromBlock, toBlock, cfg.backfillingBatchSize, log.With("chain", chainName))
+			if err != nil {
+				return fmt.Errorf("failed to record %s blocks: %w", chainName, err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "recorded %d logs of %s blocks %d to %d\n", recorded, chainName, fromBlock, toBlock)
+			return nil
+		},
+	}
+}
+
+// replayCommand indexes the archived chains instead of reading from nodes
+func replayCommand(log *slog.Logger) *cli.Command {
+	cfg := &config{}
+	var dir string
+
+	return &cli.Command{
+		Name:  "replay",
+		Usage: "Index the events recorded in an archive instead of reading them from the execution layers, then match them",
+		Flags: append(flags(cfg.dbFlags(), cfg.chainFlags(), cfg.indexFlags(), cfg.tracingFlags()),
+			archiveFlag(&dir),
+		),
+		Action: func(c *cli.Context) error {
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			shutdownTracing, err := cfg.setupTracing(ctx)
+			if err != nil {
+				return err
+			}
+			defer shutdownTracingOrLog(shutdownTracing, log)
+
+			db, err := cfg.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			ix, err := newBridgeIndexer(cfg, db, nil, nil, events.NewBus(), log)
+			if err != nil {
+				return err
+			}
+
+			// Each chain with an archive is indexed from it, from the first
+			// archived block on
+			var chains []*indexer.Chain
+			var replayed []string
+			for _, chainName := range []string{registry.L1, registry.L2} {
+				replay, err := archive.Open(filepath.Join(dir, chainName))
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("failed to open the %s archive: %w", chainName, err)
+				}
+				m := replay.Manifest()
+				for _, ch := range ix.chainsOn(chainName) {
+					ch.Source = replay
+					ch.StartBlock = max(ch.StartBlock, m.FromBlock)
+					chains = append(chains, ch)
+				}
+				replayed = append(replayed, fmt.Sprintf("%s blocks %d to %d", chainName, m.FromBlock, m.ToBlock))
+			}
+			if len(chains) == 0 {
+				return fmt.Errorf("no l1 or l2 archive found in %s", dir)
+			}
+
+			err = ix.runner.CatchUp(ctx, chains...)
+			if err != nil {
+				return fmt.Errorf("failed to replay the archive: %w", err)
+			}
+
+			result, err := ix.matcher.MatchPending(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to match replayed events: %w", err)
+			}
+
+			for _, r := range replayed {
+				fmt.Fprintf(c.App.Writer, "replayed %s\n", r)
+			}
+			fmt.Fprintf(c.App.Writer, "%d matched\n", result.Matches)
+			return nil
+		},
+	}
+}
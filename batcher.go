@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Golem-Base/bridgette/pkg/batcher"
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// batcherBatchSize bounds the L1 blocks fetched with their transactions per check
+	batcherBatchSize = 100
+	// batcherStartBlocks starts a fresh database about an hour of L1 blocks back
+	batcherStartBlocks = 300
+)
+
+// batcherMonitor returns the batcher monitor configured by the batcher flags,
+// or nil when it is disabled. The returned function closes the connection to
+// the rollup node.
+func (cfg *config) batcherMonitor(ctx context.Context, db *sql.DB, l1Client *tracing.EthClient, bus *events.Bus, log *slog.Logger) (*batcher.Monitor, func(), error) {
+	if cfg.batcherInterval <= 0 {
+		return nil, func() {}, nil
+	}
+	if !common.IsHexAddress(cfg.l1SystemConfigAddr) {
+		return nil, nil, fmt.Errorf("--l1-system-config-address is required by the batcher monitor")
+	}
+
+	var rollup batcher.RollupClient
+	closeRollup := func() {}
+	if cfg.rollupURL != "" {
+		client, err := rpc.DialContext(ctx, cfg.rollupURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dial rollup node: %w", err)
+		}
+		rollup = batcher.NewRollupClient(client)
+		closeRollup = client.Close
+	}
+
+	return batcher.New(db, l1Client, rollup, batcher.Config{
+		SystemConfig: common.HexToAddress(cfg.l1SystemConfigAddr),
+		BatchSize:    batcherBatchSize,
+		StartBlocks:  batcherStartBlocks,
+	}, bus, log.With("component", "batcher")), closeRollup, nil
+}
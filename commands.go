@@ -0,0 +1,699 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/Golem-Base/bridgette/pkg/latency"
+	"github.com/Golem-Base/bridgette/pkg/liveness"
+	"github.com/Golem-Base/bridgette/pkg/matcher"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/Golem-Base/bridgette/pkg/webui"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+// runAction runs the default command: the indexer and the web UI in one
+// process, sharing the event bus for live updates
+func runAction(cfg *config, log *slog.Logger) cli.ActionFunc {
+	return func(c *cli.Context) error {
+
+		ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+		defer stop()
+
+		shutdownTracing, err := cfg.setupTracing(ctx)
+		if err != nil {
+			return err
+		}
+		defer shutdownTracingOrLog(shutdownTracing, log)
+
+		db, err := cfg.openDB()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		log.Info("database opened", "url", cfg.dbURL)
+
+		l1Client, closeL1, err := cfg.dialL1()
+		if err != nil {
+			return err
+		}
+		defer closeL1()
+
+		l2Client, closeL2, err := cfg.dialL2()
+		if err != nil {
+			return err
+		}
+		defer closeL2()
+
+		log := log.With("l1_bridge_address", cfg.l1BridgeAddress)
+
+		// The web UI subscribes to this to push updates after every committed batch
+		bus := events.NewBus()
+
+		eg, egCtx := errgroup.WithContext(ctx)
+
+		ix, err := newBridgeIndexer(cfg, db, l1Client, l2Client, bus, log)
+		if err != nil {
+			return err
+		}
+		eg.Go(func() error {
+			return ix.run(egCtx)
+		})
+		closeMonitors, err := cfg.startMonitors(egCtx, eg, ix, l1Client, l2Client, bus, log)
+		if err != nil {
+			return err
+		}
+		defer closeMonitors()
+
+		webServer := webui.NewServer(db, bus, log.With("component", "webui"), cfg.webUIAddr, cfg.pathPrefix)
+		eg.Go(func() error {
+			return webServer.Start(egCtx)
+		})
+
+		return eg.Wait()
+	}
+}
+
+// indexCommand runs the indexer without the web UI
+func indexCommand(log *slog.Logger) *cli.Command {
+	cfg := &config{}
+
+	return &cli.Command{
+		Name:  "index",
+		Usage: "Run the indexer only",
+		Flags: flags(cfg.dbFlags(), cfg.chainFlags(), cfg.indexFlags(), cfg.verifyFlags(), cfg.solvencyFlags(), cfg.latencyFlags(), cfg.livenessFlags(), cfg.batcherFlags(), cfg.disputeGameFlags(), cfg.securityFlags(), cfg.tracingFlags()),
+		Action: func(c *cli.Context) error {
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			shutdownTracing, err := cfg.setupTracing(ctx)
+			if err != nil {
+				return err
+			}
+			defer shutdownTracingOrLog(shutdownTracing, log)
+
+			db, err := cfg.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			log.Info("database opened", "url", cfg.dbURL)
+
+			l1Client, closeL1, err := cfg.dialL1()
+			if err != nil {
+				return err
+			}
+			defer closeL1()
+
+			l2Client, closeL2, err := cfg.dialL2()
+			if err != nil {
+				return err
+			}
+			defer closeL2()
+
+			log := log.With("l1_bridge_address", cfg.l1BridgeAddress)
+
+			// Nobody subscribes in this process, a web UI started with the web
+			// command picks changes up from the database
+			bus := events.NewBus()
+			ix, err := newBridgeIndexer(cfg, db, l1Client, l2Client, bus, log)
+			if err != nil {
+				return err
+			}
+			eg, egCtx := errgroup.WithContext(ctx)
+			eg.Go(func() error {
+				return ix.run(egCtx)
+			})
+			closeMonitors, err := cfg.startMonitors(egCtx, eg, ix, l1Client, l2Client, bus, log)
+			if err != nil {
+				return err
+			}
+			defer closeMonitors()
+			return eg.Wait()
+		},
+	}
+}
+
+// startMonitors starts the periodic verification and the enabled monitors
+// in eg next to the indexer. The returned function closes the rollup node
+// client of the batcher monitor.
+func (cfg *config) startMonitors(ctx context.Context, eg *errgroup.Group, ix *bridgeIndexer, l1Client, l2Client *tracing.EthClient, bus *events.Bus, log *slog.Logger) (func(), error) {
+	solvencyMonitor, err := cfg.solvencyMonitor(ix, l1Client, bus, log)
+	if err != nil {
+		return nil, err
+	}
+	disputeGameMonitor, err := cfg.disputeGameMonitor(ix.db, l1Client, l2Client, bus, log)
+	if err != nil {
+		return nil, err
+	}
+	securityMonitor, err := cfg.securityMonitor(ix.db, l1Client, bus, log)
+	if err != nil {
+		return nil, err
+	}
+	batcherMonitor, closeRollup, err := cfg.batcherMonitor(ctx, ix.db, l1Client, bus, log)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.verifyInterval > 0 {
+		eg.Go(func() error {
+			return ix.verifyPeriodically(ctx, cfg.verifyInterval, cfg.verifySamples)
+		})
+	}
+	if solvencyMonitor != nil {
+		eg.Go(func() error {
+			return solvencyMonitor.Run(ctx, cfg.solvencyInterval)
+		})
+	}
+	if cfg.latencyInterval > 0 {
+		eg.Go(func() error {
+			return latency.New(ix.db, l1Client, l2Client, log.With("component", "latency")).Run(ctx, cfg.latencyInterval)
+		})
+	}
+	if cfg.livenessInterval > 0 {
+		eg.Go(func() error {
+			return liveness.New(ix.db, l1Client, l2Client, cfg.livenessConfig(), bus, log.With("component", "liveness")).Run(ctx, cfg.livenessInterval)
+		})
+	}
+	if batcherMonitor != nil {
+		eg.Go(func() error {
+			return batcherMonitor.Run(ctx, cfg.batcherInterval)
+		})
+	}
+	if disputeGameMonitor != nil {
+		eg.Go(func() error {
+			return disputeGameMonitor.Run(ctx, cfg.disputeGameInterval)
+		})
+	}
+	if securityMonitor != nil {
+		eg.Go(func() error {
+			return securityMonitor.Run(ctx, cfg.securityInterval)
+		})
+	}
+	return closeRollup, nil
+}
+
+// webCommand serves the web UI from a database written by an index command
+func webCommand(log *slog.Logger) *cli.Command {
+	cfg := &config{}
+	var pollInterval time.Duration
+
+	return &cli.Command{
+		Name:  "web",
+		Usage: "Run the web UI only, reading the database written by the index command",
+		Flags: append(flags(cfg.dbFlags(), cfg.webFlags(), cfg.tracingFlags()),
+			&cli.DurationFlag{
+				Name:        "poll-interval",
+				Usage:       "How often the database is checked for changes to push to the dashboard",
+				Value:       time.Second * 2,
+				EnvVars:     []string{"POLL_INTERVAL"},
+				Destination: &pollInterval,
+			},
+		),
+		Action: func(c *cli.Context) error {
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			shutdownTracing, err := cfg.setupTracing(ctx)
+			if err != nil {
+				return err
+			}
+			defer shutdownTracingOrLog(shutdownTracing, log)
+
+			db, err := cfg.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			log.Info("database opened", "url", cfg.dbURL)
+
+			webServer := webui.NewServer(db, events.NewBus(), log.With("component", "webui"), cfg.webUIAddr, cfg.pathPrefix)
+
+			eg, egCtx := errgroup.WithContext(ctx)
+			eg.Go(func() error {
+				return webServer.Start(egCtx)
+			})
+			eg.Go(func() error {
+				return webServer.WatchDatabase(egCtx, pollInterval)
+			})
+			return eg.Wait()
+		},
+	}
+}
+
+// migrateCommand applies, reverts and reports schema migrations
+func migrateCommand() *cli.Command {
+	cfg := &config{}
+	var steps int
+	var all bool
+
+	return &cli.Command{
+		Name:  "migrate",
+		Usage: "Manage the database schema",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "up",
+				Usage: "Apply all pending migrations",
+				Flags: cfg.dbFlags(),
+				Action: func(c *cli.Context) error {
+					db, err := cfg.connectDB()
+					if err != nil {
+						return err
+					}
+					defer db.Close()
+
+					err = sqlitestore.Migrate(db)
+					if err != nil {
+						return fmt.Errorf("failed to migrate database: %w", err)
+					}
+					return printMigrationVersion(c, db)
+				},
+			},
+			{
+				Name:  "down",
+				Usage: "Revert the latest migrations",
+				Flags: append(cfg.dbFlags(),
+					&cli.IntFlag{
+						Name:        "steps",
+						Usage:       "The number of migrations to revert",
+						Value:       1,
+						Destination: &steps,
+					},
+					&cli.BoolFlag{
+						Name:        "all",
+						Usage:       "Revert every migration, dropping all data",
+						Destination: &all,
+					},
+				),
+				Action: func(c *cli.Context) error {
+					if !all && steps <= 0 {
+						return fmt.Errorf("--steps must be positive, use --all to revert every migration")
+					}
+					if all {
+						steps = 0
+					}
+
+					db, err := cfg.connectDB()
+					if err != nil {
+						return err
+					}
+					defer db.Close()
+
+					err = sqlitestore.MigrateDown(db, steps)
+					if err != nil {
+						return fmt.Errorf("failed to revert migrations: %w", err)
+					}
+					return printMigrationVersion(c, db)
+				},
+			},
+			{
+				Name:  "version",
+				Usage: "Print the current schema version",
+				Flags: cfg.dbFlags(),
+				Action: func(c *cli.Context) error {
+					db, err := cfg.connectDB()
+					if err != nil {
+						return err
+					}
+					defer db.Close()
+
+					return printMigrationVersion(c, db)
+				},
+			},
+		},
+	}
+}
+
+// printMigrationVersion prints the schema version of the database
+func printMigrationVersion(c *cli.Context, db *sql.DB) error {
+	version, dirty, err := sqlitestore.MigrationVersion(db)
+	if err != nil {
+		return fmt.Errorf("failed to get migration version: %w", err)
+	}
+	if dirty {
+		fmt.Fprintf(c.App.Writer, "version %d (dirty)\n", version)
+		return nil
+	}
+	fmt.Fprintf(c.App.Writer, "version %d\n", version)
+	return nil
+}
+
+// reindexCommand deletes and ingests again the events of a block range
+func reindexCommand(log *slog.Logger) *cli.Command {
+	cfg := &config{}
+	var chainName string
+	var fromBlock, toBlock uint64
+
+	return &cli.Command{
+		Name:  "reindex",
+		Usage: "Delete and ingest again the bridge or source events of a block range",
+		Flags: append(flags(cfg.dbFlags(), cfg.chainFlags(), cfg.tracingFlags()),
+			cfg.backfillingBatchSizeFlag(),
+			cfg.eventsConfigFlag(),
+			&cli.StringFlag{
+				Name:        "chain",
+				Usage:       "The chain to reindex: l1, l2 or the name of an event source of --events-config",
+				Required:    true,
+				Destination: &chainName,
+			},
+			&cli.Uint64Flag{
+				Name:        "from",
+				Usage:       "The first block to reindex",
+				Required:    true,
+				Destination: &fromBlock,
+			},
+			&cli.Uint64Flag{
+				Name:        "to",
+				Usage:       "The last block to reindex",
+				Required:    true,
+				Destination: &toBlock,
+			},
+		),
+		Action: func(c *cli.Context) error {
+			if fromBlock > toBlock {
+				return fmt.Errorf("--from must not be after --to")
+			}
+			if cfg.backfillingBatchSize == 0 {
+				return fmt.Errorf("--backfilling-batch-size must be positive")
+			}
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			shutdownTracing, err := cfg.setupTracing(ctx)
+			if err != nil {
+				return err
+			}
+			defer shutdownTracingOrLog(shutdownTracing, log)
+
+			db, err := cfg.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			// Only the reindexed chain is dialled
+			ix, err := newBridgeIndexer(cfg, db, nil, nil, events.NewBus(), log)
+			if err != nil {
+				return err
+			}
+			ch, err := ix.chain(chainName)
+			if err != nil {
+				return err
+			}
+			var closeClient func()
+			if ch.Contracts[0].Chain == registry.L1 {
+				ch.Source, closeClient, err = cfg.dialL1()
+			} else {
+				ch.Source, closeClient, err = cfg.dialL2()
+			}
+			if err != nil {
+				return err
+			}
+			defer closeClient()
+
+			// Blocks outside of the indexed range would be indexed again by
+			// the backfilling and forward filling loops
+			err = checkIndexedRange(ctx, ix.store, ch, fromBlock, toBlock)
+			if err != nil {
+				return err
+			}
+
+			deleted, inserted, err := ix.runner.Reindex(ctx, ch, fromBlock, toBlock)
+			if err != nil {
+				return fmt.Errorf("failed to reindex blocks: %w", err)
+			}
+
+			result, err := ix.matcher.MatchPending(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to match reindexed events: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "reindexed %s blocks %d to %d: %d events deleted, %d inserted, %d matched\n", ch.Name, fromBlock, toBlock, deleted, inserted, result.Matches)
+			return nil
+		},
+	}
+}
+
+// checkIndexedRange returns an error unless the block range has already been indexed
+func checkIndexedRange(ctx context.Context, store *sqlitestore.Queries, ch *indexer.Chain, fromBlock, toBlock uint64) error {
+	low, err := store.GetBlockPointer(ctx, ch.LowPointer)
+	if err != nil {
+		return fmt.Errorf("failed to get lowest processed block: %w", err)
+	}
+	last, err := store.GetBlockPointer(ctx, ch.LastPointer)
+	if err != nil {
+		return fmt.Errorf("failed to get last processed block: %w", err)
+	}
+	if low.BlockNumber == nil || last.BlockNumber == nil {
+		return fmt.Errorf("%s has not been indexed yet", ch.Name)
+	}
+	if fromBlock < uint64(*low.BlockNumber) || toBlock > uint64(*last.BlockNumber) {
+		return fmt.Errorf("blocks %d to %d are outside of the indexed range %d to %d", fromBlock, toBlock, *low.BlockNumber, *last.BlockNumber)
+	}
+	return nil
+}
+
+// rematchCommand recomputes every match from the stored events
+func rematchCommand(log *slog.Logger) *cli.Command {
+	cfg := &config{}
+
+	return &cli.Command{
+		Name:  "rematch",
+		Usage: "Clear all matches and compute them again with the matching engine",
+		Flags: cfg.dbFlags(),
+		Action: func(c *cli.Context) error {
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			db, err := cfg.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			result, err := matcher.New(db, nil, log).Rematch(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to rematch deposits: %w", err)
+			}
+
+			fmt.Fprintf(c.App.Writer, "matched %d deposits, %d of them among several candidates\n", result.Matches, result.Ambiguous)
+			return nil
+		},
+	}
+}
+
+// pointerStatus is a block pointer in the status report
+type pointerStatus struct {
+	Name        string     `json:"name"`
+	BlockNumber *int64     `json:"block_number"`
+	BlockTime   *time.Time `json:"block_time"`
+	AgeSeconds  *int64     `json:"age_seconds"`
+}
+
+// chainStatus compares the last indexed block of a chain with its head
+type chainStatus struct {
+	Chain     string `json:"chain"`
+	LastBlock *int64 `json:"last_block"`
+	HeadBlock uint64 `json:"head_block"`
+	LagBlocks *int64 `json:"lag_blocks"`
+}
+
+// statusReport is printed by the status command
+type statusReport struct {
+	Schema struct {
+		Version           uint `json:"version"`
+		PendingMigrations int  `json:"pending_migrations"`
+	} `json:"schema"`
+	Pointers []pointerStatus `json:"pointers"`
+	Counts   struct {
+		L1Deposits               int64 `json:"l1_deposits"`
+		MatchedDeposits          int64 `json:"matched_deposits"`
+		PendingDeposits          int64 `json:"pending_deposits"`
+		L2Finalizations          int64 `json:"l2_finalizations"`
+		UnmatchedL2Finalizations int64 `json:"unmatched_l2_finalizations"`
+	} `json:"counts"`
+	Chains []chainStatus `json:"chains"`
+}
+
+// statusCommand prints the indexing progress
+func statusCommand() *cli.Command {
+	cfg := &config{}
+	var asJSON bool
+
+	return &cli.Command{
+		Name:  "status",
+		Usage: "Print the block pointers, deposit counts and, when the execution URLs are set, the lag behind each head",
+		Flags: append(flags(cfg.dbFlags(), cfg.chainFlags()),
+			&cli.BoolFlag{
+				Name:        "json",
+				Usage:       "Print the status as JSON",
+				Destination: &asJSON,
+			},
+		),
+		Action: func(c *cli.Context) error {
+			ctx := c.Context
+
+			// The status never migrates, the schema may belong to another
+			// version of the indexer
+			db, err := cfg.connectDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			store := sqlitestore.New(db)
+
+			var report statusReport
+			now := time.Now()
+
+			report.Schema.Version, report.Schema.PendingMigrations, err = sqlitestore.PendingMigrations(db)
+			if err != nil {
+				return err
+			}
+			if report.Schema.PendingMigrations > 0 {
+				// The queries below expect the latest schema
+				return writeStatus(c, report, asJSON)
+			}
+
+			pointers, err := store.ListBlockPointers(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list block pointers: %w", err)
+			}
+			lastBlocks := make(map[string]*int64)
+			for _, p := range pointers {
+				ps := pointerStatus{Name: p.Name, BlockNumber: p.BlockNumber}
+				if p.BlockTime != nil {
+					t := time.Unix(*p.BlockTime, 0).UTC()
+					age := int64(now.Sub(t).Seconds())
+					ps.BlockTime, ps.AgeSeconds = &t, &age
+				}
+				report.Pointers = append(report.Pointers, ps)
+				lastBlocks[p.Name] = p.BlockNumber
+			}
+
+			counts, err := store.GetDepositCounts(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to get deposit counts: %w", err)
+			}
+			report.Counts.L1Deposits = counts.L1Deposits
+			report.Counts.MatchedDeposits = counts.MatchedDeposits
+			report.Counts.PendingDeposits = counts.PendingDeposits
+			report.Counts.L2Finalizations = counts.L2Finalizations
+			report.Counts.UnmatchedL2Finalizations = counts.UnmatchedL2Finalizations
+
+			heads := []struct {
+				name    string
+				url     string
+				pointer string
+				dial    func() (*tracing.EthClient, func(), error)
+			}{
+				{"l1", cfg.l1ExecutionURL, L1_ETH_DEPOSIT_INITIATED_LAST_BLOCK, cfg.dialL1},
+				{"l2", cfg.l2ExecutionURL, L2_ETH_DEPOSIT_FINALIZED_LAST_BLOCK, cfg.dialL2},
+			}
+			for _, h := range heads {
+				if h.url == "" {
+					continue
+				}
+				client, closeClient, err := h.dial()
+				if err != nil {
+					return err
+				}
+				head, err := client.BlockNumber(ctx)
+				closeClient()
+				if err != nil {
+					return fmt.Errorf("failed to get %s head block: %w", h.name, err)
+				}
+
+				cs := chainStatus{Chain: h.name, LastBlock: lastBlocks[h.pointer], HeadBlock: head}
+				if cs.LastBlock != nil {
+					lag := int64(head) - *cs.LastBlock
+					cs.LagBlocks = &lag
+				}
+				report.Chains = append(report.Chains, cs)
+			}
+
+			return writeStatus(c, report, asJSON)
+		},
+	}
+}
+
+// writeStatus prints the status report as JSON or as tables
+func writeStatus(c *cli.Context, report statusReport, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(c.App.Writer)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	return printStatus(c.App.Writer, report)
+}
+
+// printStatus writes the status report as aligned tables
+func printStatus(w io.Writer, report statusReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if report.Schema.PendingMigrations > 0 {
+		fmt.Fprintf(tw, "schema version %d, %d migrations pending, run migrate up first\n", report.Schema.Version, report.Schema.PendingMigrations)
+		return tw.Flush()
+	}
+
+	fmt.Fprintln(tw, "POINTER\tBLOCK\tBLOCK TIME\tAGE")
+	for _, p := range report.Pointers {
+		if p.BlockNumber == nil {
+			fmt.Fprintf(tw, "%s\t-\t-\t-\n", p.Name)
+			continue
+		}
+		blockTime, age := "-", "-"
+		if p.BlockTime != nil {
+			blockTime = p.BlockTime.Format(time.RFC3339)
+			age = (time.Duration(*p.AgeSeconds) * time.Second).String()
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", p.Name, *p.BlockNumber, blockTime, age)
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "DEPOSITS\tCOUNT")
+	fmt.Fprintf(tw, "l1 deposits\t%d\n", report.Counts.L1Deposits)
+	fmt.Fprintf(tw, "matched\t%d\n", report.Counts.MatchedDeposits)
+	fmt.Fprintf(tw, "pending\t%d\n", report.Counts.PendingDeposits)
+	fmt.Fprintf(tw, "l2 finalizations\t%d\n", report.Counts.L2Finalizations)
+	fmt.Fprintf(tw, "unmatched l2 finalizations\t%d\n", report.Counts.UnmatchedL2Finalizations)
+
+	if len(report.Chains) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "CHAIN\tLAST BLOCK\tHEAD BLOCK\tLAG")
+		for _, cs := range report.Chains {
+			if cs.LastBlock == nil {
+				fmt.Fprintf(tw, "%s\t-\t%d\t-\n", cs.Chain, cs.HeadBlock)
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", cs.Chain, *cs.LastBlock, cs.HeadBlock, *cs.LagBlocks)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// shutdownTracingOrLog flushes pending spans, logging failures as the
+// command has already finished
+func shutdownTracingOrLog(shutdown func(context.Context) error, log *slog.Logger) {
+	err := shutdown(context.Background())
+	if err != nil {
+		log.Error("failed to shut down tracing", "error", err)
+	}
+}
@@ -0,0 +1,523 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/eventsource"
+	"github.com/Golem-Base/bridgette/pkg/liveness"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+// config holds the options shared by the commands. Every command registers
+// the flag groups it needs on its own config, so options are given after the
+// command name.
+type config struct {
+	l1ExecutionURL       string
+	l2ExecutionURL       string
+	dbURL                string
+	l1BridgeAddress      string
+	contractRegistryPath string
+	eventsConfigPath     string
+	webUIAddr            string
+	l1BlockInterval      time.Duration
+	l2BlockInterval      time.Duration
+	backfillingBatchSize uint64
+	forwardingBatchSize  uint64
+	maxRetries           int
+	retryBackoff         time.Duration
+	l1Confirmations      uint64
+	l2Confirmations      uint64
+	maxReorgDepth        uint64
+	filterChecks         int
+	pathPrefix           string
+	otlpEndpoint         string
+	traceSampleRatio     float64
+	verifyInterval       time.Duration
+	verifySamples        int
+	solvencyInterval     time.Duration
+	l1PortalAddress      string
+	solvencyThreshold    string
+	latencyInterval      time.Duration
+	livenessInterval     time.Duration
+	livenessStall        time.Duration
+	livenessHeadLag      time.Duration
+	livenessBlockTime    time.Duration
+	livenessOriginLag    uint64
+	batcherInterval      time.Duration
+	l1SystemConfigAddr   string
+	rollupURL            string
+	batcherGap           time.Duration
+	safeLag              time.Duration
+	disputeGameInterval  time.Duration
+	l1DisputeGameFactory string
+	securityInterval     time.Duration
+	l1MessengerAddr      string
+	l1SuperchainConfig   string
+}
+
+// dbFlags selects the database
+func (cfg *config) dbFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "db-url",
+			Usage:       "The URL of the database",
+			EnvVars:     []string{"DB_URL"},
+			Destination: &cfg.dbURL,
+			Value:       defaultDBURL,
+		},
+	}
+}
+
+// chainFlags select the L1 and L2 nodes and the bridge contract
+func (cfg *config) chainFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "l1-execution-url",
+			Usage:       "The URL of the L1 execution layer",
+			EnvVars:     []string{"L1_EXECUTION_URL"},
+			Destination: &cfg.l1ExecutionURL,
+		},
+		&cli.StringFlag{
+			Name:        "l2-execution-url",
+			Usage:       "The URL of the L2 execution layer",
+			EnvVars:     []string{"L2_EXECUTION_URL"},
+			Destination: &cfg.l2ExecutionURL,
+		},
+		&cli.StringFlag{
+			Name:        "l1-bridge-address",
+			Usage:       "The address of the L1 bridge",
+			EnvVars:     []string{"L1_BRIDGE_ADDRESS"},
+			Value:       defaultL1BridgeAddress,
+			Destination: &cfg.l1BridgeAddress,
+		},
+		&cli.StringFlag{
+			Name:        "contract-registry",
+			Usage:       "A JSON file listing the addresses, ABIs and active block ranges of the bridge contracts over history (default: --l1-bridge-address and the L2 predeploy for all blocks)",
+			EnvVars:     []string{"CONTRACT_REGISTRY"},
+			Destination: &cfg.contractRegistryPath,
+		},
+	}
+}
+
+// contractRegistry returns the versions of the bridge contracts indexed on
+// each chain
+func (cfg *config) contractRegistry() (*registry.Registry, error) {
+	if !common.IsHexAddress(cfg.l1BridgeAddress) {
+		return nil, fmt.Errorf("invalid --l1-bridge-address: %q", cfg.l1BridgeAddress)
+	}
+	l1Bridge := common.HexToAddress(cfg.l1BridgeAddress)
+	if cfg.contractRegistryPath == "" {
+		return registry.Default(l1Bridge)
+	}
+	return registry.Load(cfg.contractRegistryPath, l1Bridge)
+}
+
+// indexFlags tune the backfilling and forward filling loops
+func (cfg *config) indexFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "l1-block-interval",
+			Usage:       "The interval for the L1 block",
+			EnvVars:     []string{"L1_BLOCK_INTERVAL"},
+			Value:       time.Second * 2,
+			Destination: &cfg.l1BlockInterval,
+		},
+		&cli.DurationFlag{
+			Name:        "l2-block-interval",
+			Usage:       "The interval for the L2 block",
+			Value:       time.Second * 2,
+			EnvVars:     []string{"L2_BLOCK_INTERVAL"},
+			Destination: &cfg.l2BlockInterval,
+		},
+		cfg.backfillingBatchSizeFlag(),
+		&cli.Uint64Flag{
+			Name:        "forwarding-batch-size",
+			Usage:       "The batch size for the forwarding",
+			Value:       1000,
+			EnvVars:     []string{"FORWARDING_BATCH_SIZE"},
+			Destination: &cfg.forwardingBatchSize,
+		},
+		&cli.IntFlag{
+			Name:        "max-retries",
+			Usage:       "How many times a failed indexer batch is retried, with exponential backoff, before the indexer exits",
+			Value:       5,
+			EnvVars:     []string{"MAX_RETRIES"},
+			Destination: &cfg.maxRetries,
+		},
+		&cli.DurationFlag{
+			Name:        "retry-backoff",
+			Usage:       "The delay before the first retry of a failed indexer batch, doubled for each following retry",
+			Value:       time.Second,
+			EnvVars:     []string{"RETRY_BACKOFF"},
+			Destination: &cfg.retryBackoff,
+		},
+		&cli.Uint64Flag{
+			Name:        "l1-confirmations",
+			Usage:       "How many blocks behind the L1 head the indexer stays, so that shallow reorgs happen before it reads their blocks",
+			EnvVars:     []string{"L1_CONFIRMATIONS"},
+			Destination: &cfg.l1Confirmations,
+		},
+		&cli.Uint64Flag{
+			Name:        "l2-confirmations",
+			Usage:       "How many blocks behind the L2 head the indexer stays, so that shallow reorgs happen before it reads their blocks",
+			EnvVars:     []string{"L2_CONFIRMATIONS"},
+			Destination: &cfg.l2Confirmations,
+		},
+		&cli.Uint64Flag{
+			Name:        "max-reorg-depth",
+			Usage:       "How many blocks back the indexer keeps block hashes to detect reorgs and rewind to the block they forked at (0 disables reorg detection)",
+			Value:       64,
+			EnvVars:     []string{"MAX_REORG_DEPTH"},
+			Destination: &cfg.maxReorgDepth,
+		},
+		&cli.IntFlag{
+			Name:        "filter-checks",
+			Usage:       "How many times every log filter is repeated, and must return the same logs, before its result is indexed. Catches nodes dropping logs from results at the cost of more eth_getLogs calls",
+			EnvVars:     []string{"FILTER_CHECKS"},
+			Destination: &cfg.filterChecks,
+		},
+		cfg.eventsConfigFlag(),
+	}
+}
+
+func (cfg *config) eventsConfigFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:        "events-config",
+		Usage:       "A JSON file declaring the contract events indexed into the generic events table, in addition to the bridge events",
+		EnvVars:     []string{"EVENTS_CONFIG"},
+		Destination: &cfg.eventsConfigPath,
+	}
+}
+
+// eventSources returns the configured event sources, none without --events-config
+func (cfg *config) eventSources() ([]*eventsource.Source, error) {
+	if cfg.eventsConfigPath == "" {
+		return nil, nil
+	}
+	return eventsource.Load(cfg.eventsConfigPath)
+}
+
+func (cfg *config) backfillingBatchSizeFlag() cli.Flag {
+	return &cli.Uint64Flag{
+		Name:        "backfilling-batch-size",
+		Usage:       "The batch size for the backfilling",
+		Value:       10000,
+		EnvVars:     []string{"BACKFILLING_BATCH_SIZE"},
+		Destination: &cfg.backfillingBatchSize,
+	}
+}
+
+// verifyFlags configure the background consistency verification
+func (cfg *config) verifyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "verify-interval",
+			Usage:       "How often a sample of the indexed blocks is verified against the chain (disabled when 0)",
+			EnvVars:     []string{"VERIFY_INTERVAL"},
+			Destination: &cfg.verifyInterval,
+		},
+		&cli.IntFlag{
+			Name:        "verify-samples",
+			Usage:       "The number of randomly picked backfilling batches verified per chain by the background verification",
+			Value:       10,
+			EnvVars:     []string{"VERIFY_SAMPLES"},
+			Destination: &cfg.verifySamples,
+		},
+	}
+}
+
+// solvencyFlags configure the background solvency monitor
+func (cfg *config) solvencyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "solvency-interval",
+			Usage:       "How often the ETH held by the bridge on L1 is compared with the indexed deposits and withdrawals (disabled when 0)",
+			EnvVars:     []string{"SOLVENCY_INTERVAL"},
+			Destination: &cfg.solvencyInterval,
+		},
+		&cli.StringFlag{
+			Name:        "l1-portal-address",
+			Usage:       "The address of the OptimismPortal holding the bridged ETH, required by the solvency monitor",
+			EnvVars:     []string{"L1_PORTAL_ADDRESS"},
+			Destination: &cfg.l1PortalAddress,
+		},
+		&cli.StringFlag{
+			Name:        "solvency-threshold",
+			Usage:       "The shortfall in ETH tolerated before the solvency monitor alerts",
+			Value:       "0",
+			EnvVars:     []string{"SOLVENCY_THRESHOLD"},
+			Destination: &cfg.solvencyThreshold,
+		},
+	}
+}
+
+// latencyFlags configure the background latency tracker
+func (cfg *config) latencyFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "latency-interval",
+			Usage:       "How often the safe and finalized heads of both chains are recorded to break deposit latency down by phase (disabled when 0)",
+			Value:       12 * time.Second,
+			EnvVars:     []string{"LATENCY_INTERVAL"},
+			Destination: &cfg.latencyInterval,
+		},
+	}
+}
+
+// livenessFlags configure the background liveness monitor
+func (cfg *config) livenessFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "liveness-interval",
+			Usage:       "How often the L2 head and its L1 origin are checked for liveness incidents (disabled when 0)",
+			Value:       10 * time.Second,
+			EnvVars:     []string{"LIVENESS_INTERVAL"},
+			Destination: &cfg.livenessInterval,
+		},
+		&cli.DurationFlag{
+			Name:        "liveness-stall-threshold",
+			Usage:       "How long the L2 head may stay at the same block before a stall incident is opened",
+			Value:       30 * time.Second,
+			EnvVars:     []string{"LIVENESS_STALL_THRESHOLD"},
+			Destination: &cfg.livenessStall,
+		},
+		&cli.DurationFlag{
+			Name:        "liveness-head-lag-threshold",
+			Usage:       "How far the timestamp of the latest L2 block may be behind the wall clock before a head lag incident is opened",
+			Value:       time.Minute,
+			EnvVars:     []string{"LIVENESS_HEAD_LAG_THRESHOLD"},
+			Destination: &cfg.livenessHeadLag,
+		},
+		&cli.DurationFlag{
+			Name:        "liveness-max-block-time",
+			Usage:       "The L2 block time above which a block time incident is opened",
+			Value:       4 * time.Second,
+			EnvVars:     []string{"LIVENESS_MAX_BLOCK_TIME"},
+			Destination: &cfg.livenessBlockTime,
+		},
+		&cli.Uint64Flag{
+			Name:        "liveness-origin-lag-threshold",
+			Usage:       "How many L1 blocks the L1 origin of L2 may be behind the L1 head before an origin lag incident is opened",
+			Value:       150,
+			EnvVars:     []string{"LIVENESS_ORIGIN_LAG_THRESHOLD"},
+			Destination: &cfg.livenessOriginLag,
+		},
+	}
+}
+
+// livenessConfig returns the thresholds of the liveness monitor
+func (cfg *config) livenessConfig() liveness.Config {
+	c := liveness.Config{
+		StallThreshold:     cfg.livenessStall,
+		HeadLagThreshold:   cfg.livenessHeadLag,
+		MaxBlockTime:       cfg.livenessBlockTime,
+		OriginLagThreshold: cfg.livenessOriginLag,
+	}
+	// The batcher checks read what the batcher monitor records
+	if cfg.batcherInterval > 0 {
+		c.BatcherGapThreshold = cfg.batcherGap
+		if cfg.rollupURL != "" {
+			c.SafeLagThreshold = cfg.safeLag
+		}
+	}
+	return c
+}
+
+// batcherFlags configure the background batcher monitor
+func (cfg *config) batcherFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "batcher-interval",
+			Usage:       "How often the L1 blocks are scanned for batcher transactions to the batch inbox (disabled when 0)",
+			EnvVars:     []string{"BATCHER_INTERVAL"},
+			Destination: &cfg.batcherInterval,
+		},
+		&cli.StringFlag{
+			Name:        "l1-system-config-address",
+			Usage:       "The address of the SystemConfig naming the batch inbox and the batcher, required by the batcher monitor",
+			EnvVars:     []string{"L1_SYSTEM_CONFIG_ADDRESS"},
+			Destination: &cfg.l1SystemConfigAddr,
+		},
+		&cli.StringFlag{
+			Name:        "rollup-rpc-url",
+			Usage:       "The URL of the rollup node whose sync status is sampled by the batcher monitor (optional)",
+			EnvVars:     []string{"ROLLUP_RPC_URL"},
+			Destination: &cfg.rollupURL,
+		},
+		&cli.DurationFlag{
+			Name:        "batcher-gap-threshold",
+			Usage:       "How long the batcher may go without posting to the batch inbox before a batcher gap incident is opened",
+			Value:       10 * time.Minute,
+			EnvVars:     []string{"BATCHER_GAP_THRESHOLD"},
+			Destination: &cfg.batcherGap,
+		},
+		&cli.DurationFlag{
+			Name:        "safe-lag-threshold",
+			Usage:       "How far the L2 safe head may be behind the unsafe head before a safe lag incident is opened",
+			Value:       10 * time.Minute,
+			EnvVars:     []string{"SAFE_LAG_THRESHOLD"},
+			Destination: &cfg.safeLag,
+		},
+	}
+}
+
+// disputeGameFlags configure the background dispute game monitor
+func (cfg *config) disputeGameFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "dispute-game-interval",
+			Usage:       "How often dispute games are indexed, their status read and their root claims checked against L2 (disabled when 0)",
+			EnvVars:     []string{"DISPUTE_GAME_INTERVAL"},
+			Destination: &cfg.disputeGameInterval,
+		},
+		&cli.StringFlag{
+			Name:        "l1-dispute-game-factory-address",
+			Usage:       "The address of the DisputeGameFactory, required by the dispute game monitor",
+			EnvVars:     []string{"L1_DISPUTE_GAME_FACTORY_ADDRESS"},
+			Destination: &cfg.l1DisputeGameFactory,
+		},
+	}
+}
+
+// securityFlags configure the background security monitor. It also watches
+// the portal, SystemConfig and DisputeGameFactory given to the other monitors.
+func (cfg *config) securityFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.DurationFlag{
+			Name:        "security-interval",
+			Usage:       "How often the bridge contracts on L1 are checked for upgrades, pauses and ownership changes (disabled when 0)",
+			EnvVars:     []string{"SECURITY_INTERVAL"},
+			Destination: &cfg.securityInterval,
+		},
+		&cli.StringFlag{
+			Name:        "l1-messenger-address",
+			Usage:       "The address of the L1CrossDomainMessenger watched by the security monitor (optional)",
+			EnvVars:     []string{"L1_MESSENGER_ADDRESS"},
+			Destination: &cfg.l1MessengerAddr,
+		},
+		&cli.StringFlag{
+			Name:        "l1-superchain-config-address",
+			Usage:       "The address of the SuperchainConfig watched by the security monitor (optional)",
+			EnvVars:     []string{"L1_SUPERCHAIN_CONFIG_ADDRESS"},
+			Destination: &cfg.l1SuperchainConfig,
+		},
+	}
+}
+
+// webFlags configure the web UI server
+func (cfg *config) webFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "web-ui-addr",
+			Usage:       "Address for the web UI",
+			EnvVars:     []string{"WEB_UI_ADDR"},
+			Value:       ":8085",
+			Destination: &cfg.webUIAddr,
+		},
+		&cli.StringFlag{
+			Name:        "path-prefix",
+			Usage:       "The prefix for the path",
+			Value:       "",
+			EnvVars:     []string{"PATH_PREFIX"},
+			Destination: &cfg.pathPrefix,
+		},
+	}
+}
+
+// tracingFlags configure the OpenTelemetry exporter
+func (cfg *config) tracingFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "otlp-endpoint",
+			Usage:       "The OTLP/HTTP collector URL to export traces to (tracing is disabled when empty)",
+			EnvVars:     []string{"OTLP_ENDPOINT"},
+			Destination: &cfg.otlpEndpoint,
+		},
+		&cli.Float64Flag{
+			Name:        "trace-sample-ratio",
+			Usage:       "The fraction of indexer batches and HTTP requests to trace",
+			Value:       1.0,
+			EnvVars:     []string{"TRACE_SAMPLE_RATIO"},
+			Destination: &cfg.traceSampleRatio,
+		},
+	}
+}
+
+// flags concatenates flag groups
+func flags(groups ...[]cli.Flag) []cli.Flag {
+	var all []cli.Flag
+	for _, group := range groups {
+		all = append(all, group...)
+	}
+	return all
+}
+
+// setupTracing installs the tracer provider, the returned function flushes
+// and shuts it down
+func (cfg *config) setupTracing(ctx context.Context) (func(context.Context) error, error) {
+	shutdown, err := tracing.Setup(ctx, tracing.Config{
+		Endpoint:    cfg.otlpEndpoint,
+		SampleRatio: cfg.traceSampleRatio,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	return shutdown, nil
+}
+
+// connectDB opens the database without touching its schema
+func (cfg *config) connectDB() (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", cfg.dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
+
+// openDB opens the database and applies pending migrations
+func (cfg *config) openDB() (*sql.DB, error) {
+	db, err := cfg.connectDB()
+	if err != nil {
+		return nil, err
+	}
+
+	err = sqlitestore.Migrate(db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return db, nil
+}
+
+// dialL1 connects to the L1 execution layer
+func (cfg *config) dialL1() (*tracing.EthClient, func(), error) {
+	if cfg.l1ExecutionURL == "" {
+		return nil, nil, fmt.Errorf("--l1-execution-url is required")
+	}
+	client, err := ethclient.Dial(cfg.l1ExecutionURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial L1 execution layer: %w", err)
+	}
+	return tracing.NewEthClient(client, "l1"), client.Close, nil
+}
+
+// dialL2 connects to the L2 execution layer
+func (cfg *config) dialL2() (*tracing.EthClient, func(), error) {
+	if cfg.l2ExecutionURL == "" {
+		return nil, nil, fmt.Errorf("--l2-execution-url is required")
+	}
+	client, err := ethclient.Dial(cfg.l2ExecutionURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial L2 execution layer: %w", err)
+	}
+	return tracing.NewEthClient(client, "l2"), client.Close, nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/demo"
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/webui"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+// demoIncidentInterval is how often synthetic incidents are opened and closed
+const demoIncidentInterval = 10 * time.Second
+
+// demoCommand indexes synthetic chains and serves the web UI on top, without
+// any node
+func demoCommand(log *slog.Logger) *cli.Command {
+	cfg := &config{}
+	var history time.Duration
+	opts := demo.Options{}
+
+	return &cli.Command{
+		Name:  "demo",
+		Usage: "Fill a database with synthetic deposits and incidents, keep generating them in real time and serve the web UI",
+		Flags: append(cfg.webFlags(),
+			&cli.StringFlag{
+				Name:        "db-url",
+				Usage:       "The URL of an empty database to fill (default: a temporary database removed on exit)",
+				EnvVars:     []string{"DEMO_DB_URL"},
+				Destination: &cfg.dbURL,
+			},
+			&cli.Int64Flag{
+				Name:        "seed",
+				Usage:       "The seed of the synthetic traffic, the same seed generates the same deposits and incidents relative to the start",
+				Value:       1,
+				Destination: &opts.Seed,
+			},
+			&cli.DurationFlag{
+				Name:        "history",
+				Usage:       "How far back the synthetic chains start",
+				Value:       7 * 24 * time.Hour,
+				Destination: &history,
+			},
+			&cli.Float64Flag{
+				Name:        "deposits-per-hour",
+				Usage:       "The average number of deposits initiated per hour",
+				Value:       30,
+				Destination: &opts.DepositsPerHour,
+			},
+			&cli.DurationFlag{
+				Name:        "latency-median",
+				Usage:       "The median time from a deposit on L1 to its finalization on L2",
+				Value:       3 * time.Minute,
+				Destination: &opts.LatencyMedian,
+			},
+			&cli.Float64Flag{
+				Name:        "latency-spread",
+				Usage:       "The standard deviation of the logarithm of the deposit latency, larger values give a longer tail",
+				Value:       0.5,
+				Destination: &opts.LatencySpread,
+			},
+			&cli.Float64Flag{
+				Name:        "stuck-rate",
+				Usage:       "The share of deposits never finalized on L2",
+				Value:       0.01,
+				Destination: &opts.StuckRate,
+			},
+			&cli.Float64Flag{
+				Name:        "orphan-rate",
+				Usage:       "The share of deposits finalized on L2 without a deposit on L1",
+				Value:       0.005,
+				Destination: &opts.OrphanRate,
+			},
+			&cli.Float64Flag{
+				Name:        "incidents-per-day",
+				Usage:       "The average number of liveness incidents per day",
+				Value:       2,
+				Destination: &opts.IncidentsPerDay,
+			},
+			&cli.IntFlag{
+				Name:        "accounts",
+				Usage:       "The number of depositors, a few of them make most deposits",
+				Value:       200,
+				Destination: &opts.Accounts,
+			},
+		),
+		Action: func(c *cli.Context) error {
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			if cfg.dbURL == "" {
+				dir, err := os.MkdirTemp("", "bridgette-demo")
+				if err != nil {
+					return fmt.Errorf("failed to create demo database directory: %w", err)
+				}
+				defer os.RemoveAll(dir)
+				cfg.dbURL = "file:" + filepath.Join(dir, "demo.db") + "?_txlock=immediate&_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=true"
+			}
+
+			db, err := cfg.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			// Incidents are only tracked in memory, so they would be stored twice
+			counts, err := sqlitestore.New(db).GetDepositCounts(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to count deposits: %w", err)
+			}
+			if counts.L1Deposits > 0 || counts.L2Finalizations > 0 {
+				return fmt.Errorf("the demo needs an empty database")
+			}
+
+			opts.Genesis = time.Now().Add(-history).Truncate(time.Second)
+			opts.L1Bridge = common.HexToAddress(defaultL1BridgeAddress)
+			opts.L2Bridge = registry.L2StandardBridge
+			gen, err := demo.New(opts)
+			if err != nil {
+				return err
+			}
+
+			// The synthetic chains are indexed like real ones, from their
+			// first block
+			cfg.l1BridgeAddress = defaultL1BridgeAddress
+			cfg.l1BlockInterval = time.Second
+			cfg.l2BlockInterval = time.Second
+			cfg.backfillingBatchSize = 10000
+			cfg.forwardingBatchSize = 1000
+			bus := events.NewBus()
+			ix, err := newBridgeIndexer(cfg, db, nil, nil, bus, log)
+			if err != nil {
+				return err
+			}
+			ix.l1.Source, ix.l1.StartBlock = gen.L1(), gen.L1().FirstBlock()
+			ix.l2.Source, ix.l2.StartBlock = gen.L2(), gen.L2().FirstBlock()
+
+			webServer := webui.NewServer(db, bus, log.With("component", "webui"), cfg.webUIAddr, cfg.pathPrefix)
+
+			log.Info("starting demo", "seed", opts.Seed, "since", opts.Genesis, "db_url", cfg.dbURL)
+
+			eg, egCtx := errgroup.WithContext(ctx)
+			eg.Go(func() error {
+				return webServer.Start(egCtx)
+			})
+			eg.Go(func() error {
+				return ix.run(egCtx)
+			})
+			eg.Go(func() error {
+				return runDemoIncidents(egCtx, gen, ix, bus)
+			})
+			return eg.Wait()
+		},
+	}
+}
+
+// runDemoIncidents opens and closes the synthetic incidents as time passes
+func runDemoIncidents(ctx context.Context, gen *demo.Generator, ix *bridgeIndexer, bus *events.Bus) error {
+	ticker := time.NewTicker(demoIncidentInterval)
+	defer ticker.Stop()
+
+	for {
+		changed, err := gen.RecordIncidents(ctx, ix.db)
+		if err != nil {
+			return err
+		}
+		if changed > 0 {
+			bus.Publish(events.Event{Type: events.Incident, Chain: "l2"})
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
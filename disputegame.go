@@ -0,0 +1,33 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Golem-Base/bridgette/pkg/disputegame"
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// disputeGameStartBlocks starts a fresh database about a week of L1 blocks
+// back, which covers the games withdrawals are currently proven against
+const disputeGameStartBlocks = 7 * 24 * 60 * 60 / 12
+
+// disputeGameMonitor returns the dispute game monitor configured by the
+// dispute game flags, or nil when it is disabled
+func (cfg *config) disputeGameMonitor(db *sql.DB, l1Client, l2Client *tracing.EthClient, bus *events.Bus, log *slog.Logger) (*disputegame.Monitor, error) {
+	if cfg.disputeGameInterval <= 0 {
+		return nil, nil
+	}
+	if !common.IsHexAddress(cfg.l1DisputeGameFactory) {
+		return nil, fmt.Errorf("--l1-dispute-game-factory-address is required by the dispute game monitor")
+	}
+
+	return disputegame.New(db, l1Client, l2Client, disputegame.Config{
+		Factory:     common.HexToAddress(cfg.l1DisputeGameFactory),
+		BatchSize:   cfg.backfillingBatchSize,
+		StartBlocks: disputeGameStartBlocks,
+	}, bus, log.With("component", "disputegame")), nil
+}
@@ -0,0 +1,565 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/demo"
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/fakechain"
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/rpcproxy"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/verify"
+	"github.com/Golem-Base/bridgette/pkg/webui"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	e2eBridge   = common.HexToAddress("0x54d6c1435ac7b90a5d46d01ee2f22ed6ff270ed3")
+	e2eL2ETH    = common.HexToAddress("0xDeadDeAddeAddEAddeadDEaDDEAdDeaDDeAD0000")
+	e2eGenesis  = uint64(1_700_000_000)
+	e2eDeadline = 10 * time.Second
+)
+
+// e2eEnv runs the indexer and the web UI against two fake chains served
+// over JSON-RPC
+type e2eEnv struct {
+	t  *testing.T
+	l1 *fakechain.Chain
+	l2 *fakechain.Chain
+	// urls serve the chains without the fault-injecting proxy
+	urls    map[*fakechain.Chain]string
+	cfg     *config
+	ix      *bridgeIndexer
+	handler http.Handler
+
+	cancel context.CancelFunc
+	done   chan error
+}
+
+// newE2EEnv creates the environment. With faults, both chains are served
+// through a fault-injecting proxy, failed batches are retried, the indexer
+// stays ReorgDepth blocks behind the heads and every log filter is checked
+// twice.
+func newE2EEnv(t *testing.T, faults ...rpcproxy.Faults) *e2eEnv {
+	t.Helper()
+
+	l1 := fakechain.New(1, e2eGenesis, 12)
+	l2 := fakechain.New(2, e2eGenesis, 2)
+	l1Server := httptest.NewServer(l1)
+	l2Server := httptest.NewServer(l2)
+	t.Cleanup(l1Server.Close)
+	t.Cleanup(l2Server.Close)
+
+	urls := map[*fakechain.Chain]string{l1: l1Server.URL, l2: l2Server.URL}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	maxRetries, confirmations, filterChecks := 0, uint64(0), 0
+	if len(faults) > 0 {
+		l1Server = httptest.NewServer(rpcproxy.New(l1Server.URL, faults[0], 1, log))
+		l2Server = httptest.NewServer(rpcproxy.New(l2Server.URL, faults[0], 2, log))
+		t.Cleanup(l1Server.Close)
+		t.Cleanup(l2Server.Close)
+		maxRetries, confirmations, filterChecks = 20, faults[0].ReorgDepth, 2
+	}
+
+	cfg := &config{
+		l1ExecutionURL:       l1Server.URL,
+		l2ExecutionURL:       l2Server.URL,
+		dbURL:                "file:" + filepath.Join(t.TempDir(), "bridgette.db") + "?_txlock=immediate&_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=true",
+		l1BridgeAddress:      e2eBridge.Hex(),
+		l1BlockInterval:      10 * time.Millisecond,
+		l2BlockInterval:      10 * time.Millisecond,
+		backfillingBatchSize: 25,
+		forwardingBatchSize:  10,
+		maxRetries:           maxRetries,
+		retryBackoff:         time.Millisecond,
+		l1Confirmations:      confirmations,
+		l2Confirmations:      confirmations,
+		maxReorgDepth:        64,
+		filterChecks:         filterChecks,
+	}
+	db, err := cfg.openDB()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	l1Client, closeL1, err := cfg.dialL1()
+	require.NoError(t, err)
+	t.Cleanup(closeL1)
+	l2Client, closeL2, err := cfg.dialL2()
+	require.NoError(t, err)
+	t.Cleanup(closeL2)
+
+	bus := events.NewBus()
+	ix, err := newBridgeIndexer(cfg, db, l1Client, l2Client, bus, log)
+	require.NoError(t, err)
+
+	env := &e2eEnv{
+		t:       t,
+		l1:      l1,
+		l2:      l2,
+		urls:    urls,
+		cfg:     cfg,
+		ix:      ix,
+		handler: webui.NewServer(db, bus, log, "", "").Handler(),
+	}
+	t.Cleanup(func() {
+		if env.cancel != nil {
+			env.stop()
+		}
+	})
+	return env
+}
+
+// start runs the indexer in the background, on both chains by default
+func (e *e2eEnv) start(chains ...*indexer.Chain) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.done = make(chan error, 1)
+	go func() {
+		if len(chains) > 0 {
+			e.done <- e.ix.runner.Run(ctx, chains...)
+			return
+		}
+		e.done <- e.ix.run(ctx)
+	}()
+}
+
+// stop cancels the indexer and returns the error it stopped with, nil when
+// it was cancelled
+func (e *e2eEnv) stop() error {
+	e.cancel()
+	e.cancel = nil
+	err := <-e.done
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// wait returns the error the indexer stopped with on its own
+func (e *e2eEnv) wait() error {
+	select {
+	case err := <-e.done:
+		e.cancel()
+		e.cancel = nil
+		return err
+	case <-time.After(e2eDeadline):
+		e.t.Fatal("the indexer did not stop")
+		return nil
+	}
+}
+
+// caughtUp waits until both chains are indexed up to their heads, less the
+// confirmations, and every possible match is made
+func (e *e2eEnv) caughtUp(chains ...*indexer.Chain) {
+	e.t.Helper()
+	if len(chains) == 0 {
+		chains = []*indexer.Chain{e.ix.l1, e.ix.l2}
+	}
+	require.Eventually(e.t, func() bool {
+		for _, c := range chains {
+			head := e.l1.Head()
+			if c == e.ix.l2 {
+				head = e.l2.Head()
+			}
+			head -= min(head, c.Confirmations)
+			p, err := e.ix.store.GetBlockPointer(context.Background(), c.LastPointer)
+			if err != nil || p.BlockNumber == nil || uint64(*p.BlockNumber) < head {
+				return false
+			}
+		}
+		return true
+	}, e2eDeadline, 10*time.Millisecond)
+
+	_, err := e.ix.matcher.MatchPending(context.Background())
+	require.NoError(e.t, err)
+}
+
+// unproxied returns a copy of an indexed chain reading from the fake chain
+// directly, bypassing the fault-injecting proxy
+func (e *e2eEnv) unproxied(c *indexer.Chain) *indexer.Chain {
+	e.t.Helper()
+	chain := e.l1
+	if c == e.ix.l2 {
+		chain = e.l2
+	}
+	client, err := ethclient.Dial(e.urls[chain])
+	require.NoError(e.t, err)
+	e.t.Cleanup(client.Close)
+
+	direct := *c
+	direct.Source = client
+	return &direct
+}
+
+// run runs a bridgette command
+func (e *e2eEnv) run(args ...string) {
+	e.t.Helper()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	app := &cli.App{
+		Commands: []*cli.Command{recordCommand(log), replayCommand(log)},
+		Writer:   io.Discard,
+	}
+	require.NoError(e.t, app.Run(append([]string{"bridgette"}, args...)))
+}
+
+// get returns the decoded JSON response of an API endpoint
+func (e *e2eEnv) get(path string) map[string]any {
+	e.t.Helper()
+	rec := httptest.NewRecorder()
+	e.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(e.t, http.StatusOK, rec.Code, rec.Body.String())
+	var body map[string]any
+	require.NoError(e.t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body
+}
+
+// total returns the total of a paginated API list
+func (e *e2eEnv) total(path string) int {
+	e.t.Helper()
+	return int(e.get(path)["pagination"].(map[string]any)["total"].(float64))
+}
+
+// deposit is the ETH deposit of an account
+type deposit struct {
+	from   common.Address
+	amount int64
+	data   []byte
+}
+
+// initiate mines an L1 block with the ETHDepositInitiated log of the deposit
+func (e *e2eEnv) initiate(d deposit) types.Log {
+	e.t.Helper()
+	event := bridgeEvent(e.t, bindings.L1StandardBridgeMetaData, "ETHDepositInitiated")
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(d.amount), d.data)
+	require.NoError(e.t, err)
+	lg := types.Log{
+		Address: e2eBridge,
+		Topics:  []common.Hash{event.ID, common.BytesToHash(d.from.Bytes()), common.BytesToHash(d.from.Bytes())},
+		Data:    data,
+	}
+	header := e.l1.Mine(lg)
+	return e.l1.Logs(header.Number.Uint64())[0]
+}
+
+// finalize mines L2 blocks past the L1 head time, then a block with the
+// DepositFinalized log of the deposit
+func (e *e2eEnv) finalize(d deposit) {
+	e.t.Helper()
+	l1Time := e.l1.Header(e.l1.Head()).Time
+	for e.l2.Header(e.l2.Head()).Time < l1Time {
+		e.l2.Mine()
+	}
+	event := bridgeEvent(e.t, bindings.L2StandardBridgeMetaData, "DepositFinalized")
+	data, err := event.Inputs.NonIndexed().Pack(d.from, big.NewInt(d.amount), d.data)
+	require.NoError(e.t, err)
+	e.l2.Mine(types.Log{
+		Address: registry.L2StandardBridge,
+		Topics:  []common.Hash{event.ID, {}, common.BytesToHash(e2eL2ETH.Bytes()), common.BytesToHash(d.from.Bytes())},
+		Data:    data,
+	})
+}
+
+func bridgeEvent(t *testing.T, metadata *bind.MetaData, name string) abi.Event {
+	contractAbi, err := metadata.GetAbi()
+	require.NoError(t, err)
+	return contractAbi.Events[name]
+}
+
+func TestE2E(t *testing.T) {
+	alice := deposit{from: common.Address{0xa1}, amount: 1e18, data: []byte{}}
+	bob := deposit{from: common.Address{0xb0}, amount: 2e18, data: []byte("hi")}
+	carol := deposit{from: common.Address{0xca}, amount: 3e18, data: []byte{}}
+
+	t.Run("deposits before and after startup", func(t *testing.T) {
+		env := newE2EEnv(t)
+		env.l1.MineEmpty(30)
+		aliceLog := env.initiate(alice)
+		env.l1.MineEmpty(5)
+		env.finalize(alice)
+		env.l2.MineEmpty(40)
+
+		env.start()
+		env.caughtUp()
+		require.Equal(t, 1, env.total("/api/v1/deposits/matched"))
+
+		// Deposits made while forward filling, one of them still in flight
+		env.initiate(bob)
+		env.finalize(bob)
+		env.initiate(carol)
+		env.caughtUp()
+		require.NoError(t, env.stop())
+
+		require.Equal(t, 2, env.total("/api/v1/deposits/matched"))
+		unmatched := env.get("/api/v1/deposits/unmatched")["data"].([]any)
+		require.Len(t, unmatched, 1)
+		require.Equal(t, "3000000000000000000", unmatched[0].(map[string]any)["amount_wei"])
+
+		// The oldest match is alice's, with the block and transaction it was made in
+		matched := env.get("/api/v1/deposits/matched")["data"].([]any)
+		first := matched[len(matched)-1].(map[string]any)
+		require.Equal(t, "matched", first["status"])
+		require.Equal(t, strings.ToLower(alice.from.Hex()), first["from"])
+		l1 := first["l1"].(map[string]any)
+		require.Equal(t, float64(aliceLog.BlockNumber), l1["block_number"])
+		require.Equal(t, aliceLog.TxHash.Hex(), l1["tx_hash"])
+		require.NotNil(t, first["l2"])
+
+		// Both chains were indexed from genesis
+		for _, name := range []string{L1_ETH_DEPOSIT_INITIATED_LOW_BLOCK, L2_ETH_DEPOSIT_FINALIZED_LOW_BLOCK} {
+			p, err := env.ix.store.GetBlockPointer(context.Background(), name)
+			require.NoError(t, err)
+			require.Equal(t, int64(0), *p.BlockNumber)
+		}
+
+		rec := httptest.NewRecorder()
+		env.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/deposit/"+aliceLog.TxHash.Hex(), nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), strings.ToLower(alice.from.Hex()))
+	})
+
+	t.Run("identical deposits", func(t *testing.T) {
+		env := newE2EEnv(t)
+		env.initiate(alice)
+		env.initiate(alice)
+		env.finalize(alice)
+		env.finalize(alice)
+
+		env.start()
+		env.caughtUp()
+		require.NoError(t, env.stop())
+
+		require.Equal(t, 2, env.total("/api/v1/deposits/matched"))
+		require.Equal(t, 0, env.total("/api/v1/deposits/unmatched"))
+
+		groups := env.get("/api/v1/matches/ambiguous")["data"].([]any)
+		require.Len(t, groups, 1)
+		group := groups[0].(map[string]any)
+		require.Equal(t, float64(2), group["l1_count"])
+		require.Equal(t, float64(2), group["l2_count"])
+	})
+
+	t.Run("out of order arrival", func(t *testing.T) {
+		env := newE2EEnv(t)
+		env.initiate(bob)
+		env.finalize(bob)
+
+		// L2 is indexed before L1, its finalization has nothing to match yet
+		env.start(env.ix.l2)
+		env.caughtUp(env.ix.l2)
+		require.NoError(t, env.stop())
+		require.Equal(t, 1, env.total("/api/v1/finalizations/orphaned"))
+		require.Equal(t, 0, env.total("/api/v1/deposits/matched"))
+
+		env.start()
+		env.caughtUp()
+		require.NoError(t, env.stop())
+		require.Equal(t, 0, env.total("/api/v1/finalizations/orphaned"))
+		require.Equal(t, 1, env.total("/api/v1/deposits/matched"))
+	})
+
+	t.Run("reorg", func(t *testing.T) {
+		env := newE2EEnv(t)
+		env.l1.MineEmpty(10)
+		env.start()
+		env.caughtUp()
+
+		// The deposit is indexed, then reorged out of L1 and included again
+		// two blocks later
+		env.initiate(carol)
+		env.caughtUp()
+		require.Equal(t, 1, env.total("/api/v1/deposits/unmatched"))
+		env.l1.Reorg(1)
+		env.l1.MineEmpty(2)
+		included := env.initiate(carol)
+		env.l1.MineEmpty(3)
+		env.caughtUp()
+		require.NoError(t, env.stop())
+
+		// The indexer rewinds past the reorged block, so the deposit is only
+		// stored at its new block and the database matches the chain
+		unmatched := env.get("/api/v1/deposits/unmatched")["data"].([]any)
+		require.Len(t, unmatched, 1)
+		l1 := unmatched[0].(map[string]any)["l1"].(map[string]any)
+		require.Equal(t, float64(included.BlockNumber), l1["block_number"])
+		require.Equal(t, included.TxHash.Hex(), l1["tx_hash"])
+
+		report, err := runVerification(context.Background(), env.ix.db, []*indexer.Chain{env.ix.l1}, verify.Options{BatchSize: 25})
+		require.NoError(t, err)
+		require.True(t, report.OK())
+	})
+
+	t.Run("rpc failures", func(t *testing.T) {
+		env := newE2EEnv(t)
+		env.l1.MineEmpty(40)
+		env.initiate(alice)
+		env.finalize(alice)
+
+		// Without retries, a failed call stops the indexer before anything
+		// is committed
+		env.l1.Fail("eth_getLogs", 1)
+		env.start()
+		require.ErrorContains(t, env.wait(), "injected failure of eth_getLogs")
+		p, err := env.ix.store.GetBlockPointer(context.Background(), L1_ETH_DEPOSIT_INITIATED_LOW_BLOCK)
+		require.NoError(t, err)
+		require.Nil(t, p.BlockNumber)
+
+		// A failure after the logs were fetched rolls the batch back
+		env.start()
+		env.caughtUp()
+		env.l1.Fail("eth_getBlockByNumber", 1)
+		env.initiate(bob)
+		require.ErrorContains(t, env.wait(), "injected failure of eth_getBlockByNumber")
+		require.Equal(t, 0, env.total("/api/v1/deposits/unmatched"))
+
+		// Restarting resumes where the indexer stopped without duplicates
+		env.finalize(bob)
+		env.start()
+		env.caughtUp()
+		require.NoError(t, env.stop())
+		require.Equal(t, 2, env.total("/api/v1/deposits/matched"))
+		require.Equal(t, 0, env.total("/api/v1/deposits/unmatched"))
+
+		counts, err := sqlitestore.New(env.ix.db).GetDepositCounts(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, int64(2), counts.L1Deposits)
+	})
+
+	t.Run("faulty rpc proxy", func(t *testing.T) {
+		// Every kind of fault the indexer retries or works around
+		env := newE2EEnv(t, rpcproxy.Faults{
+			LatencyJitter:   time.Millisecond,
+			ErrorRate:       0.1,
+			RateLimitRate:   0.1,
+			MaxLogs:         1,
+			TruncateRate:    0.05,
+			StaleHeadRate:   0.2,
+			StaleHeadBlocks: 5,
+			ReorgRate:       0.2,
+			ReorgDepth:      2,
+		})
+		for _, d := range []deposit{alice, bob, carol} {
+			env.l1.MineEmpty(10)
+			env.initiate(d)
+			env.finalize(d)
+		}
+		// The last finalization needs confirmations too
+		env.l2.MineEmpty(2)
+
+		env.start()
+		env.caughtUp()
+		env.initiate(carol)
+		env.l1.MineEmpty(3)
+		env.caughtUp()
+		require.NoError(t, env.stop())
+
+		require.Equal(t, 3, env.total("/api/v1/deposits/matched"))
+		require.Equal(t, 1, env.total("/api/v1/deposits/unmatched"))
+
+		// Verification compares with the chains themselves
+		chains := []*indexer.Chain{env.unproxied(env.ix.l1), env.unproxied(env.ix.l2)}
+		report, err := runVerification(context.Background(), env.ix.db, chains, verify.Options{BatchSize: 25})
+		require.NoError(t, err)
+		require.True(t, report.OK())
+	})
+
+	t.Run("record and replay", func(t *testing.T) {
+		env := newE2EEnv(t)
+		for _, d := range []deposit{alice, bob, carol} {
+			env.l1.MineEmpty(7)
+			env.initiate(d)
+			env.finalize(d)
+		}
+		env.initiate(alice)
+		env.l1.MineEmpty(3)
+		env.start()
+		env.caughtUp()
+		require.NoError(t, env.stop())
+
+		// Both chains are recorded in two ranges, then replayed into a new
+		// database without the nodes
+		dir := t.TempDir()
+		l1Head, l2Head := env.l1.Head(), env.l2.Head()
+		env.run("record", "--l1-execution-url", env.cfg.l1ExecutionURL, "--chain", "l1", "--from", "0", "--to", fmt.Sprint(l1Head/2), "--archive", dir)
+		env.run("record", "--l1-execution-url", env.cfg.l1ExecutionURL, "--chain", "l1", "--from", fmt.Sprint(l1Head/2+1), "--to", fmt.Sprint(l1Head), "--archive", dir)
+		env.run("record", "--l2-execution-url", env.cfg.l2ExecutionURL, "--chain", "l2", "--from", "0", "--to", fmt.Sprint(l2Head), "--archive", dir, "--backfilling-batch-size", "7")
+
+		replayed := &config{dbURL: "file:" + filepath.Join(t.TempDir(), "replay.db") + "?_txlock=immediate&_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=true"}
+		env.run("replay", "--db-url", replayed.dbURL, "--archive", dir, "--backfilling-batch-size", "25")
+
+		db, err := replayed.openDB()
+		require.NoError(t, err)
+		defer db.Close()
+		replayedEnv := &e2eEnv{t: t, handler: webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()}
+
+		// The replayed database serves the same deposits as the indexed one
+		for _, path := range []string{"/api/v1/deposits/matched", "/api/v1/deposits/unmatched"} {
+			require.Equal(t, env.get(path), replayedEnv.get(path))
+		}
+		require.Equal(t, 3, replayedEnv.total("/api/v1/deposits/matched"))
+		require.Equal(t, 1, replayedEnv.total("/api/v1/deposits/unmatched"))
+	})
+
+	t.Run("demo", func(t *testing.T) {
+		env := newE2EEnv(t)
+		now := time.Now()
+		gen, err := demo.New(demo.Options{
+			Seed:            1,
+			Genesis:         now.Add(-6 * time.Hour),
+			DepositsPerHour: 30,
+			LatencyMedian:   3 * time.Minute,
+			LatencySpread:   0.5,
+			StuckRate:       0.1,
+			OrphanRate:      0.1,
+			Accounts:        20,
+			L1Bridge:        e2eBridge,
+			L2Bridge:        registry.L2StandardBridge,
+			Now:             func() time.Time { return now },
+		})
+		require.NoError(t, err)
+
+		// The synthetic chains replace the fake ones
+		env.ix.l1.Source, env.ix.l1.StartBlock = gen.L1(), gen.L1().FirstBlock()
+		env.ix.l2.Source, env.ix.l2.StartBlock = gen.L2(), gen.L2().FirstBlock()
+		ctx := context.Background()
+		require.NoError(t, env.ix.runner.CatchUp(ctx, env.ix.l1, env.ix.l2))
+		_, err = env.ix.matcher.MatchPending(ctx)
+		require.NoError(t, err)
+
+		// Every deposit is matched, stuck or in flight, every finalization
+		// is matched or orphaned
+		l1Logs, err := gen.L1().FilterLogs(ctx, ethereum.FilterQuery{})
+		require.NoError(t, err)
+		l2Logs, err := gen.L2().FilterLogs(ctx, ethereum.FilterQuery{})
+		require.NoError(t, err)
+		matched := env.total("/api/v1/deposits/matched")
+		unmatched := env.total("/api/v1/deposits/unmatched")
+		orphaned := env.total("/api/v1/finalizations/orphaned")
+		require.Equal(t, len(l1Logs), matched+unmatched)
+		require.Equal(t, len(l2Logs), matched+orphaned)
+		require.Greater(t, matched, 100)
+		require.Positive(t, unmatched)
+		require.Positive(t, orphaned)
+	})
+}
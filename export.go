@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/Golem-Base/bridgette/pkg/export"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/urfave/cli/v2"
+)
+
+// exportCommand writes the deposit history to a file or stdout
+func exportCommand() *cli.Command {
+	cfg := struct {
+		dbURL     string
+		format    string
+		status    string
+		since     string
+		until     string
+		output    string
+		batchSize int64
+	}{}
+
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export matched and unmatched deposits as CSV, JSON Lines or Parquet",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "db-url",
+				Usage:       "The URL of the database",
+				EnvVars:     []string{"DB_URL"},
+				Value:       defaultDBURL,
+				Destination: &cfg.dbURL,
+			},
+			&cli.StringFlag{
+				Name:        "format",
+				Usage:       "The output format: csv, jsonl or parquet",
+				Value:       string(export.CSV),
+				Destination: &cfg.format,
+			},
+			&cli.StringFlag{
+				Name:        "status",
+				Usage:       "Comma separated statuses to export: matched, unmatched_l1, unmatched_l2 (default: all)",
+				Destination: &cfg.status,
+			},
+			&cli.StringFlag{
+				Name:        "since",
+				Usage:       "Only export deposits at or after this time (RFC3339, YYYY-MM-DD or unix seconds)",
+				Destination: &cfg.since,
+			},
+			&cli.StringFlag{
+				Name:        "until",
+				Usage:       "Only export deposits before this time (RFC3339, YYYY-MM-DD or unix seconds)",
+				Destination: &cfg.until,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "The file to write to, - for stdout",
+				Value:       "-",
+				Destination: &cfg.output,
+			},
+			&cli.Int64Flag{
+				Name:        "batch-size",
+				Usage:       "The number of rows read from the database at a time",
+				Value:       export.DefaultBatchSize,
+				Destination: &cfg.batchSize,
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			format, err := export.ParseFormat(cfg.format)
+			if err != nil {
+				return err
+			}
+
+			opts := export.Options{BatchSize: cfg.batchSize}
+			opts.Statuses, err = export.ParseStatuses(cfg.status)
+			if err != nil {
+				return err
+			}
+			if cfg.since != "" {
+				since, err := export.ParseTime(cfg.since)
+				if err != nil {
+					return fmt.Errorf("invalid since: %w", err)
+				}
+				opts.Since = &since
+			}
+			if cfg.until != "" {
+				until, err := export.ParseTime(cfg.until)
+				if err != nil {
+					return fmt.Errorf("invalid until: %w", err)
+				}
+				opts.Until = &until
+			}
+
+			db, err := sql.Open("sqlite3", cfg.dbURL)
+			if err != nil {
+				return fmt.Errorf("failed to open database: %w", err)
+			}
+			defer db.Close()
+
+			err = sqlitestore.Migrate(db)
+			if err != nil {
+				return fmt.Errorf("failed to migrate database: %w", err)
+			}
+
+			out := os.Stdout
+			if cfg.output != "-" {
+				out, err = os.Create(cfg.output)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer out.Close()
+			}
+
+			bw := bufio.NewWriter(out)
+			err = export.Export(ctx, db, bw, format, opts)
+			if err != nil {
+				return fmt.Errorf("failed to export deposits: %w", err)
+			}
+
+			err = bw.Flush()
+			if err != nil {
+				return fmt.Errorf("failed to write output: %w", err)
+			}
+			if cfg.output != "-" {
+				err = out.Close()
+				if err != nil {
+					return fmt.Errorf("failed to close output file: %w", err)
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Golem-Base/bridgette/pkg/eventsource"
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// l1Handler stores the ETHDepositInitiated logs of the L1 bridge
+type l1Handler struct {
+	history registry.History
+}
+
+// HandleBatch inserts ETHDepositInitiated logs and returns the number of new deposits
+func (h l1Handler) HandleBatch(ctx context.Context, tx *sql.Tx, batch *indexer.Batch) (int, error) {
+	txStore := sqlitestore.NewTraced(tx)
+	for _, lg := range batch.Logs {
+		version := h.history.At(lg.BlockNumber)
+		if version == nil {
+			return 0, fmt.Errorf("no L1 bridge version is active in block %d", lg.BlockNumber)
+		}
+
+		// Parse the event data
+		_, parseSpan := tracing.Tracer().Start(ctx, "parse log")
+		event, err := version.Decoder.ETHDepositInitiated(&lg)
+		parseSpan.End()
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse log: %w", err)
+		}
+
+		eventJSON, err := json.Marshal(lg)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal event: %w", err)
+		}
+
+		blockTimestamp := int64(batch.BlockTimes[lg.BlockNumber])
+
+		// Insert log data into database and get the ID directly
+		_, err = txStore.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+			BlockNumber:    int64(lg.BlockNumber),
+			BlockTimestamp: blockTimestamp,
+			TxHash:         lg.TxHash.Bytes(),
+			FromAddress:    event.From.Bytes(),
+			ToAddress:      event.To.Bytes(),
+			Amount:         weiToEth(event.Amount), // Convert Wei to ETH
+			AmountWei:      common.LeftPadBytes(event.Amount.Bytes(), 32),
+			Event:          eventJSON,
+			MatchingHash:   event.DepositMatchingHash().Bytes(),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert log: %w", err)
+		}
+
+	}
+
+	return len(batch.Logs), nil
+}
+
+// ClearRange deletes the L1 deposits of a block range and unmatches their L2 finalizations
+func (h l1Handler) ClearRange(ctx context.Context, tx *sql.Tx, fromBlock, toBlock uint64) (int64, error) {
+	txStore := sqlitestore.NewTraced(tx)
+	err := txStore.ClearL2MatchesOfL1Range(ctx, sqlitestore.ClearL2MatchesOfL1RangeParams{FromBlock: int64(fromBlock), ToBlock: int64(toBlock)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to unmatch L2 finalizations: %w", err)
+	}
+	deleted, err := txStore.DeleteL1DepositsInRange(ctx, sqlitestore.DeleteL1DepositsInRangeParams{FromBlock: int64(fromBlock), ToBlock: int64(toBlock)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete L1 deposits: %w", err)
+	}
+	return deleted, nil
+}
+
+// l2Handler stores the DepositFinalized logs of the L2 bridge
+type l2Handler struct {
+	history registry.History
+}
+
+// HandleBatch inserts DepositFinalized logs
+func (h l2Handler) HandleBatch(ctx context.Context, tx *sql.Tx, batch *indexer.Batch) (int, error) {
+	txStore := sqlitestore.NewTraced(tx)
+	for _, lg := range batch.Logs {
+		version := h.history.At(lg.BlockNumber)
+		if version == nil {
+			return 0, fmt.Errorf("no L2 bridge version is active in block %d", lg.BlockNumber)
+		}
+
+		// Parse the event data
+		_, parseSpan := tracing.Tracer().Start(ctx, "parse log")
+		event, err := version.Decoder.DepositFinalized(&lg)
+		parseSpan.End()
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse log: %w", err)
+		}
+
+		eventJSON, err := json.Marshal(lg)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal event: %w", err)
+		}
+
+		blockTimestamp := int64(batch.BlockTimes[lg.BlockNumber])
+
+		// Insert log data into database and get the ID
+		_, err = txStore.InsertL2StandardBridgeDepositFinalized(ctx, sqlitestore.InsertL2StandardBridgeDepositFinalizedParams{
+			BlockNumber:    int64(lg.BlockNumber),
+			BlockTimestamp: blockTimestamp,
+			TxHash:         lg.TxHash.Bytes(),
+			FromAddress:    event.From.Bytes(),
+			ToAddress:      event.To.Bytes(),
+			L1Token:        event.L1Token.Bytes(),
+			Amount:         weiToEth(event.Amount), // Convert Wei to ETH
+			AmountWei:      common.LeftPadBytes(event.Amount.Bytes(), 32),
+			Event:          eventJSON,
+			MatchingHash:   event.DepositMatchingHash().Bytes(),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert log: %w", err)
+		}
+
+	}
+
+	// L2 finalizations are reported through the matches they complete
+	return 0, nil
+}
+
+// ClearRange deletes the L2 finalizations of a block range and unmatches their L1 deposits
+func (h l2Handler) ClearRange(ctx context.Context, tx *sql.Tx, fromBlock, toBlock uint64) (int64, error) {
+	txStore := sqlitestore.NewTraced(tx)
+	err := txStore.ClearL1MatchesOfL2Range(ctx, sqlitestore.ClearL1MatchesOfL2RangeParams{FromBlock: int64(fromBlock), ToBlock: int64(toBlock)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to unmatch L1 deposits: %w", err)
+	}
+	deleted, err := txStore.DeleteL2FinalizationsInRange(ctx, sqlitestore.DeleteL2FinalizationsInRangeParams{FromBlock: int64(fromBlock), ToBlock: int64(toBlock)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete L2 finalizations: %w", err)
+	}
+	return deleted, nil
+}
+
+// sourceHandler stores the logs of an event source with their decoded arguments
+type sourceHandler struct {
+	src *eventsource.Source
+}
+
+// HandleBatch inserts the logs of the source. Indexed events are not
+// deposits, so none are reported.
+func (h sourceHandler) HandleBatch(ctx context.Context, tx *sql.Tx, batch *indexer.Batch) (int, error) {
+	txStore := sqlitestore.NewTraced(tx)
+	for _, lg := range batch.Logs {
+		_, parseSpan := tracing.Tracer().Start(ctx, "parse log")
+		args, err := h.src.Decode(&lg)
+		parseSpan.End()
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %s log: %w", h.src.Name, err)
+		}
+
+		err = txStore.InsertIndexedEvent(ctx, sqlitestore.InsertIndexedEventParams{
+			Source:         h.src.Name,
+			Chain:          h.src.Chain,
+			Contract:       lg.Address.Bytes(),
+			EventName:      h.src.Event,
+			BlockNumber:    int64(lg.BlockNumber),
+			BlockTimestamp: int64(batch.BlockTimes[lg.BlockNumber]),
+			TxHash:         lg.TxHash.Bytes(),
+			LogIndex:       int64(lg.Index),
+			Args:           string(args),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert %s event: %w", h.src.Name, err)
+		}
+	}
+	return 0, nil
+}
+
+// ClearRange deletes the events of the source in a block range
+func (h sourceHandler) ClearRange(ctx context.Context, tx *sql.Tx, fromBlock, toBlock uint64) (int64, error) {
+	deleted, err := sqlitestore.NewTraced(tx).DeleteIndexedEventsInRange(ctx, sqlitestore.DeleteIndexedEventsInRangeParams{
+		Source:    h.src.Name,
+		FromBlock: int64(fromBlock),
+		ToBlock:   int64(toBlock),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete indexed events: %w", err)
+	}
+	return deleted, nil
+}
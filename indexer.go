@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/Golem-Base/bridgette/pkg/matcher"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"golang.org/x/sync/errgroup"
+)
+
+// bridgeIndexer indexes the bridge events of both chains with the indexer
+// runner while the matching engine pairs them. Configured event sources are
+// indexed alongside.
+type bridgeIndexer struct {
+	db                   *sql.DB
+	store                *sqlitestore.Queries
+	log                  *slog.Logger
+	matcher              *matcher.Engine
+	runner               *indexer.Runner
+	l1                   *indexer.Chain
+	l2                   *indexer.Chain
+	backfillingBatchSize uint64
+	// sources index the events of the events config
+	sources []*indexer.Chain
+}
+
+// newBridgeIndexer creates the bridge indexer. The clients may be nil for
+// commands that dial them later, or not at all.
+func newBridgeIndexer(cfg *config, db *sql.DB, l1Client, l2Client *tracing.EthClient, bus *events.Bus, log *slog.Logger) (*bridgeIndexer, error) {
+	contracts, err := cfg.contractRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := cfg.eventSources()
+	if err != nil {
+		return nil, err
+	}
+
+	ix := &bridgeIndexer{
+		db:      db,
+		store:   sqlitestore.NewTraced(db),
+		log:     log,
+		matcher: matcher.New(db, bus, log.With("component", "matcher")),
+		l1: &indexer.Chain{
+			Name:          "l1",
+			Source:        chainSource(l1Client),
+			Contracts:     contracts.L1,
+			LowPointer:    L1_ETH_DEPOSIT_INITIATED_LOW_BLOCK,
+			LastPointer:   L1_ETH_DEPOSIT_INITIATED_LAST_BLOCK,
+			BlockInterval: cfg.l1BlockInterval,
+			Confirmations: cfg.l1Confirmations,
+			Handler:       l1Handler{history: contracts.L1},
+		},
+		l2: &indexer.Chain{
+			Name:          "l2",
+			Source:        chainSource(l2Client),
+			Contracts:     contracts.L2,
+			LowPointer:    L2_ETH_DEPOSIT_FINALIZED_LOW_BLOCK,
+			LastPointer:   L2_ETH_DEPOSIT_FINALIZED_LAST_BLOCK,
+			BlockInterval: cfg.l2BlockInterval,
+			Confirmations: cfg.l2Confirmations,
+			Handler:       l2Handler{history: contracts.L2},
+		},
+		backfillingBatchSize: cfg.backfillingBatchSize,
+	}
+
+	for _, src := range sources {
+		client, blockInterval, confirmations := l1Client, cfg.l1BlockInterval, cfg.l1Confirmations
+		if src.Chain == registry.L2 {
+			client, blockInterval, confirmations = l2Client, cfg.l2BlockInterval, cfg.l2Confirmations
+		}
+		ix.sources = append(ix.sources, &indexer.Chain{
+			Name:   src.Name,
+			Source: chainSource(client),
+			Contracts: registry.History{{
+				Chain:     src.Chain,
+				Address:   src.Address,
+				FromBlock: src.FromBlock,
+				Topic:     src.Topic(),
+			}},
+			LowPointer:    src.LowPointer(),
+			LastPointer:   src.LastPointer(),
+			BlockInterval: blockInterval,
+			StartBlock:    src.FromBlock,
+			Confirmations: confirmations,
+			Handler:       sourceHandler{src: src},
+		})
+	}
+
+	ix.runner = indexer.New(db, indexer.Config{
+		BackfillingBatchSize: cfg.backfillingBatchSize,
+		ForwardingBatchSize:  cfg.forwardingBatchSize,
+		MaxRetries:           cfg.maxRetries,
+		RetryBackoff:         cfg.retryBackoff,
+		MaxReorgDepth:        cfg.maxReorgDepth,
+		FilterChecks:         cfg.filterChecks,
+		OnCommit: func(c *indexer.Chain, blockNumber uint64, count int) {
+			publishBatch(bus, c.Name, blockNumber, count)
+			ix.matcher.Notify()
+		},
+	}, log)
+	return ix, nil
+}
+
+// chainSource returns the client as a chain source, nil when it is nil
+func chainSource(client *tracing.EthClient) indexer.Source {
+	// A nil *EthClient would make a non-nil interface
+	if client == nil {
+		return nil
+	}
+	return client
+}
+
+// chain returns the chain or the event source with the given name
+func (ix *bridgeIndexer) chain(name string) (*indexer.Chain, error) {
+	switch name {
+	case "l1":
+		return ix.l1, nil
+	case "l2":
+		return ix.l2, nil
+	}
+	for _, c := range ix.sources {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown chain %q, expected l1, l2 or an event source", name)
+}
+
+// chainsOn returns the bridge chain l1 or l2 and the event sources on it
+func (ix *bridgeIndexer) chainsOn(chain string) []*indexer.Chain {
+	chains := []*indexer.Chain{ix.l1}
+	if chain == registry.L2 {
+		chains = []*indexer.Chain{ix.l2}
+	}
+	for _, c := range ix.sources {
+		if c.Contracts[0].Chain == chain {
+			chains = append(chains, c)
+		}
+	}
+	return chains
+}
+
+// matchInterval is how often the matching engine runs when it is not
+// notified of new events
+const matchInterval = time.Minute
+
+// run backfills both chains and then forward fills them until ctx is
+// cancelled, while the matching engine pairs the ingested events. Each event
+// source is backfilled and forward filled on its own.
+func (ix *bridgeIndexer) run(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return ix.matcher.Run(ctx, matchInterval)
+	})
+	eg.Go(func() error {
+		return ix.runner.Run(ctx, ix.l1, ix.l2)
+	})
+	for _, c := range ix.sources {
+		eg.Go(func() error {
+			return ix.runner.Run(ctx, c)
+		})
+	}
+	return eg.Wait()
+}
@@ -0,0 +1,314 @@
+package archive
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// An archive is a directory holding the logs of one chain in files named
+// <block>-<tx>-<log>.json, like the logparser fixtures, the headers of the
+// blocks they were emitted in under headers/<block>.json, and a manifest
+// naming the chain and the recorded block range.
+const (
+	manifestFile = "archive.json"
+	headersDir   = "headers"
+)
+
+// Manifest describes the contents of an archive
+type Manifest struct {
+	ChainID   uint64 `json:"chain_id"`
+	FromBlock uint64 `json:"from_block"`
+	ToBlock   uint64 `json:"to_block"`
+}
+
+// Client is the node an archive is recorded from
+type Client interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// LogFile returns the name of the file a log is stored in
+func LogFile(lg types.Log) string {
+	return fmt.Sprintf("%018d-%04d-%04d.json", lg.BlockNumber, lg.TxIndex, lg.Index)
+}
+
+func headerFile(number uint64) string {
+	return filepath.Join(headersDir, fmt.Sprintf("%018d.json", number))
+}
+
+// ReadManifest reads the manifest of the archive in dir
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive manifest: %w", err)
+	}
+	var m Manifest
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse archive manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// Record writes the logs the contracts emitted in blocks fromBlock to toBlock
+// to the archive in dir, a batch of blocks at a time, with the headers of
+// their blocks and of the first and last block. Recording into an existing
+// archive of the same chain extends it, as long as the ranges overlap or
+// are adjacent. It returns the number of recorded logs.
+func Record(ctx context.Context, client Client, dir string, contracts []registry.History, fromBlock, toBlock, batchSize uint64, log *slog.Logger) (int, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get chain id: %w", err)
+	}
+
+	manifest := Manifest{ChainID: chainID.Uint64(), FromBlock: fromBlock, ToBlock: toBlock}
+	existing, err := ReadManifest(dir)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return 0, err
+	case existing.ChainID != manifest.ChainID:
+		return 0, fmt.Errorf("the archive holds chain %d, not chain %d", existing.ChainID, manifest.ChainID)
+	case fromBlock > existing.ToBlock+1 || toBlock+1 < existing.FromBlock:
+		return 0, fmt.Errorf("blocks %d to %d would leave a gap in the archive of blocks %d to %d", fromBlock, toBlock, existing.FromBlock, existing.ToBlock)
+	default:
+		manifest.FromBlock = min(manifest.FromBlock, existing.FromBlock)
+		manifest.ToBlock = max(manifest.ToBlock, existing.ToBlock)
+	}
+
+	err = os.MkdirAll(filepath.Join(dir, headersDir), 0o755)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	recorded := 0
+	for start := fromBlock; start <= toBlock; start += batchSize {
+		end := min(start+batchSize-1, toBlock)
+
+		blocks := map[uint64]bool{}
+		for _, history := range contracts {
+			for _, segment := range history.Segments(start, end) {
+				logs, err := client.FilterLogs(ctx, segment.Query())
+				if err != nil {
+					return recorded, fmt.Errorf("failed to filter logs: %w", err)
+				}
+				for _, lg := range logs {
+					err = writeJSON(filepath.Join(dir, LogFile(lg)), lg)
+					if err != nil {
+						return recorded, err
+					}
+					blocks[lg.BlockNumber] = true
+				}
+				recorded += len(logs)
+			}
+		}
+
+		// The headers of the range ends bound the interpolated block times
+		if start == fromBlock {
+			blocks[fromBlock] = true
+		}
+		if end == toBlock {
+			blocks[toBlock] = true
+		}
+		for number := range blocks {
+			header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+			if err != nil {
+				return recorded, fmt.Errorf("failed to get header: %w", err)
+			}
+			err = writeJSON(filepath.Join(dir, headerFile(number)), header)
+			if err != nil {
+				return recorded, err
+			}
+		}
+
+		log.Info("recorded blocks", "from_block", start, "to_block", end, "logs", recorded)
+	}
+
+	// The manifest is written last, so an interrupted recording does not
+	// claim blocks it did not record
+	err = writeJSON(filepath.Join(dir, manifestFile), manifest)
+	if err != nil {
+		return recorded, err
+	}
+	return recorded, nil
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", filepath.Base(path), err)
+	}
+	err = os.WriteFile(path, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// Replay serves the contents of an archive in place of a node. Headers of
+// blocks without recorded logs are not archived, their timestamps are
+// interpolated between the nearest recorded headers.
+type Replay struct {
+	manifest Manifest
+	// logs are ordered by block and log index
+	logs    []types.Log
+	headers map[uint64]*types.Header
+	// numbers are the numbers of the recorded headers, in order
+	numbers []uint64
+}
+
+// Open loads the archive in dir
+func Open(dir string) (*Replay, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	r := &Replay{manifest: *manifest, headers: make(map[uint64]*types.Header)}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*-*-*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived logs: %w", err)
+	}
+	for _, file := range files {
+		var lg types.Log
+		err = readJSON(file, &lg)
+		if err != nil {
+			return nil, err
+		}
+		r.logs = append(r.logs, lg)
+	}
+	slices.SortFunc(r.logs, func(a, b types.Log) int {
+		if a.BlockNumber != b.BlockNumber {
+			return cmp.Compare(a.BlockNumber, b.BlockNumber)
+		}
+		return cmp.Compare(uint64(a.Index), uint64(b.Index))
+	})
+
+	files, err = filepath.Glob(filepath.Join(dir, headersDir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived headers: %w", err)
+	}
+	for _, file := range files {
+		var header types.Header
+		err = readJSON(file, &header)
+		if err != nil {
+			return nil, err
+		}
+		r.headers[header.Number.Uint64()] = &header
+		r.numbers = append(r.numbers, header.Number.Uint64())
+	}
+	slices.Sort(r.numbers)
+
+	for _, number := range []uint64{manifest.FromBlock, manifest.ToBlock} {
+		if r.headers[number] == nil {
+			return nil, fmt.Errorf("the archive misses the header of block %d", number)
+		}
+	}
+	return r, nil
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// Manifest returns the manifest of the archive
+func (r *Replay) Manifest() Manifest {
+	return r.manifest
+}
+
+// ChainID returns the id of the recorded chain
+func (r *Replay) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).SetUint64(r.manifest.ChainID), nil
+}
+
+// BlockNumber returns the last recorded block as the head
+func (r *Replay) BlockNumber(ctx context.Context) (uint64, error) {
+	return r.manifest.ToBlock, nil
+}
+
+// HeaderByNumber returns the recorded header of a block, or a header with
+// an interpolated timestamp. A nil number is the last recorded block.
+func (r *Replay) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	n := r.manifest.ToBlock
+	if number != nil {
+		n = number.Uint64()
+	}
+	err := r.check(n, n)
+	if err != nil {
+		return nil, err
+	}
+	if header, ok := r.headers[n]; ok {
+		return types.CopyHeader(header), nil
+	}
+
+	// The ends of the range are always recorded, so a block between them
+	// has recorded headers on both sides
+	i := sort.Search(len(r.numbers), func(i int) bool { return r.numbers[i] >= n })
+	prev, next := r.headers[r.numbers[i-1]], r.headers[r.numbers[i]]
+	prevNumber, nextNumber := prev.Number.Uint64(), next.Number.Uint64()
+	time := prev.Time + (next.Time-prev.Time)*(n-prevNumber)/(nextNumber-prevNumber)
+	return &types.Header{Number: new(big.Int).SetUint64(n), Time: time, Difficulty: new(big.Int)}, nil
+}
+
+// FilterLogs returns the recorded logs matching the query. The block range
+// must be within the archive, as logs outside of it are unknown.
+func (r *Replay) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.BlockHash != nil {
+		return nil, fmt.Errorf("filtering archived logs by block hash is not supported")
+	}
+	from, to := r.manifest.FromBlock, r.manifest.ToBlock
+	if q.FromBlock != nil {
+		from = q.FromBlock.Uint64()
+	}
+	if q.ToBlock != nil {
+		to = q.ToBlock.Uint64()
+	}
+	err := r.check(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := []types.Log{}
+	start := sort.Search(len(r.logs), func(i int) bool { return r.logs[i].BlockNumber >= from })
+	for _, lg := range r.logs[start:] {
+		if lg.BlockNumber > to {
+			break
+		}
+		if indexer.MatchesFilter(lg, q) {
+			logs = append(logs, lg)
+		}
+	}
+	return logs, nil
+}
+
+// check returns an error unless blocks from to to are in the archive
+func (r *Replay) check(from, to uint64) error {
+	if from < r.manifest.FromBlock || to > r.manifest.ToBlock {
+		return fmt.Errorf("blocks %d to %d are outside of the archive of blocks %d to %d", from, to, r.manifest.FromBlock, r.manifest.ToBlock)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package archive_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/archive"
+	"github.com/Golem-Base/bridgette/pkg/fakechain"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	contract = common.Address{0xc0}
+	topic    = common.Hash{0x01}
+)
+
+func TestLogFile(t *testing.T) {
+	// Archived logs are named like the logparser fixtures
+	name := "000000000003831667-0017-0031.json"
+	data, err := os.ReadFile("../logparser/fixtures/l1/" + name)
+	require.NoError(t, err)
+	var lg types.Log
+	require.NoError(t, json.Unmarshal(data, &lg))
+	require.Equal(t, name, archive.LogFile(lg))
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// Blocks are 2 seconds apart, every third one emits a log of the
+	// contract and one of another contract
+	chain := fakechain.New(7, 1000, 2)
+	for block := uint64(1); block <= 30; block++ {
+		if block%3 == 0 {
+			chain.Mine(
+				types.Log{Address: contract, Topics: []common.Hash{topic}},
+				types.Log{Address: common.Address{0xc1}, Topics: []common.Hash{topic}},
+			)
+		} else {
+			chain.Mine()
+		}
+	}
+	server := httptest.NewServer(chain)
+	defer server.Close()
+	client, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	dir := t.TempDir()
+	contracts := []registry.History{{{Address: contract, Topic: topic}}}
+	recorded, err := archive.Record(ctx, client, dir, contracts, 10, 20, 4, log)
+	require.NoError(t, err)
+	require.Equal(t, 3, recorded)
+
+	// The archive can only be extended without gaps
+	_, err = archive.Record(ctx, client, dir, contracts, 25, 30, 4, log)
+	require.ErrorContains(t, err, "would leave a gap")
+	recorded, err = archive.Record(ctx, client, dir, contracts, 21, 25, 4, log)
+	require.NoError(t, err)
+	require.Equal(t, 2, recorded)
+
+	replay, err := archive.Open(dir)
+	require.NoError(t, err)
+	require.Equal(t, archive.Manifest{ChainID: 7, FromBlock: 10, ToBlock: 25}, replay.Manifest())
+
+	head, err := replay.BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(25), head)
+
+	q := ethereum.FilterQuery{
+		FromBlock: big.NewInt(10),
+		ToBlock:   big.NewInt(20),
+		Addresses: []common.Address{contract},
+		Topics:    [][]common.Hash{{topic}},
+	}
+	logs, err := replay.FilterLogs(ctx, q)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+	// The replay serves the logs the node served
+	served, err := client.FilterLogs(ctx, q)
+	require.NoError(t, err)
+	require.Equal(t, served, logs)
+
+	q.FromBlock = big.NewInt(5)
+	_, err = replay.FilterLogs(ctx, q)
+	require.ErrorContains(t, err, "outside of the archive")
+
+	// Headers of blocks with logs are recorded, the others are interpolated
+	header, err := replay.HeaderByNumber(ctx, big.NewInt(12))
+	require.NoError(t, err)
+	require.Equal(t, chain.Header(12).Hash(), header.Hash())
+	for _, number := range []int64{10, 13, 14, 19, 24, 25} {
+		header, err = replay.HeaderByNumber(ctx, big.NewInt(number))
+		require.NoError(t, err)
+		require.Equal(t, chain.Header(uint64(number)).Time, header.Time)
+	}
+	_, err = replay.HeaderByNumber(ctx, big.NewInt(26))
+	require.ErrorContains(t, err, "outside of the archive")
+}
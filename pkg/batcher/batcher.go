@@ -0,0 +1,266 @@
+package batcher
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/monitor"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// LastBlock is the block pointer of the scanned L1 blocks
+const LastBlock = "batcher_transactions_last_processed_block"
+
+// BlobSize is the size of a blob in bytes
+const BlobSize = 4096 * 32
+
+// Client is the part of the L1 execution client used by the monitor
+type Client interface {
+	bind.ContractCaller
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// Config locates the batch inbox and bounds the blocks scanned per check
+type Config struct {
+	// SystemConfig is the L1 SystemConfig contract of the chain, which holds
+	// the batch inbox and batcher addresses
+	SystemConfig common.Address
+	// BatchSize is the number of L1 blocks scanned per check
+	BatchSize uint64
+	// StartBlocks is how many blocks before the L1 head the first check starts at
+	StartBlocks uint64
+}
+
+// Monitor watches the batcher transactions posted to the batch inbox on L1,
+// and samples the sync status of the rollup node if one is configured. A
+// batcher that stops posting leaves the L2 safe head behind, which delays
+// everything that waits for L2 safety.
+type Monitor struct {
+	db     *sql.DB
+	client Client
+	rollup RollupClient
+	cfg    Config
+	bus    *events.Bus
+	log    *slog.Logger
+
+	// inbox and signer are resolved on the first check, batcher on every check
+	inbox   common.Address
+	batcher common.Address
+	signer  types.Signer
+}
+
+// New creates a batcher monitor. rollup and bus may be nil.
+func New(db *sql.DB, client Client, rollup RollupClient, cfg Config, bus *events.Bus, log *slog.Logger) *Monitor {
+	return &Monitor{
+		db:     db,
+		client: client,
+		rollup: rollup,
+		cfg:    cfg,
+		bus:    bus,
+		log:    log,
+	}
+}
+
+// Run checks the batcher every interval until ctx is cancelled. The sync
+// status of the rollup node is sampled once per check.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) error {
+	return monitor.Every(ctx, interval, m.log, func(ctx context.Context) error {
+		return m.Check(ctx, time.Now())
+	})
+}
+
+// Check scans the L1 blocks since the previous check for batcher
+// transactions and samples the sync status of the rollup node
+func (m *Monitor) Check(ctx context.Context, now time.Time) error {
+	return tracing.Run(ctx, "batcher check", func(ctx context.Context) error {
+		err := m.resolve(ctx)
+		if err != nil {
+			return err
+		}
+		err = m.scan(ctx)
+		if err != nil {
+			return err
+		}
+		if m.rollup == nil {
+			return nil
+		}
+		return m.sampleSyncStatus(ctx, now)
+	})
+}
+
+// resolve reads the batch inbox on the first check, and the batcher on
+// every check so that a rotated batcher key is followed
+func (m *Monitor) resolve(ctx context.Context) error {
+	systemConfig, err := bindings.NewSystemConfigCaller(m.cfg.SystemConfig, m.client)
+	if err != nil {
+		return fmt.Errorf("failed to bind SystemConfig: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	if m.signer == nil {
+		inbox, err := systemConfig.BatchInbox(opts)
+		if err != nil {
+			return fmt.Errorf("failed to get batch inbox: %w", err)
+		}
+		chainID, err := m.client.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get L1 chain ID: %w", err)
+		}
+		m.inbox = inbox
+		m.signer = types.LatestSignerForChainID(chainID)
+		m.log.Info("resolved batch inbox", "inbox", m.inbox)
+	}
+
+	batcherHash, err := systemConfig.BatcherHash(opts)
+	if err != nil {
+		return fmt.Errorf("failed to get batcher: %w", err)
+	}
+	// The batcher hash is the batcher address left padded to 32 bytes
+	batcher := common.BytesToAddress(batcherHash[12:])
+	if batcher != m.batcher {
+		m.log.Info("resolved batcher", "batcher", batcher, "previous", m.batcher)
+		m.batcher = batcher
+	}
+	return nil
+}
+
+// scan stores the batcher transactions of up to BatchSize L1 blocks after
+// the block pointer
+func (m *Monitor) scan(ctx context.Context) error {
+	queries := sqlitestore.NewTraced(m.db)
+
+	pointer, err := queries.GetBlockPointer(ctx, LastBlock)
+	if err != nil {
+		return fmt.Errorf("failed to get batcher block pointer: %w", err)
+	}
+	head, err := m.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest L1 block: %w", err)
+	}
+	headNumber := head.Number.Uint64()
+
+	var fromBlock uint64
+	switch {
+	case pointer.BlockNumber != nil:
+		fromBlock = uint64(*pointer.BlockNumber) + 1
+	case headNumber > m.cfg.StartBlocks:
+		fromBlock = headNumber - m.cfg.StartBlocks
+	}
+	if fromBlock > headNumber {
+		return nil
+	}
+	toBlock := min(fromBlock+m.cfg.BatchSize-1, headNumber)
+
+	var txs []sqlitestore.InsertBatcherTransactionParams
+	var toTime uint64
+	for number := fromBlock; number <= toBlock; number++ {
+		block, err := m.client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+		if err != nil {
+			return fmt.Errorf("failed to get L1 block %d: %w", number, err)
+		}
+		toTime = block.Time()
+
+		for _, tx := range block.Transactions() {
+			if tx.To() == nil || *tx.To() != m.inbox {
+				continue
+			}
+			// The rollup node ignores transactions to the inbox not sent by the batcher
+			sender, err := types.Sender(m.signer, tx)
+			if err != nil || sender != m.batcher {
+				continue
+			}
+
+			params := sqlitestore.InsertBatcherTransactionParams{
+				BlockNumber: int64(number),
+				BlockTime:   int64(block.Time()),
+				TxHash:      tx.Hash().Bytes(),
+				DataSize:    int64(len(tx.Data())),
+			}
+			if tx.Type() == types.BlobTxType {
+				params.Blob = true
+				params.BlobCount = int64(len(tx.BlobHashes()))
+				params.DataSize = params.BlobCount * BlobSize
+			}
+			txs = append(txs, params)
+		}
+	}
+
+	err = m.store(ctx, txs, toBlock, toTime)
+	if err != nil {
+		return err
+	}
+	if len(txs) > 0 && m.bus != nil {
+		m.bus.Publish(events.Event{Type: events.Batcher, Chain: "l1", BlockNumber: toBlock, Count: len(txs)})
+	}
+	return nil
+}
+
+// store inserts the batcher transactions of a batch and advances the pointer in one transaction
+func (m *Monitor) store(ctx context.Context, txs []sqlitestore.InsertBatcherTransactionParams, toBlock, toTime uint64) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after a successful commit is a no-op
+	defer tx.Rollback()
+	txStore := sqlitestore.NewTraced(tx)
+
+	for _, params := range txs {
+		err := txStore.InsertBatcherTransaction(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to insert batcher transaction: %w", err)
+		}
+	}
+
+	blockNumber, blockTime := int64(toBlock), int64(toTime)
+	err = txStore.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+		BlockNumber: &blockNumber,
+		BlockTime:   &blockTime,
+		Name:        LastBlock,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update batcher block pointer: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// sampleSyncStatus records the sync status of the rollup node
+func (m *Monitor) sampleSyncStatus(ctx context.Context, now time.Time) error {
+	status, err := m.rollup.SyncStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sync status: %w", err)
+	}
+
+	err = sqlitestore.NewTraced(m.db).InsertSyncStatusSample(ctx, sqlitestore.InsertSyncStatusSampleParams{
+		ObservedAt:        now.Unix(),
+		CurrentL1Number:   int64(status.CurrentL1.Number),
+		HeadL1Number:      int64(status.HeadL1.Number),
+		UnsafeL2Number:    int64(status.UnsafeL2.Number),
+		UnsafeL2Time:      int64(status.UnsafeL2.Time),
+		SafeL2Number:      int64(status.SafeL2.Number),
+		SafeL2Time:        int64(status.SafeL2.Time),
+		FinalizedL2Number: int64(status.FinalizedL2.Number),
+		FinalizedL2Time:   int64(status.FinalizedL2.Time),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert sync status sample: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package batcher_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/batcher"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	chainID = big.NewInt(1)
+	inbox   = common.Address{0xff}
+)
+
+// fakeChain serves blocks and a SystemConfig naming the inbox and the batcher
+type fakeChain struct {
+	t       *testing.T
+	batcher common.Address
+	blocks  map[uint64]*types.Block
+	head    uint64
+}
+
+func (f *fakeChain) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeChain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	contractAbi, err := bindings.SystemConfigMetaData.GetAbi()
+	require.NoError(f.t, err)
+	method, err := contractAbi.MethodById(call.Data)
+	require.NoError(f.t, err)
+	switch method.Name {
+	case "batchInbox":
+		return method.Outputs.Pack(inbox)
+	default:
+		return method.Outputs.Pack(common.BytesToHash(f.batcher.Bytes()))
+	}
+}
+
+func (f *fakeChain) ChainID(ctx context.Context) (*big.Int, error) {
+	return chainID, nil
+}
+
+func (f *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return f.blocks[f.head].Header(), nil
+}
+
+func (f *fakeChain) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	block, ok := f.blocks[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+func TestMonitor(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(chainID)
+
+	calldata := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{ChainID: chainID, Nonce: 0, To: &inbox, Data: make([]byte, 1000)})
+	blob := types.MustSignNewTx(key, signer, &types.BlobTx{ChainID: uint256.MustFromBig(chainID), Nonce: 1, To: inbox, BlobHashes: []common.Hash{{1}, {2}}})
+	spam := types.MustSignNewTx(other, signer, &types.DynamicFeeTx{ChainID: chainID, Nonce: 0, To: &inbox, Data: make([]byte, 10)})
+
+	chain := &fakeChain{t: t, batcher: crypto.PubkeyToAddress(key.PublicKey), blocks: map[uint64]*types.Block{}, head: 12}
+	for number := uint64(0); number <= 12; number++ {
+		var txs []*types.Transaction
+		switch number {
+		case 9:
+			txs = []*types.Transaction{calldata, spam}
+		case 11:
+			txs = []*types.Transaction{blob}
+		}
+		header := &types.Header{Number: new(big.Int).SetUint64(number), Time: 1700000000 + number*12}
+		chain.blocks[number] = types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+	}
+
+	monitor := batcher.New(db, chain, nil, batcher.Config{BatchSize: 2, StartBlocks: 4}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// The first check starts 4 blocks before the head, each scans 2 blocks
+	for range 3 {
+		require.NoError(t, monitor.Check(ctx, time.Now()))
+	}
+
+	pointer, err := queries.GetBlockPointer(ctx, batcher.LastBlock)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), *pointer.BlockNumber)
+
+	txs, err := queries.ListBatcherTransactions(ctx, sqlitestore.ListBatcherTransactionsParams{Since: 0, Until: 1800000000, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.True(t, txs[0].Blob)
+	require.Equal(t, int64(2), txs[0].BlobCount)
+	require.Equal(t, int64(2*batcher.BlobSize), txs[0].DataSize)
+	require.False(t, txs[1].Blob)
+	require.Equal(t, int64(1000), txs[1].DataSize)
+	require.Equal(t, calldata.Hash().Bytes(), txs[1].TxHash)
+
+	// After the batcher key is rotated, only the new batcher's posts count
+	rotated := types.MustSignNewTx(other, signer, &types.DynamicFeeTx{ChainID: chainID, Nonce: 1, To: &inbox, Data: make([]byte, 20)})
+	stale := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{ChainID: chainID, Nonce: 2, To: &inbox, Data: make([]byte, 30)})
+	header := &types.Header{Number: big.NewInt(13), Time: 1700000000 + 13*12}
+	chain.blocks[13] = types.NewBlock(header, &types.Body{Transactions: []*types.Transaction{rotated, stale}}, nil, trie.NewStackTrie(nil))
+	chain.head = 13
+	chain.batcher = crypto.PubkeyToAddress(other.PublicKey)
+	require.NoError(t, monitor.Check(ctx, time.Now()))
+
+	txs, err = queries.ListBatcherTransactions(ctx, sqlitestore.ListBatcherTransactionsParams{Since: 0, Until: 1800000000, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	require.Equal(t, rotated.Hash().Bytes(), txs[0].TxHash)
+}
@@ -0,0 +1,52 @@
+package batcher
+
+import (
+	"context"
+
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// BlockRef identifies a block in the sync status of a rollup node
+type BlockRef struct {
+	Number uint64 `json:"number"`
+	Time   uint64 `json:"timestamp"`
+}
+
+// SyncStatus is the part of the optimism_syncStatus result used by the monitor
+type SyncStatus struct {
+	// CurrentL1 is the L1 block the derivation pipeline is at, HeadL1 the latest L1 block
+	CurrentL1   BlockRef `json:"current_l1"`
+	HeadL1      BlockRef `json:"head_l1"`
+	UnsafeL2    BlockRef `json:"unsafe_l2"`
+	SafeL2      BlockRef `json:"safe_l2"`
+	FinalizedL2 BlockRef `json:"finalized_l2"`
+}
+
+// RollupClient reads the sync status of a rollup node
+type RollupClient interface {
+	SyncStatus(ctx context.Context) (*SyncStatus, error)
+}
+
+// rpcRollupClient calls the optimism RPC namespace of a rollup node
+type rpcRollupClient struct {
+	client *rpc.Client
+}
+
+// NewRollupClient wraps an RPC client connected to a rollup node
+func NewRollupClient(client *rpc.Client) RollupClient {
+	return &rpcRollupClient{client: client}
+}
+
+// SyncStatus returns the current sync status of the rollup node
+func (c *rpcRollupClient) SyncStatus(ctx context.Context) (*SyncStatus, error) {
+	var status SyncStatus
+	err := tracing.Run(ctx, "optimism_syncStatus", func(ctx context.Context) error {
+		return c.client.CallContext(ctx, &status, "optimism_syncStatus")
+	}, trace.WithSpanKind(trace.SpanKindClient))
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,538 @@
+package demo
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/Golem-Base/bridgette/pkg/liveness"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// First blocks and block times of the synthetic chains
+const (
+	L1FirstBlock = 20_000_000
+	L2FirstBlock = 5_000_000
+	l1BlockTime  = 12
+	l2BlockTime  = 2
+)
+
+// L2ETH is the L2 token of bridged ETH in DepositFinalized events
+var L2ETH = common.HexToAddress("0xDeadDeAddeAddEAddeadDEaDDEAdDeaDDeAD0000")
+
+// maxIncident is the longest synthetic incident
+const maxIncident = time.Hour
+
+// Tags keep the random streams of blocks and incident slots apart
+const (
+	l1Stream = iota + 1
+	incidentStream
+)
+
+// Options shape the synthetic bridge traffic
+type Options struct {
+	// Seed picks the traffic, the same seed generates the same deposits and
+	// incidents relative to Genesis
+	Seed int64
+	// Genesis is the time of the first block of both chains
+	Genesis time.Time
+	// DepositsPerHour is the average number of deposits initiated per hour
+	DepositsPerHour float64
+	// LatencyMedian and LatencySpread shape the log-normal distribution of
+	// the time from a deposit on L1 to its finalization on L2. The spread is
+	// the standard deviation of the logarithm of the latency.
+	LatencyMedian time.Duration
+	LatencySpread float64
+	// StuckRate is the share of deposits never finalized on L2
+	StuckRate float64
+	// OrphanRate is the share of deposits finalized on L2 without a deposit
+	// on L1, as if it was missed
+	OrphanRate float64
+	// IncidentsPerDay is the average number of liveness incidents per day
+	IncidentsPerDay float64
+	// Accounts is the number of depositors, a few of them make most deposits
+	Accounts int
+	// L1Bridge is the address the L1 deposits are emitted from
+	L1Bridge common.Address
+	// L2Bridge is the address the L2 finalizations are emitted from
+	L2Bridge common.Address
+	// Now returns the current time, time.Now when nil
+	Now func() time.Time
+}
+
+// Generator synthesizes deposits on two chains whose heads follow the clock,
+// and liveness incidents. Everything is derived from the seed and the block
+// number or the hour, so nothing is kept in memory and the chains can be
+// indexed like real ones.
+type Generator struct {
+	opts       Options
+	l1         *Chain
+	l2         *Chain
+	initiated  abi.Event
+	finalized  abi.Event
+	maxLatency time.Duration
+	// recorded are the ids of the stored incidents by hour slot, and whether
+	// they were closed
+	recorded map[int64]*recordedIncident
+}
+
+type recordedIncident struct {
+	id     int64
+	closed bool
+}
+
+// New creates a generator
+func New(opts Options) (*Generator, error) {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Accounts < 1 {
+		return nil, fmt.Errorf("at least one account is required")
+	}
+	if opts.LatencyMedian <= 0 {
+		return nil, fmt.Errorf("the latency median must be positive")
+	}
+
+	l1ABI, err := bindings.L1StandardBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1StandardBridge ABI: %w", err)
+	}
+	l2ABI, err := bindings.L2StandardBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2StandardBridge ABI: %w", err)
+	}
+
+	g := &Generator{
+		opts:      opts,
+		initiated: l1ABI.Events["ETHDepositInitiated"],
+		finalized: l2ABI.Events["DepositFinalized"],
+		// Latencies are cut off at four standard deviations
+		maxLatency: time.Duration(float64(opts.LatencyMedian) * math.Exp(4*opts.LatencySpread)),
+		recorded:   make(map[int64]*recordedIncident),
+	}
+	g.l1 = &Chain{g: g, name: "l1", first: L1FirstBlock, blockTime: l1BlockTime}
+	g.l2 = &Chain{g: g, name: "l2", first: L2FirstBlock, blockTime: l2BlockTime}
+	return g, nil
+}
+
+// L1 returns the chain deposits are initiated on
+func (g *Generator) L1() *Chain {
+	return g.l1
+}
+
+// L2 returns the chain deposits are finalized on
+func (g *Generator) L2() *Chain {
+	return g.l2
+}
+
+// rng returns the random stream of a block or an incident slot
+func (g *Generator) rng(stream uint64, n uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(uint64(g.opts.Seed), stream<<56^n))
+}
+
+// hash derives a hash from the seed and the given parts
+func (g *Generator) hash(parts ...any) common.Hash {
+	data := binary.BigEndian.AppendUint64(nil, uint64(g.opts.Seed))
+	return crypto.Keccak256Hash(append(data, fmt.Sprint(parts...)...))
+}
+
+// account returns a depositor, the first ones are picked far more often
+func (g *Generator) account(rng *rand.Rand) common.Address {
+	u := rng.Float64()
+	index := int(float64(g.opts.Accounts) * u * u)
+	return common.BytesToAddress(g.hash("account", index).Bytes())
+}
+
+// deposit is a synthetic deposit
+type deposit struct {
+	l1Block uint64
+	index   int
+	from    common.Address
+	to      common.Address
+	amount  *big.Int
+	data    []byte
+	// stuck deposits are not finalized, orphans are not initiated
+	stuck  bool
+	orphan bool
+	// finalizedAt is when the deposit is finalized on L2
+	finalizedAt uint64
+}
+
+// deposits returns the deposits initiated in an L1 block
+func (g *Generator) deposits(l1Block uint64) []deposit {
+	rng := g.rng(l1Stream, l1Block)
+	initiatedAt := g.l1.time(l1Block)
+
+	n := poisson(rng, g.opts.DepositsPerHour*l1BlockTime/3600)
+	deposits := make([]deposit, n)
+	for i := range deposits {
+		d := &deposits[i]
+		d.l1Block = l1Block
+		d.index = i
+		d.from = g.account(rng)
+		d.to = d.from
+		if rng.Float64() < 0.1 {
+			d.to = g.account(rng)
+		}
+
+		// Amounts are log-normal around 0.5 ETH, with four decimals
+		eth := math.Exp(math.Log(0.5) + 1.5*rng.NormFloat64())
+		eth = min(max(eth, 0.0001), 500)
+		d.amount = new(big.Int).Mul(big.NewInt(int64(eth*1e4)), big.NewInt(1e14))
+
+		d.data = []byte{}
+		if rng.Float64() < 0.1 {
+			d.data = g.hash("data", l1Block, i).Bytes()[:4+rng.IntN(28)]
+		}
+
+		switch u := rng.Float64(); {
+		case u < g.opts.StuckRate:
+			d.stuck = true
+		case u < g.opts.StuckRate+g.opts.OrphanRate:
+			d.orphan = true
+		}
+
+		latency := time.Duration(float64(g.opts.LatencyMedian) * math.Exp(g.opts.LatencySpread*rng.NormFloat64()))
+		latency = min(max(latency, time.Second), g.maxLatency)
+		d.finalizedAt = initiatedAt + uint64(latency.Seconds())
+
+		// The sequencer does not finalize deposits while L2 is halted
+		incident := g.incidentAt(d.finalizedAt)
+		if incident != nil && (incident.Kind == liveness.Stall || incident.Kind == liveness.HeadLag) {
+			d.finalizedAt = uint64(incident.End.Unix())
+		}
+	}
+	return deposits
+}
+
+// poisson draws from a Poisson distribution with mean lambda
+func poisson(rng *rand.Rand, lambda float64) int {
+	limit := math.Exp(-lambda)
+	k, p := 0, rng.Float64()
+	for p > limit {
+		k++
+		p *= rng.Float64()
+	}
+	return k
+}
+
+// initiatedLog returns the ETHDepositInitiated log of a deposit
+func (g *Generator) initiatedLog(d deposit, blockHash common.Hash) types.Log {
+	data, err := g.initiated.Inputs.NonIndexed().Pack(d.amount, d.data)
+	if err != nil {
+		// The arguments always match the ABI
+		panic(fmt.Sprintf("failed to pack ETHDepositInitiated: %v", err))
+	}
+	return types.Log{
+		Address:     g.opts.L1Bridge,
+		Topics:      []common.Hash{g.initiated.ID, common.BytesToHash(d.from.Bytes()), common.BytesToHash(d.to.Bytes())},
+		Data:        data,
+		BlockNumber: d.l1Block,
+		TxHash:      g.hash("l1", d.l1Block, d.index),
+		TxIndex:     uint(d.index),
+		BlockHash:   blockHash,
+		Index:       uint(d.index),
+	}
+}
+
+// finalizedLog returns the DepositFinalized log of a deposit
+func (g *Generator) finalizedLog(d deposit, l2Block uint64) types.Log {
+	data, err := g.finalized.Inputs.NonIndexed().Pack(d.to, d.amount, d.data)
+	if err != nil {
+		// The arguments always match the ABI
+		panic(fmt.Sprintf("failed to pack DepositFinalized: %v", err))
+	}
+	return types.Log{
+		Address:     g.opts.L2Bridge,
+		Topics:      []common.Hash{g.finalized.ID, {}, common.BytesToHash(L2ETH.Bytes()), common.BytesToHash(d.from.Bytes())},
+		Data:        data,
+		BlockNumber: l2Block,
+		TxHash:      g.hash("l2", d.l1Block, d.index),
+		BlockHash:   g.l2.header(l2Block).Hash(),
+	}
+}
+
+// Chain is a synthetic chain, whose head is the latest block at the current
+// time. It implements the indexer source.
+type Chain struct {
+	g         *Generator
+	name      string
+	first     uint64
+	blockTime uint64
+}
+
+// FirstBlock returns the number of the first block
+func (c *Chain) FirstBlock() uint64 {
+	return c.first
+}
+
+func (c *Chain) genesis() uint64 {
+	return uint64(c.g.opts.Genesis.Unix())
+}
+
+// time returns the timestamp of a block
+func (c *Chain) time(number uint64) uint64 {
+	return c.genesis() + (number-c.first)*c.blockTime
+}
+
+// blockAt returns the first block at or after a timestamp
+func (c *Chain) blockAt(t uint64) uint64 {
+	if t <= c.genesis() {
+		return c.first
+	}
+	return c.first + (t-c.genesis()+c.blockTime-1)/c.blockTime
+}
+
+func (c *Chain) head() uint64 {
+	now := uint64(c.g.opts.Now().Unix())
+	if now <= c.genesis() {
+		return c.first
+	}
+	return c.first + (now-c.genesis())/c.blockTime
+}
+
+func (c *Chain) header(number uint64) *types.Header {
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Time:       c.time(number),
+		Difficulty: new(big.Int),
+		Extra:      []byte(c.name),
+	}
+}
+
+// BlockNumber returns the head
+func (c *Chain) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head(), nil
+}
+
+// HeaderByNumber returns the header of a block, the head when number is nil
+func (c *Chain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		return c.header(c.head()), nil
+	}
+	if !number.IsUint64() || number.Uint64() < c.first || number.Uint64() > c.head() {
+		return nil, ethereum.NotFound
+	}
+	return c.header(number.Uint64()), nil
+}
+
+// FilterLogs returns the logs of the blocks in the query range up to the head
+func (c *Chain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.BlockHash != nil {
+		return nil, fmt.Errorf("filtering synthetic logs by block hash is not supported")
+	}
+	from, to := c.first, c.head()
+	if q.FromBlock != nil {
+		from = max(from, q.FromBlock.Uint64())
+	}
+	if q.ToBlock != nil {
+		to = min(to, q.ToBlock.Uint64())
+	}
+	if from > to {
+		return []types.Log{}, nil
+	}
+
+	var logs []types.Log
+	if c == c.g.l1 {
+		logs = c.g.l1Logs(from, to)
+	} else {
+		logs = c.g.l2Logs(from, to)
+	}
+
+	filtered := []types.Log{}
+	for _, lg := range logs {
+		if indexer.MatchesFilter(lg, q) {
+			filtered = append(filtered, lg)
+		}
+	}
+	return filtered, nil
+}
+
+// l1Logs returns the logs of the deposits initiated in L1 blocks from to to
+func (g *Generator) l1Logs(from, to uint64) []types.Log {
+	var logs []types.Log
+	for block := from; block <= to; block++ {
+		blockHash := g.l1.header(block).Hash()
+		for _, d := range g.deposits(block) {
+			if !d.orphan {
+				logs = append(logs, g.initiatedLog(d, blockHash))
+			}
+		}
+	}
+	return logs
+}
+
+// l2Logs returns the logs of the deposits finalized in L2 blocks from to
+// to. They are found among the deposits initiated up to the longest latency,
+// plus the longest incident, before the first block.
+func (g *Generator) l2Logs(from, to uint64) []types.Log {
+	earliest := int64(g.l2.time(from)) - int64((g.maxLatency + maxIncident).Seconds())
+	l1From := g.l1.blockAt(uint64(max(earliest, 0)))
+	l1To := min(g.l1.blockAt(g.l2.time(to)), g.l1.head())
+
+	var finalized []deposit
+	for block := l1From; block <= l1To; block++ {
+		for _, d := range g.deposits(block) {
+			l2Block := g.l2.blockAt(d.finalizedAt)
+			if !d.stuck && l2Block >= from && l2Block <= to {
+				finalized = append(finalized, d)
+			}
+		}
+	}
+
+	// Finalizations are ordered by block, then by the deposits they finalize
+	slices.SortFunc(finalized, func(a, b deposit) int {
+		return cmp.Or(
+			cmp.Compare(g.l2.blockAt(a.finalizedAt), g.l2.blockAt(b.finalizedAt)),
+			cmp.Compare(a.l1Block, b.l1Block),
+			cmp.Compare(a.index, b.index),
+		)
+	})
+
+	var logs []types.Log
+	index := uint(0)
+	for i, d := range finalized {
+		lg := g.finalizedLog(d, g.l2.blockAt(d.finalizedAt))
+		if i > 0 && lg.BlockNumber != logs[i-1].BlockNumber {
+			index = 0
+		}
+		lg.TxIndex, lg.Index = index, index
+		index++
+		logs = append(logs, lg)
+	}
+	return logs
+}
+
+// Incident is a synthetic liveness incident
+type Incident struct {
+	Kind    string
+	Start   time.Time
+	End     time.Time
+	L2Block uint64
+	Worst   int64
+	Detail  string
+}
+
+// incident returns the incident started in an hour after genesis, if any
+func (g *Generator) incident(slot int64) *Incident {
+	if slot < 0 {
+		return nil
+	}
+	rng := g.rng(incidentStream, uint64(slot))
+	if rng.Float64() >= g.opts.IncidentsPerDay/24 {
+		return nil
+	}
+
+	kind := liveness.Kinds[rng.IntN(len(liveness.Kinds))]
+	start := g.opts.Genesis.Add(time.Duration(slot)*time.Hour + time.Duration(rng.Float64()*float64(time.Hour))).Truncate(time.Second)
+	duration := time.Duration(float64(10*time.Minute) * math.Exp(0.8*rng.NormFloat64()))
+	duration = min(max(duration, time.Minute), maxIncident).Truncate(time.Second)
+
+	incident := &Incident{
+		Kind:    kind,
+		Start:   start,
+		End:     start.Add(duration),
+		L2Block: g.l2.blockAt(uint64(start.Unix())),
+	}
+	switch kind {
+	case liveness.Stall:
+		incident.Worst = int64(duration.Seconds())
+		incident.Detail = fmt.Sprintf("L2 head stuck at block %d for %s", incident.L2Block, duration)
+	case liveness.HeadLag:
+		incident.Worst = int64(duration.Seconds())
+		incident.Detail = fmt.Sprintf("Latest L2 block %d is %s behind the wall clock", incident.L2Block, duration)
+	case liveness.BlockTime:
+		incident.Worst = int64(3 + rng.IntN(8))
+		incident.Detail = fmt.Sprintf("L2 block %d took %ds", incident.L2Block, incident.Worst)
+	case liveness.OriginLag:
+		incident.Worst = int64(150 + rng.IntN(300))
+		incident.Detail = fmt.Sprintf("L1 origin of L2 block %d is %d blocks behind the L1 head", incident.L2Block, incident.Worst)
+	case liveness.BatcherGap:
+		gap := 10*time.Minute + duration
+		incident.Worst = int64(gap.Seconds())
+		incident.Detail = fmt.Sprintf("No batch posted for %s", gap)
+	case liveness.SafeLag:
+		lag := 10*time.Minute + duration
+		incident.Worst = int64(lag.Seconds())
+		incident.Detail = fmt.Sprintf("L2 safe head is %s behind the unsafe head", lag)
+	}
+	return incident
+}
+
+// incidentAt returns an incident ongoing at a timestamp, if any
+func (g *Generator) incidentAt(t uint64) *Incident {
+	at := time.Unix(int64(t), 0)
+	slot := int64(at.Sub(g.opts.Genesis) / time.Hour)
+	// Incidents are shorter than an hour, so only the previous slot can overlap
+	for _, s := range []int64{slot - 1, slot} {
+		incident := g.incident(s)
+		if incident != nil && !at.Before(incident.Start) && at.Before(incident.End) {
+			return incident
+		}
+	}
+	return nil
+}
+
+// Incidents returns the incidents started before until
+func (g *Generator) Incidents(until time.Time) []Incident {
+	var incidents []Incident
+	for slot := int64(0); g.opts.Genesis.Add(time.Duration(slot) * time.Hour).Before(until); slot++ {
+		incident := g.incident(slot)
+		if incident != nil && incident.Start.Before(until) {
+			incidents = append(incidents, *incident)
+		}
+	}
+	return incidents
+}
+
+// RecordIncidents stores the incidents started by now and closes those that
+// ended. It returns how many incidents were opened or closed.
+func (g *Generator) RecordIncidents(ctx context.Context, db *sql.DB) (int, error) {
+	now := g.opts.Now()
+	queries := sqlitestore.NewTraced(db)
+
+	changed := 0
+	for _, incident := range g.Incidents(now) {
+		slot := int64(incident.Start.Sub(g.opts.Genesis) / time.Hour)
+		recorded := g.recorded[slot]
+		if recorded == nil {
+			id, err := queries.InsertLivenessIncident(ctx, sqlitestore.InsertLivenessIncidentParams{
+				Kind:          incident.Kind,
+				StartedAt:     incident.Start.Unix(),
+				L2BlockNumber: int64(incident.L2Block),
+				Worst:         incident.Worst,
+				Detail:        incident.Detail,
+			})
+			if err != nil {
+				return changed, fmt.Errorf("failed to insert %s incident: %w", incident.Kind, err)
+			}
+			recorded = &recordedIncident{id: id}
+			g.recorded[slot] = recorded
+			changed++
+		}
+		if !recorded.closed && !incident.End.After(now) {
+			endedAt := incident.End.Unix()
+			err := queries.CloseLivenessIncident(ctx, sqlitestore.CloseLivenessIncidentParams{EndedAt: &endedAt, ID: recorded.id})
+			if err != nil {
+				return changed, fmt.Errorf("failed to close %s incident %d: %w", incident.Kind, recorded.id, err)
+			}
+			recorded.closed = true
+			changed++
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,135 @@
+package demo_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/demo"
+	"github.com/Golem-Base/bridgette/pkg/liveness"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	l1Bridge = common.Address{0xb1}
+	l2Bridge = common.Address{0xb2}
+	start    = time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func newGenerator(t *testing.T, seed int64, now *time.Time) *demo.Generator {
+	g, err := demo.New(demo.Options{
+		Seed:            seed,
+		Genesis:         start,
+		DepositsPerHour: 60,
+		LatencyMedian:   3 * time.Minute,
+		LatencySpread:   0.5,
+		StuckRate:       0.05,
+		OrphanRate:      0.05,
+		IncidentsPerDay: 6,
+		Accounts:        20,
+		L1Bridge:        l1Bridge,
+		L2Bridge:        l2Bridge,
+		Now:             func() time.Time { return *now },
+	})
+	require.NoError(t, err)
+	return g
+}
+
+func filter(t *testing.T, c *demo.Chain, from, to uint64) []types.Log {
+	logs, err := c.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+	})
+	require.NoError(t, err)
+	return logs
+}
+
+func TestGenerator(t *testing.T) {
+	ctx := context.Background()
+	now := start.Add(24 * time.Hour)
+	g := newGenerator(t, 1, &now)
+
+	// The heads follow the clock
+	l1Head, err := g.L1().BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(demo.L1FirstBlock+24*3600/12), l1Head)
+	l2Head, err := g.L2().BlockNumber(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(demo.L2FirstBlock+24*3600/2), l2Head)
+	_, err = g.L2().HeaderByNumber(ctx, new(big.Int).SetUint64(l2Head+1))
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// The same seed generates the same logs, another one does not
+	l1Logs := filter(t, g.L1(), demo.L1FirstBlock, l1Head)
+	l2Logs := filter(t, g.L2(), demo.L2FirstBlock, l2Head)
+	require.Equal(t, l1Logs, filter(t, newGenerator(t, 1, &now).L1(), demo.L1FirstBlock, l1Head))
+	require.NotEqual(t, l1Logs, filter(t, newGenerator(t, 2, &now).L1(), demo.L1FirstBlock, l1Head))
+
+	// About 60 deposits an hour, some of them stuck or orphaned
+	require.InDelta(t, 24*60*0.95, len(l1Logs), 24*60*0.1)
+	require.InDelta(t, 24*60*0.95, len(l2Logs), 24*60*0.1)
+
+	// Ranges can be filtered in any batches
+	mid := demo.L2FirstBlock + (l2Head-demo.L2FirstBlock)/3
+	require.Equal(t, l2Logs, append(filter(t, g.L2(), demo.L2FirstBlock, mid), filter(t, g.L2(), mid+1, l2Head)...))
+	for i := 1; i < len(l2Logs); i++ {
+		if l2Logs[i].BlockNumber == l2Logs[i-1].BlockNumber {
+			require.Equal(t, l2Logs[i-1].Index+1, l2Logs[i].Index)
+		}
+	}
+
+	// Finalizations wait for the L2 head, and for the end of stalls
+	incidents := g.Incidents(now)
+	require.NotEmpty(t, incidents)
+	for _, lg := range l2Logs {
+		header, err := g.L2().HeaderByNumber(ctx, new(big.Int).SetUint64(lg.BlockNumber))
+		require.NoError(t, err)
+		require.LessOrEqual(t, header.Time, uint64(now.Unix()))
+		for _, incident := range incidents {
+			if incident.Kind == liveness.Stall {
+				at := time.Unix(int64(header.Time), 0)
+				require.False(t, at.After(incident.Start) && at.Before(incident.End), "finalized during a stall")
+			}
+		}
+	}
+}
+
+func TestRecordIncidents(t *testing.T) {
+	ctx := context.Background()
+	db := sqlitetest.Open(t)
+
+	now := start.Add(48 * time.Hour)
+	g := newGenerator(t, 1, &now)
+	incidents := g.Incidents(now)
+	require.NotEmpty(t, incidents)
+
+	// Incidents are opened once and closed once they ended
+	changed, err := g.RecordIncidents(ctx, db)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, changed, 2*len(incidents)-1)
+	changed, err = g.RecordIncidents(ctx, db)
+	require.NoError(t, err)
+	require.Zero(t, changed)
+
+	since := start.Unix()
+	stored, err := sqlitestore.New(db).ListLivenessIncidents(ctx, sqlitestore.ListLivenessIncidentsParams{
+		Since: &since,
+		Until: now.Unix(),
+		Limit: 1000,
+	})
+	require.NoError(t, err)
+	require.Len(t, stored, len(incidents))
+
+	now = now.Add(2 * time.Hour)
+	_, err = g.RecordIncidents(ctx, db)
+	require.NoError(t, err)
+	var open int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM liveness_incidents WHERE ended_at IS NULL").Scan(&open))
+	require.Zero(t, open)
+}
@@ -0,0 +1,429 @@
+package disputegame
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/logparser"
+	"github.com/Golem-Base/bridgette/pkg/monitor"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DisputeGameCreatedEvent is the topic of the DisputeGameFactory
+// DisputeGameCreated (index_topic_1 address disputeProxy, index_topic_2 uint32 gameType, index_topic_3 bytes32 rootClaim) event
+var DisputeGameCreatedEvent = common.HexToHash("0x5b565efe82411da98814f356d0e7bcb8f0219b8d970307c5afb4a6903a8b2e35")
+
+// L2ToL1MessagePasser is the predeploy whose storage root is part of the output root
+var L2ToL1MessagePasser = common.HexToAddress("0x4200000000000000000000000000000000000016")
+
+// LastBlock is the block pointer of the scanned DisputeGameCreated events
+const LastBlock = "dispute_games_last_processed_block"
+
+// GameStatus values of a dispute game
+const (
+	InProgress     = 0
+	ChallengerWins = 1
+	DefenderWins   = 2
+)
+
+// MaxGamesPerCheck bounds the games whose status is read and whose root claim
+// is verified per check, the oldest first
+const MaxGamesPerCheck = 100
+
+// L1Client is the part of the L1 execution client used by the monitor
+type L1Client interface {
+	bind.ContractCaller
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// L2Client is the part of the L2 execution client the output roots are computed from
+type L2Client interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	StorageHash(ctx context.Context, account common.Address, blockNumber *big.Int) (common.Hash, error)
+}
+
+// Config locates the DisputeGameFactory and bounds the blocks scanned
+type Config struct {
+	Factory common.Address
+	// BatchSize is the number of L1 blocks scanned for new games per request
+	BatchSize uint64
+	// StartBlocks is how many blocks before the L1 head the first check starts at
+	StartBlocks uint64
+}
+
+// Monitor indexes the dispute games created by the DisputeGameFactory on L1,
+// tracks their status until they resolve, and checks their root claim against
+// the output root computed from L2. Withdrawals are proven against these
+// claims, so an invalid claim, or a game resolved against the honest output,
+// is flagged as critical.
+type Monitor struct {
+	db  *sql.DB
+	l1  L1Client
+	l2  L2Client
+	cfg Config
+	bus *events.Bus
+	log *slog.Logger
+}
+
+// New creates a dispute game monitor. Changes are published on bus, which may be nil.
+func New(db *sql.DB, l1 L1Client, l2 L2Client, cfg Config, bus *events.Bus, log *slog.Logger) *Monitor {
+	return &Monitor{
+		db:  db,
+		l1:  l1,
+		l2:  l2,
+		cfg: cfg,
+		bus: bus,
+		log: log,
+	}
+}
+
+// Run checks the dispute games every interval until ctx is cancelled. Games
+// that L2 has not safely derived yet are verified at a later check.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) error {
+	return monitor.Every(ctx, interval, m.log, m.Check)
+}
+
+// Check indexes the games created since the previous check, updates the
+// status of the games in progress and verifies the root claims of the blocks
+// L2 has safely derived
+func (m *Monitor) Check(ctx context.Context) error {
+	return tracing.Run(ctx, "dispute game check", func(ctx context.Context) error {
+		queries := sqlitestore.NewTraced(m.db)
+
+		created, err := m.scan(ctx, queries)
+		if err != nil {
+			return err
+		}
+		resolved, err := m.updateStatuses(ctx, queries)
+		if err != nil {
+			return err
+		}
+		verified, err := m.verify(ctx, queries)
+		if err != nil {
+			return err
+		}
+
+		changed := created + resolved + verified
+		if changed > 0 && m.bus != nil {
+			m.bus.Publish(events.Event{Type: events.DisputeGame, Chain: "l1", Count: changed})
+		}
+		return nil
+	})
+}
+
+// scan stores the games created after the block pointer up to the L1 head and
+// returns how many were found
+func (m *Monitor) scan(ctx context.Context, queries *sqlitestore.Queries) (int, error) {
+	pointer, err := queries.GetBlockPointer(ctx, LastBlock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get dispute games block pointer: %w", err)
+	}
+	head, err := m.l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest L1 block: %w", err)
+	}
+	headNumber := head.Number.Uint64()
+
+	var fromBlock uint64
+	switch {
+	case pointer.BlockNumber != nil:
+		fromBlock = uint64(*pointer.BlockNumber) + 1
+	case headNumber > m.cfg.StartBlocks:
+		fromBlock = headNumber - m.cfg.StartBlocks
+	}
+
+	created := 0
+	for start := fromBlock; start <= headNumber; start += m.cfg.BatchSize {
+		end := min(start+m.cfg.BatchSize-1, headNumber)
+
+		logs, err := m.l1.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: []common.Address{m.cfg.Factory},
+			Topics:    [][]common.Hash{{DisputeGameCreatedEvent}},
+		})
+		if err != nil {
+			return created, fmt.Errorf("failed to filter dispute game logs: %w", err)
+		}
+
+		games, err := m.readGames(ctx, logs)
+		if err != nil {
+			return created, err
+		}
+		err = m.store(ctx, games, end)
+		if err != nil {
+			return created, err
+		}
+		created += len(games)
+	}
+	return created, nil
+}
+
+// readGames reads the L2 block number claimed by each created game and the
+// time of the L1 block it was created in
+func (m *Monitor) readGames(ctx context.Context, logs []types.Log) ([]sqlitestore.InsertDisputeGameParams, error) {
+	blockTimes := make(map[uint64]uint64)
+	games := make([]sqlitestore.InsertDisputeGameParams, 0, len(logs))
+	for _, lg := range logs {
+		event, err := logparser.ParseDisputeGameFactoryDisputeGameCreatedEvent(&lg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse dispute game log: %w", err)
+		}
+
+		blockTime, ok := blockTimes[lg.BlockNumber]
+		if !ok {
+			header, err := m.l1.HeaderByNumber(ctx, new(big.Int).SetUint64(lg.BlockNumber))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get L1 block %d: %w", lg.BlockNumber, err)
+			}
+			blockTime = header.Time
+			blockTimes[lg.BlockNumber] = blockTime
+		}
+
+		game, err := bindings.NewFaultDisputeGameCaller(event.DisputeProxy, m.l1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind dispute game: %w", err)
+		}
+		l2BlockNumber, err := game.L2BlockNumber(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get L2 block number of game %s: %w", event.DisputeProxy, err)
+		}
+
+		games = append(games, sqlitestore.InsertDisputeGameParams{
+			GameAddress:   event.DisputeProxy.Bytes(),
+			GameType:      int64(event.GameType),
+			RootClaim:     event.RootClaim[:],
+			L2BlockNumber: l2BlockNumber.Int64(),
+			BlockNumber:   int64(lg.BlockNumber),
+			BlockTime:     int64(blockTime),
+			TxHash:        lg.TxHash.Bytes(),
+		})
+	}
+	return games, nil
+}
+
+// store inserts the games of a batch and advances the pointer in one transaction
+func (m *Monitor) store(ctx context.Context, games []sqlitestore.InsertDisputeGameParams, toBlock uint64) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after a successful commit is a no-op
+	defer tx.Rollback()
+	txStore := sqlitestore.NewTraced(tx)
+
+	for _, params := range games {
+		err := txStore.InsertDisputeGame(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to insert dispute game: %w", err)
+		}
+	}
+
+	blockNumber := int64(toBlock)
+	err = txStore.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+		BlockNumber: &blockNumber,
+		Name:        LastBlock,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update dispute games block pointer: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// updateStatuses reads the status of the games in progress and returns how
+// many resolved
+func (m *Monitor) updateStatuses(ctx context.Context, queries *sqlitestore.Queries) (int, error) {
+	games, err := queries.ListUnresolvedDisputeGames(ctx, MaxGamesPerCheck)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list unresolved dispute games: %w", err)
+	}
+
+	resolved := 0
+	for _, game := range games {
+		caller, err := bindings.NewFaultDisputeGameCaller(common.BytesToAddress(game.GameAddress), m.l1)
+		if err != nil {
+			return resolved, fmt.Errorf("failed to bind dispute game: %w", err)
+		}
+		opts := &bind.CallOpts{Context: ctx}
+		status, err := caller.Status(opts)
+		if err != nil {
+			return resolved, fmt.Errorf("failed to get status of game %s: %w", common.BytesToAddress(game.GameAddress), err)
+		}
+		if status == InProgress {
+			continue
+		}
+		resolvedAt, err := caller.ResolvedAt(opts)
+		if err != nil {
+			return resolved, fmt.Errorf("failed to get resolution time of game %s: %w", common.BytesToAddress(game.GameAddress), err)
+		}
+
+		resolvedAtUnix := int64(resolvedAt)
+		game.Status = int64(status)
+		game.ResolvedAt = &resolvedAtUnix
+		err = queries.UpdateDisputeGameStatus(ctx, sqlitestore.UpdateDisputeGameStatusParams{
+			Status:     game.Status,
+			ResolvedAt: game.ResolvedAt,
+			ID:         game.ID,
+		})
+		if err != nil {
+			return resolved, fmt.Errorf("failed to update dispute game status: %w", err)
+		}
+		err = m.flag(ctx, queries, game)
+		if err != nil {
+			return resolved, err
+		}
+		resolved++
+	}
+	return resolved, nil
+}
+
+// verify compares the root claims of the games up to the L2 safe head with
+// the output root computed from L2 and returns how many were verified. Blocks
+// above the safe head are not derived from L1 yet and may still change, so
+// their games stay pending. Games whose state the L2 node no longer has, e.g.
+// because it pruned it, are logged and marked so that they are not verified
+// again and do not hold back newer games.
+func (m *Monitor) verify(ctx context.Context, queries *sqlitestore.Queries) (int, error) {
+	head, err := m.l2.HeaderByNumber(ctx, big.NewInt(rpc.SafeBlockNumber.Int64()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get safe L2 block: %w", err)
+	}
+	games, err := queries.ListUnverifiedDisputeGames(ctx, sqlitestore.ListUnverifiedDisputeGamesParams{
+		L2BlockNumber: head.Number.Int64(),
+		Limit:         MaxGamesPerCheck,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list unverified dispute games: %w", err)
+	}
+
+	verified := 0
+	for _, game := range games {
+		number := big.NewInt(game.L2BlockNumber)
+		header, err := m.l2.HeaderByNumber(ctx, number)
+		if err != nil {
+			return verified, fmt.Errorf("failed to get L2 block %d: %w", game.L2BlockNumber, err)
+		}
+		storageHash, err := m.l2.StorageHash(ctx, L2ToL1MessagePasser, number)
+		if err != nil {
+			err = m.skip(ctx, queries, game, err)
+			if err != nil {
+				return verified, err
+			}
+			continue
+		}
+
+		outputRoot := outputRootV0(header, storageHash)
+		valid := common.BytesToHash(game.RootClaim) == outputRoot
+		game.ExpectedRoot = outputRoot.Bytes()
+		game.Valid = &valid
+		err = queries.UpdateDisputeGameOutputRoot(ctx, sqlitestore.UpdateDisputeGameOutputRootParams{
+			ExpectedRoot: game.ExpectedRoot,
+			Valid:        game.Valid,
+			ID:           game.ID,
+		})
+		if err != nil {
+			return verified, fmt.Errorf("failed to update dispute game output root: %w", err)
+		}
+		err = m.flag(ctx, queries, game)
+		if err != nil {
+			return verified, err
+		}
+		verified++
+	}
+	return verified, nil
+}
+
+// skip records why the output root of a game cannot be computed
+func (m *Monitor) skip(ctx context.Context, queries *sqlitestore.Queries, game sqlitestore.DisputeGame, reason error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	m.log.Warn("skipping dispute game without L2 state", "game", common.BytesToAddress(game.GameAddress), "l2_block_number", game.L2BlockNumber, "error", reason)
+
+	message := reason.Error()
+	err := queries.UpdateDisputeGameOutputRootError(ctx, sqlitestore.UpdateDisputeGameOutputRootErrorParams{
+		OutputRootError: &message,
+		ID:              game.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark output root of dispute game as unavailable: %w", err)
+	}
+	return nil
+}
+
+// flag records why a game is critical when that changed
+func (m *Monitor) flag(ctx context.Context, queries *sqlitestore.Queries, game sqlitestore.DisputeGame) error {
+	reason := CriticalReason(game)
+	if reason == "" || (game.CriticalReason != nil && *game.CriticalReason == reason) {
+		return nil
+	}
+
+	err := queries.FlagDisputeGame(ctx, sqlitestore.FlagDisputeGameParams{CriticalReason: &reason, ID: game.ID})
+	if err != nil {
+		return fmt.Errorf("failed to flag dispute game: %w", err)
+	}
+	m.log.Error("critical dispute game", "game", common.BytesToAddress(game.GameAddress), "l2_block_number", game.L2BlockNumber, "reason", reason)
+	return nil
+}
+
+// CriticalReason describes why a game threatens withdrawals, or returns an
+// empty string when it does not. A game is critical when its root claim does
+// not match the output root computed from L2, or when it resolved against the
+// honest output.
+func CriticalReason(game sqlitestore.DisputeGame) string {
+	if game.Valid == nil {
+		return ""
+	}
+	rootClaim := hexutil.Encode(game.RootClaim)
+	switch {
+	case !*game.Valid && game.Status == DefenderWins:
+		return fmt.Sprintf("Invalid root claim %s for L2 block %d resolved in favour of the defender, the output root is %s", rootClaim, game.L2BlockNumber, hexutil.Encode(game.ExpectedRoot))
+	case !*game.Valid:
+		return fmt.Sprintf("Root claim %s does not match the output root %s of L2 block %d", rootClaim, hexutil.Encode(game.ExpectedRoot), game.L2BlockNumber)
+	case game.Status == ChallengerWins:
+		return fmt.Sprintf("Valid root claim %s for L2 block %d resolved in favour of the challenger", rootClaim, game.L2BlockNumber)
+	}
+	return ""
+}
+
+// OutputRoot computes the version 0 output root of an L2 block: the hash of
+// the version, the state root, the storage root of the L2ToL1MessagePasser and
+// the block hash
+func OutputRoot(ctx context.Context, l2 L2Client, blockNumber uint64) (common.Hash, error) {
+	number := new(big.Int).SetUint64(blockNumber)
+	header, err := l2.HeaderByNumber(ctx, number)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get L2 block %d: %w", blockNumber, err)
+	}
+	storageHash, err := l2.StorageHash(ctx, L2ToL1MessagePasser, number)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to get message passer storage root at L2 block %d: %w", blockNumber, err)
+	}
+	return outputRootV0(header, storageHash), nil
+}
+
+// outputRootV0 hashes the version 0 output root of a block
+func outputRootV0(header *types.Header, storageHash common.Hash) common.Hash {
+	var version common.Hash
+	return crypto.Keccak256Hash(version[:], header.Root[:], storageHash[:], header.Hash().Bytes())
+}
@@ -0,0 +1,220 @@
+package disputegame_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/disputegame"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var factory = common.Address{0xfa}
+
+// game is the state of a dispute game served by fakeL1
+type game struct {
+	l2BlockNumber uint64
+	status        uint8
+}
+
+// fakeL1 serves DisputeGameCreated logs and the games they created
+type fakeL1 struct {
+	t     *testing.T
+	head  uint64
+	logs  []types.Log
+	games map[common.Address]*game
+}
+
+func (f *fakeL1) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeL1) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	contractAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(f.t, err)
+	method, err := contractAbi.MethodById(call.Data)
+	require.NoError(f.t, err)
+	g := f.games[*call.To]
+	switch method.Name {
+	case "l2BlockNumber":
+		return method.Outputs.Pack(new(big.Int).SetUint64(g.l2BlockNumber))
+	case "status":
+		return method.Outputs.Pack(g.status)
+	default:
+		return method.Outputs.Pack(uint64(1700000000))
+	}
+}
+
+func (f *fakeL1) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	require.Equal(f.t, []common.Address{factory}, q.Addresses)
+	var logs []types.Log
+	for _, lg := range f.logs {
+		if lg.BlockNumber >= q.FromBlock.Uint64() && lg.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, lg)
+		}
+	}
+	return logs, nil
+}
+
+func (f *fakeL1) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		number = new(big.Int).SetUint64(f.head)
+	}
+	return &types.Header{Number: number, Time: 1600000000 + number.Uint64()*12}, nil
+}
+
+// create adds a game claiming rootClaim for an L2 block, created in an L1 block
+func (f *fakeL1) create(address common.Address, l1BlockNumber, l2BlockNumber uint64, rootClaim common.Hash) {
+	f.games[address] = &game{l2BlockNumber: l2BlockNumber}
+	f.logs = append(f.logs, types.Log{
+		Address:     factory,
+		Topics:      []common.Hash{disputegame.DisputeGameCreatedEvent, common.BytesToHash(address.Bytes()), {}, rootClaim},
+		BlockNumber: l1BlockNumber,
+		TxHash:      common.Hash{byte(l1BlockNumber)},
+	})
+}
+
+// fakeL2 serves headers up to head, the safe head and a fixed message passer
+// storage root. The state of the blocks below prunedBelow is gone.
+type fakeL2 struct {
+	head, safe  uint64
+	prunedBelow uint64
+}
+
+func (f *fakeL2) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	switch {
+	case number == nil:
+		number = new(big.Int).SetUint64(f.head)
+	case number.Int64() == rpc.SafeBlockNumber.Int64():
+		number = new(big.Int).SetUint64(f.safe)
+	}
+	return &types.Header{Number: number, Root: common.BigToHash(number)}, nil
+}
+
+func (f *fakeL2) StorageHash(ctx context.Context, account common.Address, blockNumber *big.Int) (common.Hash, error) {
+	if blockNumber.Uint64() < f.prunedBelow {
+		return common.Hash{}, errors.New("missing trie node")
+	}
+	return common.Hash{0x16}, nil
+}
+
+func TestMonitor(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	l1 := &fakeL1{t: t, head: 100, games: map[common.Address]*game{}}
+	l2 := &fakeL2{head: 500, safe: 400}
+	outputRoot := func(number uint64) common.Hash {
+		root, err := disputegame.OutputRoot(ctx, l2, number)
+		require.NoError(t, err)
+		return root
+	}
+
+	honest, invalid, defeated, ahead, old, unsafe := common.Address{1}, common.Address{2}, common.Address{3}, common.Address{4}, common.Address{5}, common.Address{6}
+	l1.create(honest, 60, 10, outputRoot(10))
+	l1.create(invalid, 70, 20, common.Hash{0xba, 0xd})
+	l1.create(defeated, 80, 30, outputRoot(30))
+	l1.create(ahead, 90, 1000, common.Hash{0xfe})
+	// Above the safe head, the block may still be reorged
+	l1.create(unsafe, 95, 450, outputRoot(450))
+	// Before the first scanned block
+	l1.create(old, 10, 5, outputRoot(5))
+
+	monitor := disputegame.New(db, l1, l2, disputegame.Config{Factory: factory, BatchSize: 20, StartBlocks: 50}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, monitor.Check(ctx))
+
+	games, err := queries.ListDisputeGames(ctx, sqlitestore.ListDisputeGamesParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, games, 5)
+	byAddress := func(address common.Address) sqlitestore.DisputeGame {
+		for _, g := range games {
+			if common.BytesToAddress(g.GameAddress) == address {
+				return g
+			}
+		}
+		t.Fatalf("game %s not indexed", address)
+		return sqlitestore.DisputeGame{}
+	}
+
+	require.True(t, *byAddress(honest).Valid)
+	require.Nil(t, byAddress(honest).CriticalReason)
+	require.False(t, *byAddress(invalid).Valid)
+	require.Contains(t, *byAddress(invalid).CriticalReason, "does not match the output root")
+	require.Nil(t, byAddress(ahead).Valid)
+	require.Nil(t, byAddress(unsafe).Valid)
+	require.Equal(t, int64(1600000000+80*12), byAddress(defeated).BlockTime)
+
+	// The honest claims resolve, one of them against the defender
+	l1.games[honest].status = disputegame.DefenderWins
+	l1.games[defeated].status = disputegame.ChallengerWins
+	l1.games[invalid].status = disputegame.DefenderWins
+	l2.safe = 460
+	require.NoError(t, monitor.Check(ctx))
+
+	games, err = queries.ListCriticalDisputeGames(ctx, sqlitestore.ListCriticalDisputeGamesParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, games, 2)
+	require.Contains(t, *byAddress(defeated).CriticalReason, "resolved in favour of the challenger")
+	require.Contains(t, *byAddress(invalid).CriticalReason, "resolved in favour of the defender")
+
+	// The block became safe
+	games, err = queries.ListDisputeGames(ctx, sqlitestore.ListDisputeGamesParams{Limit: 10})
+	require.NoError(t, err)
+	require.True(t, *byAddress(unsafe).Valid)
+
+	counts, err := queries.CountDisputeGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, sqlitestore.CountDisputeGamesRow{Total: 5, InProgress: 2, Critical: 2, Verified: 4}, counts)
+}
+
+func TestMonitorPrunedState(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	l1 := &fakeL1{t: t, head: 100, games: map[common.Address]*game{}}
+	l2 := &fakeL2{head: 500, safe: 400, prunedBelow: 200}
+
+	// A full check worth of old games whose state the L2 node pruned,
+	// followed by a newer invalid game
+	for i := range disputegame.MaxGamesPerCheck {
+		l1.create(common.BigToAddress(big.NewInt(int64(1000+i))), 60, uint64(1+i), common.Hash{0x01})
+	}
+	invalid := common.Address{0x02}
+	l1.create(invalid, 90, 300, common.Hash{0xba, 0xd})
+
+	monitor := disputegame.New(db, l1, l2, disputegame.Config{Factory: factory, BatchSize: 20, StartBlocks: 50}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, monitor.Check(ctx))
+	require.NoError(t, monitor.Check(ctx))
+
+	// The old games are marked once and do not hold back the newer one
+	games, err := queries.ListUnverifiedDisputeGames(ctx, sqlitestore.ListUnverifiedDisputeGamesParams{L2BlockNumber: 400, Limit: 1000})
+	require.NoError(t, err)
+	require.Empty(t, games)
+
+	games, err = queries.ListCriticalDisputeGames(ctx, sqlitestore.ListCriticalDisputeGamesParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+	require.Equal(t, invalid, common.BytesToAddress(games[0].GameAddress))
+
+	games, err = queries.ListDisputeGames(ctx, sqlitestore.ListDisputeGamesParams{Limit: 1000})
+	require.NoError(t, err)
+	require.Len(t, games, disputegame.MaxGamesPerCheck+1)
+	for _, g := range games[1:] {
+		require.Nil(t, g.Valid)
+		require.Contains(t, *g.OutputRootError, "missing trie node")
+	}
+}
@@ -0,0 +1,86 @@
+package events
+
+import "sync"
+
+// Type identifies what changed in the database
+type Type string
+
+const (
+	// Deposit is published when new L1 deposits were indexed
+	Deposit Type = "deposit"
+	// Match is published when deposits were matched with their L2 confirmation
+	Match Type = "match"
+	// Pointer is published when an indexer block pointer advanced
+	Pointer Type = "pointer"
+	// Solvency is published when a solvency sample was recorded
+	Solvency Type = "solvency"
+	// Incident is published when a liveness incident started or ended
+	Incident Type = "incident"
+	// Batcher is published when batcher transactions were found on L1
+	Batcher Type = "batcher"
+	// DisputeGame is published when dispute games were created, resolved or verified
+	DisputeGame Type = "dispute_game"
+	// Security is published when security findings were recorded for the bridge contracts
+	Security Type = "security"
+)
+
+// Event describes a change committed by the indexer
+type Event struct {
+	Type Type `json:"type"`
+	// Chain is empty for matches, which involve both chains
+	Chain       string `json:"chain,omitempty"`
+	BlockNumber uint64 `json:"block_number"`
+	Count       int    `json:"count,omitempty"`
+}
+
+// subscriberBuffer is the number of events a slow subscriber may lag behind
+// before further events are dropped for it
+const subscriberBuffer = 16
+
+// Bus fans out indexer events to in-process subscribers
+type Bus struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewBus creates an event bus without subscribers
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving published events and a function that
+// unsubscribes and closes the channel
+func (b *Bus) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Publish sends the event to every subscriber without blocking. Subscribers
+// whose buffer is full miss the event; they only need to know that something
+// changed, and later events will tell them again.
+func (b *Bus) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
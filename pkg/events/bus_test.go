@@ -0,0 +1,31 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusFansOutAndUnsubscribes(t *testing.T) {
+	bus := events.NewBus()
+
+	first, unsubscribeFirst := bus.Subscribe()
+	second, unsubscribeSecond := bus.Subscribe()
+	defer unsubscribeSecond()
+
+	event := events.Event{Type: events.Match, BlockNumber: 42, Count: 1}
+	bus.Publish(event)
+	require.Equal(t, event, <-first)
+	require.Equal(t, event, <-second)
+
+	unsubscribeFirst()
+	_, ok := <-first
+	require.False(t, ok)
+
+	// Publishing must not block on a subscriber that stopped reading
+	for i := 0; i < 100; i++ {
+		bus.Publish(event)
+	}
+	require.Len(t, second, cap(second))
+}
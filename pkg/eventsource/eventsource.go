@@ -0,0 +1,185 @@
+package eventsource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"regexp"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// namePattern restricts source names to what is safe in pointer names and URLs
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Source is an event of a contract indexed into the generic events table
+type Source struct {
+	// Name identifies the source in the database and the API
+	Name string `json:"name"`
+	// Chain is l1 or l2
+	Chain   string         `json:"chain"`
+	Address common.Address `json:"address"`
+	// ABI is a JSON ABI fragment declaring at least the event
+	ABI   json.RawMessage `json:"abi"`
+	Event string          `json:"event"`
+	// FromBlock is the first block indexed, usually the deployment block
+	FromBlock uint64 `json:"from_block"`
+
+	event abi.Event
+}
+
+// Topic returns the topic of the indexed event
+func (s *Source) Topic() common.Hash {
+	return s.event.ID
+}
+
+// LowPointer is the block pointer of the lowest block backfilled
+func (s *Source) LowPointer() string {
+	return "indexed_events_" + s.Name + "_lowest_processed_block"
+}
+
+// LastPointer is the block pointer of the last block forward filled
+func (s *Source) LastPointer() string {
+	return "indexed_events_" + s.Name + "_last_processed_block"
+}
+
+// file is the JSON layout of an event sources file
+type file struct {
+	Sources []*Source `json:"sources"`
+}
+
+// Load reads and validates an event sources file
+func Load(path string) ([]*Source, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event sources: %w", err)
+	}
+	var f file
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse event sources: %w", err)
+	}
+
+	names := make(map[string]bool, len(f.Sources))
+	for i, s := range f.Sources {
+		if !namePattern.MatchString(s.Name) {
+			return nil, fmt.Errorf("source %d: name %q must only contain lowercase letters, digits and underscores", i, s.Name)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("source %s: duplicate name", s.Name)
+		}
+		names[s.Name] = true
+		if s.Chain != "l1" && s.Chain != "l2" {
+			return nil, fmt.Errorf("source %s: unknown chain %q, expected l1 or l2", s.Name, s.Chain)
+		}
+		if s.Address == (common.Address{}) {
+			return nil, fmt.Errorf("source %s: address is required", s.Name)
+		}
+
+		contractAbi, err := abi.JSON(bytes.NewReader(s.ABI))
+		if err != nil {
+			return nil, fmt.Errorf("source %s: invalid ABI: %w", s.Name, err)
+		}
+		event, ok := contractAbi.Events[s.Event]
+		if !ok {
+			return nil, fmt.Errorf("source %s: ABI has no %s event", s.Name, s.Event)
+		}
+		if event.Anonymous {
+			return nil, fmt.Errorf("source %s: anonymous events cannot be filtered by topic", s.Name)
+		}
+		s.event = event
+	}
+	return f.Sources, nil
+}
+
+// Decode returns the arguments of a log of the event as a JSON object.
+// Addresses, hashes and bytes are hex encoded and integers are decimal
+// strings, so that no precision is lost. Indexed arguments of dynamic types
+// are stored as the hash in their topic.
+func (s *Source) Decode(lg *types.Log) ([]byte, error) {
+	if len(lg.Topics) == 0 || lg.Topics[0] != s.event.ID {
+		return nil, fmt.Errorf("log is not a %s event", s.Event)
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range s.event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(lg.Topics) != len(indexed)+1 {
+		return nil, fmt.Errorf("invalid number of topics: got %d, want %d", len(lg.Topics), len(indexed)+1)
+	}
+
+	values := make(map[string]any)
+	err := s.event.Inputs.NonIndexed().UnpackIntoMap(values, lg.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack log data: %w", err)
+	}
+	err = abi.ParseTopicsIntoMap(values, indexed, lg.Topics[1:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log topics: %w", err)
+	}
+
+	args := make(map[string]any, len(values))
+	for name, value := range values {
+		args[name] = jsonValue(reflect.ValueOf(value))
+	}
+	return json.Marshal(args)
+}
+
+var (
+	addressType = reflect.TypeOf(common.Address{})
+	hashType    = reflect.TypeOf(common.Hash{})
+	bigIntType  = reflect.TypeOf((*big.Int)(nil))
+)
+
+// jsonValue converts a decoded ABI value to its JSON representation
+func jsonValue(v reflect.Value) any {
+	switch v.Type() {
+	case addressType:
+		return v.Interface().(common.Address).Hex()
+	case hashType:
+		return v.Interface().(common.Hash).Hex()
+	case bigIntType:
+		return v.Interface().(*big.Int).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("%d", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("%d", v.Uint())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hexutil.Encode(b)
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = jsonValue(v.Index(i))
+		}
+		return items
+	case reflect.Struct:
+		// Tuples are decoded into structs whose json tags are the component names
+		fields := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			name := v.Type().Field(i).Tag.Get("json")
+			if name == "" {
+				name = v.Type().Field(i).Name
+			}
+			fields[name] = jsonValue(v.Field(i))
+		}
+		return fields
+	}
+	return v.Interface()
+}
@@ -0,0 +1,92 @@
+package eventsource_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/eventsource"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const transferABI = `[{"type":"event","name":"Transfer","inputs":[
+	{"name":"from","type":"address","indexed":true},
+	{"name":"to","type":"address","indexed":true},
+	{"name":"value","type":"uint256","indexed":false},
+	{"name":"memo","type":"bytes","indexed":false}
+]}]`
+
+func writeSources(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "events.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadAndDecode(t *testing.T) {
+	sources, err := eventsource.Load(writeSources(t, `{"sources":[{
+		"name": "token_transfers",
+		"chain": "l2",
+		"address": "0x4200000000000000000000000000000000000042",
+		"abi": `+transferABI+`,
+		"event": "Transfer",
+		"from_block": 100
+	}]}`))
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+
+	src := sources[0]
+	require.Equal(t, crypto.Keccak256Hash([]byte("Transfer(address,address,uint256,bytes)")), src.Topic())
+	require.Equal(t, "indexed_events_token_transfers_lowest_processed_block", src.LowPointer())
+	require.Equal(t, "indexed_events_token_transfers_last_processed_block", src.LastPointer())
+
+	from, to := common.Address{0x01}, common.Address{0x02}
+	value, ok := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.True(t, ok)
+	// value, offset of memo, length of memo, memo
+	data := append(common.LeftPadBytes(value.Bytes(), 32), common.LeftPadBytes([]byte{0x40}, 32)...)
+	data = append(data, common.LeftPadBytes([]byte{2}, 32)...)
+	data = append(data, common.RightPadBytes([]byte{0xca, 0xfe}, 32)...)
+
+	args, err := src.Decode(&types.Log{
+		Topics: []common.Hash{src.Topic(), common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:   data,
+	})
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(args, &decoded))
+	require.Equal(t, map[string]any{
+		"from":  from.Hex(),
+		"to":    to.Hex(),
+		"value": "123456789012345678901234567890",
+		"memo":  "0xcafe",
+	}, decoded)
+
+	_, err = src.Decode(&types.Log{Topics: []common.Hash{{0x01}}})
+	require.Error(t, err)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	source := func(name, chain, event string) string {
+		return `{"name":"` + name + `","chain":"` + chain + `","address":"0x4200000000000000000000000000000000000042","abi":` + transferABI + `,"event":"` + event + `"}`
+	}
+
+	for name, content := range map[string]string{
+		"invalid name":    `{"sources":[` + source("Token Transfers", "l2", "Transfer") + `]}`,
+		"duplicate name":  `{"sources":[` + source("transfers", "l2", "Transfer") + `,` + source("transfers", "l1", "Transfer") + `]}`,
+		"unknown chain":   `{"sources":[` + source("transfers", "l3", "Transfer") + `]}`,
+		"unknown event":   `{"sources":[` + source("transfers", "l2", "Approval") + `]}`,
+		"unknown field":   `{"sources":[],"blocks":1}`,
+		"missing address": `{"sources":[{"name":"transfers","chain":"l2","abi":` + transferABI + `,"event":"Transfer"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := eventsource.Load(writeSources(t, content))
+			require.Error(t, err)
+		})
+	}
+}
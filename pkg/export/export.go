@@ -0,0 +1,261 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Status selects which kind of deposit records are exported
+type Status string
+
+const (
+	// Matched exports L1 deposits together with the L2 finalization they were matched to
+	Matched Status = "matched"
+	// UnmatchedL1 exports L1 deposits that have not been finalized on L2 yet
+	UnmatchedL1 Status = "unmatched_l1"
+	// UnmatchedL2 exports L2 finalizations without a known L1 deposit
+	UnmatchedL2 Status = "unmatched_l2"
+)
+
+// AllStatuses lists every status in the order they are exported
+var AllStatuses = []Status{Matched, UnmatchedL1, UnmatchedL2}
+
+// DefaultBatchSize is the number of rows read from the database per query
+const DefaultBatchSize = 1000
+
+// Options restricts what is exported
+type Options struct {
+	// Statuses defaults to AllStatuses when empty
+	Statuses []Status
+	// Since and Until bound the L1 timestamp of matched and unmatched L1
+	// records and the L2 timestamp of unmatched L2 records. Since is
+	// inclusive, Until exclusive.
+	Since *time.Time
+	Until *time.Time
+	// BatchSize defaults to DefaultBatchSize when zero
+	BatchSize int64
+}
+
+// Event describes one side of a deposit
+type Event struct {
+	ID          int64
+	BlockNumber int64
+	Timestamp   time.Time
+	TxHash      common.Hash
+}
+
+// Record is a single exported row
+type Record struct {
+	Status    Status
+	L1        *Event
+	L2        *Event
+	From      common.Address
+	To        common.Address
+	AmountWei *big.Int
+}
+
+// ConfirmationSeconds returns the time between the L1 deposit and its L2
+// finalization, or nil when the record is not matched
+func (r Record) ConfirmationSeconds() *int64 {
+	if r.L1 == nil || r.L2 == nil {
+		return nil
+	}
+	seconds := int64(r.L2.Timestamp.Sub(r.L1.Timestamp) / time.Second)
+	return &seconds
+}
+
+// ParseStatuses parses a comma separated list of statuses. An empty string
+// selects all statuses.
+func ParseStatuses(s string) ([]Status, error) {
+	if strings.TrimSpace(s) == "" {
+		return AllStatuses, nil
+	}
+
+	var statuses []Status
+	for _, part := range strings.Split(s, ",") {
+		status := Status(strings.TrimSpace(part))
+		switch status {
+		case Matched, UnmatchedL1, UnmatchedL2:
+			statuses = append(statuses, status)
+		default:
+			return nil, fmt.Errorf("unknown status %q, expected one of matched, unmatched_l1, unmatched_l2", part)
+		}
+	}
+	return statuses, nil
+}
+
+// ParseTime accepts an RFC3339 timestamp, a YYYY-MM-DD date or unix seconds
+func ParseTime(v string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not an RFC3339 timestamp, a YYYY-MM-DD date or unix seconds", v)
+}
+
+// Export streams the selected records to w. Rows are read from the database
+// in batches of opts.BatchSize so memory use does not grow with the size of
+// the export.
+func Export(ctx context.Context, db *sql.DB, w io.Writer, format Format, opts Options) error {
+	enc, err := newEncoder(format, w)
+	if err != nil {
+		return err
+	}
+
+	statuses := opts.Statuses
+	if len(statuses) == 0 {
+		statuses = AllStatuses
+	}
+
+	queries := sqlitestore.NewTraced(db)
+	for _, status := range statuses {
+		err := exportStatus(ctx, queries, enc, status, opts)
+		if err != nil {
+			return fmt.Errorf("failed to export %s records: %w", status, err)
+		}
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return fmt.Errorf("failed to finish %s export: %w", format, err)
+	}
+	return nil
+}
+
+// exportStatus pages through the records of one status by ascending id
+func exportStatus(ctx context.Context, queries *sqlitestore.Queries, enc encoder, status Status, opts Options) error {
+	limit := opts.BatchSize
+	if limit <= 0 {
+		limit = DefaultBatchSize
+	}
+	since := unixOrNil(opts.Since)
+	until := unixOrNil(opts.Until)
+
+	afterID := int64(0)
+	for {
+		records, lastID, err := fetchBatch(ctx, queries, status, afterID, since, until, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			err := enc.Write(record)
+			if err != nil {
+				return fmt.Errorf("failed to write record: %w", err)
+			}
+		}
+
+		if int64(len(records)) < limit {
+			return nil
+		}
+		afterID = lastID
+	}
+}
+
+// fetchBatch returns up to limit records with an id above afterID, and the
+// id of the last one
+func fetchBatch(ctx context.Context, queries *sqlitestore.Queries, status Status, afterID int64, since, until *int64, limit int64) ([]Record, int64, error) {
+	var records []Record
+	lastID := afterID
+
+	switch status {
+	case Matched:
+		rows, err := queries.ExportMatchedDeposits(ctx, sqlitestore.ExportMatchedDepositsParams{
+			AfterID: afterID,
+			Since:   since,
+			Until:   until,
+			Limit:   limit,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get matched deposits: %w", err)
+		}
+		for _, row := range rows {
+			records = append(records, Record{
+				Status:    Matched,
+				L1:        newEvent(row.ID, row.L1BlockNumber, row.L1Timestamp, row.TxHashL1),
+				L2:        newEvent(row.L2ID, row.L2BlockNumber, row.L2Timestamp, row.TxHashL2),
+				From:      common.BytesToAddress(row.FromAddress),
+				To:        common.BytesToAddress(row.ToAddress),
+				AmountWei: new(big.Int).SetBytes(row.AmountWei),
+			})
+			lastID = row.ID
+		}
+
+	case UnmatchedL1:
+		rows, err := queries.ExportUnmatchedL1Deposits(ctx, sqlitestore.ExportUnmatchedL1DepositsParams{
+			AfterID: afterID,
+			Since:   since,
+			Until:   until,
+			Limit:   limit,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get unmatched L1 deposits: %w", err)
+		}
+		for _, row := range rows {
+			records = append(records, Record{
+				Status:    UnmatchedL1,
+				L1:        newEvent(row.ID, row.BlockNumber, row.BlockTimestamp, row.TxHash),
+				From:      common.BytesToAddress(row.FromAddress),
+				To:        common.BytesToAddress(row.ToAddress),
+				AmountWei: new(big.Int).SetBytes(row.AmountWei),
+			})
+			lastID = row.ID
+		}
+
+	case UnmatchedL2:
+		rows, err := queries.ExportUnmatchedL2Finalizations(ctx, sqlitestore.ExportUnmatchedL2FinalizationsParams{
+			AfterID: afterID,
+			Since:   since,
+			Until:   until,
+			Limit:   limit,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get unmatched L2 finalizations: %w", err)
+		}
+		for _, row := range rows {
+			records = append(records, Record{
+				Status:    UnmatchedL2,
+				L2:        newEvent(row.ID, row.BlockNumber, row.BlockTimestamp, row.TxHash),
+				From:      common.BytesToAddress(row.FromAddress),
+				To:        common.BytesToAddress(row.ToAddress),
+				AmountWei: new(big.Int).SetBytes(row.AmountWei),
+			})
+			lastID = row.ID
+		}
+
+	default:
+		return nil, 0, fmt.Errorf("unknown status %q", status)
+	}
+
+	return records, lastID, nil
+}
+
+func newEvent(id, blockNumber, timestamp int64, txHash []byte) *Event {
+	return &Event{
+		ID:          id,
+		BlockNumber: blockNumber,
+		Timestamp:   time.Unix(timestamp, 0).UTC(),
+		TxHash:      common.BytesToHash(txHash),
+	}
+}
+
+func unixOrNil(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	unix := t.Unix()
+	return &unix
+}
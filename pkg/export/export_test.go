@@ -0,0 +1,146 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/export"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// One wei above 1.5 ETH, which a float64 cannot represent
+const amountWei = "1500000000000000001"
+
+// newTestDB stores a matched deposit, an unmatched L1 deposit and an
+// unmatched L2 finalization
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+	amount, _ := new(big.Int).SetString(amountWei, 10)
+
+	insertL1 := func(blockNumber, timestamp int64) int64 {
+		id, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+			BlockNumber:    blockNumber,
+			BlockTimestamp: timestamp,
+			TxHash:         common.BigToHash(big.NewInt(blockNumber)).Bytes(),
+			FromAddress:    common.Address{0xaa}.Bytes(),
+			ToAddress:      common.Address{0xaa}.Bytes(),
+			Amount:         1.5,
+			AmountWei:      common.LeftPadBytes(amount.Bytes(), 32),
+			Event:          []byte("{}"),
+			MatchingHash:   []byte{byte(blockNumber)},
+		})
+		require.NoError(t, err)
+		return id
+	}
+	insertL2 := func(blockNumber, timestamp int64) int64 {
+		id, err := queries.InsertL2StandardBridgeDepositFinalized(ctx, sqlitestore.InsertL2StandardBridgeDepositFinalizedParams{
+			BlockNumber:    blockNumber,
+			BlockTimestamp: timestamp,
+			TxHash:         common.BigToHash(big.NewInt(blockNumber)).Bytes(),
+			FromAddress:    common.Address{0xaa}.Bytes(),
+			ToAddress:      common.Address{0xaa}.Bytes(),
+			L1Token:        common.Address{}.Bytes(),
+			Amount:         1.5,
+			AmountWei:      common.LeftPadBytes(amount.Bytes(), 32),
+			Event:          []byte("{}"),
+			MatchingHash:   []byte{byte(blockNumber)},
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	l1ID := insertL1(100, 1700000000)
+	l2ID := insertL2(200, 1700000060)
+	require.NoError(t, queries.UpdateL1DepositWithMatch(ctx, sqlitestore.UpdateL1DepositWithMatchParams{
+		MatchedL2StandardBridgeDepositFinalizedID: &l2ID,
+		ID: l1ID,
+	}))
+	require.NoError(t, queries.UpdateL2DepositWithMatch(ctx, sqlitestore.UpdateL2DepositWithMatchParams{
+		MatchedL1StandardBridgeEthDepositInitiatedID: &l1ID,
+		ID: l2ID,
+	}))
+
+	insertL1(101, 1700000100)
+	insertL2(201, 1700000200)
+
+	return db
+}
+
+func TestExportCSV(t *testing.T) {
+	db := newTestDB(t)
+
+	var out bytes.Buffer
+	require.NoError(t, export.Export(context.Background(), db, &out, export.CSV, export.Options{BatchSize: 1}))
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	require.Equal(t, "status", rows[0][0])
+
+	require.Equal(t, []string{"matched", "unmatched_l1", "unmatched_l2"}, []string{rows[1][0], rows[2][0], rows[3][0]})
+	require.Equal(t, amountWei, rows[1][11])
+	require.Equal(t, "60", rows[1][12])
+	require.Equal(t, "2023-11-14T22:13:20Z", rows[1][3])
+	require.Empty(t, rows[2][5])
+	require.Empty(t, rows[3][1])
+}
+
+func TestExportJSONLFilters(t *testing.T) {
+	db := newTestDB(t)
+
+	since := time.Unix(1700000050, 0)
+	var out bytes.Buffer
+	require.NoError(t, export.Export(context.Background(), db, &out, export.JSONL, export.Options{
+		Statuses: []export.Status{export.UnmatchedL1, export.UnmatchedL2},
+		Since:    &since,
+	}))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	require.Equal(t, "unmatched_l2", record["status"])
+	require.Equal(t, amountWei, record["amount_wei"])
+	require.Nil(t, record["l1_id"])
+	require.Nil(t, record["confirmation_seconds"])
+}
+
+func TestExportParquet(t *testing.T) {
+	db := newTestDB(t)
+
+	var out bytes.Buffer
+	require.NoError(t, export.Export(context.Background(), db, &out, export.Parquet, export.Options{
+		Statuses: []export.Status{export.Matched},
+	}))
+
+	file, err := buffer.NewBufferFile(out.Bytes())
+	require.NoError(t, err)
+	pr, err := reader.NewParquetReader(file, nil, 1)
+	require.NoError(t, err)
+	defer pr.ReadStop()
+	require.Equal(t, int64(1), pr.GetNumRows())
+
+	rows, err := pr.ReadByNumber(1)
+	require.NoError(t, err)
+	encoded, err := json.Marshal(rows[0])
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"Amount_wei":"`+amountWei+`"`)
+}
@@ -0,0 +1,220 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Format is the file format of an export
+type Format string
+
+const (
+	CSV     Format = "csv"
+	JSONL   Format = "jsonl"
+	Parquet Format = "parquet"
+)
+
+// parquetRowGroupSize bounds how much the parquet writer buffers before
+// flushing a row group to the output
+const parquetRowGroupSize = 8 * 1024 * 1024
+
+// ParseFormat validates a format name
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case CSV, JSONL, Parquet:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unknown format %q, expected one of csv, jsonl, parquet", s)
+	}
+}
+
+// ContentType returns the MIME type served for the format
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case JSONL:
+		return "application/jsonl"
+	default:
+		return "application/vnd.apache.parquet"
+	}
+}
+
+// columns is the header shared by every format
+var columns = []string{
+	"status",
+	"l1_id",
+	"l1_block_number",
+	"l1_timestamp",
+	"l1_tx_hash",
+	"l2_id",
+	"l2_block_number",
+	"l2_timestamp",
+	"l2_tx_hash",
+	"from",
+	"to",
+	"amount_wei",
+	"confirmation_seconds",
+}
+
+type encoder interface {
+	Write(Record) error
+	Close() error
+}
+
+func newEncoder(format Format, w io.Writer) (encoder, error) {
+	switch format {
+	case CSV:
+		cw := csv.NewWriter(w)
+		err := cw.Write(columns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write csv header: %w", err)
+		}
+		return &csvEncoder{w: cw}, nil
+	case JSONL:
+		return &jsonlEncoder{enc: json.NewEncoder(w)}, nil
+	case Parquet:
+		pw, err := writer.NewParquetWriterFromWriter(w, new(parquetRow), 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create parquet writer: %w", err)
+		}
+		pw.RowGroupSize = parquetRowGroupSize
+		pw.CompressionType = parquet.CompressionCodec_SNAPPY
+		return &parquetEncoder{w: pw}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) Write(r Record) error {
+	row := make([]string, 0, len(columns))
+	row = append(row, string(r.Status))
+	row = append(row, csvEvent(r.L1)...)
+	row = append(row, csvEvent(r.L2)...)
+	row = append(row, r.From.Hex(), r.To.Hex(), r.AmountWei.String())
+	if seconds := r.ConfirmationSeconds(); seconds != nil {
+		row = append(row, strconv.FormatInt(*seconds, 10))
+	} else {
+		row = append(row, "")
+	}
+	return e.w.Write(row)
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvEvent returns the id, block number, timestamp and tx hash columns of one
+// side of a record, left empty when the side is missing
+func csvEvent(e *Event) []string {
+	if e == nil {
+		return []string{"", "", "", ""}
+	}
+	return []string{
+		strconv.FormatInt(e.ID, 10),
+		strconv.FormatInt(e.BlockNumber, 10),
+		e.Timestamp.Format(time.RFC3339),
+		e.TxHash.Hex(),
+	}
+}
+
+// jsonRecord is the JSON Lines representation of a Record
+type jsonRecord struct {
+	Status              Status  `json:"status"`
+	L1ID                *int64  `json:"l1_id"`
+	L1BlockNumber       *int64  `json:"l1_block_number"`
+	L1Timestamp         *string `json:"l1_timestamp"`
+	L1TxHash            *string `json:"l1_tx_hash"`
+	L2ID                *int64  `json:"l2_id"`
+	L2BlockNumber       *int64  `json:"l2_block_number"`
+	L2Timestamp         *string `json:"l2_timestamp"`
+	L2TxHash            *string `json:"l2_tx_hash"`
+	From                string  `json:"from"`
+	To                  string  `json:"to"`
+	AmountWei           string  `json:"amount_wei"`
+	ConfirmationSeconds *int64  `json:"confirmation_seconds"`
+}
+
+type jsonlEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *jsonlEncoder) Write(r Record) error {
+	row := jsonRecord{
+		Status:              r.Status,
+		From:                r.From.Hex(),
+		To:                  r.To.Hex(),
+		AmountWei:           r.AmountWei.String(),
+		ConfirmationSeconds: r.ConfirmationSeconds(),
+	}
+	if r.L1 != nil {
+		timestamp, txHash := r.L1.Timestamp.Format(time.RFC3339), r.L1.TxHash.Hex()
+		row.L1ID, row.L1BlockNumber, row.L1Timestamp, row.L1TxHash = &r.L1.ID, &r.L1.BlockNumber, &timestamp, &txHash
+	}
+	if r.L2 != nil {
+		timestamp, txHash := r.L2.Timestamp.Format(time.RFC3339), r.L2.TxHash.Hex()
+		row.L2ID, row.L2BlockNumber, row.L2Timestamp, row.L2TxHash = &r.L2.ID, &r.L2.BlockNumber, &timestamp, &txHash
+	}
+	return e.enc.Encode(row)
+}
+
+func (e *jsonlEncoder) Close() error {
+	return nil
+}
+
+// parquetRow is the Parquet schema of a Record. Amounts are kept as decimal
+// strings since uint256 does not fit any Parquet integer type.
+type parquetRow struct {
+	Status              string  `parquet:"name=status, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN_DICTIONARY"`
+	L1ID                *int64  `parquet:"name=l1_id, type=INT64, repetitiontype=OPTIONAL"`
+	L1BlockNumber       *int64  `parquet:"name=l1_block_number, type=INT64, repetitiontype=OPTIONAL"`
+	L1Timestamp         *int64  `parquet:"name=l1_timestamp, type=INT64, convertedtype=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+	L1TxHash            *string `parquet:"name=l1_tx_hash, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	L2ID                *int64  `parquet:"name=l2_id, type=INT64, repetitiontype=OPTIONAL"`
+	L2BlockNumber       *int64  `parquet:"name=l2_block_number, type=INT64, repetitiontype=OPTIONAL"`
+	L2Timestamp         *int64  `parquet:"name=l2_timestamp, type=INT64, convertedtype=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+	L2TxHash            *string `parquet:"name=l2_tx_hash, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	From                string  `parquet:"name=from, type=BYTE_ARRAY, convertedtype=UTF8"`
+	To                  string  `parquet:"name=to, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AmountWei           string  `parquet:"name=amount_wei, type=BYTE_ARRAY, convertedtype=UTF8"`
+	ConfirmationSeconds *int64  `parquet:"name=confirmation_seconds, type=INT64, repetitiontype=OPTIONAL"`
+}
+
+type parquetEncoder struct {
+	w *writer.ParquetWriter
+}
+
+func (e *parquetEncoder) Write(r Record) error {
+	row := parquetRow{
+		Status:              string(r.Status),
+		From:                r.From.Hex(),
+		To:                  r.To.Hex(),
+		AmountWei:           r.AmountWei.String(),
+		ConfirmationSeconds: r.ConfirmationSeconds(),
+	}
+	if r.L1 != nil {
+		timestamp, txHash := r.L1.Timestamp.UnixMilli(), r.L1.TxHash.Hex()
+		row.L1ID, row.L1BlockNumber, row.L1Timestamp, row.L1TxHash = &r.L1.ID, &r.L1.BlockNumber, &timestamp, &txHash
+	}
+	if r.L2 != nil {
+		timestamp, txHash := r.L2.Timestamp.UnixMilli(), r.L2.TxHash.Hex()
+		row.L2ID, row.L2BlockNumber, row.L2Timestamp, row.L2TxHash = &r.L2.ID, &r.L2.BlockNumber, &timestamp, &txHash
+	}
+	return e.w.Write(row)
+}
+
+func (e *parquetEncoder) Close() error {
+	return e.w.WriteStop()
+}
@@ -0,0 +1,264 @@
+package fakechain
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Chain is an in-memory chain served over JSON-RPC. It answers the calls the
+// indexer makes (eth_blockNumber, eth_getBlockByNumber without transactions,
+// eth_getLogs and eth_chainId), and lets tests mine blocks with logs, reorg
+// the head and make calls fail.
+type Chain struct {
+	mu        sync.Mutex
+	chainID   *big.Int
+	blockTime uint64
+	blocks    []*block
+	// fork is incremented by every reorg, so replaced blocks get new hashes
+	fork   uint64
+	faults map[string]int
+	server *rpc.Server
+}
+
+type block struct {
+	header *types.Header
+	logs   []types.Log
+}
+
+// New creates a chain with a genesis block at genesisTime and blockTime
+// seconds between blocks
+func New(chainID, genesisTime, blockTime uint64) *Chain {
+	c := &Chain{
+		chainID:   new(big.Int).SetUint64(chainID),
+		blockTime: blockTime,
+		faults:    make(map[string]int),
+		server:    rpc.NewServer(),
+	}
+	c.blocks = []*block{{header: c.newHeader(0, common.Hash{}, genesisTime)}}
+
+	err := c.server.RegisterName("eth", &service{chain: c})
+	if err != nil {
+		// The service is statically valid
+		panic(fmt.Sprintf("failed to register the eth service: %v", err))
+	}
+	return c
+}
+
+// ServeHTTP serves the JSON-RPC API
+func (c *Chain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.server.ServeHTTP(w, r)
+}
+
+// Close stops serving requests
+func (c *Chain) Close() {
+	c.server.Stop()
+}
+
+func (c *Chain) newHeader(number uint64, parent common.Hash, time uint64) *types.Header {
+	extra := binary.BigEndian.AppendUint64(nil, c.fork)
+	return &types.Header{
+		ParentHash: parent,
+		Number:     new(big.Int).SetUint64(number),
+		Time:       time,
+		Difficulty: new(big.Int),
+		GasLimit:   30_000_000,
+		Extra:      extra,
+	}
+}
+
+// Head returns the number of the latest block
+func (c *Chain) Head() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return uint64(len(c.blocks) - 1)
+}
+
+// Header returns the header of a block, nil past the head
+func (c *Chain) Header(number uint64) *types.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number >= uint64(len(c.blocks)) {
+		return nil
+	}
+	return types.CopyHeader(c.blocks[number].header)
+}
+
+// Logs returns the logs emitted in a block
+func (c *Chain) Logs(number uint64) []types.Log {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number >= uint64(len(c.blocks)) {
+		return nil
+	}
+	return append([]types.Log(nil), c.blocks[number].logs...)
+}
+
+// Mine appends a block emitting the logs, in order, and returns it. The
+// block fields of the logs are filled in. Logs without a transaction hash
+// are each given one of their own.
+func (c *Chain) Mine(logs ...types.Log) *types.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	parent := c.blocks[len(c.blocks)-1].header
+	header := c.newHeader(parent.Number.Uint64()+1, parent.Hash(), parent.Time+c.blockTime)
+	hash := header.Hash()
+
+	b := &block{header: header}
+	for i, lg := range logs {
+		lg.BlockNumber = header.Number.Uint64()
+		lg.BlockHash = hash
+		lg.Index = uint(i)
+		if lg.TxHash == (common.Hash{}) {
+			lg.TxHash = crypto.Keccak256Hash(hash.Bytes(), binary.BigEndian.AppendUint64(nil, uint64(i)))
+			lg.TxIndex = uint(i)
+		}
+		b.logs = append(b.logs, lg)
+	}
+	c.blocks = append(c.blocks, b)
+	return types.CopyHeader(header)
+}
+
+// MineEmpty appends n blocks without logs
+func (c *Chain) MineEmpty(n int) {
+	for range n {
+		c.Mine()
+	}
+}
+
+// Reorg removes the latest depth blocks. The blocks mined after it have new
+// hashes even when they emit the same logs.
+func (c *Chain) Reorg(depth int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The genesis block is never removed
+	keep := max(len(c.blocks)-depth, 1)
+	c.blocks = c.blocks[:keep]
+	c.fork++
+}
+
+// Fail makes the next n calls of a JSON-RPC method, e.g. eth_getLogs, fail
+func (c *Chain) Fail(method string, n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faults[method] += n
+}
+
+// fault returns the injected error of a call, if any. It is called with the lock held.
+func (c *Chain) fault(method string) error {
+	if c.faults[method] == 0 {
+		return nil
+	}
+	c.faults[method]--
+	return fmt.Errorf("injected failure of %s", method)
+}
+
+// resolve returns the block a block number argument refers to
+func (c *Chain) resolve(number rpc.BlockNumber) uint64 {
+	head := uint64(len(c.blocks) - 1)
+	switch number {
+	case rpc.EarliestBlockNumber:
+		return 0
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber, rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+		return head
+	}
+	return uint64(number.Int64())
+}
+
+// service implements the eth namespace
+type service struct {
+	chain *Chain
+}
+
+// ChainId implements eth_chainId
+func (s *service) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(s.chain.chainID)
+}
+
+// BlockNumber implements eth_blockNumber
+func (s *service) BlockNumber() (hexutil.Uint64, error) {
+	c := s.chain
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.fault("eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(len(c.blocks) - 1), nil
+}
+
+// GetBlockByNumber implements eth_getBlockByNumber. Blocks have no
+// transactions, so only the header is returned.
+func (s *service) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (*types.Header, error) {
+	c := s.chain
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.fault("eth_getBlockByNumber"); err != nil {
+		return nil, err
+	}
+	n := c.resolve(number)
+	if n >= uint64(len(c.blocks)) {
+		// Unknown blocks are null
+		return nil, nil
+	}
+	return c.blocks[n].header, nil
+}
+
+// filterQuery is the argument of eth_getLogs
+type filterQuery struct {
+	BlockHash *common.Hash     `json:"blockHash"`
+	FromBlock *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   *rpc.BlockNumber `json:"toBlock"`
+	Addresses []common.Address `json:"address"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+// GetLogs implements eth_getLogs
+func (s *service) GetLogs(ctx context.Context, q filterQuery) ([]types.Log, error) {
+	c := s.chain
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.fault("eth_getLogs"); err != nil {
+		return nil, err
+	}
+
+	// A block hash filter searches every block
+	from, to := uint64(0), uint64(len(c.blocks)-1)
+	if q.BlockHash == nil {
+		if q.FromBlock != nil {
+			from = c.resolve(*q.FromBlock)
+		}
+		if q.ToBlock != nil {
+			to = min(c.resolve(*q.ToBlock), to)
+		}
+		if from > to {
+			return []types.Log{}, nil
+		}
+	}
+
+	filter := ethereum.FilterQuery{Addresses: q.Addresses, Topics: q.Topics}
+	logs := []types.Log{}
+	for _, b := range c.blocks[from : to+1] {
+		if q.BlockHash != nil && b.header.Hash() != *q.BlockHash {
+			continue
+		}
+		for _, lg := range b.logs {
+			if indexer.MatchesFilter(lg, filter) {
+				logs = append(logs, lg)
+			}
+		}
+	}
+	return logs, nil
+}
@@ -0,0 +1,837 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+// Source is the node a chain is indexed from
+type Source interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Batch holds the logs of a block range and the timestamps of the blocks
+// they were emitted in
+type Batch struct {
+	FromBlock  uint64
+	ToBlock    uint64
+	Logs       []types.Log
+	BlockTimes map[uint64]uint64
+}
+
+// Handler stores the logs of a chain. Its methods are called inside the
+// transaction that moves the block pointers, so a batch is either stored and
+// marked as processed or not at all.
+type Handler interface {
+	// HandleBatch stores the logs of a batch and returns the number of
+	// stored items worth notifying about
+	HandleBatch(ctx context.Context, tx *sql.Tx, batch *Batch) (int, error)
+	// ClearRange deletes what was stored for a block range before it is
+	// indexed again, returning the number of deleted items
+	ClearRange(ctx context.Context, tx *sql.Tx, fromBlock, toBlock uint64) (int64, error)
+}
+
+// Chain describes how the events of contracts on one chain are indexed
+type Chain struct {
+	Name   string
+	Source Source
+	// Contracts are the addresses and event topics filtered over history
+	Contracts registry.History
+	// LowPointer and LastPointer name the block pointers of the lowest
+	// backfilled block and the last forward filled block
+	LowPointer    string
+	LastPointer   string
+	BlockInterval time.Duration
+	// StartBlock is the lowest block backfilled
+	StartBlock uint64
+	// Confirmations is how many blocks behind the head the chain is
+	// indexed, so that shallow reorgs happen before their blocks are read
+	Confirmations uint64
+	Handler       Handler
+}
+
+// Config tunes the runner
+type Config struct {
+	BackfillingBatchSize uint64
+	ForwardingBatchSize  uint64
+	// MaxRetries is how many times a failed batch is retried before the
+	// runner gives up, waiting RetryBackoff before the first retry and
+	// twice as long before each following one
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// MaxReorgDepth is how many blocks behind the last processed block the
+	// hashes of forward filled blocks are kept to follow reorgs (disabled
+	// when 0)
+	MaxReorgDepth uint64
+	// FilterChecks is how many times a log filter is repeated after it
+	// succeeded. Its logs are only accepted once that many answers in a row
+	// agree, which catches nodes dropping logs from their results.
+	FilterChecks int
+	// OnCommit is called after a batch of a chain is committed, with the
+	// block its pointer moved to and the count returned by the handler
+	OnCommit func(c *Chain, blockNumber uint64, count int)
+}
+
+// Runner backfills chains down to their start block, then follows their heads
+type Runner struct {
+	db    *sql.DB
+	store *sqlitestore.Queries
+	cfg   Config
+	log   *slog.Logger
+}
+
+// New creates a runner storing its block pointers in db
+func New(db *sql.DB, cfg Config, log *slog.Logger) *Runner {
+	return &Runner{
+		db:    db,
+		store: sqlitestore.NewTraced(db),
+		cfg:   cfg,
+		log:   log,
+	}
+}
+
+// Run backfills the chains and then forward fills them until ctx is cancelled.
+// Forward filling starts once every chain is backfilled.
+func (r *Runner) Run(ctx context.Context, chains ...*Chain) error {
+	err := r.createPointers(ctx, chains)
+	if err != nil {
+		return err
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, c := range chains {
+		eg.Go(func() error {
+			return r.Backfill(egCtx, c)
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		return fmt.Errorf("error backfilling logs: %w", err)
+	}
+
+	r.log.Info("backfilling logs completed")
+
+	// start forward filling
+	r.log.Info("starting forward filling")
+
+	// Keep running until context is cancelled
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, c := range chains {
+		eg.Go(func() error {
+			return r.Forward(egCtx, c)
+		})
+	}
+	return eg.Wait()
+}
+
+// CatchUp backfills the chains and forward fills them up to the heads they
+// had when it was called, then returns
+func (r *Runner) CatchUp(ctx context.Context, chains ...*Chain) error {
+	err := r.createPointers(ctx, chains)
+	if err != nil {
+		return err
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, c := range chains {
+		eg.Go(func() error {
+			log := r.log.With("chain", c.Name, "mode", "catch up")
+
+			var head uint64
+			err := r.retry(egCtx, log, func() error {
+				var err error
+				head, err = r.head(egCtx, c)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			err = r.Backfill(egCtx, c)
+			if err != nil {
+				return err
+			}
+
+			for {
+				last, err := r.store.GetBlockPointer(egCtx, c.LastPointer)
+				if err != nil {
+					return fmt.Errorf("failed to get last processed block: %w", err)
+				}
+				if last.BlockNumber != nil && uint64(*last.BlockNumber) >= head {
+					return nil
+				}
+				err = r.retry(egCtx, log, func() error {
+					return r.ForwardBatch(egCtx, c)
+				})
+				if err != nil {
+					return err
+				}
+			}
+		})
+	}
+	return eg.Wait()
+}
+
+// createPointers creates the block pointers of the chains indexed for the first time
+func (r *Runner) createPointers(ctx context.Context, chains []*Chain) error {
+	for _, c := range chains {
+		for _, name := range []string{c.LowPointer, c.LastPointer} {
+			err := r.store.InsertBlockPointer(ctx, name)
+			if err != nil {
+				return fmt.Errorf("failed to create block pointer %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Backfill indexes the chain from the lowest processed block down to its start block
+func (r *Runner) Backfill(ctx context.Context, c *Chain) error {
+	log := r.log.With("chain", c.Name)
+
+	var fromBlock uint64
+	err := r.retry(ctx, log, func() error {
+		var err error
+		fromBlock, err = r.head(ctx, c)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	lowestProcessedBlock, err := r.store.GetBlockPointer(ctx, c.LowPointer)
+	if err != nil {
+		return fmt.Errorf("failed to get lowest processed block: %w", err)
+	}
+
+	if lowestProcessedBlock.BlockNumber != nil {
+		fromBlock = uint64(*lowestProcessedBlock.BlockNumber)
+	}
+
+	for fromBlock > c.StartBlock {
+
+		toBlock := fromBlock - 1
+
+		if fromBlock > c.StartBlock+r.cfg.BackfillingBatchSize {
+			fromBlock -= r.cfg.BackfillingBatchSize
+		} else {
+			fromBlock = c.StartBlock
+		}
+
+		err := r.retry(ctx, log, func() error {
+			return tracing.Run(ctx, "backfill batch", func(ctx context.Context) error {
+				return r.backfillBatch(ctx, c, log, fromBlock, toBlock)
+			}, batchAttributes(c.Name, "backfill", fromBlock, toBlock))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) backfillBatch(ctx context.Context, c *Chain, log *slog.Logger, fromBlock, toBlock uint64) error {
+	log.Info("filtering logs", "from_block", fromBlock, "to_block", toBlock)
+
+	batch, err := r.FetchLogs(ctx, c, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+
+	// Get block time for the lowest block
+	lowBlockHeader, err := c.Source.HeaderByNumber(ctx, big.NewInt(int64(fromBlock)))
+	if err != nil {
+		return fmt.Errorf("failed to get header for lowest block: %w", err)
+	}
+	lowBlockTime := int64(lowBlockHeader.Time)
+
+	// Get block time for the latest block, used if the last processed block pointer is NULL
+	toBlockHeader, err := c.Source.HeaderByNumber(ctx, big.NewInt(int64(toBlock)))
+	if err != nil {
+		return fmt.Errorf("failed to get header for toBlock: %w", err)
+	}
+	toBlockTime := int64(toBlockHeader.Time)
+	toBlockNumber := int64(toBlock)
+
+	ctx, txSpan := tracing.Tracer().Start(ctx, "db transaction")
+	defer txSpan.End()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after a successful commit is a no-op
+	defer tx.Rollback()
+	txStore := sqlitestore.NewTraced(tx)
+
+	count, err := c.Handler.HandleBatch(ctx, tx, batch)
+	if err != nil {
+		return err
+	}
+
+	if len(batch.Logs) == 0 {
+		log.Info("no logs found", "from_block", fromBlock)
+	} else {
+		log.Info("got logs", "from_block", fromBlock, "count", len(batch.Logs))
+	}
+
+	blockNumber := int64(fromBlock)
+
+	err = txStore.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+		BlockNumber: &blockNumber,
+		BlockTime:   &lowBlockTime,
+		Name:        c.LowPointer,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update block pointer: %w", err)
+	}
+
+	err = txStore.UpdateBlockPointerIfNull(ctx, sqlitestore.UpdateBlockPointerIfNullParams{
+		BlockNumber: &toBlockNumber,
+		BlockTime:   &toBlockTime,
+		Name:        c.LastPointer,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update last block pointer: %w", err)
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.onCommit(c, fromBlock, count)
+
+	return nil
+}
+
+// Forward polls the chain head and indexes new blocks until ctx is cancelled
+func (r *Runner) Forward(ctx context.Context, c *Chain) error {
+	log := r.log.With("chain", c.Name, "mode", "forward")
+
+	sleepDuration := c.BlockInterval
+	pollTicker := time.NewTicker(sleepDuration)
+	defer pollTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-pollTicker.C:
+			err := r.retry(ctx, log, func() error {
+				return tracing.Run(ctx, "forward batch", func(ctx context.Context) error {
+					log.Info("forward filling", "sleep_duration", sleepDuration)
+					return r.ForwardBatch(ctx, c)
+				}, batchAttributes(c.Name, "forward"))
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ForwardBatch indexes the blocks after the last processed block, at most a
+// forwarding batch of them
+func (r *Runner) ForwardBatch(ctx context.Context, c *Chain) error {
+	log := r.log.With("chain", c.Name, "mode", "forward")
+
+	// Get the last processed block
+	lastProcessedBlock, err := r.store.GetBlockPointer(ctx, c.LastPointer)
+	if err != nil {
+		return fmt.Errorf("failed to get last processed block: %w", err)
+	}
+
+	// The last processed block is only unset when the head was at the start
+	// block during backfilling, so there was nothing to backfill
+	fromBlock := c.StartBlock
+	if lastProcessedBlock.BlockNumber != nil {
+		fromBlock = uint64(*lastProcessedBlock.BlockNumber) + 1
+	}
+
+	// Get the current head block
+	headBlock, err := r.head(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	// If we're already at the head, only check the indexed blocks for a reorg
+	if fromBlock > headBlock {
+		log.Info("already at head, skipping", "from_block", fromBlock, "head_block", headBlock)
+		if lastProcessedBlock.BlockNumber == nil {
+			return nil
+		}
+		_, err := r.rewind(ctx, c, log, uint64(*lastProcessedBlock.BlockNumber))
+		return err
+	}
+
+	// Process blocks in chunks of at most the forwarding batch size
+	toBlock := fromBlock + (r.cfg.ForwardingBatchSize - 1)
+	if toBlock > headBlock {
+		toBlock = headBlock
+	}
+
+	tracing.SetAttributes(ctx, blockRangeAttributes(fromBlock, toBlock)...)
+
+	log.Info("forward filling", "from_block", fromBlock, "to_block", toBlock)
+
+	toBlockHeader, err := c.Source.HeaderByNumber(ctx, big.NewInt(int64(toBlock)))
+	if err != nil {
+		return fmt.Errorf("failed to get header for toBlock: %w", err)
+	}
+	toBlockTime := int64(toBlockHeader.Time)
+
+	// The indexed blocks are checked for a reorg after the header is read.
+	// A reorg before it is rewound and the batch ends, a reorg after it
+	// changes the hash the next batch checks.
+	if lastProcessedBlock.BlockNumber != nil {
+		rewound, err := r.rewind(ctx, c, log, uint64(*lastProcessedBlock.BlockNumber))
+		if err != nil || rewound {
+			return err
+		}
+	}
+
+	batch, err := r.FetchLogs(ctx, c, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+
+	ctx, txSpan := tracing.Tracer().Start(ctx, "db transaction")
+	defer txSpan.End()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after a successful commit is a no-op
+	defer tx.Rollback()
+	txStore := sqlitestore.NewTraced(tx)
+
+	count, err := c.Handler.HandleBatch(ctx, tx, batch)
+	if err != nil {
+		return err
+	}
+
+	if len(batch.Logs) > 0 {
+		log.Info("processed logs", "count", len(batch.Logs))
+	}
+
+	// Update the last processed block pointer
+	toBlockNumber := int64(toBlock)
+	err = txStore.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+		BlockNumber: &toBlockNumber,
+		BlockTime:   &toBlockTime,
+		Name:        c.LastPointer,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update last block pointer: %w", err)
+	}
+
+	err = r.storeBlockHash(ctx, txStore, c, toBlockHeader)
+	if err != nil {
+		return err
+	}
+
+	// Commit the transaction
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.onCommit(c, toBlock, count)
+
+	// If we've reached the head, wait for the next polling interval
+	if toBlock == headBlock {
+		log.Info("reached head block, waiting for next poll", "head_block", headBlock)
+	}
+
+	return nil
+}
+
+// Reindex deletes what was stored for blocks fromBlock to toBlock and
+// ingests them again from the node, one backfilling batch at a time. It
+// returns the number of deleted and inserted events.
+func (r *Runner) Reindex(ctx context.Context, c *Chain, fromBlock, toBlock uint64) (int64, int64, error) {
+	log := r.log.With("chain", c.Name, "mode", "reindex")
+
+	var deleted, inserted int64
+	for start := fromBlock; start <= toBlock; start += r.cfg.BackfillingBatchSize {
+		end := min(start+r.cfg.BackfillingBatchSize-1, toBlock)
+
+		err := r.retry(ctx, log, func() error {
+			return tracing.Run(ctx, "reindex batch", func(ctx context.Context) error {
+				batch, err := r.FetchLogs(ctx, c, start, end)
+				if err != nil {
+					return err
+				}
+
+				tx, err := r.db.Begin()
+				if err != nil {
+					return fmt.Errorf("failed to begin transaction: %w", err)
+				}
+				// Rolling back after a successful commit is a no-op
+				defer tx.Rollback()
+
+				n, err := c.Handler.ClearRange(ctx, tx, start, end)
+				if err != nil {
+					return err
+				}
+
+				_, err = c.Handler.HandleBatch(ctx, tx, batch)
+				if err != nil {
+					return err
+				}
+
+				err = tx.Commit()
+				if err != nil {
+					return fmt.Errorf("failed to commit transaction: %w", err)
+				}
+
+				log.Info("reindexed blocks", "from_block", start, "to_block", end, "deleted", n, "inserted", len(batch.Logs))
+				deleted += n
+				inserted += int64(len(batch.Logs))
+				return nil
+			}, batchAttributes(c.Name, "reindex", start, end))
+		})
+		if err != nil {
+			return deleted, inserted, err
+		}
+	}
+
+	return deleted, inserted, nil
+}
+
+// FetchLogs returns the logs of a block range and the timestamps of the
+// blocks they were emitted in. The range is filtered once per version of
+// the contracts active in it.
+func (r *Runner) FetchLogs(ctx context.Context, c *Chain, fromBlock, toBlock uint64) (*Batch, error) {
+	batch := &Batch{FromBlock: fromBlock, ToBlock: toBlock, BlockTimes: make(map[uint64]uint64)}
+	for _, segment := range c.Contracts.Segments(fromBlock, toBlock) {
+		logs, err := r.filterLogs(ctx, c, segment.Query())
+		if err != nil {
+			return nil, err
+		}
+		batch.Logs = append(batch.Logs, logs...)
+	}
+
+	// Get block times for each block with events. Logs of a block the chain
+	// no longer has were filtered while it reorged, the batch is retried.
+	blockHashes := make(map[uint64]common.Hash)
+	for _, log := range batch.Logs {
+		if _, exists := blockHashes[log.BlockNumber]; !exists {
+			header, err := c.Source.HeaderByNumber(ctx, big.NewInt(int64(log.BlockNumber)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get header: %w", err)
+			}
+			if header == nil {
+				return nil, fmt.Errorf("failed to get header: block %d not found", log.BlockNumber)
+			}
+			blockHashes[log.BlockNumber] = header.Hash()
+			batch.BlockTimes[log.BlockNumber] = header.Time
+		}
+		if log.BlockHash != blockHashes[log.BlockNumber] {
+			return nil, fmt.Errorf("log of block %d is from block hash %s, the chain has %s", log.BlockNumber, log.BlockHash.Hex(), blockHashes[log.BlockNumber].Hex())
+		}
+	}
+
+	return batch, nil
+}
+
+// MatchesFilter reports whether a log passes the address and topic filters
+// of q, for sources that serve logs from memory. The block range and hash of
+// q are left to the caller.
+func MatchesFilter(lg types.Log, q ethereum.FilterQuery) bool {
+	if len(q.Addresses) > 0 && !slices.Contains(q.Addresses, lg.Address) {
+		return false
+	}
+	for i, alternatives := range q.Topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		if i >= len(lg.Topics) || !slices.Contains(alternatives, lg.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterLogs filters the logs of a query, splitting its block range in
+// halves while the node refuses it for returning too many results
+func (r *Runner) filterLogs(ctx context.Context, c *Chain, q ethereum.FilterQuery) ([]types.Log, error) {
+	logs, err := c.Source.FilterLogs(ctx, q)
+	if err == nil {
+		return r.checkLogs(ctx, c, q, logs)
+	}
+
+	fromBlock, toBlock := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if fromBlock == toBlock || !isTooManyResults(err) {
+		return nil, fmt.Errorf("failed to filter logs: %w", err)
+	}
+
+	mid := fromBlock + (toBlock-fromBlock)/2
+	r.log.Debug("splitting log filter", "chain", c.Name, "from_block", fromBlock, "to_block", toBlock, "error", err)
+
+	low, high := q, q
+	low.ToBlock = new(big.Int).SetUint64(mid)
+	high.FromBlock = new(big.Int).SetUint64(mid + 1)
+
+	logs, err = r.filterLogs(ctx, c, low)
+	if err != nil {
+		return nil, err
+	}
+	more, err := r.filterLogs(ctx, c, high)
+	if err != nil {
+		return nil, err
+	}
+	return append(logs, more...), nil
+}
+
+// checkLogs repeats a log filter until FilterChecks answers in a row agree
+// with the logs it returned, taking the latest answer when they differ.
+// Failed checks are repeated too, for at most MaxRetries more checks.
+func (r *Runner) checkLogs(ctx context.Context, c *Chain, q ethereum.FilterQuery, logs []types.Log) ([]types.Log, error) {
+	fromBlock, toBlock := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	agreed := 0
+	for checks := 0; agreed < r.cfg.FilterChecks; checks++ {
+		if checks >= r.cfg.FilterChecks+r.cfg.MaxRetries {
+			return nil, fmt.Errorf("failed to check filtered logs: %d of %d answers for blocks %d to %d agree", agreed, r.cfg.FilterChecks, fromBlock, toBlock)
+		}
+		again, err := c.Source.FilterLogs(ctx, q)
+		switch {
+		case ctx.Err() != nil:
+			return nil, ctx.Err()
+		case err != nil:
+			r.log.Debug("log filter check failed", "chain", c.Name, "from_block", fromBlock, "to_block", toBlock, "error", err)
+		case sameLogs(logs, again):
+			agreed++
+		default:
+			r.log.Warn("log filter answers differ", "chain", c.Name, "from_block", fromBlock, "to_block", toBlock, "count", len(logs), "again", len(again))
+			logs, agreed = again, 0
+		}
+	}
+	return logs, nil
+}
+
+// sameLogs reports whether two answers of a log filter hold the same logs
+func sameLogs(a, b []types.Log) bool {
+	return slices.EqualFunc(a, b, func(x, y types.Log) bool {
+		return x.BlockHash == y.BlockHash && x.TxHash == y.TxHash && x.Index == y.Index
+	})
+}
+
+// tooManyResultsErrors are parts of the messages nodes and providers reject
+// eth_getLogs with when a range holds too many logs or blocks
+var tooManyResultsErrors = []string{
+	"query returned more than",
+	"too many results",
+	"response size exceeded",
+	"response size should not",
+	"block range is too large",
+	"exceed maximum block range",
+	"limit exceeded",
+}
+
+// isTooManyResults reports whether a log filter failed because of its size.
+// Rate limited requests are left to the retries, since splitting them would
+// only send more requests to a throttling provider.
+func isTooManyResults(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, part := range tooManyResultsErrors {
+		if strings.Contains(msg, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// head returns the latest block of the chain with enough confirmations
+func (r *Runner) head(ctx context.Context, c *Chain) (uint64, error) {
+	head, err := c.Source.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get current block number: %w", err)
+	}
+	return head - min(head, c.Confirmations), nil
+}
+
+// rewind follows a reorg of the forward filled blocks. It compares the stored
+// block hashes with the chain, newest first, and when the newest differs,
+// clears the blocks after the newest that matches and moves the last
+// processed block pointer back to it. It reports whether it rewound.
+func (r *Runner) rewind(ctx context.Context, c *Chain, log *slog.Logger, lastBlock uint64) (bool, error) {
+	if r.cfg.MaxReorgDepth == 0 {
+		return false, nil
+	}
+	hashes, err := r.store.ListBlockPointerHashes(ctx, sqlitestore.ListBlockPointerHashesParams{
+		Pointer:     c.LastPointer,
+		BlockNumber: int64(lastBlock),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list block hashes: %w", err)
+	}
+	if len(hashes) == 0 {
+		return false, nil
+	}
+
+	var forkBlock *types.Header
+	for i, stored := range hashes {
+		header, err := c.Source.HeaderByNumber(ctx, big.NewInt(stored.BlockNumber))
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return false, fmt.Errorf("failed to get header of block %d: %w", stored.BlockNumber, err)
+		}
+		if header != nil && header.Hash() == common.BytesToHash(stored.BlockHash) {
+			if i == 0 {
+				return false, nil
+			}
+			forkBlock = header
+			break
+		}
+	}
+	if forkBlock == nil {
+		// Every stored block was reorged, the blocks below the oldest are
+		// trusted and verification finds what they got wrong
+		oldest := uint64(max(hashes[len(hashes)-1].BlockNumber-1, 0))
+		log.Error("chain reorged deeper than the stored block hashes", "last_block", lastBlock, "oldest_block", oldest+1)
+		forkBlock, err = c.Source.HeaderByNumber(ctx, new(big.Int).SetUint64(oldest))
+		if err != nil {
+			return false, fmt.Errorf("failed to get header of block %d: %w", oldest, err)
+		}
+	}
+	fromBlock := forkBlock.Number.Uint64()
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after a successful commit is a no-op
+	defer tx.Rollback()
+	txStore := sqlitestore.NewTraced(tx)
+
+	deleted, err := c.Handler.ClearRange(ctx, tx, fromBlock+1, lastBlock)
+	if err != nil {
+		return false, err
+	}
+	blockNumber, blockTime := int64(fromBlock), int64(forkBlock.Time)
+	err = txStore.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+		BlockNumber: &blockNumber,
+		BlockTime:   &blockTime,
+		Name:        c.LastPointer,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to update last block pointer: %w", err)
+	}
+	err = txStore.DeleteBlockPointerHashesAfter(ctx, sqlitestore.DeleteBlockPointerHashesAfterParams{
+		Pointer:     c.LastPointer,
+		BlockNumber: blockNumber,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete reorged block hashes: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	log.Warn("chain reorged, rewound the last processed block", "from_block", lastBlock, "to_block", fromBlock, "deleted", deleted)
+	r.onCommit(c, fromBlock, 0)
+	return true, nil
+}
+
+// storeBlockHash stores the hash of the block the last processed block
+// pointer moved to and drops the hashes older than MaxReorgDepth blocks
+func (r *Runner) storeBlockHash(ctx context.Context, txStore *sqlitestore.Queries, c *Chain, header *types.Header) error {
+	if r.cfg.MaxReorgDepth == 0 {
+		return nil
+	}
+	blockNumber := header.Number.Int64()
+	err := txStore.InsertBlockPointerHash(ctx, sqlitestore.InsertBlockPointerHashParams{
+		Pointer:     c.LastPointer,
+		BlockNumber: blockNumber,
+		BlockHash:   header.Hash().Bytes(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store block hash: %w", err)
+	}
+	err = txStore.DeleteBlockPointerHashesBefore(ctx, sqlitestore.DeleteBlockPointerHashesBeforeParams{
+		Pointer:     c.LastPointer,
+		BlockNumber: blockNumber - int64(min(r.cfg.MaxReorgDepth, uint64(blockNumber))),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete old block hashes: %w", err)
+	}
+	return nil
+}
+
+// retry runs fn until it succeeds, at most MaxRetries times more, backing
+// off between attempts. The last error is returned.
+func (r *Runner) retry(ctx context.Context, log *slog.Logger, fn func() error) error {
+	backoff := r.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= r.cfg.MaxRetries || ctx.Err() != nil {
+			return err
+		}
+
+		log.Warn("batch failed, retrying", "attempt", attempt+1, "max_retries", r.cfg.MaxRetries, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (r *Runner) onCommit(c *Chain, blockNumber uint64, count int) {
+	if r.cfg.OnCommit != nil {
+		r.cfg.OnCommit(c, blockNumber, count)
+	}
+}
+
+// blockRangeAttributes returns the span attributes describing a block range
+func blockRangeAttributes(fromBlock, toBlock uint64) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int64("from_block", int64(fromBlock)),
+		attribute.Int64("to_block", int64(toBlock)),
+	}
+}
+
+// batchAttributes returns the span options for an indexer batch. The block
+// range is optional as forward batches only learn it after reading the head.
+func batchAttributes(chain, mode string, blockRange ...uint64) trace.SpanStartOption {
+	attrs := []attribute.KeyValue{
+		attribute.String("chain", chain),
+		attribute.String("mode", mode),
+	}
+	if len(blockRange) == 2 {
+		attrs = append(attrs, blockRangeAttributes(blockRange[0], blockRange[1])...)
+	}
+	return trace.WithAttributes(attrs...)
+}
@@ -0,0 +1,391 @@
+package indexer_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	contract = common.Address{0xc0}
+	topic    = common.Hash{0x01}
+)
+
+// fakeSource is a chain whose blocks are 10 seconds apart. Its next
+// failures log filters fail, its next rateLimited log filters are answered
+// with HTTP 429, its next truncated log filters drop their last log, and
+// filters over more than maxLogs logs are refused. The blocks from
+// reorgedFrom on get new hashes with every fork.
+type fakeSource struct {
+	head        uint64
+	logs        []types.Log
+	failures    int
+	rateLimited int
+	truncated   int
+	maxLogs     int
+	filters     int
+	fork        byte
+	reorgedFrom uint64
+}
+
+func (f *fakeSource) header(number uint64) *types.Header {
+	header := &types.Header{Number: new(big.Int).SetUint64(number), Time: 1000 + 10*number}
+	if f.fork > 0 && number >= f.reorgedFrom {
+		header.Extra = []byte{f.fork}
+	}
+	return header
+}
+
+func (f *fakeSource) BlockNumber(ctx context.Context) (uint64, error) {
+	return f.head, nil
+}
+
+func (f *fakeSource) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return f.header(number.Uint64()), nil
+}
+
+func (f *fakeSource) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.filters++
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("connection reset")
+	}
+	if f.rateLimited > 0 {
+		f.rateLimited--
+		return nil, rpc.HTTPError{StatusCode: 429, Status: "429 Too Many Requests", Body: []byte("rate limit exceeded\n")}
+	}
+	var logs []types.Log
+	for _, lg := range f.logs {
+		if lg.BlockNumber >= q.FromBlock.Uint64() && lg.BlockNumber <= q.ToBlock.Uint64() && indexer.MatchesFilter(lg, q) {
+			lg.BlockHash = f.header(lg.BlockNumber).Hash()
+			logs = append(logs, lg)
+		}
+	}
+	if f.maxLogs > 0 && len(logs) > f.maxLogs {
+		return nil, fmt.Errorf("query returned more than %d results", f.maxLogs)
+	}
+	if f.truncated > 0 && len(logs) > 0 {
+		f.truncated--
+		logs = logs[:len(logs)-1]
+	}
+	return logs, nil
+}
+
+// recordingHandler keeps the handled logs by block
+type recordingHandler struct {
+	blocks map[uint64]int
+}
+
+func (h *recordingHandler) HandleBatch(ctx context.Context, tx *sql.Tx, batch *indexer.Batch) (int, error) {
+	for _, lg := range batch.Logs {
+		h.blocks[lg.BlockNumber]++
+		if batch.BlockTimes[lg.BlockNumber] != 1000+10*lg.BlockNumber {
+			return 0, io.ErrUnexpectedEOF
+		}
+	}
+	return len(batch.Logs), nil
+}
+
+func (h *recordingHandler) ClearRange(ctx context.Context, tx *sql.Tx, fromBlock, toBlock uint64) (int64, error) {
+	var deleted int64
+	for block, n := range h.blocks {
+		if block >= fromBlock && block <= toBlock {
+			deleted += int64(n)
+			delete(h.blocks, block)
+		}
+	}
+	return deleted, nil
+}
+
+func TestRunner(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	source := &fakeSource{head: 100}
+	for _, block := range []uint64{5, 20, 45, 99, 120} {
+		source.logs = append(source.logs, types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: block})
+	}
+	// Logs of other contracts or events are not requested
+	source.logs = append(source.logs, types.Log{Address: common.Address{0xc1}, Topics: []common.Hash{topic}, BlockNumber: 30})
+
+	handler := &recordingHandler{blocks: map[uint64]int{}}
+	c := &indexer.Chain{
+		Name:        "test",
+		Source:      source,
+		Contracts:   registry.History{{Address: contract, Topic: topic}},
+		LowPointer:  "test_lowest_processed_block",
+		LastPointer: "test_last_processed_block",
+		StartBlock:  10,
+		Handler:     handler,
+	}
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LowPointer))
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LastPointer))
+
+	var commits []uint64
+	runner := indexer.New(db, indexer.Config{
+		BackfillingBatchSize: 30,
+		ForwardingBatchSize:  15,
+		OnCommit: func(c *indexer.Chain, blockNumber uint64, count int) {
+			commits = append(commits, blockNumber)
+		},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Blocks 10 to 99 are backfilled, the head is left to forward filling
+	require.NoError(t, runner.Backfill(ctx, c))
+	require.Equal(t, map[uint64]int{20: 1, 45: 1, 99: 1}, handler.blocks)
+	require.Equal(t, []uint64{70, 40, 10}, commits)
+
+	low, err := queries.GetBlockPointer(ctx, c.LowPointer)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), *low.BlockNumber)
+	require.Equal(t, int64(1100), *low.BlockTime)
+
+	last, err := queries.GetBlockPointer(ctx, c.LastPointer)
+	require.NoError(t, err)
+	require.Equal(t, int64(99), *last.BlockNumber)
+
+	// Backfilling again has nothing left to do
+	require.NoError(t, runner.Backfill(ctx, c))
+	require.Len(t, commits, 3)
+
+	source.head = 130
+	require.NoError(t, runner.ForwardBatch(ctx, c))
+	require.NoError(t, runner.ForwardBatch(ctx, c))
+	require.NoError(t, runner.ForwardBatch(ctx, c))
+	require.Equal(t, []uint64{70, 40, 10, 114, 129, 130}, commits)
+	require.Equal(t, 1, handler.blocks[120])
+
+	last, err = queries.GetBlockPointer(ctx, c.LastPointer)
+	require.NoError(t, err)
+	require.Equal(t, int64(130), *last.BlockNumber)
+
+	deleted, inserted, err := runner.Reindex(ctx, c, 15, 50)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+	require.Equal(t, int64(2), inserted)
+	require.Equal(t, map[uint64]int{20: 1, 45: 1, 99: 1, 120: 1}, handler.blocks)
+}
+
+func TestRunnerFaults(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	source := &fakeSource{head: 100, maxLogs: 2}
+	for block := uint64(1); block <= 100; block += 10 {
+		source.logs = append(source.logs, types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: block})
+	}
+
+	handler := &recordingHandler{blocks: map[uint64]int{}}
+	c := &indexer.Chain{
+		Name:        "test",
+		Source:      source,
+		Contracts:   registry.History{{Address: contract, Topic: topic}},
+		LowPointer:  "test_lowest_processed_block",
+		LastPointer: "test_last_processed_block",
+		Handler:     handler,
+	}
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LowPointer))
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LastPointer))
+
+	runner := indexer.New(db, indexer.Config{
+		BackfillingBatchSize: 100,
+		ForwardingBatchSize:  100,
+		MaxRetries:           2,
+		RetryBackoff:         time.Millisecond,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Failures beyond the retries stop the runner without moving the pointers
+	source.failures = 3
+	require.ErrorContains(t, runner.Backfill(ctx, c), "connection reset")
+	low, err := queries.GetBlockPointer(ctx, c.LowPointer)
+	require.NoError(t, err)
+	require.Nil(t, low.BlockNumber)
+
+	// Fewer failures are retried, and the range holding 10 logs is split
+	// until no filter returns more than 2
+	source.failures = 2
+	source.filters = 0
+	require.NoError(t, runner.Backfill(ctx, c))
+	require.Len(t, handler.blocks, 10)
+	require.Greater(t, source.filters, 3)
+
+	// A single block over the limit cannot be split
+	source.head = 101
+	source.logs = append(source.logs,
+		types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: 101},
+		types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: 101},
+		types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: 101},
+	)
+	require.ErrorContains(t, runner.ForwardBatch(ctx, c), "query returned more than 2 results")
+}
+
+func TestRunnerRateLimited(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	source := &fakeSource{head: 100}
+	for block := uint64(1); block <= 100; block += 10 {
+		source.logs = append(source.logs, types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: block})
+	}
+
+	handler := &recordingHandler{blocks: map[uint64]int{}}
+	c := &indexer.Chain{
+		Name:        "test",
+		Source:      source,
+		Contracts:   registry.History{{Address: contract, Topic: topic}},
+		LowPointer:  "test_lowest_processed_block",
+		LastPointer: "test_last_processed_block",
+		Handler:     handler,
+	}
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LowPointer))
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LastPointer))
+
+	runner := indexer.New(db, indexer.Config{
+		BackfillingBatchSize: 100,
+		ForwardingBatchSize:  100,
+		MaxRetries:           1,
+		RetryBackoff:         time.Millisecond,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// A rate limited filter is retried whole instead of being split
+	source.rateLimited = 1
+	require.NoError(t, runner.Backfill(ctx, c))
+	require.Len(t, handler.blocks, 10)
+	require.Equal(t, 2, source.filters)
+}
+
+func TestRunnerReorg(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	source := &fakeSource{head: 100}
+	for _, block := range []uint64{60, 99, 105} {
+		source.logs = append(source.logs, types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: block})
+	}
+
+	handler := &recordingHandler{blocks: map[uint64]int{}}
+	c := &indexer.Chain{
+		Name:          "test",
+		Source:        source,
+		Contracts:     registry.History{{Address: contract, Topic: topic}},
+		LowPointer:    "test_lowest_processed_block",
+		LastPointer:   "test_last_processed_block",
+		StartBlock:    50,
+		Confirmations: 2,
+		Handler:       handler,
+	}
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LowPointer))
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LastPointer))
+
+	var commits []uint64
+	runner := indexer.New(db, indexer.Config{
+		BackfillingBatchSize: 100,
+		ForwardingBatchSize:  5,
+		MaxReorgDepth:        20,
+		OnCommit: func(c *indexer.Chain, blockNumber uint64, count int) {
+			commits = append(commits, blockNumber)
+		},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Blocks without 2 confirmations are left for later
+	require.NoError(t, runner.Backfill(ctx, c))
+	require.Equal(t, map[uint64]int{60: 1}, handler.blocks)
+
+	source.head = 112
+	for range 3 {
+		require.NoError(t, runner.ForwardBatch(ctx, c))
+	}
+	require.Equal(t, []uint64{50, 102, 107, 110}, commits)
+	require.Equal(t, map[uint64]int{60: 1, 99: 1, 105: 1}, handler.blocks)
+
+	// The chain reorgs from block 105, where the log moves to block 107.
+	// The next batch rewinds to block 102, the newest block with an
+	// unchanged hash, and the following one indexes the new blocks.
+	source.fork, source.reorgedFrom = 1, 105
+	source.logs[2].BlockNumber = 107
+	require.NoError(t, runner.ForwardBatch(ctx, c))
+	require.Equal(t, []uint64{50, 102, 107, 110, 102}, commits)
+	require.Equal(t, map[uint64]int{60: 1, 99: 1}, handler.blocks)
+	require.NoError(t, runner.ForwardBatch(ctx, c))
+	require.Equal(t, []uint64{50, 102, 107, 110, 102, 107}, commits)
+	require.Equal(t, map[uint64]int{60: 1, 99: 1, 107: 1}, handler.blocks)
+
+	last, err := queries.GetBlockPointer(ctx, c.LastPointer)
+	require.NoError(t, err)
+	require.Equal(t, int64(107), *last.BlockNumber)
+
+	hashes, err := queries.ListBlockPointerHashes(ctx, sqlitestore.ListBlockPointerHashesParams{Pointer: c.LastPointer, BlockNumber: 200})
+	require.NoError(t, err)
+	require.Len(t, hashes, 2)
+	require.Equal(t, source.header(107).Hash().Bytes(), hashes[0].BlockHash)
+}
+
+func TestRunnerFilterChecks(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	source := &fakeSource{head: 40}
+	for _, block := range []uint64{10, 20, 30} {
+		source.logs = append(source.logs, types.Log{Address: contract, Topics: []common.Hash{topic}, BlockNumber: block})
+	}
+
+	handler := &recordingHandler{blocks: map[uint64]int{}}
+	c := &indexer.Chain{
+		Name:        "test",
+		Source:      source,
+		Contracts:   registry.History{{Address: contract, Topic: topic}},
+		LowPointer:  "test_lowest_processed_block",
+		LastPointer: "test_last_processed_block",
+		Handler:     handler,
+	}
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LowPointer))
+	require.NoError(t, queries.InsertBlockPointer(ctx, c.LastPointer))
+
+	runner := indexer.New(db, indexer.Config{
+		BackfillingBatchSize: 100,
+		ForwardingBatchSize:  100,
+		MaxRetries:           1,
+		FilterChecks:         1,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// The truncated first answer disagrees with the next one, which is
+	// accepted once a third answer agrees
+	source.truncated = 1
+	require.NoError(t, runner.Backfill(ctx, c))
+	require.Equal(t, map[uint64]int{10: 1, 20: 1, 30: 1}, handler.blocks)
+	require.Equal(t, 3, source.filters)
+}
+
+func TestMatchesFilter(t *testing.T) {
+	other := common.Hash{0x02}
+	lg := types.Log{Address: contract, Topics: []common.Hash{topic, other}}
+
+	require.True(t, indexer.MatchesFilter(lg, ethereum.FilterQuery{}))
+	require.True(t, indexer.MatchesFilter(lg, ethereum.FilterQuery{Addresses: []common.Address{{0xc1}, contract}}))
+	require.False(t, indexer.MatchesFilter(lg, ethereum.FilterQuery{Addresses: []common.Address{{0xc1}}}))
+	// An empty position matches any topic
+	require.True(t, indexer.MatchesFilter(lg, ethereum.FilterQuery{Topics: [][]common.Hash{nil, {topic, other}}}))
+	require.False(t, indexer.MatchesFilter(lg, ethereum.FilterQuery{Topics: [][]common.Hash{{other}}}))
+	require.False(t, indexer.MatchesFilter(lg, ethereum.FilterQuery{Topics: [][]common.Hash{nil, nil, {topic}}}))
+}
@@ -0,0 +1,266 @@
+package latency
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/monitor"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Labels of the recorded chain heads
+const (
+	Safe      = "safe"
+	Finalized = "finalized"
+	// Origin is recorded on the L1 chain, it is the L1 origin of the latest L2 block
+	Origin = "origin"
+)
+
+// OriginBatchSize is the number of L2 blocks whose L1 origin is read per update
+const OriginBatchSize = 100
+
+// Selectors of the L1 attributes transaction, the first transaction of every
+// L2 block, which sets the L1 origin of the block in the L1Block predeploy
+var (
+	setL1BlockValuesBedrock = crypto.Keccak256([]byte("setL1BlockValues(uint64,uint64,uint256,bytes32,uint64,bytes32,uint256,uint256)"))[:4]
+	setL1BlockValuesEcotone = crypto.Keccak256([]byte("setL1BlockValuesEcotone()"))[:4]
+	setL1BlockValuesIsthmus = crypto.Keccak256([]byte("setL1BlockValuesIsthmus()"))[:4]
+)
+
+// HeadClient is the part of an execution client used to read the safe and finalized heads
+type HeadClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// L2Client also reads the L1 attributes transaction of L2 blocks
+type L2Client interface {
+	HeadClient
+	TransactionInput(ctx context.Context, blockNumber *big.Int, index uint) ([]byte, error)
+}
+
+// Tracker records when blocks of both chains become safe and finalized, and
+// the L1 origin of the L2 blocks that finalized deposits. Together with the
+// block timestamps they break the latency of a deposit down into L1 finality,
+// the time until the sequencer adopted the L1 block as its origin, and L2
+// derivation and finality.
+type Tracker struct {
+	db  *sql.DB
+	l1  HeadClient
+	l2  L2Client
+	log *slog.Logger
+	// previous holds the last head recorded by this tracker per chain and label
+	previous map[string]int64
+}
+
+// New creates a latency tracker
+func New(db *sql.DB, l1 HeadClient, l2 L2Client, log *slog.Logger) *Tracker {
+	return &Tracker{
+		db:       db,
+		l1:       l1,
+		l2:       l2,
+		log:      log,
+		previous: map[string]int64{},
+	}
+}
+
+// Run updates the heads and origins every interval until ctx is cancelled.
+// The time a block became safe or finalized is only known to within the
+// interval.
+func (t *Tracker) Run(ctx context.Context, interval time.Duration) error {
+	return monitor.Every(ctx, interval, t.log, t.Update)
+}
+
+// Update records the current safe and finalized heads and L1 origin, and
+// reads the L1 origin of up to OriginBatchSize L2 blocks
+func (t *Tracker) Update(ctx context.Context) error {
+	return tracing.Run(ctx, "latency update", func(ctx context.Context) error {
+		err := t.ObserveHeads(ctx)
+		if err != nil {
+			return err
+		}
+		err = t.ObserveOrigin(ctx)
+		if err != nil {
+			return err
+		}
+		return t.FillOrigins(ctx)
+	})
+}
+
+// ObserveHeads records the safe and finalized heads of both chains that
+// advanced since the previous observation
+func (t *Tracker) ObserveHeads(ctx context.Context) error {
+	queries := sqlitestore.NewTraced(t.db)
+	observedAt := time.Now().Unix()
+
+	for _, c := range []struct {
+		name   string
+		client HeadClient
+	}{{"l1", t.l1}, {"l2", t.l2}} {
+		for _, head := range []struct {
+			label  string
+			number rpc.BlockNumber
+		}{{Safe, rpc.SafeBlockNumber}, {Finalized, rpc.FinalizedBlockNumber}} {
+			header, err := c.client.HeaderByNumber(ctx, big.NewInt(head.number.Int64()))
+			if err != nil {
+				return fmt.Errorf("failed to get %s %s head: %w", c.name, head.label, err)
+			}
+
+			err = t.record(ctx, queries, c.name, head.label, header.Number.Int64(), int64(header.Time), observedAt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ObserveOrigin records the L1 origin of the latest L2 block when it advanced
+func (t *Tracker) ObserveOrigin(ctx context.Context) error {
+	observedAt := time.Now().Unix()
+
+	header, err := t.l2.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest L2 block: %w", err)
+	}
+
+	input, err := t.l2.TransactionInput(ctx, header.Number, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get L1 attributes transaction of L2 block %s: %w", header.Number, err)
+	}
+
+	number, timestamp, err := DecodeL1Info(input)
+	if err != nil {
+		return fmt.Errorf("failed to decode L1 attributes of L2 block %s: %w", header.Number, err)
+	}
+
+	return t.record(ctx, sqlitestore.NewTraced(t.db), "l1", Origin, int64(number), int64(timestamp), observedAt)
+}
+
+// record stores a head if it advanced since the previous one recorded by this tracker
+func (t *Tracker) record(ctx context.Context, queries *sqlitestore.Queries, chain, label string, blockNumber, blockTime, observedAt int64) error {
+	key := chain + "/" + label
+	previous, ok := t.previous[key]
+	if ok && blockNumber <= previous {
+		return nil
+	}
+
+	params := sqlitestore.InsertChainHeadParams{
+		Chain:       chain,
+		Label:       label,
+		BlockNumber: blockNumber,
+		BlockTime:   blockTime,
+		ObservedAt:  observedAt,
+	}
+	if ok {
+		params.PreviousBlockNumber = &previous
+	}
+	err := queries.InsertChainHead(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to insert %s %s head: %w", chain, label, err)
+	}
+	t.previous[key] = blockNumber
+	return nil
+}
+
+// FillOrigins reads the L1 origin of the L2 blocks that finalized deposits
+// and do not have one yet. Blocks without a decodable L1 attributes
+// transaction are logged and marked, so that they are not read again.
+func (t *Tracker) FillOrigins(ctx context.Context) error {
+	queries := sqlitestore.NewTraced(t.db)
+
+	blocks, err := queries.ListL2BlocksWithoutOrigin(ctx, OriginBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list L2 blocks without origin: %w", err)
+	}
+
+	for _, block := range blocks {
+		input, err := t.l2.TransactionInput(ctx, big.NewInt(block), 0)
+		if errors.Is(err, ethereum.NotFound) {
+			err = t.skipOrigin(ctx, queries, block, fmt.Errorf("no L1 attributes transaction"))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get L1 attributes transaction of L2 block %d: %w", block, err)
+		}
+
+		number, timestamp, err := DecodeL1Info(input)
+		if err != nil {
+			err = t.skipOrigin(ctx, queries, block, err)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		originNumber, originTimestamp := int64(number), int64(timestamp)
+		err = queries.UpdateL2Origin(ctx, sqlitestore.UpdateL2OriginParams{
+			L1OriginNumber:    &originNumber,
+			L1OriginTimestamp: &originTimestamp,
+			BlockNumber:       block,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update origin of L2 block %d: %w", block, err)
+		}
+	}
+	return nil
+}
+
+// skipOrigin records why the L1 origin of an L2 block cannot be read
+func (t *Tracker) skipOrigin(ctx context.Context, queries *sqlitestore.Queries, block int64, reason error) error {
+	t.log.Warn("skipping L2 block without decodable L1 attributes", "block_number", block, "error", reason)
+
+	message := reason.Error()
+	err := queries.UpdateL2OriginError(ctx, sqlitestore.UpdateL2OriginErrorParams{
+		L1OriginError: &message,
+		BlockNumber:   block,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark origin of L2 block %d as undecodable: %w", block, err)
+	}
+	return nil
+}
+
+// DecodeL1Info returns the L1 origin number and timestamp set by the input
+// of an L1 attributes transaction
+func DecodeL1Info(input []byte) (uint64, uint64, error) {
+	if len(input) < 4 {
+		return 0, 0, fmt.Errorf("input too short: %d bytes", len(input))
+	}
+
+	switch selector := input[:4]; {
+	case bytes.Equal(selector, setL1BlockValuesBedrock):
+		// ABI encoded (uint64 number, uint64 timestamp, ...)
+		if len(input) < 4+2*32 {
+			return 0, 0, fmt.Errorf("bedrock input too short: %d bytes", len(input))
+		}
+		number := binary.BigEndian.Uint64(input[4+24 : 4+32])
+		timestamp := binary.BigEndian.Uint64(input[36+24 : 36+32])
+		return number, timestamp, nil
+	case bytes.Equal(selector, setL1BlockValuesEcotone), bytes.Equal(selector, setL1BlockValuesIsthmus):
+		// Packed (uint32 baseFeeScalar, uint32 blobBaseFeeScalar,
+		// uint64 sequenceNumber, uint64 timestamp, uint64 number, ...)
+		if len(input) < 36 {
+			return 0, 0, fmt.Errorf("ecotone input too short: %d bytes", len(input))
+		}
+		timestamp := binary.BigEndian.Uint64(input[20:28])
+		number := binary.BigEndian.Uint64(input[28:36])
+		return number, timestamp, nil
+	default:
+		return 0, 0, fmt.Errorf("unknown L1 attributes selector %x", selector)
+	}
+}
@@ -0,0 +1,163 @@
+package latency_test
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/latency"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain serves the configured latest, safe and finalized heads and the L1
+// attributes transaction of every L2 block
+type fakeChain struct {
+	latest, safe, finalized uint64
+	origins                 map[uint64]uint64
+	// inputs overrides the L1 attributes transaction input of a block
+	inputs map[uint64][]byte
+}
+
+func (f *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	n := f.latest
+	switch {
+	case number == nil:
+	case number.Int64() == rpc.SafeBlockNumber.Int64():
+		n = f.safe
+	case number.Int64() == rpc.FinalizedBlockNumber.Int64():
+		n = f.finalized
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(n), Time: 1000 + n}, nil
+}
+
+func (f *fakeChain) TransactionInput(ctx context.Context, blockNumber *big.Int, index uint) ([]byte, error) {
+	if input, ok := f.inputs[blockNumber.Uint64()]; ok {
+		return input, nil
+	}
+	origin := f.origins[blockNumber.Uint64()]
+	return ecotoneInput(origin, 1000+origin), nil
+}
+
+func ecotoneInput(number, timestamp uint64) []byte {
+	input := crypto.Keccak256([]byte("setL1BlockValuesEcotone()"))[:4]
+	input = append(input, make([]byte, 16)...)
+	input = binary.BigEndian.AppendUint64(input, timestamp)
+	input = binary.BigEndian.AppendUint64(input, number)
+	return append(input, make([]byte, 32*4)...)
+}
+
+func TestDecodeL1Info(t *testing.T) {
+	number, timestamp, err := latency.DecodeL1Info(ecotoneInput(100, 1234))
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), number)
+	require.Equal(t, uint64(1234), timestamp)
+
+	bedrock := crypto.Keccak256([]byte("setL1BlockValues(uint64,uint64,uint256,bytes32,uint64,bytes32,uint256,uint256)"))[:4]
+	bedrock = append(bedrock, common.LeftPadBytes(big.NewInt(7).Bytes(), 32)...)
+	bedrock = append(bedrock, common.LeftPadBytes(big.NewInt(5678).Bytes(), 32)...)
+	bedrock = append(bedrock, make([]byte, 32*6)...)
+	number, timestamp, err = latency.DecodeL1Info(bedrock)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), number)
+	require.Equal(t, uint64(5678), timestamp)
+
+	_, _, err = latency.DecodeL1Info([]byte{1, 2, 3, 4})
+	require.Error(t, err)
+}
+
+func TestTracker(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	deposit := func(l1Block, l2Block int64) int64 {
+		l1ID, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+			BlockNumber:    l1Block,
+			BlockTimestamp: 1000 + l1Block,
+			TxHash:         common.Hash{byte(l1Block)}.Bytes(),
+			FromAddress:    []byte{},
+			ToAddress:      []byte{},
+			AmountWei:      make([]byte, 32),
+			Event:          []byte("{}"),
+			MatchingHash:   []byte{1},
+		})
+		require.NoError(t, err)
+		l2ID, err := queries.InsertL2StandardBridgeDepositFinalized(ctx, sqlitestore.InsertL2StandardBridgeDepositFinalizedParams{
+			BlockNumber:    l2Block,
+			BlockTimestamp: 1000 + l1Block + 4,
+			TxHash:         common.Hash{byte(l2Block)}.Bytes(),
+			FromAddress:    []byte{},
+			ToAddress:      []byte{},
+			L1Token:        []byte{},
+			AmountWei:      make([]byte, 32),
+			Event:          []byte("{}"),
+			MatchingHash:   []byte{1},
+		})
+		require.NoError(t, err)
+		require.NoError(t, queries.UpdateL1DepositWithMatch(ctx, sqlitestore.UpdateL1DepositWithMatchParams{MatchedL2StandardBridgeDepositFinalizedID: &l2ID, ID: l1ID}))
+		require.NoError(t, queries.UpdateL2DepositWithMatch(ctx, sqlitestore.UpdateL2DepositWithMatchParams{MatchedL1StandardBridgeEthDepositInitiatedID: &l1ID, ID: l2ID}))
+		return l1ID
+	}
+
+	// The first deposit was already safe when the tracker started, the second one is not
+	before := deposit(85, 400)
+	after := deposit(100, 500)
+	// The L1 attributes of the third one cannot be decoded
+	undecodable := deposit(90, 450)
+
+	l1 := &fakeChain{safe: 90, finalized: 80}
+	l2 := &fakeChain{
+		latest: 500, safe: 450, finalized: 300,
+		origins: map[uint64]uint64{400: 85, 500: 100, 530: 115},
+		inputs:  map[uint64][]byte{450: {1, 2, 3, 4}},
+	}
+	tracker := latency.New(db, l1, l2, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	require.NoError(t, tracker.Update(ctx))
+	l1.safe, l1.finalized = 110, 95
+	l2.safe, l2.finalized = 510, 450
+	require.NoError(t, tracker.Update(ctx))
+	l1.finalized = 120
+	l2.latest, l2.finalized = 530, 520
+	require.NoError(t, tracker.Update(ctx))
+
+	origin, err := queries.GetLatestChainHead(ctx, sqlitestore.GetLatestChainHeadParams{Chain: "l1", Label: latency.Origin})
+	require.NoError(t, err)
+	require.Equal(t, int64(115), origin.BlockNumber)
+	require.Equal(t, int64(1115), origin.BlockTime)
+
+	row, err := queries.GetDepositLatency(ctx, after)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), *row.L1OriginNumber)
+	require.Equal(t, int64(1100), *row.L1OriginTimestamp)
+	require.NotNil(t, row.L1SafeAt)
+	require.NotNil(t, row.L1FinalizedAt)
+	require.NotNil(t, row.L2SafeAt)
+	require.NotNil(t, row.L2FinalizedAt)
+
+	row, err = queries.GetDepositLatency(ctx, before)
+	require.NoError(t, err)
+	require.Equal(t, int64(85), *row.L1OriginNumber)
+	require.Nil(t, row.L1SafeAt)
+	require.NotNil(t, row.L1FinalizedAt)
+	require.Nil(t, row.L2SafeAt)
+	require.NotNil(t, row.L2FinalizedAt)
+
+	// The undecodable block is skipped once and does not hold back the others
+	row, err = queries.GetDepositLatency(ctx, undecodable)
+	require.NoError(t, err)
+	require.Nil(t, row.L1OriginNumber)
+	blocks, err := queries.ListL2BlocksWithoutOrigin(ctx, latency.OriginBatchSize)
+	require.NoError(t, err)
+	require.Empty(t, blocks)
+}
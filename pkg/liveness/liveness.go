@@ -0,0 +1,305 @@
+package liveness
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/batcher"
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/latency"
+	"github.com/Golem-Base/bridgette/pkg/monitor"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+)
+
+// Kinds of incidents
+const (
+	// Stall is an L2 head that did not advance, measured in seconds
+	Stall = "stall"
+	// HeadLag is a latest L2 block whose timestamp is behind the wall clock,
+	// for example while the sequencer catches up after a stall, measured in seconds
+	HeadLag = "head_lag"
+	// BlockTime is an L2 head whose timestamps advanced by more than the
+	// maximum block time per block, measured in seconds per block
+	BlockTime = "block_time"
+	// OriginLag is an L1 origin of L2 far behind the L1 head, which eats into
+	// the sequencing window, measured in L1 blocks
+	OriginLag = "origin_lag"
+	// BatcherGap is a batcher that did not post to the batch inbox in the
+	// scanned L1 blocks, measured in seconds since the last batch
+	BatcherGap = "batcher_gap"
+	// SafeLag is an L2 safe head far behind the unsafe head according to the
+	// rollup node, measured in seconds
+	SafeLag = "safe_lag"
+)
+
+// Kinds lists the incident kinds in the order they are checked
+var Kinds = []string{Stall, HeadLag, BlockTime, OriginLag, BatcherGap, SafeLag}
+
+// MaxSyncStatusAge is how long ago the sync status of the rollup node must
+// have been sampled to be checked
+const MaxSyncStatusAge = 5 * time.Minute
+
+// Config holds the thresholds above which an incident is opened
+type Config struct {
+	StallThreshold   time.Duration
+	HeadLagThreshold time.Duration
+	MaxBlockTime     time.Duration
+	// OriginLagThreshold is the number of L1 blocks the L1 origin of L2 may be behind the L1 head
+	OriginLagThreshold uint64
+	// BatcherGapThreshold and SafeLagThreshold check the data recorded by the
+	// batcher monitor, they are disabled when 0
+	BatcherGapThreshold time.Duration
+	SafeLagThreshold    time.Duration
+}
+
+// head is the latest L2 block seen by the monitor
+type head struct {
+	number     uint64
+	time       uint64
+	advancedAt time.Time
+}
+
+// measurement is the state of one incident kind in a check
+type measurement struct {
+	kind   string
+	active bool
+	value  int64
+	detail string
+	// since is when the problem started, the zero time when only the check noticed it
+	since time.Time
+}
+
+// Monitor periodically checks that L2 keeps producing blocks on time and
+// that its L1 origin keeps up with L1. Each kind of problem is recorded as an
+// incident that starts at the first check seeing it and ends at the first
+// check that does not.
+type Monitor struct {
+	db   *sql.DB
+	l1   latency.HeadClient
+	l2   latency.L2Client
+	cfg  Config
+	bus  *events.Bus
+	log  *slog.Logger
+	head *head
+}
+
+// New creates a liveness monitor. Incidents are published on bus, which may be nil.
+func New(db *sql.DB, l1 latency.HeadClient, l2 latency.L2Client, cfg Config, bus *events.Bus, log *slog.Logger) *Monitor {
+	return &Monitor{
+		db:  db,
+		l1:  l1,
+		l2:  l2,
+		cfg: cfg,
+		bus: bus,
+		log: log,
+	}
+}
+
+// Run checks the liveness every interval until ctx is cancelled. Incidents
+// are opened and closed at the check that sees them, so their start and end
+// are only known to within the interval.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) error {
+	return monitor.Every(ctx, interval, m.log, func(ctx context.Context) error {
+		return m.Check(ctx, time.Now())
+	})
+}
+
+// Check reads the L1 and L2 heads and the L1 origin of L2, then opens,
+// updates or closes the incident of every kind
+func (m *Monitor) Check(ctx context.Context, now time.Time) error {
+	return tracing.Run(ctx, "liveness check", func(ctx context.Context) error {
+		l2Head, err := m.l2.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get latest L2 block: %w", err)
+		}
+		l1Head, err := m.l1.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get latest L1 block: %w", err)
+		}
+		input, err := m.l2.TransactionInput(ctx, l2Head.Number, 0)
+		if err != nil {
+			return fmt.Errorf("failed to get L1 attributes transaction of L2 block %s: %w", l2Head.Number, err)
+		}
+		origin, _, err := latency.DecodeL1Info(input)
+		if err != nil {
+			return fmt.Errorf("failed to decode L1 attributes of L2 block %s: %w", l2Head.Number, err)
+		}
+
+		number := l2Head.Number.Uint64()
+		var measurements []measurement
+
+		previous := m.head
+		switch {
+		case previous == nil:
+			// The first head seen was produced around its timestamp, which
+			// keeps a stall ongoing across restarts of the monitor
+			advancedAt := time.Unix(int64(l2Head.Time), 0)
+			if advancedAt.After(now) {
+				advancedAt = now
+			}
+			m.head = &head{number: number, time: l2Head.Time, advancedAt: advancedAt}
+		case number > previous.number:
+			m.head = &head{number: number, time: l2Head.Time, advancedAt: now}
+		}
+		stalled := now.Sub(m.head.advancedAt)
+		measurements = append(measurements, measurement{
+			kind:   Stall,
+			active: stalled >= m.cfg.StallThreshold,
+			value:  int64(stalled.Seconds()),
+			detail: fmt.Sprintf("L2 head stuck at block %d for %s", m.head.number, stalled.Round(time.Second)),
+			since:  m.head.advancedAt,
+		})
+
+		lag := now.Sub(time.Unix(int64(l2Head.Time), 0))
+		measurements = append(measurements, measurement{
+			kind:   HeadLag,
+			active: lag >= m.cfg.HeadLagThreshold,
+			value:  int64(lag.Seconds()),
+			detail: fmt.Sprintf("L2 block %d is %s behind the wall clock", number, lag.Round(time.Second)),
+		})
+
+		// Block times are only known when the head advanced since the previous check
+		if previous != nil && number > previous.number && l2Head.Time >= previous.time {
+			blocks := number - previous.number
+			perBlock := time.Duration((l2Head.Time-previous.time)/blocks) * time.Second
+			measurements = append(measurements, measurement{
+				kind:   BlockTime,
+				active: perBlock > m.cfg.MaxBlockTime,
+				value:  int64(perBlock.Seconds()),
+				detail: fmt.Sprintf("L2 blocks %d to %d took %s per block", previous.number+1, number, perBlock),
+			})
+		}
+
+		var originLag uint64
+		if l1 := l1Head.Number.Uint64(); l1 > origin {
+			originLag = l1 - origin
+		}
+		measurements = append(measurements, measurement{
+			kind:   OriginLag,
+			active: originLag > m.cfg.OriginLagThreshold,
+			value:  int64(originLag),
+			detail: fmt.Sprintf("L1 origin %d of L2 block %d is %d blocks behind L1 block %d", origin, number, originLag, l1Head.Number.Uint64()),
+		})
+
+		queries := sqlitestore.NewTraced(m.db)
+		batcherMeasurements, err := m.measureBatcher(ctx, queries, now)
+		if err != nil {
+			return err
+		}
+		measurements = append(measurements, batcherMeasurements...)
+
+		for _, measurement := range measurements {
+			err := m.record(ctx, queries, measurement, number, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// measureBatcher measures the gap since the last batch up to the last L1
+// block scanned by the batcher monitor, and the lag of the L2 safe head in the
+// latest sync status. Kinds without data are not measured.
+func (m *Monitor) measureBatcher(ctx context.Context, queries *sqlitestore.Queries, now time.Time) ([]measurement, error) {
+	var measurements []measurement
+
+	if m.cfg.BatcherGapThreshold > 0 {
+		pointer, err := queries.GetBlockPointer(ctx, batcher.LastBlock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get batcher block pointer: %w", err)
+		}
+		latest, err := queries.GetLatestBatcherTransaction(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get latest batcher transaction: %w", err)
+		}
+		if err == nil && pointer.BlockTime != nil {
+			lastBatch := time.Unix(latest.BlockTime, 0)
+			gap := time.Unix(*pointer.BlockTime, 0).Sub(lastBatch)
+			measurements = append(measurements, measurement{
+				kind:   BatcherGap,
+				active: gap >= m.cfg.BatcherGapThreshold,
+				value:  int64(gap.Seconds()),
+				detail: fmt.Sprintf("No batch posted since L1 block %d, %s before L1 block %d", latest.BlockNumber, gap.Round(time.Second), *pointer.BlockNumber),
+				since:  lastBatch,
+			})
+		}
+	}
+
+	if m.cfg.SafeLagThreshold > 0 {
+		status, err := queries.GetLatestSyncStatusSample(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get latest sync status sample: %w", err)
+		}
+		if err == nil && now.Sub(time.Unix(status.ObservedAt, 0)) <= MaxSyncStatusAge {
+			lag := time.Duration(status.UnsafeL2Time-status.SafeL2Time) * time.Second
+			measurements = append(measurements, measurement{
+				kind:   SafeLag,
+				active: lag >= m.cfg.SafeLagThreshold,
+				value:  int64(lag.Seconds()),
+				detail: fmt.Sprintf("L2 safe head %d is %d blocks and %s behind unsafe head %d", status.SafeL2Number, status.UnsafeL2Number-status.SafeL2Number, lag, status.UnsafeL2Number),
+			})
+		}
+	}
+	return measurements, nil
+}
+
+// record opens an incident when a measurement becomes active, keeps the
+// worst value of an ongoing incident, and closes it once the measurement is
+// no longer active
+func (m *Monitor) record(ctx context.Context, queries *sqlitestore.Queries, measurement measurement, l2BlockNumber uint64, now time.Time) error {
+	open, err := queries.GetOpenLivenessIncident(ctx, measurement.kind)
+	ongoing := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get open %s incident: %w", measurement.kind, err)
+	}
+
+	switch {
+	case measurement.active && !ongoing:
+		startedAt := now
+		if !measurement.since.IsZero() {
+			startedAt = measurement.since
+		}
+		id, err := queries.InsertLivenessIncident(ctx, sqlitestore.InsertLivenessIncidentParams{
+			Kind:          measurement.kind,
+			StartedAt:     startedAt.Unix(),
+			L2BlockNumber: int64(l2BlockNumber),
+			Worst:         measurement.value,
+			Detail:        measurement.detail,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert %s incident: %w", measurement.kind, err)
+		}
+		m.log.Warn("liveness incident started", "id", id, "kind", measurement.kind, "detail", measurement.detail)
+		m.publish(l2BlockNumber)
+	case measurement.active:
+		err := queries.UpdateLivenessIncidentWorst(ctx, sqlitestore.UpdateLivenessIncidentWorstParams{
+			Worst:  measurement.value,
+			Detail: measurement.detail,
+			ID:     open.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update %s incident %d: %w", measurement.kind, open.ID, err)
+		}
+	case ongoing:
+		endedAt := now.Unix()
+		err := queries.CloseLivenessIncident(ctx, sqlitestore.CloseLivenessIncidentParams{EndedAt: &endedAt, ID: open.ID})
+		if err != nil {
+			return fmt.Errorf("failed to close %s incident %d: %w", measurement.kind, open.ID, err)
+		}
+		m.log.Info("liveness incident ended", "id", open.ID, "kind", measurement.kind, "duration", now.Sub(time.Unix(open.StartedAt, 0)).Round(time.Second))
+		m.publish(l2BlockNumber)
+	}
+	return nil
+}
+
+func (m *Monitor) publish(l2BlockNumber uint64) {
+	if m.bus != nil {
+		m.bus.Publish(events.Event{Type: events.Incident, Chain: "l2", BlockNumber: l2BlockNumber})
+	}
+}
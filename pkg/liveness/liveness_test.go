@@ -0,0 +1,100 @@
+package liveness_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/liveness"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain serves a latest block and the L1 origin set by its L1 attributes transaction
+type fakeChain struct {
+	number, time, origin uint64
+}
+
+func (f *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{Number: new(big.Int).SetUint64(f.number), Time: f.time}, nil
+}
+
+func (f *fakeChain) TransactionInput(ctx context.Context, blockNumber *big.Int, index uint) ([]byte, error) {
+	input := crypto.Keccak256([]byte("setL1BlockValuesEcotone()"))[:4]
+	input = append(input, make([]byte, 24)...)
+	input = binary.BigEndian.AppendUint64(input, f.origin)
+	return append(input, make([]byte, 32*4)...), nil
+}
+
+func TestMonitor(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	start := time.Unix(1700000000, 0)
+	l1 := &fakeChain{number: 1000}
+	l2 := &fakeChain{number: 500, time: uint64(start.Unix()), origin: 995}
+	monitor := liveness.New(db, l1, l2, liveness.Config{
+		StallThreshold:     30 * time.Second,
+		HeadLagThreshold:   60 * time.Second,
+		MaxBlockTime:       2 * time.Second,
+		OriginLagThreshold: 10,
+	}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	open := func(kind string) *sqlitestore.LivenessIncident {
+		incident, err := queries.GetOpenLivenessIncident(ctx, kind)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		require.NoError(t, err)
+		return &incident
+	}
+
+	require.NoError(t, monitor.Check(ctx, start))
+	for _, kind := range liveness.Kinds {
+		require.Nil(t, open(kind), kind)
+	}
+
+	// The head does not advance for 40 seconds while L1 moves on
+	l1.number = 1020
+	require.NoError(t, monitor.Check(ctx, start.Add(40*time.Second)))
+	stall := open(liveness.Stall)
+	require.NotNil(t, stall)
+	require.Equal(t, start.Unix(), stall.StartedAt)
+	require.Equal(t, int64(40), stall.Worst)
+	require.NotNil(t, open(liveness.OriginLag))
+	require.Nil(t, open(liveness.HeadLag))
+
+	require.NoError(t, monitor.Check(ctx, start.Add(70*time.Second)))
+	require.Equal(t, int64(70), open(liveness.Stall).Worst)
+	require.NotNil(t, open(liveness.HeadLag))
+
+	// The sequencer catches up with 10 second blocks and a current origin
+	l2.number, l2.time, l2.origin = 510, uint64(start.Add(100*time.Second).Unix()), 1019
+	require.NoError(t, monitor.Check(ctx, start.Add(100*time.Second)))
+	require.Nil(t, open(liveness.Stall))
+	require.Nil(t, open(liveness.HeadLag))
+	require.Nil(t, open(liveness.OriginLag))
+	require.NotNil(t, open(liveness.BlockTime))
+
+	closed, err := queries.GetLivenessIncident(ctx, stall.ID)
+	require.NoError(t, err)
+	require.Equal(t, start.Add(100*time.Second).Unix(), *closed.EndedAt)
+
+	incidents, err := queries.ListLivenessIncidents(ctx, sqlitestore.ListLivenessIncidentsParams{
+		Until: start.Add(time.Hour).Unix(),
+		Since: new(int64),
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, incidents, 4)
+}
@@ -0,0 +1,166 @@
+package logparser
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Decoder decodes the bridge events with one version of the bridge ABI.
+// Arguments are matched by name, the leading underscore of the legacy
+// contracts is ignored and their _data argument is read as extraData.
+type Decoder struct {
+	abi *abi.ABI
+}
+
+// NewDecoder creates a decoder for the events of contractAbi
+func NewDecoder(contractAbi *abi.ABI) *Decoder {
+	return &Decoder{abi: contractAbi}
+}
+
+// Topic returns the topic of an event of the ABI
+func (d *Decoder) Topic(event string) (common.Hash, error) {
+	ev, ok := d.abi.Events[event]
+	if !ok {
+		return common.Hash{}, fmt.Errorf("ABI has no %s event", event)
+	}
+	return ev.ID, nil
+}
+
+// ETHDepositInitiated decodes an L1StandardBridge ETHDepositInitiated log
+func (d *Decoder) ETHDepositInitiated(log *types.Log) (*L1StandardBridgeETHDepositInitiated, error) {
+	args, err := d.unpack("ETHDepositInitiated", log)
+	if err != nil {
+		return nil, err
+	}
+	event := new(L1StandardBridgeETHDepositInitiated)
+	event.From, err = args.address("from")
+	if err != nil {
+		return nil, err
+	}
+	event.To, err = args.address("to")
+	if err != nil {
+		return nil, err
+	}
+	event.Amount, err = args.bigInt("amount")
+	if err != nil {
+		return nil, err
+	}
+	event.ExtraData, err = args.bytes("extraData")
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// DepositFinalized decodes an L2StandardBridge DepositFinalized log
+func (d *Decoder) DepositFinalized(log *types.Log) (*L2StandardBridgeDepositFinalized, error) {
+	args, err := d.unpack("DepositFinalized", log)
+	if err != nil {
+		return nil, err
+	}
+	event := new(L2StandardBridgeDepositFinalized)
+	event.L1Token, err = args.address("l1Token")
+	if err != nil {
+		return nil, err
+	}
+	event.L2Token, err = args.address("l2Token")
+	if err != nil {
+		return nil, err
+	}
+	event.From, err = args.address("from")
+	if err != nil {
+		return nil, err
+	}
+	event.To, err = args.address("to")
+	if err != nil {
+		return nil, err
+	}
+	event.Amount, err = args.bigInt("amount")
+	if err != nil {
+		return nil, err
+	}
+	event.ExtraData, err = args.bytes("extraData")
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// eventArgs are the decoded arguments of a log by normalized name
+type eventArgs map[string]any
+
+// unpack decodes the indexed and data arguments of a log of the named event
+func (d *Decoder) unpack(name string, log *types.Log) (eventArgs, error) {
+	event, ok := d.abi.Events[name]
+	if !ok {
+		return nil, fmt.Errorf("ABI has no %s event", name)
+	}
+	if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+		return nil, fmt.Errorf("log is not a %s event", name)
+	}
+
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(log.Topics) != len(indexed)+1 {
+		return nil, fmt.Errorf("invalid number of topics: got %d, want %d", len(log.Topics), len(indexed)+1)
+	}
+
+	values := make(map[string]any)
+	err := event.Inputs.NonIndexed().UnpackIntoMap(values, log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack log data: %w", err)
+	}
+	err = abi.ParseTopicsIntoMap(values, indexed, log.Topics[1:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse log topics: %w", err)
+	}
+
+	args := make(eventArgs, len(values))
+	for name, value := range values {
+		name = strings.TrimPrefix(name, "_")
+		if name == "data" {
+			name = "extraData"
+		}
+		args[name] = value
+	}
+	return args, nil
+}
+
+func (a eventArgs) address(name string) (common.Address, error) {
+	value, ok := a[name].(common.Address)
+	if !ok {
+		return common.Address{}, fmt.Errorf("event has no address argument %s", name)
+	}
+	return value, nil
+}
+
+func (a eventArgs) bigInt(name string) (*big.Int, error) {
+	value, ok := a[name].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("event has no uint256 argument %s", name)
+	}
+	return value, nil
+}
+
+// bytes returns an empty slice when the event has no such argument, as
+// versions without extra data match deposits without it
+func (a eventArgs) bytes(name string) ([]byte, error) {
+	value, ok := a[name]
+	if !ok {
+		return []byte{}, nil
+	}
+	b, ok := value.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("event argument %s is not bytes", name)
+	}
+	return b, nil
+}
@@ -0,0 +1,25 @@
+package logparser
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+type DisputeGameFactoryDisputeGameCreated bindings.DisputeGameFactoryDisputeGameCreated
+
+func ParseDisputeGameFactoryDisputeGameCreatedEvent(log *types.Log) (*DisputeGameFactoryDisputeGameCreated, error) {
+	// All fields are indexed: the game proxy, the game type and the root claim
+	if len(log.Topics) != 4 {
+		return nil, fmt.Errorf("invalid number of topics: got %d, want 4", len(log.Topics))
+	}
+
+	return &DisputeGameFactoryDisputeGameCreated{
+		DisputeProxy: common.BytesToAddress(log.Topics[1].Bytes()),
+		GameType:     uint32(log.Topics[2].Big().Uint64()),
+		RootClaim:    log.Topics[3],
+		Raw:          *log,
+	}, nil
+}
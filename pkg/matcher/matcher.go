@@ -0,0 +1,296 @@
+package matcher
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+)
+
+// Match methods recorded with every match
+const (
+	// MethodUnique is a match between the only eligible L1 deposit and L2 finalization
+	MethodUnique = "unique"
+	// MethodFIFO is a match where several events were eligible and the
+	// earliest ones were paired first
+	MethodFIFO = "fifo"
+)
+
+// hashBatchSize is the number of matching hashes loaded at a time
+const hashBatchSize = 1000
+
+// Engine pairs stored L1 deposits with their L2 finalizations. It runs after
+// ingestion, separately from the indexer transactions, and only touches
+// unmatched events, so running it again over the same history is a no-op.
+//
+// Events are grouped by matching hash. Within a group, unmatched L1 deposits
+// are paired in time order with the earliest unmatched L2 finalization that
+// happened at the same time or later. A match records how many events either
+// side could have been paired with instead, and a confidence of 1 divided by
+// that number of candidates.
+type Engine struct {
+	db     *sql.DB
+	bus    *events.Bus
+	log    *slog.Logger
+	notify chan struct{}
+}
+
+// Result summarizes a matching run
+type Result struct {
+	// Matches is the number of new matches
+	Matches int `json:"matches"`
+	// Ambiguous is the number of new matches made among several candidates
+	Ambiguous int `json:"ambiguous"`
+}
+
+// New creates a matching engine. Match events are published on bus, which may be nil.
+func New(db *sql.DB, bus *events.Bus, log *slog.Logger) *Engine {
+	return &Engine{
+		db:     db,
+		bus:    bus,
+		log:    log,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// Notify asks a running engine to look for new matches, it never blocks
+func (e *Engine) Notify() {
+	select {
+	case e.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run matches pending events whenever Notify is called, and at least every
+// interval to pick up events written by other processes, until ctx is
+// cancelled. A failed run is logged and retried at the next notification or
+// tick, only the cancellation of ctx stops the loop.
+func (e *Engine) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-e.notify:
+		case <-ticker.C:
+		}
+
+		_, err := e.MatchPending(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			e.log.Error("matching failed", "error", err)
+		}
+	}
+}
+
+// MatchPending matches the unmatched events
+func (e *Engine) MatchPending(ctx context.Context) (Result, error) {
+	var result Result
+	err := tracing.Run(ctx, "match pending", func(ctx context.Context) error {
+		return e.inTx(ctx, func(txStore *sqlitestore.Queries) error {
+			var err error
+			result, err = e.match(ctx, txStore)
+			return err
+		})
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	e.publish(result)
+	return result, nil
+}
+
+// Rematch clears every match and matches the whole history again, in a single transaction
+func (e *Engine) Rematch(ctx context.Context) (Result, error) {
+	var result Result
+	err := tracing.Run(ctx, "rematch", func(ctx context.Context) error {
+		return e.inTx(ctx, func(txStore *sqlitestore.Queries) error {
+			err := txStore.ClearL1Matches(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to clear L1 matches: %w", err)
+			}
+			err = txStore.ClearL2Matches(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to clear L2 matches: %w", err)
+			}
+
+			result, err = e.match(ctx, txStore)
+			return err
+		})
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	e.publish(result)
+	return result, nil
+}
+
+func (e *Engine) inTx(ctx context.Context, fn func(txStore *sqlitestore.Queries) error) error {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after a successful commit is a no-op
+	defer tx.Rollback()
+
+	err = fn(sqlitestore.NewTraced(tx))
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (e *Engine) publish(result Result) {
+	if e.bus != nil && result.Matches > 0 {
+		e.bus.Publish(events.Event{Type: events.Match, Count: result.Matches})
+	}
+}
+
+// match matches the groups that have unmatched events on both sides
+func (e *Engine) match(ctx context.Context, txStore *sqlitestore.Queries) (Result, error) {
+	var result Result
+	params := sqlitestore.ListMatchableHashesParams{AfterHash: []byte{}, Limit: hashBatchSize}
+	for {
+		hashes, err := txStore.ListMatchableHashes(ctx, params)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to list matchable hashes: %w", err)
+		}
+
+		for _, hash := range hashes {
+			err := e.matchGroup(ctx, txStore, hash, &result)
+			if err != nil {
+				return Result{}, err
+			}
+		}
+
+		if len(hashes) < hashBatchSize {
+			return result, nil
+		}
+		params.AfterHash = hashes[len(hashes)-1]
+	}
+}
+
+// event is an unmatched event of a group
+type event struct {
+	id        int64
+	timestamp int64
+}
+
+// pair is a match chosen within a group
+type pair struct {
+	l1, l2     event
+	candidates int
+}
+
+// pairGroup pairs the unmatched L1 deposits and L2 finalizations of a group,
+// both sorted by time. Each L1 deposit in turn takes the earliest remaining
+// L2 finalization at the same time or later. The candidates of a pair are the
+// larger of the number of L2 finalizations of the group the deposit could have
+// taken and the number of L1 deposits that could have taken the finalization.
+// Used events are still counted, as a wrong earlier pick makes the later ones
+// wrong too.
+func pairGroup(l1s, l2s []event) []pair {
+	var pairs []pair
+	used := make([]bool, len(l2s))
+	for _, l1 := range l1s {
+		chosen, eligible := -1, 0
+		for j, l2 := range l2s {
+			if l2.timestamp < l1.timestamp {
+				continue
+			}
+			if chosen < 0 && !used[j] {
+				chosen = j
+			}
+			eligible++
+		}
+		if chosen < 0 {
+			continue
+		}
+		used[chosen] = true
+
+		competing := 0
+		for _, other := range l1s {
+			if other.timestamp <= l2s[chosen].timestamp {
+				competing++
+			}
+		}
+
+		pairs = append(pairs, pair{l1: l1, l2: l2s[chosen], candidates: max(eligible, competing)})
+	}
+	return pairs
+}
+
+func (e *Engine) matchGroup(ctx context.Context, txStore *sqlitestore.Queries, hash []byte, result *Result) error {
+	l1Rows, err := txStore.ListUnmatchedL1DepositsByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to list unmatched L1 deposits: %w", err)
+	}
+	l2Rows, err := txStore.ListUnmatchedL2FinalizationsByHash(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to list unmatched L2 finalizations: %w", err)
+	}
+
+	l1s := make([]event, 0, len(l1Rows))
+	for _, r := range l1Rows {
+		l1s = append(l1s, event{r.ID, r.BlockTimestamp})
+	}
+	l2s := make([]event, 0, len(l2Rows))
+	for _, r := range l2Rows {
+		l2s = append(l2s, event{r.ID, r.BlockTimestamp})
+	}
+
+	for _, p := range pairGroup(l1s, l2s) {
+		method := MethodUnique
+		if p.candidates > 1 {
+			method = MethodFIFO
+			result.Ambiguous++
+		}
+		candidates := int64(p.candidates)
+		confidence := 1 / float64(p.candidates)
+
+		err := txStore.RecordL1Match(ctx, sqlitestore.RecordL1MatchParams{
+			L2ID:       &p.l2.id,
+			Method:     &method,
+			Candidates: &candidates,
+			Confidence: &confidence,
+			ID:         p.l1.id,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update L1 deposit with match: %w", err)
+		}
+
+		err = txStore.UpdateL2DepositWithMatch(ctx, sqlitestore.UpdateL2DepositWithMatchParams{
+			MatchedL1StandardBridgeEthDepositInitiatedID: &p.l1.id,
+			ID: p.l2.id,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update L2 deposit with match: %w", err)
+		}
+
+		result.Matches++
+
+		e.log.Info("matched deposits",
+			"l1_deposit_id", p.l1.id,
+			"l2_deposit_id", p.l2.id,
+			"time_difference_seconds", p.l2.timestamp-p.l1.timestamp,
+			"method", method,
+			"candidates", p.candidates,
+			"confidence", confidence)
+	}
+
+	return nil
+}
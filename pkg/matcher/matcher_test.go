@@ -0,0 +1,206 @@
+package matcher_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/matcher"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	t       *testing.T
+	db      *sql.DB
+	queries *sqlitestore.Queries
+	tx      byte
+}
+
+func newFixture(t *testing.T) *fixture {
+	db := sqlitetest.Open(t)
+	return &fixture{t: t, db: db, queries: sqlitestore.New(db)}
+}
+
+func (f *fixture) l1(hash byte, timestamp int64) int64 {
+	f.tx++
+	id, err := f.queries.InsertL1StandardBridgeETHDepositInitiated(context.Background(), sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+		BlockTimestamp: timestamp,
+		TxHash:         []byte{f.tx},
+		FromAddress:    []byte{},
+		ToAddress:      []byte{},
+		AmountWei:      make([]byte, 32),
+		Event:          []byte("{}"),
+		MatchingHash:   []byte{hash},
+	})
+	require.NoError(f.t, err)
+	return id
+}
+
+func (f *fixture) l2(hash byte, timestamp int64) int64 {
+	f.tx++
+	id, err := f.queries.InsertL2StandardBridgeDepositFinalized(context.Background(), sqlitestore.InsertL2StandardBridgeDepositFinalizedParams{
+		BlockTimestamp: timestamp,
+		TxHash:         []byte{f.tx},
+		FromAddress:    []byte{},
+		ToAddress:      []byte{},
+		L1Token:        []byte{},
+		AmountWei:      make([]byte, 32),
+		Event:          []byte("{}"),
+		MatchingHash:   []byte{hash},
+	})
+	require.NoError(f.t, err)
+	return id
+}
+
+// storedMatch is the match recorded on an L1 deposit
+type storedMatch struct {
+	l2ID       *int64
+	method     *string
+	candidates *int64
+	confidence *float64
+}
+
+func (f *fixture) match(l1ID int64) storedMatch {
+	var m storedMatch
+	err := f.db.QueryRow(`SELECT matched_l2_standard_bridge_deposit_finalized_id, match_method, match_candidates, match_confidence
+		FROM l1_standard_bridge_eth_deposit_initiated WHERE id = ?`, l1ID).Scan(&m.l2ID, &m.method, &m.candidates, &m.confidence)
+	require.NoError(f.t, err)
+	return m
+}
+
+func TestMatchPending(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	engine := matcher.New(f.db, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	unique := f.l1(1, 100)
+	uniqueL2 := f.l2(1, 160)
+
+	// Two identical deposits finalized after both were sent
+	first := f.l1(2, 100)
+	second := f.l1(2, 110)
+	firstL2 := f.l2(2, 200)
+	secondL2 := f.l2(2, 210)
+
+	// Finalized before it was sent, so it cannot be its confirmation
+	early := f.l1(3, 500)
+	f.l2(3, 400)
+
+	result, err := engine.MatchPending(ctx)
+	require.NoError(t, err)
+	require.Equal(t, matcher.Result{Matches: 3, Ambiguous: 2}, result)
+
+	d := f.match(unique)
+	require.Equal(t, uniqueL2, *d.l2ID)
+	require.Equal(t, matcher.MethodUnique, *d.method)
+	require.Equal(t, int64(1), *d.candidates)
+	require.Equal(t, 1.0, *d.confidence)
+
+	d = f.match(first)
+	require.Equal(t, firstL2, *d.l2ID)
+	require.Equal(t, matcher.MethodFIFO, *d.method)
+	require.Equal(t, int64(2), *d.candidates)
+	require.Equal(t, 0.5, *d.confidence)
+
+	d = f.match(second)
+	require.Equal(t, secondL2, *d.l2ID)
+	require.Equal(t, matcher.MethodFIFO, *d.method)
+
+	require.Nil(t, f.match(early).l2ID)
+
+	// Running again over the same history changes nothing
+	result, err = engine.MatchPending(ctx)
+	require.NoError(t, err)
+	require.Zero(t, result.Matches)
+
+	// A late finalization is picked up by the next run
+	lateL2 := f.l2(3, 600)
+	result, err = engine.MatchPending(ctx)
+	require.NoError(t, err)
+	require.Equal(t, matcher.Result{Matches: 1}, result)
+	require.Equal(t, lateL2, *f.match(early).l2ID)
+
+	groups, err := f.queries.GetAmbiguousGroups(ctx, sqlitestore.GetAmbiguousGroupsParams{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+}
+
+// syncBuffer is a log output that can be read while the engine writes it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRunRetries(t *testing.T) {
+	f := newFixture(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var logs syncBuffer
+	engine := matcher.New(f.db, nil, slog.New(slog.NewTextHandler(&logs, nil)))
+	done := make(chan error, 1)
+	go func() { done <- engine.Run(ctx, time.Hour) }()
+
+	// A run failing because the L2 table is missing is logged
+	_, err := f.db.Exec(`ALTER TABLE l2_standard_bridge_deposit_finalized RENAME TO moved`)
+	require.NoError(t, err)
+	engine.Notify()
+	require.Eventually(t, func() bool { return strings.Contains(logs.String(), "matching failed") }, time.Second, time.Millisecond)
+
+	// and the engine keeps running to match once the table is back
+	_, err = f.db.Exec(`ALTER TABLE moved RENAME TO l2_standard_bridge_deposit_finalized`)
+	require.NoError(t, err)
+	l1 := f.l1(1, 100)
+	l2 := f.l2(1, 160)
+	engine.Notify()
+	require.Eventually(t, func() bool {
+		m := f.match(l1)
+		return m.l2ID != nil && *m.l2ID == l2
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestRematch(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	l1ID := f.l1(1, 100)
+	l2ID := f.l2(1, 160)
+
+	// A match made before the engine existed
+	require.NoError(t, f.queries.UpdateL1DepositWithMatch(ctx, sqlitestore.UpdateL1DepositWithMatchParams{
+		MatchedL2StandardBridgeDepositFinalizedID: &l2ID,
+		ID: l1ID,
+	}))
+	require.Nil(t, f.match(l1ID).method)
+
+	result, err := matcher.New(f.db, nil, slog.New(slog.NewTextHandler(io.Discard, nil))).Rematch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, matcher.Result{Matches: 1}, result)
+
+	d := f.match(l1ID)
+	require.Equal(t, l2ID, *d.l2ID)
+	require.Equal(t, matcher.MethodUnique, *d.method)
+}
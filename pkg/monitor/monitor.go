@@ -0,0 +1,30 @@
+package monitor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Every calls check every interval until ctx is cancelled. A failed check is
+// logged and retried at the next tick, only the cancellation of ctx stops the
+// loop.
+func Every(ctx context.Context, interval time.Duration, log *slog.Logger, check func(context.Context) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			err := check(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				log.Error("check failed", "error", err)
+			}
+		}
+	}
+}
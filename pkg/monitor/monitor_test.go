@@ -0,0 +1,31 @@
+package monitor_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/monitor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEveryKeepsCheckingAfterFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	calls := 0
+	err := monitor.Every(ctx, time.Millisecond, log, func(ctx context.Context) error {
+		calls++
+		if calls == 3 {
+			cancel()
+			return ctx.Err()
+		}
+		return errors.New("rpc unavailable")
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 3, calls)
+}
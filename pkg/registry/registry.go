@@ -0,0 +1,245 @@
+package registry
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Golem-Base/bridgette/pkg/logparser"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Chains of the registry
+const (
+	L1 = "l1"
+	L2 = "l2"
+)
+
+// ABIs built into the registry. Any other ABI is the path of an ABI JSON file.
+const (
+	// Bedrock is the current ABI of the op-e2e bindings
+	Bedrock = "bedrock"
+	// Legacy is the pre-Bedrock ABI, whose arguments are prefixed with an underscore
+	Legacy = "legacy"
+)
+
+// L2StandardBridge is the predeploy of the L2 bridge
+var L2StandardBridge = common.HexToAddress("0x4200000000000000000000000000000000000010")
+
+// events are the indexed events of the bridge contract of each chain
+var events = map[string]string{
+	L1: "ETHDepositInitiated",
+	L2: "DepositFinalized",
+}
+
+//go:embed abis/*.json
+var legacyABIs embed.FS
+
+// Version is a version of the bridge contract of a chain: the address it
+// emitted its events from, the ABI they are decoded with and the blocks it
+// was active in
+type Version struct {
+	Chain   string         `json:"chain"`
+	Address common.Address `json:"address"`
+	// ABI is bedrock (the default), legacy or the path of an ABI JSON file,
+	// relative to the registry file
+	ABI       string `json:"abi"`
+	FromBlock uint64 `json:"from_block"`
+	// ToBlock is the last block the version was active in, nil while it is active
+	ToBlock *uint64 `json:"to_block,omitempty"`
+
+	// Topic and Decoder are resolved from the ABI
+	Topic   common.Hash        `json:"-"`
+	Decoder *logparser.Decoder `json:"-"`
+}
+
+// active reports whether the version was active in a block
+func (v *Version) active(block uint64) bool {
+	return block >= v.FromBlock && (v.ToBlock == nil || block <= *v.ToBlock)
+}
+
+// History lists the versions of the bridge contract of a chain, oldest first
+type History []*Version
+
+// At returns the version active in a block, or nil when there was none
+func (h History) At(block uint64) *Version {
+	for _, v := range h {
+		if v.active(block) {
+			return v
+		}
+	}
+	return nil
+}
+
+// Segment is a block range covered by a single version
+type Segment struct {
+	FromBlock uint64
+	ToBlock   uint64
+	Version   *Version
+}
+
+// Query returns the filter query of the events of the segment
+func (s Segment) Query() ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: []common.Address{s.Version.Address},
+		Topics:    [][]common.Hash{{s.Version.Topic}},
+		FromBlock: new(big.Int).SetUint64(s.FromBlock),
+		ToBlock:   new(big.Int).SetUint64(s.ToBlock),
+	}
+}
+
+// Segments splits the block range [fromBlock, toBlock] at the version
+// boundaries. Blocks without an active version are left out.
+func (h History) Segments(fromBlock, toBlock uint64) []Segment {
+	var segments []Segment
+	for _, v := range h {
+		start := max(fromBlock, v.FromBlock)
+		end := toBlock
+		if v.ToBlock != nil {
+			end = min(end, *v.ToBlock)
+		}
+		if start <= end {
+			segments = append(segments, Segment{FromBlock: start, ToBlock: end, Version: v})
+		}
+	}
+	return segments
+}
+
+// Registry holds the history of the bridge contracts of both chains
+type Registry struct {
+	L1 History
+	L2 History
+}
+
+// History returns the history of the bridge contract of a chain
+func (r *Registry) History(chain string) History {
+	if chain == L2 {
+		return r.L2
+	}
+	return r.L1
+}
+
+// Default returns the registry of a bridge that never changed: l1Bridge and
+// the L2 predeploy with the Bedrock ABI for all of history
+func Default(l1Bridge common.Address) (*Registry, error) {
+	l1 := &Version{Chain: L1, Address: l1Bridge, ABI: Bedrock}
+	l2 := &Version{Chain: L2, Address: L2StandardBridge, ABI: Bedrock}
+	for _, v := range []*Version{l1, l2} {
+		err := v.resolve("")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Registry{L1: History{l1}, L2: History{l2}}, nil
+}
+
+// file is the JSON layout of a registry file
+type file struct {
+	Versions []*Version `json:"versions"`
+}
+
+// Load reads a registry file. A chain without versions in the file uses the
+// version of Default.
+func Load(path string, l1Bridge common.Address) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract registry: %w", err)
+	}
+	var f file
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse contract registry: %w", err)
+	}
+
+	r, err := Default(l1Bridge)
+	if err != nil {
+		return nil, err
+	}
+	histories := map[string]History{}
+	for i, v := range f.Versions {
+		if _, ok := events[v.Chain]; !ok {
+			return nil, fmt.Errorf("version %d: unknown chain %q, expected l1 or l2", i, v.Chain)
+		}
+		if v.Address == (common.Address{}) {
+			return nil, fmt.Errorf("version %d: address is required", i)
+		}
+		if v.ToBlock != nil && *v.ToBlock < v.FromBlock {
+			return nil, fmt.Errorf("version %d: to_block is before from_block", i)
+		}
+		err := v.resolve(filepath.Dir(path))
+		if err != nil {
+			return nil, fmt.Errorf("version %d: %w", i, err)
+		}
+		histories[v.Chain] = append(histories[v.Chain], v)
+	}
+
+	for chain, h := range histories {
+		sort.Slice(h, func(i, j int) bool { return h[i].FromBlock < h[j].FromBlock })
+		for i := 1; i < len(h); i++ {
+			if h[i-1].ToBlock == nil || *h[i-1].ToBlock >= h[i].FromBlock {
+				return nil, fmt.Errorf("%s versions at %s and %s overlap", chain, h[i-1].Address, h[i].Address)
+			}
+		}
+		if chain == L1 {
+			r.L1 = h
+		} else {
+			r.L2 = h
+		}
+	}
+	return r, nil
+}
+
+// resolve loads the ABI of the version and the topic of the indexed event.
+// ABI files are relative to dir.
+func (v *Version) resolve(dir string) error {
+	var contractAbi *abi.ABI
+	var err error
+	switch v.ABI {
+	case "", Bedrock:
+		v.ABI = Bedrock
+		if v.Chain == L1 {
+			contractAbi, err = bindings.L1StandardBridgeMetaData.GetAbi()
+		} else {
+			contractAbi, err = bindings.L2StandardBridgeMetaData.GetAbi()
+		}
+	case Legacy:
+		contractAbi, err = readABI(legacyABIs.ReadFile(fmt.Sprintf("abis/%s_standard_bridge_legacy.json", v.Chain)))
+	default:
+		path := v.ABI
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		contractAbi, err = readABI(os.ReadFile(path))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load ABI %s: %w", v.ABI, err)
+	}
+
+	v.Decoder = logparser.NewDecoder(contractAbi)
+	v.Topic, err = v.Decoder.Topic(events[v.Chain])
+	if err != nil {
+		return fmt.Errorf("invalid ABI %s: %w", v.ABI, err)
+	}
+	return nil
+}
+
+func readABI(data []byte, err error) (*abi.ABI, error) {
+	if err != nil {
+		return nil, err
+	}
+	contractAbi, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return &contractAbi, nil
+}
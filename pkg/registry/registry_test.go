@@ -0,0 +1,115 @@
+package registry_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/logparser"
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	legacyBridge = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	l1Bridge     = common.HexToAddress("0x54d6c1435ac7b90a5d46d01ee2f22ed6ff270ed3")
+)
+
+func writeRegistry(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeRegistry(t, `{"versions": [
+		{"chain": "l1", "address": "0x54d6c1435ac7b90a5d46d01ee2f22ed6ff270ed3", "from_block": 3000000},
+		{"chain": "l1", "address": "0x1000000000000000000000000000000000000001", "abi": "legacy", "from_block": 100, "to_block": 2999999}
+	]}`)
+	r, err := registry.Load(path, l1Bridge)
+	require.NoError(t, err)
+
+	// Versions are ordered and the L2 bridge keeps its default
+	require.Len(t, r.L1, 2)
+	require.Equal(t, legacyBridge, r.L1[0].Address)
+	require.Equal(t, registry.L2StandardBridge, r.History(registry.L2).At(0).Address)
+	require.Nil(t, r.L1.At(99))
+	require.Equal(t, legacyBridge, r.L1.At(2999999).Address)
+	require.Equal(t, l1Bridge, r.L1.At(5000000).Address)
+
+	segments := r.L1.Segments(0, 3000010)
+	require.Len(t, segments, 2)
+	require.Equal(t, uint64(100), segments[0].FromBlock)
+	require.Equal(t, uint64(2999999), segments[0].ToBlock)
+	require.Equal(t, uint64(3000000), segments[1].FromBlock)
+	require.Equal(t, []common.Address{l1Bridge}, segments[1].Query().Addresses)
+
+	// The legacy ABI names the arguments differently, both decode the same deposit
+	data, err := os.ReadFile("../logparser/fixtures/l1/000000000003831667-0017-0031.json")
+	require.NoError(t, err)
+	var lg types.Log
+	require.NoError(t, json.Unmarshal(data, &lg))
+	expected, err := logparser.ParseL1StandardBridgeETHDepositInitiatedEvent(&lg)
+	require.NoError(t, err)
+	for _, v := range r.L1 {
+		require.Equal(t, lg.Topics[0], v.Topic)
+		event, err := v.Decoder.ETHDepositInitiated(&lg)
+		require.NoError(t, err)
+		require.Equal(t, expected, event)
+	}
+}
+
+func TestLoadCustomABI(t *testing.T) {
+	dir := t.TempDir()
+	// A bridge whose deposit event has no extra data
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "old.json"), []byte(`[{"type": "event", "name": "ETHDepositInitiated", "inputs": [
+		{"indexed": true, "name": "from", "type": "address"},
+		{"indexed": true, "name": "to", "type": "address"},
+		{"indexed": false, "name": "amount", "type": "uint256"}
+	]}]`), 0o644))
+	path := filepath.Join(dir, "registry.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"versions": [
+		{"chain": "l1", "address": "0x1000000000000000000000000000000000000001", "abi": "old.json", "to_block": 10}
+	]}`), 0o644))
+
+	r, err := registry.Load(path, l1Bridge)
+	require.NoError(t, err)
+	v := r.L1.At(5)
+	require.NotEqual(t, v.Topic, common.HexToHash("0x35d79ab81f2b2017e19afb5c5571778877782d7a8786f5907f93b0f4702f4f23"))
+
+	from, to := common.Address{0xf}, common.Address{0x7}
+	event, err := v.Decoder.ETHDepositInitiated(&types.Log{
+		Topics: []common.Hash{v.Topic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:   common.BigToHash(common.Big3).Bytes(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, from, event.From)
+	require.Equal(t, to, event.To)
+	require.Equal(t, int64(3), event.Amount.Int64())
+	require.Empty(t, event.ExtraData)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"overlap": `{"versions": [
+			{"chain": "l1", "address": "0x1000000000000000000000000000000000000001", "to_block": 100},
+			{"chain": "l1", "address": "0x54d6c1435ac7b90a5d46d01ee2f22ed6ff270ed3", "from_block": 100}
+		]}`,
+		"open ended": `{"versions": [
+			{"chain": "l1", "address": "0x1000000000000000000000000000000000000001"},
+			{"chain": "l1", "address": "0x54d6c1435ac7b90a5d46d01ee2f22ed6ff270ed3", "from_block": 100}
+		]}`,
+		"chain":   `{"versions": [{"chain": "l3", "address": "0x1000000000000000000000000000000000000001"}]}`,
+		"address": `{"versions": [{"chain": "l1"}]}`,
+		"abi":     `{"versions": [{"chain": "l1", "address": "0x1000000000000000000000000000000000000001", "abi": "missing.json"}]}`,
+		"field":   `{"versions": [{"chain": "l1", "address": "0x1000000000000000000000000000000000000001", "from": 1}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := registry.Load(writeRegistry(t, content), l1Bridge)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package rpcproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Faults configures what the proxy injects. Rates are probabilities between
+// 0 and 1, rolled once per JSON-RPC call.
+type Faults struct {
+	// Latency delays every call, by up to LatencyJitter more
+	Latency       time.Duration
+	LatencyJitter time.Duration
+	// ErrorRate fails calls with a JSON-RPC error
+	ErrorRate float64
+	// RateLimitRate answers requests with HTTP 429 Too Many Requests
+	RateLimitRate float64
+	// MaxLogs rejects eth_getLogs calls returning more logs, the way
+	// providers limit their results (disabled when 0)
+	MaxLogs int
+	// TruncateRate drops the second half of the logs returned by eth_getLogs
+	TruncateRate float64
+	// StaleHeadRate answers eth_blockNumber with a head StaleHeadBlocks behind
+	StaleHeadRate   float64
+	StaleHeadBlocks uint64
+	// ReorgRate answers eth_getLogs from a short lived fork without the logs
+	// of the latest ReorgDepth blocks, as if the chain reorged back to the
+	// canonical blocks afterwards
+	ReorgRate  float64
+	ReorgDepth uint64
+	// Methods limits the faults to these methods, all methods when empty
+	Methods []string
+}
+
+// Proxy forwards JSON-RPC requests to an execution endpoint and injects
+// faults into the calls. The faults are drawn from a seeded random source,
+// so the same sequence of calls sees the same faults.
+type Proxy struct {
+	target string
+	faults Faults
+	client *http.Client
+	log    *slog.Logger
+
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// New creates a proxy in front of the endpoint at target
+func New(target string, faults Faults, seed int64, log *slog.Logger) *Proxy {
+	return &Proxy{
+		target: target,
+		faults: faults,
+		client: &http.Client{Timeout: time.Minute},
+		log:    log,
+		rng:    rand.New(rand.NewSource(seed)),
+	}
+}
+
+// message is a JSON-RPC request or response
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// errRateLimited makes the whole HTTP request fail with 429
+var errRateLimited = errors.New("rate limited")
+
+// ServeHTTP forwards a single or batch JSON-RPC request
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+
+	var requests []*message
+	if batch {
+		err = json.Unmarshal(body, &requests)
+	} else {
+		var req message
+		err = json.Unmarshal(body, &req)
+		requests = append(requests, &req)
+	}
+	if err != nil {
+		http.Error(w, "invalid JSON-RPC request", http.StatusBadRequest)
+		return
+	}
+
+	responses := make([]*message, 0, len(requests))
+	for _, req := range requests {
+		resp, err := p.call(r.Context(), req)
+		if err == errRateLimited {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		if err != nil {
+			p.log.Warn("failed to forward call", "method", req.Method, "error", err)
+			resp = errorResponse(req, -32603, err.Error())
+		}
+		responses = append(responses, resp)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if batch {
+		json.NewEncoder(w).Encode(responses)
+	} else {
+		json.NewEncoder(w).Encode(responses[0])
+	}
+}
+
+// call forwards one call, injecting the faults rolled for it
+func (p *Proxy) call(ctx context.Context, req *message) (*message, error) {
+	if !p.applies(req.Method) {
+		return p.forward(ctx, req)
+	}
+
+	f := p.faults
+	if delay := f.Latency + p.jitter(); delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	if p.roll(f.RateLimitRate) {
+		p.log.Info("injecting rate limit", "method", req.Method)
+		return nil, errRateLimited
+	}
+	if p.roll(f.ErrorRate) {
+		p.log.Info("injecting error", "method", req.Method)
+		return errorResponse(req, -32000, "injected error"), nil
+	}
+
+	resp, err := p.forward(ctx, req)
+	if err != nil || resp.Error != nil {
+		return resp, err
+	}
+
+	switch req.Method {
+	case "eth_blockNumber":
+		return p.staleHead(req, resp)
+	case "eth_getLogs":
+		return p.filterLogs(ctx, req, resp)
+	}
+	return resp, nil
+}
+
+// staleHead rolls whether the head is answered StaleHeadBlocks behind
+func (p *Proxy) staleHead(req, resp *message) (*message, error) {
+	if !p.roll(p.faults.StaleHeadRate) {
+		return resp, nil
+	}
+
+	var head hexutil.Uint64
+	err := json.Unmarshal(resp.Result, &head)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode block number: %w", err)
+	}
+	stale := hexutil.Uint64(uint64(head) - min(uint64(head), p.faults.StaleHeadBlocks))
+	p.log.Info("injecting stale head", "head", uint64(head), "stale_head", uint64(stale))
+	return result(req, stale)
+}
+
+// filterLogs applies the result limit and rolls the truncation and reorg
+// faults of an eth_getLogs response
+func (p *Proxy) filterLogs(ctx context.Context, req, resp *message) (*message, error) {
+	// Logs are decoded loosely, only the block number is needed
+	var logs []map[string]any
+	err := json.Unmarshal(resp.Result, &logs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode logs: %w", err)
+	}
+
+	f := p.faults
+	if f.MaxLogs > 0 && len(logs) > f.MaxLogs {
+		p.log.Info("rejecting logs over the limit", "count", len(logs), "max_logs", f.MaxLogs)
+		return errorResponse(req, -32005, fmt.Sprintf("query returned more than %d results", f.MaxLogs)), nil
+	}
+
+	if p.roll(f.TruncateRate) && len(logs) > 0 {
+		p.log.Info("injecting truncated logs", "count", len(logs), "kept", len(logs)/2)
+		return result(req, logs[:len(logs)/2])
+	}
+
+	if p.roll(f.ReorgRate) && len(logs) > 0 {
+		head, err := p.head(ctx)
+		if err != nil {
+			return nil, err
+		}
+		kept := slices.DeleteFunc(logs, func(lg map[string]any) bool {
+			number, err := hexutil.DecodeUint64(fmt.Sprint(lg["blockNumber"]))
+			return err == nil && number+f.ReorgDepth > head
+		})
+		p.log.Info("injecting reorged logs", "head", head, "depth", f.ReorgDepth, "kept", len(kept))
+		return result(req, kept)
+	}
+
+	return resp, nil
+}
+
+// head returns the block number of the target
+func (p *Proxy) head(ctx context.Context) (uint64, error) {
+	resp, err := p.forward(ctx, &message{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "eth_blockNumber"})
+	if err != nil {
+		return 0, err
+	}
+	if resp.Error != nil {
+		return 0, fmt.Errorf("failed to get block number: %s", resp.Error.Message)
+	}
+	var head hexutil.Uint64
+	err = json.Unmarshal(resp.Result, &head)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode block number: %w", err)
+	}
+	return uint64(head), nil
+}
+
+// forward sends a call to the target
+func (p *Proxy) forward(ctx context.Context, req *message) (*message, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call target: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		return nil, fmt.Errorf("target returned %s: %s", httpResp.Status, bytes.TrimSpace(msg))
+	}
+
+	var resp message
+	err = json.NewDecoder(httpResp.Body).Decode(&resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &resp, nil
+}
+
+// applies reports whether the faults apply to a method
+func (p *Proxy) applies(method string) bool {
+	return len(p.faults.Methods) == 0 || slices.Contains(p.faults.Methods, method)
+}
+
+// roll returns true with probability rate
+func (p *Proxy) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rng.Float64() < rate
+}
+
+// jitter returns a random delay up to LatencyJitter
+func (p *Proxy) jitter() time.Duration {
+	if p.faults.LatencyJitter <= 0 {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return time.Duration(p.rng.Int63n(int64(p.faults.LatencyJitter)))
+}
+
+// result returns a response to req with the given result
+func result(req *message, v any) (*message, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	return &message{JSONRPC: "2.0", ID: req.ID, Result: raw}, nil
+}
+
+// errorResponse returns an error response to req
+func errorResponse(req *message, code int, msg string) *message {
+	return &message{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: code, Message: msg}}
+}
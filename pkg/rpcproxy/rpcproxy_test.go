@@ -0,0 +1,105 @@
+package rpcproxy_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/fakechain"
+	"github.com/Golem-Base/bridgette/pkg/rpcproxy"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+)
+
+var contract = common.Address{0xc0}
+
+// proxied returns a client calling a chain with 10 blocks, each with one log
+// from the second block on, through a proxy injecting faults
+func proxied(t *testing.T, faults rpcproxy.Faults) *ethclient.Client {
+	chain := fakechain.New(1, 1000, 2)
+	for range 10 {
+		chain.Mine(types.Log{Address: contract, Topics: []common.Hash{{0x01}}})
+	}
+	upstream := httptest.NewServer(chain)
+	t.Cleanup(upstream.Close)
+
+	proxy := httptest.NewServer(rpcproxy.New(upstream.URL, faults, 1, slog.New(slog.NewTextHandler(io.Discard, nil))))
+	t.Cleanup(proxy.Close)
+
+	client, err := ethclient.Dial(proxy.URL)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func query(fromBlock, toBlock int64) ethereum.FilterQuery {
+	return ethereum.FilterQuery{FromBlock: big.NewInt(fromBlock), ToBlock: big.NewInt(toBlock), Addresses: []common.Address{contract}}
+}
+
+func TestProxy(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passes calls through", func(t *testing.T) {
+		client := proxied(t, rpcproxy.Faults{})
+		head, err := client.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), head)
+
+		logs, err := client.FilterLogs(ctx, query(0, 10))
+		require.NoError(t, err)
+		require.Len(t, logs, 10)
+	})
+
+	t.Run("errors and rate limits", func(t *testing.T) {
+		client := proxied(t, rpcproxy.Faults{ErrorRate: 1})
+		_, err := client.BlockNumber(ctx)
+		require.ErrorContains(t, err, "injected error")
+
+		client = proxied(t, rpcproxy.Faults{RateLimitRate: 1})
+		_, err = client.BlockNumber(ctx)
+		require.ErrorContains(t, err, "429")
+	})
+
+	t.Run("faults are limited to methods", func(t *testing.T) {
+		client := proxied(t, rpcproxy.Faults{ErrorRate: 1, Methods: []string{"eth_getLogs"}})
+		_, err := client.BlockNumber(ctx)
+		require.NoError(t, err)
+		_, err = client.FilterLogs(ctx, query(0, 10))
+		require.ErrorContains(t, err, "injected error")
+	})
+
+	t.Run("log limit and truncation", func(t *testing.T) {
+		client := proxied(t, rpcproxy.Faults{MaxLogs: 4})
+		_, err := client.FilterLogs(ctx, query(1, 5))
+		require.ErrorContains(t, err, "query returned more than 4 results")
+		logs, err := client.FilterLogs(ctx, query(1, 4))
+		require.NoError(t, err)
+		require.Len(t, logs, 4)
+
+		client = proxied(t, rpcproxy.Faults{TruncateRate: 1})
+		logs, err = client.FilterLogs(ctx, query(1, 10))
+		require.NoError(t, err)
+		require.Len(t, logs, 5)
+		require.Equal(t, uint64(5), logs[4].BlockNumber)
+	})
+
+	t.Run("stale heads and reorgs", func(t *testing.T) {
+		client := proxied(t, rpcproxy.Faults{StaleHeadRate: 1, StaleHeadBlocks: 3})
+		head, err := client.BlockNumber(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(7), head)
+
+		// The logs of blocks 8 to 10 are reorged away
+		client = proxied(t, rpcproxy.Faults{ReorgRate: 1, ReorgDepth: 3})
+		logs, err := client.FilterLogs(ctx, query(5, 10))
+		require.NoError(t, err)
+		require.Len(t, logs, 3)
+		require.Equal(t, uint64(7), logs[2].BlockNumber)
+	})
+}
@@ -0,0 +1,178 @@
+package security
+
+import (
+	"fmt"
+
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Topics of the events recorded by the monitor
+var (
+	UpgradedEvent             = crypto.Keccak256Hash([]byte("Upgraded(address)"))
+	AdminChangedEvent         = crypto.Keccak256Hash([]byte("AdminChanged(address,address)"))
+	OwnershipTransferredEvent = crypto.Keccak256Hash([]byte("OwnershipTransferred(address,address)"))
+	// PausedEvent and UnpausedEvent are emitted by the SuperchainConfig
+	PausedEvent   = crypto.Keccak256Hash([]byte("Paused(string)"))
+	UnpausedEvent = crypto.Keccak256Hash([]byte("Unpaused()"))
+	// PausedAddressEvent and UnpausedAddressEvent are emitted by the
+	// SuperchainConfig since pauses are scoped to an identifier address
+	PausedAddressEvent   = crypto.Keccak256Hash([]byte("Paused(address)"))
+	UnpausedAddressEvent = crypto.Keccak256Hash([]byte("Unpaused(address)"))
+	// SystemConfigUpdateEvent is emitted by the SystemConfig
+	SystemConfigUpdateEvent = crypto.Keccak256Hash([]byte("ConfigUpdate(uint256,uint8,bytes)"))
+	// SuperchainConfigUpdateEvent is emitted by the SuperchainConfig
+	SuperchainConfigUpdateEvent = crypto.Keccak256Hash([]byte("ConfigUpdate(uint8,bytes)"))
+)
+
+// Topics lists all the topics recorded by the monitor
+var Topics = []common.Hash{
+	UpgradedEvent,
+	AdminChangedEvent,
+	OwnershipTransferredEvent,
+	PausedEvent,
+	UnpausedEvent,
+	PausedAddressEvent,
+	UnpausedAddressEvent,
+	SystemConfigUpdateEvent,
+	SuperchainConfigUpdateEvent,
+}
+
+// systemConfigUpdateTypes names the SystemConfig UpdateType values
+var systemConfigUpdateTypes = map[uint64]string{
+	0: "BATCHER",
+	1: "FEE_SCALARS",
+	2: "GAS_LIMIT",
+	3: "UNSAFE_BLOCK_SIGNER",
+	4: "EIP_1559_PARAMS",
+	5: "OPERATOR_FEE_PARAMS",
+}
+
+// superchainConfigUpdateTypes names the SuperchainConfig UpdateType values
+var superchainConfigUpdateTypes = map[uint64]string{
+	0: "GUARDIAN",
+}
+
+// ParseLog turns a log with one of the Topics into a finding of the named
+// contract. ObservedAt is left to the caller.
+func ParseLog(lg *types.Log, contractName string) (sqlitestore.InsertSecurityFindingParams, error) {
+	if len(lg.Topics) == 0 {
+		return sqlitestore.InsertSecurityFindingParams{}, fmt.Errorf("failed to parse security log: no topics")
+	}
+	logIndex := int64(lg.Index)
+	finding := sqlitestore.InsertSecurityFindingParams{
+		BlockNumber:  int64(lg.BlockNumber),
+		TxHash:       lg.TxHash.Bytes(),
+		LogIndex:     &logIndex,
+		Contract:     lg.Address.Bytes(),
+		ContractName: contractName,
+	}
+
+	var err error
+	switch lg.Topics[0] {
+	case UpgradedEvent:
+		err = expect(lg, 2, 0)
+		if err == nil {
+			implementation := common.BytesToAddress(lg.Topics[1].Bytes())
+			finding.Kind, finding.Severity = Upgraded, Critical
+			finding.Value = implementation.Bytes()
+			finding.Detail = fmt.Sprintf("Proxy upgraded to implementation %s", implementation)
+		}
+	case AdminChangedEvent:
+		err = expect(lg, 1, 64)
+		if err == nil {
+			previous, admin := common.BytesToAddress(lg.Data[:32]), common.BytesToAddress(lg.Data[32:64])
+			finding.Kind, finding.Severity = AdminChanged, Critical
+			finding.Value = admin.Bytes()
+			finding.Detail = fmt.Sprintf("Proxy admin changed from %s to %s", previous, admin)
+		}
+	case OwnershipTransferredEvent:
+		err = expect(lg, 3, 0)
+		if err == nil {
+			previous, owner := common.BytesToAddress(lg.Topics[1].Bytes()), common.BytesToAddress(lg.Topics[2].Bytes())
+			finding.Kind, finding.Severity = OwnershipTransferred, Critical
+			finding.Value = owner.Bytes()
+			finding.Detail = fmt.Sprintf("Ownership transferred from %s to %s", previous, owner)
+		}
+	case PausedEvent:
+		var identifier string
+		identifier, err = unpackString(lg.Data)
+		finding.Kind, finding.Severity = Paused, Critical
+		finding.Detail = "Paused"
+		if identifier != "" {
+			finding.Detail = fmt.Sprintf("Paused by %q", identifier)
+		}
+	case PausedAddressEvent:
+		err = expect(lg, 1, 32)
+		if err == nil {
+			finding.Kind, finding.Severity = Paused, Critical
+			finding.Detail = fmt.Sprintf("Paused for %s", common.BytesToAddress(lg.Data[:32]))
+		}
+	case UnpausedEvent:
+		finding.Kind, finding.Severity = Unpaused, Warning
+		finding.Detail = "Unpaused"
+	case UnpausedAddressEvent:
+		err = expect(lg, 1, 32)
+		if err == nil {
+			finding.Kind, finding.Severity = Unpaused, Warning
+			finding.Detail = fmt.Sprintf("Unpaused for %s", common.BytesToAddress(lg.Data[:32]))
+		}
+	case SystemConfigUpdateEvent:
+		err = expect(lg, 3, 0)
+		if err == nil {
+			updateType := lg.Topics[2].Big().Uint64()
+			finding.Kind, finding.Severity = ConfigUpdate, Info
+			if updateType == 0 || updateType == 3 {
+				finding.Severity = Warning
+			}
+			finding.Detail = fmt.Sprintf("SystemConfig update of %s", updateTypeName(systemConfigUpdateTypes, updateType))
+		}
+	case SuperchainConfigUpdateEvent:
+		err = expect(lg, 2, 0)
+		if err == nil {
+			updateType := lg.Topics[1].Big().Uint64()
+			finding.Kind, finding.Severity = ConfigUpdate, Warning
+			finding.Detail = fmt.Sprintf("SuperchainConfig update of %s", updateTypeName(superchainConfigUpdateTypes, updateType))
+		}
+	default:
+		err = fmt.Errorf("unknown topic %s", lg.Topics[0])
+	}
+	if err != nil {
+		return sqlitestore.InsertSecurityFindingParams{}, fmt.Errorf("failed to parse security log %s/%d: %w", lg.TxHash, lg.Index, err)
+	}
+	return finding, nil
+}
+
+// expect checks the number of topics and the minimum data length of a log
+func expect(lg *types.Log, topics, data int) error {
+	if len(lg.Topics) != topics {
+		return fmt.Errorf("expected %d topics, got %d", topics, len(lg.Topics))
+	}
+	if len(lg.Data) < data {
+		return fmt.Errorf("expected %d bytes of data, got %d", data, len(lg.Data))
+	}
+	return nil
+}
+
+// unpackString decodes the ABI encoded string of a Paused event
+func unpackString(data []byte) (string, error) {
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		return "", err
+	}
+	values, err := abi.Arguments{{Type: stringType}}.Unpack(data)
+	if err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
+
+func updateTypeName(names map[uint64]string, updateType uint64) string {
+	if name, ok := names[updateType]; ok {
+		return name
+	}
+	return fmt.Sprintf("type %d", updateType)
+}
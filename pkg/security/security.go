@@ -0,0 +1,391 @@
+package security
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/monitor"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ImplementationSlot is the EIP-1967 storage slot of the implementation of a proxy
+var ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// AdminSlot is the EIP-1967 storage slot of the admin of a proxy
+var AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+
+// LastBlock is the block pointer of the scanned security events
+const LastBlock = "security_events_last_processed_block"
+
+// Kinds of security findings. Upgraded, AdminChanged, OwnershipTransferred
+// and ConfigUpdate come from events, Implementation and Admin from the
+// EIP-1967 slots, Paused and Unpaused from both.
+const (
+	Upgraded             = "upgraded"
+	AdminChanged         = "admin_changed"
+	OwnershipTransferred = "ownership_transferred"
+	Paused               = "paused"
+	Unpaused             = "unpaused"
+	ConfigUpdate         = "config_update"
+	Implementation       = "implementation"
+	Admin                = "admin"
+)
+
+// Severities of security findings
+const (
+	Critical = "critical"
+	Warning  = "warning"
+	Info     = "info"
+)
+
+// Contract is a bridge contract on L1 watched by the monitor
+type Contract struct {
+	Name    string
+	Address common.Address
+}
+
+// Client is the part of the L1 execution client used by the monitor
+type Client interface {
+	bind.ContractCaller
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// Config lists the watched contracts and bounds the blocks scanned
+type Config struct {
+	Contracts []Contract
+	// Pausable is the contract whose paused() is read every check, e.g. the
+	// OptimismPortal. With the zero address the pause state follows the events.
+	Pausable common.Address
+	// BatchSize is the number of L1 blocks scanned for events per request
+	BatchSize uint64
+	// StartBlocks is how many blocks before the L1 head the first check starts at
+	StartBlocks uint64
+}
+
+// Monitor keeps an audit trail of the security relevant changes to the bridge
+// contracts on L1. It records proxy upgrades, admin and ownership transfers,
+// pauses and configuration updates from their events, and reads the EIP-1967
+// slots and the pause state every check, so that changes made without an
+// event, or before the first scanned block, are recorded too.
+type Monitor struct {
+	db     *sql.DB
+	client Client
+	cfg    Config
+	bus    *events.Bus
+	log    *slog.Logger
+}
+
+// New creates a security monitor. Findings are published on bus, which may be nil.
+func New(db *sql.DB, client Client, cfg Config, bus *events.Bus, log *slog.Logger) *Monitor {
+	return &Monitor{
+		db:     db,
+		client: client,
+		cfg:    cfg,
+		bus:    bus,
+		log:    log,
+	}
+}
+
+// Run checks the bridge contracts every interval until ctx is cancelled. The
+// slots and the pause state are compared between checks, so a change undone
+// within one interval is only recorded through its events.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) error {
+	return monitor.Every(ctx, interval, m.log, m.Check)
+}
+
+// Check records the security events emitted since the previous check, then
+// the changes of the EIP-1967 slots and of the pause state at the L1 head
+func (m *Monitor) Check(ctx context.Context) error {
+	return tracing.Run(ctx, "security check", func(ctx context.Context) error {
+		queries := sqlitestore.NewTraced(m.db)
+
+		head, err := m.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get latest L1 block: %w", err)
+		}
+
+		found, err := m.scan(ctx, queries, head.Number.Uint64())
+		if err != nil {
+			return err
+		}
+		changed, err := m.checkSlots(ctx, queries, head)
+		if err != nil {
+			return err
+		}
+		found += changed
+		changed, err = m.checkPaused(ctx, queries, head)
+		if err != nil {
+			return err
+		}
+		found += changed
+
+		if found > 0 && m.bus != nil {
+			m.bus.Publish(events.Event{Type: events.Security, Chain: "l1", BlockNumber: head.Number.Uint64(), Count: found})
+		}
+		return nil
+	})
+}
+
+// scan stores the findings from the events emitted after the block pointer up
+// to headNumber and returns how many were found
+func (m *Monitor) scan(ctx context.Context, queries *sqlitestore.Queries, headNumber uint64) (int, error) {
+	pointer, err := queries.GetBlockPointer(ctx, LastBlock)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get security events block pointer: %w", err)
+	}
+
+	var fromBlock uint64
+	switch {
+	case pointer.BlockNumber != nil:
+		fromBlock = uint64(*pointer.BlockNumber) + 1
+	case headNumber > m.cfg.StartBlocks:
+		fromBlock = headNumber - m.cfg.StartBlocks
+	}
+
+	names := make(map[common.Address]string, len(m.cfg.Contracts))
+	addresses := make([]common.Address, 0, len(m.cfg.Contracts))
+	for _, contract := range m.cfg.Contracts {
+		names[contract.Address] = contract.Name
+		addresses = append(addresses, contract.Address)
+	}
+
+	found := 0
+	for start := fromBlock; start <= headNumber; start += m.cfg.BatchSize {
+		end := min(start+m.cfg.BatchSize-1, headNumber)
+
+		logs, err := m.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+			Addresses: addresses,
+			Topics:    [][]common.Hash{Topics},
+		})
+		if err != nil {
+			return found, fmt.Errorf("failed to filter security logs: %w", err)
+		}
+
+		blockTimes := make(map[uint64]uint64)
+		findings := make([]sqlitestore.InsertSecurityFindingParams, 0, len(logs))
+		for _, lg := range logs {
+			finding, err := ParseLog(&lg, names[lg.Address])
+			if err != nil {
+				return found, err
+			}
+
+			blockTime, ok := blockTimes[lg.BlockNumber]
+			if !ok {
+				header, err := m.client.HeaderByNumber(ctx, new(big.Int).SetUint64(lg.BlockNumber))
+				if err != nil {
+					return found, fmt.Errorf("failed to get L1 block %d: %w", lg.BlockNumber, err)
+				}
+				blockTime = header.Time
+				blockTimes[lg.BlockNumber] = blockTime
+			}
+			finding.ObservedAt = int64(blockTime)
+			findings = append(findings, finding)
+		}
+
+		err = m.store(ctx, findings, &end)
+		if err != nil {
+			return found, err
+		}
+		found += len(findings)
+	}
+	return found, nil
+}
+
+// checkSlots reads the EIP-1967 implementation and admin slots of the watched
+// contracts and records the slots that changed since they were last recorded,
+// by an event or a previous read. The first read of a slot is recorded as a
+// baseline. Contracts that are not EIP-1967 proxies have empty slots and are
+// skipped.
+func (m *Monitor) checkSlots(ctx context.Context, queries *sqlitestore.Queries, head *types.Header) (int, error) {
+	slots := []struct {
+		slot      common.Hash
+		kind      string
+		eventKind string
+		name      string
+	}{
+		{ImplementationSlot, Implementation, Upgraded, "Implementation"},
+		{AdminSlot, Admin, AdminChanged, "Proxy admin"},
+	}
+
+	var findings []sqlitestore.InsertSecurityFindingParams
+	for _, contract := range m.cfg.Contracts {
+		for _, slot := range slots {
+			value, err := m.client.StorageAt(ctx, contract.Address, slot.slot, head.Number)
+			if err != nil {
+				return 0, fmt.Errorf("failed to read %s slot of %s: %w", slot.kind, contract.Name, err)
+			}
+			current := common.BytesToAddress(value)
+			if current == (common.Address{}) {
+				continue
+			}
+
+			previous, err := m.latestValue(ctx, queries, contract.Address, slot.kind, slot.eventKind)
+			if err != nil {
+				return 0, err
+			}
+			if previous != nil && *previous == current {
+				continue
+			}
+
+			finding := sqlitestore.InsertSecurityFindingParams{
+				ObservedAt:   int64(head.Time),
+				BlockNumber:  head.Number.Int64(),
+				Contract:     contract.Address.Bytes(),
+				ContractName: contract.Name,
+				Kind:         slot.kind,
+				Severity:     Info,
+				Value:        current.Bytes(),
+				Detail:       fmt.Sprintf("%s is %s", slot.name, current),
+			}
+			if previous != nil {
+				finding.Severity = Critical
+				finding.Detail = fmt.Sprintf("%s changed from %s to %s", slot.name, *previous, current)
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	if len(findings) == 0 {
+		return 0, nil
+	}
+	return len(findings), m.store(ctx, findings, nil)
+}
+
+// latestValue returns the value of the newest finding of either kind for a
+// contract, or nil when there is none
+func (m *Monitor) latestValue(ctx context.Context, queries *sqlitestore.Queries, contract common.Address, kinds ...string) (*common.Address, error) {
+	var latest *sqlitestore.SecurityFinding
+	for _, kind := range kinds {
+		finding, err := queries.GetLatestSecurityFinding(ctx, sqlitestore.GetLatestSecurityFindingParams{
+			Contract: contract.Bytes(),
+			Kind:     kind,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest %s finding: %w", kind, err)
+		}
+		if latest == nil || finding.ObservedAt > latest.ObservedAt || (finding.ObservedAt == latest.ObservedAt && finding.ID > latest.ID) {
+			latest = &finding
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	value := common.BytesToAddress(latest.Value)
+	return &value, nil
+}
+
+// checkPaused reads paused() of the pausable contract and records a pause or
+// unpause when it differs from the latest recorded pause state. The read is
+// authoritative, so it also ends a pause whose Unpaused event was missed.
+func (m *Monitor) checkPaused(ctx context.Context, queries *sqlitestore.Queries, head *types.Header) (int, error) {
+	if m.cfg.Pausable == (common.Address{}) {
+		return 0, nil
+	}
+
+	// The OptimismPortal and the SuperchainConfig share the paused() getter
+	caller, err := bindings.NewSuperchainConfigCaller(m.cfg.Pausable, m.client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bind pausable contract: %w", err)
+	}
+	paused, err := caller.Paused(&bind.CallOpts{Context: ctx, BlockNumber: head.Number})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pause state: %w", err)
+	}
+
+	latest, err := queries.GetLatestPauseFinding(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to get latest pause finding: %w", err)
+	}
+	wasPaused := err == nil && latest.Kind == Paused
+	if paused == wasPaused {
+		return 0, nil
+	}
+
+	name := m.cfg.Pausable.Hex()
+	for _, contract := range m.cfg.Contracts {
+		if contract.Address == m.cfg.Pausable {
+			name = contract.Name
+		}
+	}
+	finding := sqlitestore.InsertSecurityFindingParams{
+		ObservedAt:   int64(head.Time),
+		BlockNumber:  head.Number.Int64(),
+		Contract:     m.cfg.Pausable.Bytes(),
+		ContractName: name,
+		Kind:         Unpaused,
+		Severity:     Warning,
+		Detail:       "paused() returned false",
+	}
+	if paused {
+		finding.Kind = Paused
+		finding.Severity = Critical
+		finding.Detail = "paused() returned true"
+	}
+	return 1, m.store(ctx, []sqlitestore.InsertSecurityFindingParams{finding}, nil)
+}
+
+// store inserts findings and advances the pointer to toBlock, when it is not
+// nil, in one transaction
+func (m *Monitor) store(ctx context.Context, findings []sqlitestore.InsertSecurityFindingParams, toBlock *uint64) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after a successful commit is a no-op
+	defer tx.Rollback()
+	txStore := sqlitestore.NewTraced(tx)
+
+	for _, finding := range findings {
+		err := txStore.InsertSecurityFinding(ctx, finding)
+		if err != nil {
+			return fmt.Errorf("failed to insert security finding: %w", err)
+		}
+	}
+
+	if toBlock != nil {
+		blockNumber := int64(*toBlock)
+		err = txStore.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+			BlockNumber: &blockNumber,
+			Name:        LastBlock,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update security events block pointer: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, finding := range findings {
+		log := m.log.Info
+		switch finding.Severity {
+		case Critical:
+			log = m.log.Error
+		case Warning:
+			log = m.log.Warn
+		}
+		log("security finding", "contract", finding.ContractName, "kind", finding.Kind, "detail", finding.Detail)
+	}
+	return nil
+}
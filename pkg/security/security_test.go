@@ -0,0 +1,171 @@
+package security_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/security"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	bridge = common.Address{0xb1}
+	portal = common.Address{0xb2}
+)
+
+// fakeL1 serves security logs, the EIP-1967 slots and the pause state
+type fakeL1 struct {
+	t      *testing.T
+	head   uint64
+	logs   []types.Log
+	slots  map[common.Address]map[common.Hash]common.Address
+	paused bool
+}
+
+func (f *fakeL1) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeL1) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	require.Equal(f.t, portal, *call.To)
+	contractAbi, err := bindings.SuperchainConfigMetaData.GetAbi()
+	require.NoError(f.t, err)
+	return contractAbi.Methods["paused"].Outputs.Pack(f.paused)
+}
+
+func (f *fakeL1) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	require.Equal(f.t, []common.Address{bridge, portal}, q.Addresses)
+	var logs []types.Log
+	for _, lg := range f.logs {
+		if lg.BlockNumber >= q.FromBlock.Uint64() && lg.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, lg)
+		}
+	}
+	return logs, nil
+}
+
+func (f *fakeL1) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		number = new(big.Int).SetUint64(f.head)
+	}
+	return &types.Header{Number: number, Time: 1600000000 + number.Uint64()*12}, nil
+}
+
+func (f *fakeL1) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return common.BytesToHash(f.slots[account][key].Bytes()).Bytes(), nil
+}
+
+// emit adds a log of a contract in a block
+func (f *fakeL1) emit(contract common.Address, blockNumber uint64, data []byte, topics ...common.Hash) {
+	f.logs = append(f.logs, types.Log{
+		Address:     contract,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: blockNumber,
+		TxHash:      common.Hash{byte(blockNumber)},
+	})
+}
+
+func TestMonitor(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	implementation, upgraded, owner := common.Address{0x11}, common.Address{0x12}, common.Address{0x13}
+	l1 := &fakeL1{t: t, head: 100, slots: map[common.Address]map[common.Hash]common.Address{
+		bridge: {security.ImplementationSlot: implementation},
+		portal: {},
+	}}
+	// The portal is upgraded and changes owner in scanned blocks, the bridge
+	// owner changed before the first scanned block
+	l1.emit(portal, 70, nil, security.UpgradedEvent, common.BytesToHash(upgraded.Bytes()))
+	l1.emit(portal, 80, nil, security.OwnershipTransferredEvent, common.Hash{}, common.BytesToHash(owner.Bytes()))
+	l1.emit(bridge, 10, nil, security.OwnershipTransferredEvent, common.Hash{}, common.BytesToHash(owner.Bytes()))
+	l1.slots[portal][security.ImplementationSlot] = upgraded
+
+	monitor := security.New(db, l1, security.Config{
+		Contracts:   []security.Contract{{Name: "L1StandardBridge", Address: bridge}, {Name: "OptimismPortal", Address: portal}},
+		Pausable:    portal,
+		BatchSize:   20,
+		StartBlocks: 50,
+	}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, monitor.Check(ctx))
+
+	findings, err := queries.ListSecurityFindings(ctx, sqlitestore.ListSecurityFindingsParams{Limit: 10})
+	require.NoError(t, err)
+	kinds := func() []string {
+		var kinds []string
+		for _, f := range findings {
+			kinds = append(kinds, common.BytesToAddress(f.Contract).Hex()[:4]+" "+f.Kind+" "+f.Severity)
+		}
+		return kinds
+	}
+	// The slot of the portal matches the Upgraded event, the bridge slot is a baseline
+	require.Equal(t, []string{
+		"0xB1 implementation info",
+		"0xB2 ownership_transferred critical",
+		"0xB2 upgraded critical",
+	}, kinds())
+
+	// An upgrade without an event and a pause are caught by the reads
+	replaced := common.Address{0x14}
+	l1.slots[bridge][security.ImplementationSlot] = replaced
+	l1.paused = true
+	l1.head = 110
+	require.NoError(t, monitor.Check(ctx))
+
+	findings, err = queries.ListSecurityFindings(ctx, sqlitestore.ListSecurityFindingsParams{Limit: 2})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"0xB1 implementation critical", "0xB2 paused critical"}, kinds())
+	for _, f := range findings {
+		if f.Kind == security.Implementation {
+			require.Equal(t, "Implementation changed from "+implementation.Hex()+" to "+replaced.Hex(), f.Detail)
+		}
+	}
+
+	// Nothing changed
+	l1.head = 120
+	require.NoError(t, monitor.Check(ctx))
+	count, err := queries.CountSecurityFindings(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), count)
+
+	l1.paused = false
+	require.NoError(t, monitor.Check(ctx))
+	pause, err := queries.GetLatestPauseFinding(ctx)
+	require.NoError(t, err)
+	require.Equal(t, security.Unpaused, pause.Kind)
+}
+
+func TestParseLog(t *testing.T) {
+	previous, admin := common.Address{0x21}, common.Address{0x22}
+	finding, err := security.ParseLog(&types.Log{
+		Address: bridge,
+		Topics:  []common.Hash{security.AdminChangedEvent},
+		Data:    append(common.BytesToHash(previous.Bytes()).Bytes(), common.BytesToHash(admin.Bytes()).Bytes()...),
+	}, "L1StandardBridge")
+	require.NoError(t, err)
+	require.Equal(t, security.AdminChanged, finding.Kind)
+	require.Equal(t, admin.Bytes(), finding.Value)
+	require.Equal(t, "Proxy admin changed from "+previous.Hex()+" to "+admin.Hex(), finding.Detail)
+
+	finding, err = security.ParseLog(&types.Log{
+		Topics: []common.Hash{security.SystemConfigUpdateEvent, {}, common.BigToHash(big.NewInt(3))},
+	}, "SystemConfig")
+	require.NoError(t, err)
+	require.Equal(t, security.Warning, finding.Severity)
+	require.Equal(t, "SystemConfig update of UNSAFE_BLOCK_SIGNER", finding.Detail)
+
+	_, err = security.ParseLog(&types.Log{Topics: []common.Hash{security.AdminChangedEvent}}, "L1StandardBridge")
+	require.Error(t, err)
+}
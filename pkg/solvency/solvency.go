@@ -0,0 +1,367 @@
+package solvency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/logparser"
+	"github.com/Golem-Base/bridgette/pkg/monitor"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ETHWithdrawalFinalizedEvent is the topic of the L1StandardBridge
+// ETHWithdrawalFinalized (index_topic_1 address from, index_topic_2 address to, uint256 amount, bytes extraData) event
+var ETHWithdrawalFinalizedEvent = common.HexToHash("0x2ac69ee804d9a7a0984249f508dfab7cb2534b465b6ce1580f99a38ba9c5e631")
+
+// WithdrawalsLastBlock and WithdrawalsLowBlock are the block pointers of the
+// highest and lowest scanned withdrawal events
+const (
+	WithdrawalsLastBlock = "l1_standard_bridge_eth_withdrawal_finalized_last_processed_block"
+	WithdrawalsLowBlock  = "l1_standard_bridge_eth_withdrawal_finalized_lowest_processed_block"
+)
+
+// Client is the part of the L1 execution client used by the monitor
+type Client interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Config describes the bridge contracts and where the L1 deposits are indexed
+type Config struct {
+	// Bridge is the L1StandardBridge, which emits the deposit and withdrawal events
+	Bridge common.Address
+	// Holders are the contracts whose ETH balance backs the bridged ETH,
+	// usually the OptimismPortal and the bridge itself
+	Holders []common.Address
+	// ThresholdWei is the shortfall tolerated before alerting
+	ThresholdWei *big.Int
+	// BatchSize is the number of blocks scanned for withdrawals per request
+	BatchSize uint64
+	// LowPointer and LastPointer are the block pointers of the indexed L1 deposits
+	LowPointer  string
+	LastPointer string
+	// StartBlock is the lowest L1 block the deposits are backfilled to. No
+	// sample is recorded before the backfill reached it.
+	StartBlock uint64
+}
+
+// Sample is the solvency of the bridge at an L1 block
+type Sample struct {
+	BlockNumber  uint64
+	BlockTime    time.Time
+	BalanceWei   *big.Int
+	DepositedWei *big.Int
+	WithdrawnWei *big.Int
+	// Alerting is set when the shortfall exceeded the threshold
+	Alerting bool
+}
+
+// ExpectedWei is the balance the bridge should hold: the deposits minus the withdrawals
+func (s Sample) ExpectedWei() *big.Int {
+	return new(big.Int).Sub(s.DepositedWei, s.WithdrawnWei)
+}
+
+// GapWei is the balance minus the expected balance, it is negative when the
+// bridge holds less than the deposits it has not paid out yet
+func (s Sample) GapWei() *big.Int {
+	return new(big.Int).Sub(s.BalanceWei, s.ExpectedWei())
+}
+
+// Monitor periodically compares the ETH held by the bridge contracts on L1
+// with the value derived from the indexed deposits and withdrawals. Each check
+// samples the last L1 block processed by the indexer, so that the deposits of
+// the sample are complete, and records the result as a time series.
+type Monitor struct {
+	db     *sql.DB
+	client Client
+	cfg    Config
+	bus    *events.Bus
+	log    *slog.Logger
+}
+
+// New creates a solvency monitor. Samples are published on bus, which may be nil.
+func New(db *sql.DB, client Client, cfg Config, bus *events.Bus, log *slog.Logger) *Monitor {
+	return &Monitor{
+		db:     db,
+		client: client,
+		cfg:    cfg,
+		bus:    bus,
+		log:    log,
+	}
+}
+
+// Run samples the solvency every interval until ctx is cancelled. Samples
+// are only taken at indexed L1 blocks, so the interval bounds how far the
+// latest sample lags behind the indexer.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) error {
+	return monitor.Every(ctx, interval, m.log, func(ctx context.Context) error {
+		_, err := m.Check(ctx)
+		return err
+	})
+}
+
+// Check scans the withdrawals of the indexed L1 blocks, reads the balance of
+// the holders at the last one and records a sample. It returns nil when the
+// L1 deposits are not backfilled yet or the block was already sampled.
+func (m *Monitor) Check(ctx context.Context) (*Sample, error) {
+	var sample *Sample
+	err := tracing.Run(ctx, "solvency check", func(ctx context.Context) error {
+		var err error
+		sample, err = m.check(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if sample == nil {
+		return nil, nil
+	}
+
+	gap := sample.GapWei()
+	attrs := []any{
+		"block_number", sample.BlockNumber,
+		"balance_wei", sample.BalanceWei.String(),
+		"expected_wei", sample.ExpectedWei().String(),
+		"gap_wei", gap.String(),
+	}
+	if sample.Alerting {
+		m.log.Error("bridge holds less ETH than deposited and not withdrawn", attrs...)
+	} else {
+		m.log.Info("recorded solvency sample", attrs...)
+	}
+
+	if m.bus != nil {
+		m.bus.Publish(events.Event{Type: events.Solvency, Chain: "l1", BlockNumber: sample.BlockNumber})
+	}
+	return sample, nil
+}
+
+func (m *Monitor) check(ctx context.Context) (*Sample, error) {
+	queries := sqlitestore.NewTraced(m.db)
+
+	last, err := queries.GetBlockPointer(ctx, m.cfg.LastPointer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last processed L1 block: %w", err)
+	}
+	if last.BlockNumber == nil || last.BlockTime == nil {
+		return nil, nil
+	}
+	blockNumber := uint64(*last.BlockNumber)
+
+	// Until the backfill is done the deposits and withdrawals of the older
+	// blocks are missing, which would show a gap that is not there
+	low, err := queries.GetBlockPointer(ctx, m.cfg.LowPointer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lowest processed L1 block: %w", err)
+	}
+	if low.BlockNumber == nil || uint64(*low.BlockNumber) > m.cfg.StartBlock {
+		return nil, nil
+	}
+
+	latest, err := queries.GetLatestSolvencySample(ctx)
+	if err == nil && uint64(latest.BlockNumber) >= blockNumber {
+		return nil, nil
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get latest solvency sample: %w", err)
+	}
+
+	err = m.scanWithdrawals(ctx, queries, uint64(*low.BlockNumber), blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &Sample{
+		BlockNumber: blockNumber,
+		BlockTime:   time.Unix(*last.BlockTime, 0),
+		BalanceWei:  new(big.Int),
+	}
+	for _, holder := range m.cfg.Holders {
+		balance, err := m.client.BalanceAt(ctx, holder, new(big.Int).SetUint64(blockNumber))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance of %s: %w", holder, err)
+		}
+		sample.BalanceWei.Add(sample.BalanceWei, balance)
+	}
+
+	deposits, err := queries.ListL1DepositAmountsUpToBlock(ctx, int64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deposit amounts: %w", err)
+	}
+	sample.DepositedWei = sum(deposits)
+
+	withdrawals, err := queries.ListL1WithdrawalAmountsUpToBlock(ctx, int64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list withdrawal amounts: %w", err)
+	}
+	sample.WithdrawnWei = sum(withdrawals)
+
+	gap := sample.GapWei()
+	sample.Alerting = new(big.Int).Neg(gap).Cmp(m.cfg.ThresholdWei) > 0
+
+	gapEth, _ := new(big.Float).Quo(new(big.Float).SetInt(gap), big.NewFloat(params.Ether)).Float64()
+	err = queries.InsertSolvencySample(ctx, sqlitestore.InsertSolvencySampleParams{
+		BlockNumber:  int64(sample.BlockNumber),
+		BlockTime:    sample.BlockTime.Unix(),
+		BalanceWei:   common.LeftPadBytes(sample.BalanceWei.Bytes(), 32),
+		DepositedWei: common.LeftPadBytes(sample.DepositedWei.Bytes(), 32),
+		WithdrawnWei: common.LeftPadBytes(sample.WithdrawnWei.Bytes(), 32),
+		GapEth:       gapEth,
+		Alerting:     sample.Alerting,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert solvency sample: %w", err)
+	}
+
+	return sample, nil
+}
+
+// scanWithdrawals stores the withdrawal events of the blocks fromBlock to
+// toBlock, the blocks the deposits are indexed for. New blocks are scanned
+// forwards from the last scanned one and blocks backfilled by the indexer
+// since the previous scan backwards from the lowest scanned one.
+func (m *Monitor) scanWithdrawals(ctx context.Context, queries *sqlitestore.Queries, fromBlock, toBlock uint64) error {
+	err := queries.InsertBlockPointer(ctx, WithdrawalsLowBlock)
+	if err != nil {
+		return fmt.Errorf("failed to create withdrawals block pointer: %w", err)
+	}
+	last, err := queries.GetBlockPointer(ctx, WithdrawalsLastBlock)
+	if err != nil {
+		return fmt.Errorf("failed to get withdrawals block pointer: %w", err)
+	}
+	low, err := queries.GetBlockPointer(ctx, WithdrawalsLowBlock)
+	if err != nil {
+		return fmt.Errorf("failed to get lowest withdrawals block pointer: %w", err)
+	}
+
+	// The first scan starts with a single block, databases scanned before
+	// the low pointer existed are scanned again down from their last block
+	var lastBlock, lowBlock uint64
+	if low.BlockNumber != nil && last.BlockNumber != nil {
+		lastBlock, lowBlock = uint64(*last.BlockNumber), uint64(*low.BlockNumber)
+	} else {
+		anchor := toBlock
+		if last.BlockNumber != nil {
+			anchor = uint64(*last.BlockNumber)
+		}
+		logs, err := m.filterWithdrawals(ctx, anchor, anchor)
+		if err != nil {
+			return err
+		}
+		err = m.storeWithdrawals(ctx, logs, anchor, WithdrawalsLowBlock, WithdrawalsLastBlock)
+		if err != nil {
+			return err
+		}
+		lastBlock, lowBlock = anchor, anchor
+	}
+
+	for start := lastBlock + 1; start <= toBlock; start += m.cfg.BatchSize {
+		end := min(start+m.cfg.BatchSize-1, toBlock)
+		logs, err := m.filterWithdrawals(ctx, start, end)
+		if err != nil {
+			return err
+		}
+		err = m.storeWithdrawals(ctx, logs, end, WithdrawalsLastBlock)
+		if err != nil {
+			return err
+		}
+	}
+
+	for end := lowBlock; end > fromBlock; {
+		start := fromBlock
+		if end > fromBlock+m.cfg.BatchSize {
+			start = end - m.cfg.BatchSize
+		}
+		logs, err := m.filterWithdrawals(ctx, start, end-1)
+		if err != nil {
+			return err
+		}
+		err = m.storeWithdrawals(ctx, logs, start, WithdrawalsLowBlock)
+		if err != nil {
+			return err
+		}
+		end = start
+	}
+	return nil
+}
+
+// filterWithdrawals returns the withdrawal logs of a block range
+func (m *Monitor) filterWithdrawals(ctx context.Context, fromBlock, toBlock uint64) ([]types.Log, error) {
+	logs, err := m.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{m.cfg.Bridge},
+		Topics:    [][]common.Hash{{ETHWithdrawalFinalizedEvent}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter withdrawal logs: %w", err)
+	}
+	return logs, nil
+}
+
+// storeWithdrawals inserts the withdrawals of a batch and moves the pointers
+// to blockNumber in one transaction
+func (m *Monitor) storeWithdrawals(ctx context.Context, logs []types.Log, blockNumber uint64, pointers ...string) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after a successful commit is a no-op
+	defer tx.Rollback()
+	txStore := sqlitestore.NewTraced(tx)
+
+	for _, lg := range logs {
+		event, err := logparser.ParseL1StandardBridgeETHWithdrawalFinalizedEvent(&lg)
+		if err != nil {
+			return fmt.Errorf("failed to parse withdrawal log: %w", err)
+		}
+
+		err = txStore.InsertL1WithdrawalFinalized(ctx, sqlitestore.InsertL1WithdrawalFinalizedParams{
+			BlockNumber: int64(lg.BlockNumber),
+			TxHash:      lg.TxHash.Bytes(),
+			LogIndex:    int64(lg.Index),
+			FromAddress: event.From.Bytes(),
+			ToAddress:   event.To.Bytes(),
+			AmountWei:   common.LeftPadBytes(event.Amount.Bytes(), 32),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert withdrawal: %w", err)
+		}
+	}
+
+	number := int64(blockNumber)
+	for _, name := range pointers {
+		err = txStore.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+			BlockNumber: &number,
+			Name:        name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update withdrawals block pointer: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func sum(amounts [][]byte) *big.Int {
+	total := new(big.Int)
+	for _, amount := range amounts {
+		total.Add(total, new(big.Int).SetBytes(amount))
+	}
+	return total
+}
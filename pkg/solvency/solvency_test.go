@@ -0,0 +1,214 @@
+package solvency_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/solvency"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lowPointer  = "l1_standard_bridge_eth_deposit_initiated_lowest_processed_block"
+	lastPointer = "l1_standard_bridge_eth_deposit_initiated_last_processed_block"
+)
+
+var (
+	bridge = common.Address{0xb}
+	portal = common.Address{0xc}
+)
+
+// fakeChain serves fixed balances and the withdrawal logs in the requested range
+type fakeChain struct {
+	balances map[common.Address]*big.Int
+	logs     []types.Log
+}
+
+func (f *fakeChain) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if balance, ok := f.balances[account]; ok {
+		return balance, nil
+	}
+	return new(big.Int), nil
+}
+
+func (f *fakeChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for _, lg := range f.logs {
+		if lg.BlockNumber >= q.FromBlock.Uint64() && lg.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, lg)
+		}
+	}
+	return logs, nil
+}
+
+func withdrawalLog(t *testing.T, blockNumber uint64, amount int64) types.Log {
+	contractAbi, err := bindings.L1StandardBridgeMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := contractAbi.Events["ETHWithdrawalFinalized"].Inputs.NonIndexed().Pack(big.NewInt(amount), []byte{})
+	require.NoError(t, err)
+
+	return types.Log{
+		Address:     bridge,
+		Topics:      []common.Hash{solvency.ETHWithdrawalFinalizedEvent, {0x1}, {0x2}},
+		Data:        data,
+		BlockNumber: blockNumber,
+		TxHash:      common.Hash{byte(blockNumber)},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	deposit := func(blockNumber int64, amount int64) {
+		_, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+			BlockNumber:  blockNumber,
+			TxHash:       common.Hash{byte(blockNumber), 0xd}.Bytes(),
+			FromAddress:  []byte{},
+			ToAddress:    []byte{},
+			AmountWei:    common.LeftPadBytes(big.NewInt(amount).Bytes(), 32),
+			Event:        []byte("{}"),
+			MatchingHash: []byte{1},
+		})
+		require.NoError(t, err)
+	}
+	index := func(blockNumber int64) {
+		blockTime := 1_700_000_000 + blockNumber*12
+		require.NoError(t, queries.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+			BlockNumber: &blockNumber,
+			BlockTime:   &blockTime,
+			Name:        lastPointer,
+		}))
+	}
+
+	low := int64(10)
+	require.NoError(t, queries.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{BlockNumber: &low, Name: lowPointer}))
+
+	chain := &fakeChain{
+		balances: map[common.Address]*big.Int{portal: big.NewInt(60), bridge: big.NewInt(5)},
+		logs:     []types.Log{withdrawalLog(t, 15, 40)},
+	}
+	monitor := solvency.New(db, chain, solvency.Config{
+		Bridge:       bridge,
+		Holders:      []common.Address{portal, bridge},
+		ThresholdWei: big.NewInt(10),
+		BatchSize:    4,
+		LowPointer:   lowPointer,
+		LastPointer:  lastPointer,
+		StartBlock:   10,
+	}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Nothing is sampled before the deposits are indexed
+	sample, err := monitor.Check(ctx)
+	require.NoError(t, err)
+	require.Nil(t, sample)
+
+	deposit(12, 100)
+	deposit(25, 1000)
+	index(20)
+
+	// The deposit after the sampled block is not counted yet
+	sample, err = monitor.Check(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, sample)
+	require.Equal(t, uint64(20), sample.BlockNumber)
+	require.Equal(t, big.NewInt(65), sample.BalanceWei)
+	require.Equal(t, big.NewInt(100), sample.DepositedWei)
+	require.Equal(t, big.NewInt(40), sample.WithdrawnWei)
+	require.Equal(t, big.NewInt(5), sample.GapWei())
+	require.False(t, sample.Alerting)
+
+	// The same block is sampled once
+	sample, err = monitor.Check(ctx)
+	require.NoError(t, err)
+	require.Nil(t, sample)
+
+	index(30)
+	sample, err = monitor.Check(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, sample)
+	require.Equal(t, big.NewInt(1060), sample.ExpectedWei())
+	require.Equal(t, big.NewInt(-995), sample.GapWei())
+	require.True(t, sample.Alerting)
+
+	latest, err := queries.GetLatestSolvencySample(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(30), latest.BlockNumber)
+	require.True(t, latest.Alerting)
+
+	// The withdrawal was stored once although two checks scanned blocks
+	withdrawals, err := queries.ListL1WithdrawalAmountsUpToBlock(ctx, 30)
+	require.NoError(t, err)
+	require.Len(t, withdrawals, 1)
+}
+
+func TestCheckBackfilledWithdrawals(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+	setPointer := func(name string, blockNumber int64) {
+		blockTime := 1_700_000_000 + blockNumber*12
+		require.NoError(t, queries.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+			BlockNumber: &blockNumber,
+			BlockTime:   &blockTime,
+			Name:        name,
+		}))
+	}
+
+	chain := &fakeChain{
+		balances: map[common.Address]*big.Int{portal: big.NewInt(100)},
+		logs:     []types.Log{withdrawalLog(t, 3, 1), withdrawalLog(t, 15, 10), withdrawalLog(t, 35, 100)},
+	}
+	newMonitor := func(startBlock uint64) *solvency.Monitor {
+		return solvency.New(db, chain, solvency.Config{
+			Bridge:       bridge,
+			Holders:      []common.Address{portal},
+			ThresholdWei: new(big.Int),
+			BatchSize:    4,
+			LowPointer:   lowPointer,
+			LastPointer:  lastPointer,
+			StartBlock:   startBlock,
+		}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+
+	// Nothing is sampled while the deposits are backfilled
+	setPointer(lastPointer, 30)
+	setPointer(lowPointer, 20)
+	monitor := newMonitor(10)
+	sample, err := monitor.Check(ctx)
+	require.NoError(t, err)
+	require.Nil(t, sample)
+
+	setPointer(lowPointer, 10)
+	sample, err = monitor.Check(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, sample)
+	require.Equal(t, big.NewInt(10), sample.WithdrawnWei)
+
+	// Restarted with an earlier start block, the indexer backfills more
+	// blocks and the withdrawals of these blocks are scanned backwards
+	setPointer(lowPointer, 0)
+	setPointer(lastPointer, 40)
+	sample, err = newMonitor(0).Check(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, sample)
+	require.Equal(t, big.NewInt(111), sample.WithdrawnWei)
+
+	for name, want := range map[string]int64{solvency.WithdrawalsLowBlock: 0, solvency.WithdrawalsLastBlock: 40} {
+		pointer, err := queries.GetBlockPointer(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, want, *pointer.BlockNumber)
+	}
+}
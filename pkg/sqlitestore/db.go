@@ -24,11 +24,71 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
-	if q.findMatchingL1DepositsStmt, err = db.PrepareContext(ctx, findMatchingL1Deposits); err != nil {
-		return nil, fmt.Errorf("error preparing query FindMatchingL1Deposits: %w", err)
+	if q.clearL1MatchesStmt, err = db.PrepareContext(ctx, clearL1Matches); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearL1Matches: %w", err)
 	}
-	if q.findMatchingL2DepositsStmt, err = db.PrepareContext(ctx, findMatchingL2Deposits); err != nil {
-		return nil, fmt.Errorf("error preparing query FindMatchingL2Deposits: %w", err)
+	if q.clearL1MatchesOfL2RangeStmt, err = db.PrepareContext(ctx, clearL1MatchesOfL2Range); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearL1MatchesOfL2Range: %w", err)
+	}
+	if q.clearL2MatchesStmt, err = db.PrepareContext(ctx, clearL2Matches); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearL2Matches: %w", err)
+	}
+	if q.clearL2MatchesOfL1RangeStmt, err = db.PrepareContext(ctx, clearL2MatchesOfL1Range); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearL2MatchesOfL1Range: %w", err)
+	}
+	if q.closeLivenessIncidentStmt, err = db.PrepareContext(ctx, closeLivenessIncident); err != nil {
+		return nil, fmt.Errorf("error preparing query CloseLivenessIncident: %w", err)
+	}
+	if q.countDepositsInFlightStmt, err = db.PrepareContext(ctx, countDepositsInFlight); err != nil {
+		return nil, fmt.Errorf("error preparing query CountDepositsInFlight: %w", err)
+	}
+	if q.countDisputeGamesStmt, err = db.PrepareContext(ctx, countDisputeGames); err != nil {
+		return nil, fmt.Errorf("error preparing query CountDisputeGames: %w", err)
+	}
+	if q.countIndexedEventsStmt, err = db.PrepareContext(ctx, countIndexedEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query CountIndexedEvents: %w", err)
+	}
+	if q.countLivenessIncidentsStmt, err = db.PrepareContext(ctx, countLivenessIncidents); err != nil {
+		return nil, fmt.Errorf("error preparing query CountLivenessIncidents: %w", err)
+	}
+	if q.countSecurityFindingsStmt, err = db.PrepareContext(ctx, countSecurityFindings); err != nil {
+		return nil, fmt.Errorf("error preparing query CountSecurityFindings: %w", err)
+	}
+	if q.deleteBlockPointerHashesAfterStmt, err = db.PrepareContext(ctx, deleteBlockPointerHashesAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteBlockPointerHashesAfter: %w", err)
+	}
+	if q.deleteBlockPointerHashesBeforeStmt, err = db.PrepareContext(ctx, deleteBlockPointerHashesBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteBlockPointerHashesBefore: %w", err)
+	}
+	if q.deleteIndexedEventsInRangeStmt, err = db.PrepareContext(ctx, deleteIndexedEventsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIndexedEventsInRange: %w", err)
+	}
+	if q.deleteL1DepositsInRangeStmt, err = db.PrepareContext(ctx, deleteL1DepositsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteL1DepositsInRange: %w", err)
+	}
+	if q.deleteL2FinalizationsInRangeStmt, err = db.PrepareContext(ctx, deleteL2FinalizationsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteL2FinalizationsInRange: %w", err)
+	}
+	if q.exportMatchedDepositsStmt, err = db.PrepareContext(ctx, exportMatchedDeposits); err != nil {
+		return nil, fmt.Errorf("error preparing query ExportMatchedDeposits: %w", err)
+	}
+	if q.exportUnmatchedL1DepositsStmt, err = db.PrepareContext(ctx, exportUnmatchedL1Deposits); err != nil {
+		return nil, fmt.Errorf("error preparing query ExportUnmatchedL1Deposits: %w", err)
+	}
+	if q.exportUnmatchedL2FinalizationsStmt, err = db.PrepareContext(ctx, exportUnmatchedL2Finalizations); err != nil {
+		return nil, fmt.Errorf("error preparing query ExportUnmatchedL2Finalizations: %w", err)
+	}
+	if q.findInconsistentL1MatchesStmt, err = db.PrepareContext(ctx, findInconsistentL1Matches); err != nil {
+		return nil, fmt.Errorf("error preparing query FindInconsistentL1Matches: %w", err)
+	}
+	if q.findInconsistentL2MatchesStmt, err = db.PrepareContext(ctx, findInconsistentL2Matches); err != nil {
+		return nil, fmt.Errorf("error preparing query FindInconsistentL2Matches: %w", err)
+	}
+	if q.flagDisputeGameStmt, err = db.PrepareContext(ctx, flagDisputeGame); err != nil {
+		return nil, fmt.Errorf("error preparing query FlagDisputeGame: %w", err)
+	}
+	if q.getAmbiguousGroupsStmt, err = db.PrepareContext(ctx, getAmbiguousGroups); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAmbiguousGroups: %w", err)
 	}
 	if q.getBlockPointerStmt, err = db.PrepareContext(ctx, getBlockPointer); err != nil {
 		return nil, fmt.Errorf("error preparing query GetBlockPointer: %w", err)
@@ -36,61 +96,353 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getBridgeStatsStmt, err = db.PrepareContext(ctx, getBridgeStats); err != nil {
 		return nil, fmt.Errorf("error preparing query GetBridgeStats: %w", err)
 	}
+	if q.getDepositByIDStmt, err = db.PrepareContext(ctx, getDepositByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDepositByID: %w", err)
+	}
+	if q.getDepositCountsStmt, err = db.PrepareContext(ctx, getDepositCounts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDepositCounts: %w", err)
+	}
+	if q.getDepositLatencyStmt, err = db.PrepareContext(ctx, getDepositLatency); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDepositLatency: %w", err)
+	}
+	if q.getDepositTimingsStmt, err = db.PrepareContext(ctx, getDepositTimings); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDepositTimings: %w", err)
+	}
+	if q.getDepositsByAddressStmt, err = db.PrepareContext(ctx, getDepositsByAddress); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDepositsByAddress: %w", err)
+	}
+	if q.getDepositsByTxHashStmt, err = db.PrepareContext(ctx, getDepositsByTxHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDepositsByTxHash: %w", err)
+	}
+	if q.getDisputeGameStmt, err = db.PrepareContext(ctx, getDisputeGame); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDisputeGame: %w", err)
+	}
+	if q.getLatestBatcherTransactionStmt, err = db.PrepareContext(ctx, getLatestBatcherTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestBatcherTransaction: %w", err)
+	}
+	if q.getLatestChainHeadStmt, err = db.PrepareContext(ctx, getLatestChainHead); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestChainHead: %w", err)
+	}
 	if q.getLatestL1BlockStmt, err = db.PrepareContext(ctx, getLatestL1Block); err != nil {
 		return nil, fmt.Errorf("error preparing query GetLatestL1Block: %w", err)
 	}
 	if q.getLatestL2BlockStmt, err = db.PrepareContext(ctx, getLatestL2Block); err != nil {
 		return nil, fmt.Errorf("error preparing query GetLatestL2Block: %w", err)
 	}
+	if q.getLatestPauseFindingStmt, err = db.PrepareContext(ctx, getLatestPauseFinding); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestPauseFinding: %w", err)
+	}
+	if q.getLatestSecurityFindingStmt, err = db.PrepareContext(ctx, getLatestSecurityFinding); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestSecurityFinding: %w", err)
+	}
+	if q.getLatestSolvencySampleStmt, err = db.PrepareContext(ctx, getLatestSolvencySample); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestSolvencySample: %w", err)
+	}
+	if q.getLatestSyncStatusSampleStmt, err = db.PrepareContext(ctx, getLatestSyncStatusSample); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLatestSyncStatusSample: %w", err)
+	}
+	if q.getLivenessIncidentStmt, err = db.PrepareContext(ctx, getLivenessIncident); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLivenessIncident: %w", err)
+	}
+	if q.getMatchedAmountsWeiStmt, err = db.PrepareContext(ctx, getMatchedAmountsWei); err != nil {
+		return nil, fmt.Errorf("error preparing query GetMatchedAmountsWei: %w", err)
+	}
 	if q.getMatchedDepositsStmt, err = db.PrepareContext(ctx, getMatchedDeposits); err != nil {
 		return nil, fmt.Errorf("error preparing query GetMatchedDeposits: %w", err)
 	}
+	if q.getOpenLivenessIncidentStmt, err = db.PrepareContext(ctx, getOpenLivenessIncident); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOpenLivenessIncident: %w", err)
+	}
+	if q.getOrphanedFinalizationsStmt, err = db.PrepareContext(ctx, getOrphanedFinalizations); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrphanedFinalizations: %w", err)
+	}
 	if q.getPendingDepositsStmt, err = db.PrepareContext(ctx, getPendingDeposits); err != nil {
 		return nil, fmt.Errorf("error preparing query GetPendingDeposits: %w", err)
 	}
 	if q.getTimeSeriesChartDataStmt, err = db.PrepareContext(ctx, getTimeSeriesChartData); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTimeSeriesChartData: %w", err)
 	}
+	if q.getTotalAmbiguousGroupsStmt, err = db.PrepareContext(ctx, getTotalAmbiguousGroups); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTotalAmbiguousGroups: %w", err)
+	}
+	if q.getTotalDepositsByAddressStmt, err = db.PrepareContext(ctx, getTotalDepositsByAddress); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTotalDepositsByAddress: %w", err)
+	}
 	if q.getTotalMatchedDepositsStmt, err = db.PrepareContext(ctx, getTotalMatchedDeposits); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTotalMatchedDeposits: %w", err)
 	}
+	if q.getTotalOrphanedFinalizationsStmt, err = db.PrepareContext(ctx, getTotalOrphanedFinalizations); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTotalOrphanedFinalizations: %w", err)
+	}
 	if q.getTotalUnmatchedDepositsStmt, err = db.PrepareContext(ctx, getTotalUnmatchedDeposits); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTotalUnmatchedDeposits: %w", err)
 	}
 	if q.getUnmatchedDepositsStmt, err = db.PrepareContext(ctx, getUnmatchedDeposits); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUnmatchedDeposits: %w", err)
 	}
+	if q.insertBatcherTransactionStmt, err = db.PrepareContext(ctx, insertBatcherTransaction); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertBatcherTransaction: %w", err)
+	}
+	if q.insertBlockPointerStmt, err = db.PrepareContext(ctx, insertBlockPointer); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertBlockPointer: %w", err)
+	}
+	if q.insertBlockPointerHashStmt, err = db.PrepareContext(ctx, insertBlockPointerHash); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertBlockPointerHash: %w", err)
+	}
+	if q.insertChainHeadStmt, err = db.PrepareContext(ctx, insertChainHead); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertChainHead: %w", err)
+	}
+	if q.insertDisputeGameStmt, err = db.PrepareContext(ctx, insertDisputeGame); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertDisputeGame: %w", err)
+	}
+	if q.insertIndexedEventStmt, err = db.PrepareContext(ctx, insertIndexedEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertIndexedEvent: %w", err)
+	}
 	if q.insertL1StandardBridgeETHDepositInitiatedStmt, err = db.PrepareContext(ctx, insertL1StandardBridgeETHDepositInitiated); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertL1StandardBridgeETHDepositInitiated: %w", err)
 	}
+	if q.insertL1WithdrawalFinalizedStmt, err = db.PrepareContext(ctx, insertL1WithdrawalFinalized); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertL1WithdrawalFinalized: %w", err)
+	}
 	if q.insertL2StandardBridgeDepositFinalizedStmt, err = db.PrepareContext(ctx, insertL2StandardBridgeDepositFinalized); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertL2StandardBridgeDepositFinalized: %w", err)
 	}
+	if q.insertLivenessIncidentStmt, err = db.PrepareContext(ctx, insertLivenessIncident); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertLivenessIncident: %w", err)
+	}
+	if q.insertSecurityFindingStmt, err = db.PrepareContext(ctx, insertSecurityFinding); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertSecurityFinding: %w", err)
+	}
+	if q.insertSolvencySampleStmt, err = db.PrepareContext(ctx, insertSolvencySample); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertSolvencySample: %w", err)
+	}
+	if q.insertSyncStatusSampleStmt, err = db.PrepareContext(ctx, insertSyncStatusSample); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertSyncStatusSample: %w", err)
+	}
+	if q.listBatcherTransactionsStmt, err = db.PrepareContext(ctx, listBatcherTransactions); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBatcherTransactions: %w", err)
+	}
+	if q.listBlockPointerHashesStmt, err = db.PrepareContext(ctx, listBlockPointerHashes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBlockPointerHashes: %w", err)
+	}
+	if q.listBlockPointersStmt, err = db.PrepareContext(ctx, listBlockPointers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListBlockPointers: %w", err)
+	}
+	if q.listCriticalDisputeGamesStmt, err = db.PrepareContext(ctx, listCriticalDisputeGames); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCriticalDisputeGames: %w", err)
+	}
+	if q.listDepositLatenciesStmt, err = db.PrepareContext(ctx, listDepositLatencies); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDepositLatencies: %w", err)
+	}
+	if q.listDepositsInFlightStmt, err = db.PrepareContext(ctx, listDepositsInFlight); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDepositsInFlight: %w", err)
+	}
+	if q.listDisputeGamesStmt, err = db.PrepareContext(ctx, listDisputeGames); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDisputeGames: %w", err)
+	}
+	if q.listIndexedEventSourcesStmt, err = db.PrepareContext(ctx, listIndexedEventSources); err != nil {
+		return nil, fmt.Errorf("error preparing query ListIndexedEventSources: %w", err)
+	}
+	if q.listIndexedEventsStmt, err = db.PrepareContext(ctx, listIndexedEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListIndexedEvents: %w", err)
+	}
+	if q.listL1AmountsInRangeStmt, err = db.PrepareContext(ctx, listL1AmountsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListL1AmountsInRange: %w", err)
+	}
+	if q.listL1DepositAmountsUpToBlockStmt, err = db.PrepareContext(ctx, listL1DepositAmountsUpToBlock); err != nil {
+		return nil, fmt.Errorf("error preparing query ListL1DepositAmountsUpToBlock: %w", err)
+	}
+	if q.listL1LogsInRangeStmt, err = db.PrepareContext(ctx, listL1LogsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListL1LogsInRange: %w", err)
+	}
+	if q.listL1WithdrawalAmountsUpToBlockStmt, err = db.PrepareContext(ctx, listL1WithdrawalAmountsUpToBlock); err != nil {
+		return nil, fmt.Errorf("error preparing query ListL1WithdrawalAmountsUpToBlock: %w", err)
+	}
+	if q.listL2AmountsInRangeStmt, err = db.PrepareContext(ctx, listL2AmountsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListL2AmountsInRange: %w", err)
+	}
+	if q.listL2BlocksWithoutOriginStmt, err = db.PrepareContext(ctx, listL2BlocksWithoutOrigin); err != nil {
+		return nil, fmt.Errorf("error preparing query ListL2BlocksWithoutOrigin: %w", err)
+	}
+	if q.listL2LogsInRangeStmt, err = db.PrepareContext(ctx, listL2LogsInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query ListL2LogsInRange: %w", err)
+	}
+	if q.listLivenessIncidentsStmt, err = db.PrepareContext(ctx, listLivenessIncidents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListLivenessIncidents: %w", err)
+	}
+	if q.listMatchableHashesStmt, err = db.PrepareContext(ctx, listMatchableHashes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListMatchableHashes: %w", err)
+	}
+	if q.listRecentConfirmationSecondsStmt, err = db.PrepareContext(ctx, listRecentConfirmationSeconds); err != nil {
+		return nil, fmt.Errorf("error preparing query ListRecentConfirmationSeconds: %w", err)
+	}
+	if q.listSecurityFindingsStmt, err = db.PrepareContext(ctx, listSecurityFindings); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSecurityFindings: %w", err)
+	}
+	if q.listSolvencySamplesStmt, err = db.PrepareContext(ctx, listSolvencySamples); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSolvencySamples: %w", err)
+	}
+	if q.listSyncStatusSamplesStmt, err = db.PrepareContext(ctx, listSyncStatusSamples); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSyncStatusSamples: %w", err)
+	}
+	if q.listUnmatchedL1DepositsByHashStmt, err = db.PrepareContext(ctx, listUnmatchedL1DepositsByHash); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnmatchedL1DepositsByHash: %w", err)
+	}
+	if q.listUnmatchedL2FinalizationsByHashStmt, err = db.PrepareContext(ctx, listUnmatchedL2FinalizationsByHash); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnmatchedL2FinalizationsByHash: %w", err)
+	}
+	if q.listUnresolvedDisputeGamesStmt, err = db.PrepareContext(ctx, listUnresolvedDisputeGames); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnresolvedDisputeGames: %w", err)
+	}
+	if q.listUnverifiedDisputeGamesStmt, err = db.PrepareContext(ctx, listUnverifiedDisputeGames); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUnverifiedDisputeGames: %w", err)
+	}
+	if q.recordL1MatchStmt, err = db.PrepareContext(ctx, recordL1Match); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordL1Match: %w", err)
+	}
 	if q.updateBlockPointerStmt, err = db.PrepareContext(ctx, updateBlockPointer); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateBlockPointer: %w", err)
 	}
 	if q.updateBlockPointerIfNullStmt, err = db.PrepareContext(ctx, updateBlockPointerIfNull); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateBlockPointerIfNull: %w", err)
 	}
+	if q.updateDisputeGameOutputRootStmt, err = db.PrepareContext(ctx, updateDisputeGameOutputRoot); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateDisputeGameOutputRoot: %w", err)
+	}
+	if q.updateDisputeGameOutputRootErrorStmt, err = db.PrepareContext(ctx, updateDisputeGameOutputRootError); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateDisputeGameOutputRootError: %w", err)
+	}
+	if q.updateDisputeGameStatusStmt, err = db.PrepareContext(ctx, updateDisputeGameStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateDisputeGameStatus: %w", err)
+	}
 	if q.updateL1DepositWithMatchStmt, err = db.PrepareContext(ctx, updateL1DepositWithMatch); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateL1DepositWithMatch: %w", err)
 	}
 	if q.updateL2DepositWithMatchStmt, err = db.PrepareContext(ctx, updateL2DepositWithMatch); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateL2DepositWithMatch: %w", err)
 	}
+	if q.updateL2OriginStmt, err = db.PrepareContext(ctx, updateL2Origin); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateL2Origin: %w", err)
+	}
+	if q.updateL2OriginErrorStmt, err = db.PrepareContext(ctx, updateL2OriginError); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateL2OriginError: %w", err)
+	}
+	if q.updateLivenessIncidentWorstStmt, err = db.PrepareContext(ctx, updateLivenessIncidentWorst); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateLivenessIncidentWorst: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
-	if q.findMatchingL1DepositsStmt != nil {
-		if cerr := q.findMatchingL1DepositsStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing findMatchingL1DepositsStmt: %w", cerr)
+	if q.clearL1MatchesStmt != nil {
+		if cerr := q.clearL1MatchesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearL1MatchesStmt: %w", cerr)
+		}
+	}
+	if q.clearL1MatchesOfL2RangeStmt != nil {
+		if cerr := q.clearL1MatchesOfL2RangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearL1MatchesOfL2RangeStmt: %w", cerr)
+		}
+	}
+	if q.clearL2MatchesStmt != nil {
+		if cerr := q.clearL2MatchesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearL2MatchesStmt: %w", cerr)
+		}
+	}
+	if q.clearL2MatchesOfL1RangeStmt != nil {
+		if cerr := q.clearL2MatchesOfL1RangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearL2MatchesOfL1RangeStmt: %w", cerr)
+		}
+	}
+	if q.closeLivenessIncidentStmt != nil {
+		if cerr := q.closeLivenessIncidentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing closeLivenessIncidentStmt: %w", cerr)
+		}
+	}
+	if q.countDepositsInFlightStmt != nil {
+		if cerr := q.countDepositsInFlightStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countDepositsInFlightStmt: %w", cerr)
 		}
 	}
-	if q.findMatchingL2DepositsStmt != nil {
-		if cerr := q.findMatchingL2DepositsStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing findMatchingL2DepositsStmt: %w", cerr)
+	if q.countDisputeGamesStmt != nil {
+		if cerr := q.countDisputeGamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countDisputeGamesStmt: %w", cerr)
+		}
+	}
+	if q.countIndexedEventsStmt != nil {
+		if cerr := q.countIndexedEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countIndexedEventsStmt: %w", cerr)
+		}
+	}
+	if q.countLivenessIncidentsStmt != nil {
+		if cerr := q.countLivenessIncidentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countLivenessIncidentsStmt: %w", cerr)
+		}
+	}
+	if q.countSecurityFindingsStmt != nil {
+		if cerr := q.countSecurityFindingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countSecurityFindingsStmt: %w", cerr)
+		}
+	}
+	if q.deleteBlockPointerHashesAfterStmt != nil {
+		if cerr := q.deleteBlockPointerHashesAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteBlockPointerHashesAfterStmt: %w", cerr)
+		}
+	}
+	if q.deleteBlockPointerHashesBeforeStmt != nil {
+		if cerr := q.deleteBlockPointerHashesBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteBlockPointerHashesBeforeStmt: %w", cerr)
+		}
+	}
+	if q.deleteIndexedEventsInRangeStmt != nil {
+		if cerr := q.deleteIndexedEventsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIndexedEventsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.deleteL1DepositsInRangeStmt != nil {
+		if cerr := q.deleteL1DepositsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteL1DepositsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.deleteL2FinalizationsInRangeStmt != nil {
+		if cerr := q.deleteL2FinalizationsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteL2FinalizationsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.exportMatchedDepositsStmt != nil {
+		if cerr := q.exportMatchedDepositsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing exportMatchedDepositsStmt: %w", cerr)
+		}
+	}
+	if q.exportUnmatchedL1DepositsStmt != nil {
+		if cerr := q.exportUnmatchedL1DepositsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing exportUnmatchedL1DepositsStmt: %w", cerr)
+		}
+	}
+	if q.exportUnmatchedL2FinalizationsStmt != nil {
+		if cerr := q.exportUnmatchedL2FinalizationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing exportUnmatchedL2FinalizationsStmt: %w", cerr)
+		}
+	}
+	if q.findInconsistentL1MatchesStmt != nil {
+		if cerr := q.findInconsistentL1MatchesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findInconsistentL1MatchesStmt: %w", cerr)
+		}
+	}
+	if q.findInconsistentL2MatchesStmt != nil {
+		if cerr := q.findInconsistentL2MatchesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findInconsistentL2MatchesStmt: %w", cerr)
+		}
+	}
+	if q.flagDisputeGameStmt != nil {
+		if cerr := q.flagDisputeGameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing flagDisputeGameStmt: %w", cerr)
+		}
+	}
+	if q.getAmbiguousGroupsStmt != nil {
+		if cerr := q.getAmbiguousGroupsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAmbiguousGroupsStmt: %w", cerr)
 		}
 	}
 	if q.getBlockPointerStmt != nil {
@@ -103,6 +455,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getBridgeStatsStmt: %w", cerr)
 		}
 	}
+	if q.getDepositByIDStmt != nil {
+		if cerr := q.getDepositByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDepositByIDStmt: %w", cerr)
+		}
+	}
+	if q.getDepositCountsStmt != nil {
+		if cerr := q.getDepositCountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDepositCountsStmt: %w", cerr)
+		}
+	}
+	if q.getDepositLatencyStmt != nil {
+		if cerr := q.getDepositLatencyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDepositLatencyStmt: %w", cerr)
+		}
+	}
+	if q.getDepositTimingsStmt != nil {
+		if cerr := q.getDepositTimingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDepositTimingsStmt: %w", cerr)
+		}
+	}
+	if q.getDepositsByAddressStmt != nil {
+		if cerr := q.getDepositsByAddressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDepositsByAddressStmt: %w", cerr)
+		}
+	}
+	if q.getDepositsByTxHashStmt != nil {
+		if cerr := q.getDepositsByTxHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDepositsByTxHashStmt: %w", cerr)
+		}
+	}
+	if q.getDisputeGameStmt != nil {
+		if cerr := q.getDisputeGameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDisputeGameStmt: %w", cerr)
+		}
+	}
+	if q.getLatestBatcherTransactionStmt != nil {
+		if cerr := q.getLatestBatcherTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestBatcherTransactionStmt: %w", cerr)
+		}
+	}
+	if q.getLatestChainHeadStmt != nil {
+		if cerr := q.getLatestChainHeadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestChainHeadStmt: %w", cerr)
+		}
+	}
 	if q.getLatestL1BlockStmt != nil {
 		if cerr := q.getLatestL1BlockStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getLatestL1BlockStmt: %w", cerr)
@@ -113,11 +510,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getLatestL2BlockStmt: %w", cerr)
 		}
 	}
+	if q.getLatestPauseFindingStmt != nil {
+		if cerr := q.getLatestPauseFindingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestPauseFindingStmt: %w", cerr)
+		}
+	}
+	if q.getLatestSecurityFindingStmt != nil {
+		if cerr := q.getLatestSecurityFindingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestSecurityFindingStmt: %w", cerr)
+		}
+	}
+	if q.getLatestSolvencySampleStmt != nil {
+		if cerr := q.getLatestSolvencySampleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestSolvencySampleStmt: %w", cerr)
+		}
+	}
+	if q.getLatestSyncStatusSampleStmt != nil {
+		if cerr := q.getLatestSyncStatusSampleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLatestSyncStatusSampleStmt: %w", cerr)
+		}
+	}
+	if q.getLivenessIncidentStmt != nil {
+		if cerr := q.getLivenessIncidentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLivenessIncidentStmt: %w", cerr)
+		}
+	}
+	if q.getMatchedAmountsWeiStmt != nil {
+		if cerr := q.getMatchedAmountsWeiStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getMatchedAmountsWeiStmt: %w", cerr)
+		}
+	}
 	if q.getMatchedDepositsStmt != nil {
 		if cerr := q.getMatchedDepositsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getMatchedDepositsStmt: %w", cerr)
 		}
 	}
+	if q.getOpenLivenessIncidentStmt != nil {
+		if cerr := q.getOpenLivenessIncidentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOpenLivenessIncidentStmt: %w", cerr)
+		}
+	}
+	if q.getOrphanedFinalizationsStmt != nil {
+		if cerr := q.getOrphanedFinalizationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrphanedFinalizationsStmt: %w", cerr)
+		}
+	}
 	if q.getPendingDepositsStmt != nil {
 		if cerr := q.getPendingDepositsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getPendingDepositsStmt: %w", cerr)
@@ -128,11 +565,26 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getTimeSeriesChartDataStmt: %w", cerr)
 		}
 	}
+	if q.getTotalAmbiguousGroupsStmt != nil {
+		if cerr := q.getTotalAmbiguousGroupsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTotalAmbiguousGroupsStmt: %w", cerr)
+		}
+	}
+	if q.getTotalDepositsByAddressStmt != nil {
+		if cerr := q.getTotalDepositsByAddressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTotalDepositsByAddressStmt: %w", cerr)
+		}
+	}
 	if q.getTotalMatchedDepositsStmt != nil {
 		if cerr := q.getTotalMatchedDepositsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTotalMatchedDepositsStmt: %w", cerr)
 		}
 	}
+	if q.getTotalOrphanedFinalizationsStmt != nil {
+		if cerr := q.getTotalOrphanedFinalizationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTotalOrphanedFinalizationsStmt: %w", cerr)
+		}
+	}
 	if q.getTotalUnmatchedDepositsStmt != nil {
 		if cerr := q.getTotalUnmatchedDepositsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTotalUnmatchedDepositsStmt: %w", cerr)
@@ -143,16 +595,206 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUnmatchedDepositsStmt: %w", cerr)
 		}
 	}
+	if q.insertBatcherTransactionStmt != nil {
+		if cerr := q.insertBatcherTransactionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertBatcherTransactionStmt: %w", cerr)
+		}
+	}
+	if q.insertBlockPointerStmt != nil {
+		if cerr := q.insertBlockPointerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertBlockPointerStmt: %w", cerr)
+		}
+	}
+	if q.insertBlockPointerHashStmt != nil {
+		if cerr := q.insertBlockPointerHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertBlockPointerHashStmt: %w", cerr)
+		}
+	}
+	if q.insertChainHeadStmt != nil {
+		if cerr := q.insertChainHeadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertChainHeadStmt: %w", cerr)
+		}
+	}
+	if q.insertDisputeGameStmt != nil {
+		if cerr := q.insertDisputeGameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertDisputeGameStmt: %w", cerr)
+		}
+	}
+	if q.insertIndexedEventStmt != nil {
+		if cerr := q.insertIndexedEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertIndexedEventStmt: %w", cerr)
+		}
+	}
 	if q.insertL1StandardBridgeETHDepositInitiatedStmt != nil {
 		if cerr := q.insertL1StandardBridgeETHDepositInitiatedStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing insertL1StandardBridgeETHDepositInitiatedStmt: %w", cerr)
 		}
 	}
+	if q.insertL1WithdrawalFinalizedStmt != nil {
+		if cerr := q.insertL1WithdrawalFinalizedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertL1WithdrawalFinalizedStmt: %w", cerr)
+		}
+	}
 	if q.insertL2StandardBridgeDepositFinalizedStmt != nil {
 		if cerr := q.insertL2StandardBridgeDepositFinalizedStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing insertL2StandardBridgeDepositFinalizedStmt: %w", cerr)
 		}
 	}
+	if q.insertLivenessIncidentStmt != nil {
+		if cerr := q.insertLivenessIncidentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertLivenessIncidentStmt: %w", cerr)
+		}
+	}
+	if q.insertSecurityFindingStmt != nil {
+		if cerr := q.insertSecurityFindingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertSecurityFindingStmt: %w", cerr)
+		}
+	}
+	if q.insertSolvencySampleStmt != nil {
+		if cerr := q.insertSolvencySampleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertSolvencySampleStmt: %w", cerr)
+		}
+	}
+	if q.insertSyncStatusSampleStmt != nil {
+		if cerr := q.insertSyncStatusSampleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertSyncStatusSampleStmt: %w", cerr)
+		}
+	}
+	if q.listBatcherTransactionsStmt != nil {
+		if cerr := q.listBatcherTransactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBatcherTransactionsStmt: %w", cerr)
+		}
+	}
+	if q.listBlockPointerHashesStmt != nil {
+		if cerr := q.listBlockPointerHashesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBlockPointerHashesStmt: %w", cerr)
+		}
+	}
+	if q.listBlockPointersStmt != nil {
+		if cerr := q.listBlockPointersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listBlockPointersStmt: %w", cerr)
+		}
+	}
+	if q.listCriticalDisputeGamesStmt != nil {
+		if cerr := q.listCriticalDisputeGamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCriticalDisputeGamesStmt: %w", cerr)
+		}
+	}
+	if q.listDepositLatenciesStmt != nil {
+		if cerr := q.listDepositLatenciesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDepositLatenciesStmt: %w", cerr)
+		}
+	}
+	if q.listDepositsInFlightStmt != nil {
+		if cerr := q.listDepositsInFlightStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDepositsInFlightStmt: %w", cerr)
+		}
+	}
+	if q.listDisputeGamesStmt != nil {
+		if cerr := q.listDisputeGamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDisputeGamesStmt: %w", cerr)
+		}
+	}
+	if q.listIndexedEventSourcesStmt != nil {
+		if cerr := q.listIndexedEventSourcesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listIndexedEventSourcesStmt: %w", cerr)
+		}
+	}
+	if q.listIndexedEventsStmt != nil {
+		if cerr := q.listIndexedEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listIndexedEventsStmt: %w", cerr)
+		}
+	}
+	if q.listL1AmountsInRangeStmt != nil {
+		if cerr := q.listL1AmountsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listL1AmountsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listL1DepositAmountsUpToBlockStmt != nil {
+		if cerr := q.listL1DepositAmountsUpToBlockStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listL1DepositAmountsUpToBlockStmt: %w", cerr)
+		}
+	}
+	if q.listL1LogsInRangeStmt != nil {
+		if cerr := q.listL1LogsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listL1LogsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listL1WithdrawalAmountsUpToBlockStmt != nil {
+		if cerr := q.listL1WithdrawalAmountsUpToBlockStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listL1WithdrawalAmountsUpToBlockStmt: %w", cerr)
+		}
+	}
+	if q.listL2AmountsInRangeStmt != nil {
+		if cerr := q.listL2AmountsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listL2AmountsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listL2BlocksWithoutOriginStmt != nil {
+		if cerr := q.listL2BlocksWithoutOriginStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listL2BlocksWithoutOriginStmt: %w", cerr)
+		}
+	}
+	if q.listL2LogsInRangeStmt != nil {
+		if cerr := q.listL2LogsInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listL2LogsInRangeStmt: %w", cerr)
+		}
+	}
+	if q.listLivenessIncidentsStmt != nil {
+		if cerr := q.listLivenessIncidentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listLivenessIncidentsStmt: %w", cerr)
+		}
+	}
+	if q.listMatchableHashesStmt != nil {
+		if cerr := q.listMatchableHashesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listMatchableHashesStmt: %w", cerr)
+		}
+	}
+	if q.listRecentConfirmationSecondsStmt != nil {
+		if cerr := q.listRecentConfirmationSecondsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listRecentConfirmationSecondsStmt: %w", cerr)
+		}
+	}
+	if q.listSecurityFindingsStmt != nil {
+		if cerr := q.listSecurityFindingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSecurityFindingsStmt: %w", cerr)
+		}
+	}
+	if q.listSolvencySamplesStmt != nil {
+		if cerr := q.listSolvencySamplesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSolvencySamplesStmt: %w", cerr)
+		}
+	}
+	if q.listSyncStatusSamplesStmt != nil {
+		if cerr := q.listSyncStatusSamplesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSyncStatusSamplesStmt: %w", cerr)
+		}
+	}
+	if q.listUnmatchedL1DepositsByHashStmt != nil {
+		if cerr := q.listUnmatchedL1DepositsByHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnmatchedL1DepositsByHashStmt: %w", cerr)
+		}
+	}
+	if q.listUnmatchedL2FinalizationsByHashStmt != nil {
+		if cerr := q.listUnmatchedL2FinalizationsByHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnmatchedL2FinalizationsByHashStmt: %w", cerr)
+		}
+	}
+	if q.listUnresolvedDisputeGamesStmt != nil {
+		if cerr := q.listUnresolvedDisputeGamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnresolvedDisputeGamesStmt: %w", cerr)
+		}
+	}
+	if q.listUnverifiedDisputeGamesStmt != nil {
+		if cerr := q.listUnverifiedDisputeGamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUnverifiedDisputeGamesStmt: %w", cerr)
+		}
+	}
+	if q.recordL1MatchStmt != nil {
+		if cerr := q.recordL1MatchStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordL1MatchStmt: %w", cerr)
+		}
+	}
 	if q.updateBlockPointerStmt != nil {
 		if cerr := q.updateBlockPointerStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateBlockPointerStmt: %w", cerr)
@@ -163,6 +805,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateBlockPointerIfNullStmt: %w", cerr)
 		}
 	}
+	if q.updateDisputeGameOutputRootStmt != nil {
+		if cerr := q.updateDisputeGameOutputRootStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateDisputeGameOutputRootStmt: %w", cerr)
+		}
+	}
+	if q.updateDisputeGameOutputRootErrorStmt != nil {
+		if cerr := q.updateDisputeGameOutputRootErrorStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateDisputeGameOutputRootErrorStmt: %w", cerr)
+		}
+	}
+	if q.updateDisputeGameStatusStmt != nil {
+		if cerr := q.updateDisputeGameStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateDisputeGameStatusStmt: %w", cerr)
+		}
+	}
 	if q.updateL1DepositWithMatchStmt != nil {
 		if cerr := q.updateL1DepositWithMatchStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateL1DepositWithMatchStmt: %w", cerr)
@@ -173,6 +830,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateL2DepositWithMatchStmt: %w", cerr)
 		}
 	}
+	if q.updateL2OriginStmt != nil {
+		if cerr := q.updateL2OriginStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateL2OriginStmt: %w", cerr)
+		}
+	}
+	if q.updateL2OriginErrorStmt != nil {
+		if cerr := q.updateL2OriginErrorStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateL2OriginErrorStmt: %w", cerr)
+		}
+	}
+	if q.updateLivenessIncidentWorstStmt != nil {
+		if cerr := q.updateLivenessIncidentWorstStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateLivenessIncidentWorstStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -212,47 +884,215 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 type Queries struct {
 	db                                            DBTX
 	tx                                            *sql.Tx
-	findMatchingL1DepositsStmt                    *sql.Stmt
-	findMatchingL2DepositsStmt                    *sql.Stmt
+	clearL1MatchesStmt                            *sql.Stmt
+	clearL1MatchesOfL2RangeStmt                   *sql.Stmt
+	clearL2MatchesStmt                            *sql.Stmt
+	clearL2MatchesOfL1RangeStmt                   *sql.Stmt
+	closeLivenessIncidentStmt                     *sql.Stmt
+	countDepositsInFlightStmt                     *sql.Stmt
+	countDisputeGamesStmt                         *sql.Stmt
+	countIndexedEventsStmt                        *sql.Stmt
+	countLivenessIncidentsStmt                    *sql.Stmt
+	countSecurityFindingsStmt                     *sql.Stmt
+	deleteBlockPointerHashesAfterStmt             *sql.Stmt
+	deleteBlockPointerHashesBeforeStmt            *sql.Stmt
+	deleteIndexedEventsInRangeStmt                *sql.Stmt
+	deleteL1DepositsInRangeStmt                   *sql.Stmt
+	deleteL2FinalizationsInRangeStmt              *sql.Stmt
+	exportMatchedDepositsStmt                     *sql.Stmt
+	exportUnmatchedL1DepositsStmt                 *sql.Stmt
+	exportUnmatchedL2FinalizationsStmt            *sql.Stmt
+	findInconsistentL1MatchesStmt                 *sql.Stmt
+	findInconsistentL2MatchesStmt                 *sql.Stmt
+	flagDisputeGameStmt                           *sql.Stmt
+	getAmbiguousGroupsStmt                        *sql.Stmt
 	getBlockPointerStmt                           *sql.Stmt
 	getBridgeStatsStmt                            *sql.Stmt
+	getDepositByIDStmt                            *sql.Stmt
+	getDepositCountsStmt                          *sql.Stmt
+	getDepositLatencyStmt                         *sql.Stmt
+	getDepositTimingsStmt                         *sql.Stmt
+	getDepositsByAddressStmt                      *sql.Stmt
+	getDepositsByTxHashStmt                       *sql.Stmt
+	getDisputeGameStmt                            *sql.Stmt
+	getLatestBatcherTransactionStmt               *sql.Stmt
+	getLatestChainHeadStmt                        *sql.Stmt
 	getLatestL1BlockStmt                          *sql.Stmt
 	getLatestL2BlockStmt                          *sql.Stmt
+	getLatestPauseFindingStmt                     *sql.Stmt
+	getLatestSecurityFindingStmt                  *sql.Stmt
+	getLatestSolvencySampleStmt                   *sql.Stmt
+	getLatestSyncStatusSampleStmt                 *sql.Stmt
+	getLivenessIncidentStmt                       *sql.Stmt
+	getMatchedAmountsWeiStmt                      *sql.Stmt
 	getMatchedDepositsStmt                        *sql.Stmt
+	getOpenLivenessIncidentStmt                   *sql.Stmt
+	getOrphanedFinalizationsStmt                  *sql.Stmt
 	getPendingDepositsStmt                        *sql.Stmt
 	getTimeSeriesChartDataStmt                    *sql.Stmt
+	getTotalAmbiguousGroupsStmt                   *sql.Stmt
+	getTotalDepositsByAddressStmt                 *sql.Stmt
 	getTotalMatchedDepositsStmt                   *sql.Stmt
+	getTotalOrphanedFinalizationsStmt             *sql.Stmt
 	getTotalUnmatchedDepositsStmt                 *sql.Stmt
 	getUnmatchedDepositsStmt                      *sql.Stmt
+	insertBatcherTransactionStmt                  *sql.Stmt
+	insertBlockPointerStmt                        *sql.Stmt
+	insertBlockPointerHashStmt                    *sql.Stmt
+	insertChainHeadStmt                           *sql.Stmt
+	insertDisputeGameStmt                         *sql.Stmt
+	insertIndexedEventStmt                        *sql.Stmt
 	insertL1StandardBridgeETHDepositInitiatedStmt *sql.Stmt
+	insertL1WithdrawalFinalizedStmt               *sql.Stmt
 	insertL2StandardBridgeDepositFinalizedStmt    *sql.Stmt
+	insertLivenessIncidentStmt                    *sql.Stmt
+	insertSecurityFindingStmt                     *sql.Stmt
+	insertSolvencySampleStmt                      *sql.Stmt
+	insertSyncStatusSampleStmt                    *sql.Stmt
+	listBatcherTransactionsStmt                   *sql.Stmt
+	listBlockPointerHashesStmt                    *sql.Stmt
+	listBlockPointersStmt                         *sql.Stmt
+	listCriticalDisputeGamesStmt                  *sql.Stmt
+	listDepositLatenciesStmt                      *sql.Stmt
+	listDepositsInFlightStmt                      *sql.Stmt
+	listDisputeGamesStmt                          *sql.Stmt
+	listIndexedEventSourcesStmt                   *sql.Stmt
+	listIndexedEventsStmt                         *sql.Stmt
+	listL1AmountsInRangeStmt                      *sql.Stmt
+	listL1DepositAmountsUpToBlockStmt             *sql.Stmt
+	listL1LogsInRangeStmt                         *sql.Stmt
+	listL1WithdrawalAmountsUpToBlockStmt          *sql.Stmt
+	listL2AmountsInRangeStmt                      *sql.Stmt
+	listL2BlocksWithoutOriginStmt                 *sql.Stmt
+	listL2LogsInRangeStmt                         *sql.Stmt
+	listLivenessIncidentsStmt                     *sql.Stmt
+	listMatchableHashesStmt                       *sql.Stmt
+	listRecentConfirmationSecondsStmt             *sql.Stmt
+	listSecurityFindingsStmt                      *sql.Stmt
+	listSolvencySamplesStmt                       *sql.Stmt
+	listSyncStatusSamplesStmt                     *sql.Stmt
+	listUnmatchedL1DepositsByHashStmt             *sql.Stmt
+	listUnmatchedL2FinalizationsByHashStmt        *sql.Stmt
+	listUnresolvedDisputeGamesStmt                *sql.Stmt
+	listUnverifiedDisputeGamesStmt                *sql.Stmt
+	recordL1MatchStmt                             *sql.Stmt
 	updateBlockPointerStmt                        *sql.Stmt
 	updateBlockPointerIfNullStmt                  *sql.Stmt
+	updateDisputeGameOutputRootStmt               *sql.Stmt
+	updateDisputeGameOutputRootErrorStmt          *sql.Stmt
+	updateDisputeGameStatusStmt                   *sql.Stmt
 	updateL1DepositWithMatchStmt                  *sql.Stmt
 	updateL2DepositWithMatchStmt                  *sql.Stmt
+	updateL2OriginStmt                            *sql.Stmt
+	updateL2OriginErrorStmt                       *sql.Stmt
+	updateLivenessIncidentWorstStmt               *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                            tx,
-		tx:                            tx,
-		findMatchingL1DepositsStmt:    q.findMatchingL1DepositsStmt,
-		findMatchingL2DepositsStmt:    q.findMatchingL2DepositsStmt,
-		getBlockPointerStmt:           q.getBlockPointerStmt,
-		getBridgeStatsStmt:            q.getBridgeStatsStmt,
-		getLatestL1BlockStmt:          q.getLatestL1BlockStmt,
-		getLatestL2BlockStmt:          q.getLatestL2BlockStmt,
-		getMatchedDepositsStmt:        q.getMatchedDepositsStmt,
-		getPendingDepositsStmt:        q.getPendingDepositsStmt,
-		getTimeSeriesChartDataStmt:    q.getTimeSeriesChartDataStmt,
-		getTotalMatchedDepositsStmt:   q.getTotalMatchedDepositsStmt,
-		getTotalUnmatchedDepositsStmt: q.getTotalUnmatchedDepositsStmt,
-		getUnmatchedDepositsStmt:      q.getUnmatchedDepositsStmt,
+		db:                                            tx,
+		tx:                                            tx,
+		clearL1MatchesStmt:                            q.clearL1MatchesStmt,
+		clearL1MatchesOfL2RangeStmt:                   q.clearL1MatchesOfL2RangeStmt,
+		clearL2MatchesStmt:                            q.clearL2MatchesStmt,
+		clearL2MatchesOfL1RangeStmt:                   q.clearL2MatchesOfL1RangeStmt,
+		closeLivenessIncidentStmt:                     q.closeLivenessIncidentStmt,
+		countDepositsInFlightStmt:                     q.countDepositsInFlightStmt,
+		countDisputeGamesStmt:                         q.countDisputeGamesStmt,
+		countIndexedEventsStmt:                        q.countIndexedEventsStmt,
+		countLivenessIncidentsStmt:                    q.countLivenessIncidentsStmt,
+		countSecurityFindingsStmt:                     q.countSecurityFindingsStmt,
+		deleteBlockPointerHashesAfterStmt:             q.deleteBlockPointerHashesAfterStmt,
+		deleteBlockPointerHashesBeforeStmt:            q.deleteBlockPointerHashesBeforeStmt,
+		deleteIndexedEventsInRangeStmt:                q.deleteIndexedEventsInRangeStmt,
+		deleteL1DepositsInRangeStmt:                   q.deleteL1DepositsInRangeStmt,
+		deleteL2FinalizationsInRangeStmt:              q.deleteL2FinalizationsInRangeStmt,
+		exportMatchedDepositsStmt:                     q.exportMatchedDepositsStmt,
+		exportUnmatchedL1DepositsStmt:                 q.exportUnmatchedL1DepositsStmt,
+		exportUnmatchedL2FinalizationsStmt:            q.exportUnmatchedL2FinalizationsStmt,
+		findInconsistentL1MatchesStmt:                 q.findInconsistentL1MatchesStmt,
+		findInconsistentL2MatchesStmt:                 q.findInconsistentL2MatchesStmt,
+		flagDisputeGameStmt:                           q.flagDisputeGameStmt,
+		getAmbiguousGroupsStmt:                        q.getAmbiguousGroupsStmt,
+		getBlockPointerStmt:                           q.getBlockPointerStmt,
+		getBridgeStatsStmt:                            q.getBridgeStatsStmt,
+		getDepositByIDStmt:                            q.getDepositByIDStmt,
+		getDepositCountsStmt:                          q.getDepositCountsStmt,
+		getDepositLatencyStmt:                         q.getDepositLatencyStmt,
+		getDepositTimingsStmt:                         q.getDepositTimingsStmt,
+		getDepositsByAddressStmt:                      q.getDepositsByAddressStmt,
+		getDepositsByTxHashStmt:                       q.getDepositsByTxHashStmt,
+		getDisputeGameStmt:                            q.getDisputeGameStmt,
+		getLatestBatcherTransactionStmt:               q.getLatestBatcherTransactionStmt,
+		getLatestChainHeadStmt:                        q.getLatestChainHeadStmt,
+		getLatestL1BlockStmt:                          q.getLatestL1BlockStmt,
+		getLatestL2BlockStmt:                          q.getLatestL2BlockStmt,
+		getLatestPauseFindingStmt:                     q.getLatestPauseFindingStmt,
+		getLatestSecurityFindingStmt:                  q.getLatestSecurityFindingStmt,
+		getLatestSolvencySampleStmt:                   q.getLatestSolvencySampleStmt,
+		getLatestSyncStatusSampleStmt:                 q.getLatestSyncStatusSampleStmt,
+		getLivenessIncidentStmt:                       q.getLivenessIncidentStmt,
+		getMatchedAmountsWeiStmt:                      q.getMatchedAmountsWeiStmt,
+		getMatchedDepositsStmt:                        q.getMatchedDepositsStmt,
+		getOpenLivenessIncidentStmt:                   q.getOpenLivenessIncidentStmt,
+		getOrphanedFinalizationsStmt:                  q.getOrphanedFinalizationsStmt,
+		getPendingDepositsStmt:                        q.getPendingDepositsStmt,
+		getTimeSeriesChartDataStmt:                    q.getTimeSeriesChartDataStmt,
+		getTotalAmbiguousGroupsStmt:                   q.getTotalAmbiguousGroupsStmt,
+		getTotalDepositsByAddressStmt:                 q.getTotalDepositsByAddressStmt,
+		getTotalMatchedDepositsStmt:                   q.getTotalMatchedDepositsStmt,
+		getTotalOrphanedFinalizationsStmt:             q.getTotalOrphanedFinalizationsStmt,
+		getTotalUnmatchedDepositsStmt:                 q.getTotalUnmatchedDepositsStmt,
+		getUnmatchedDepositsStmt:                      q.getUnmatchedDepositsStmt,
+		insertBatcherTransactionStmt:                  q.insertBatcherTransactionStmt,
+		insertBlockPointerStmt:                        q.insertBlockPointerStmt,
+		insertBlockPointerHashStmt:                    q.insertBlockPointerHashStmt,
+		insertChainHeadStmt:                           q.insertChainHeadStmt,
+		insertDisputeGameStmt:                         q.insertDisputeGameStmt,
+		insertIndexedEventStmt:                        q.insertIndexedEventStmt,
 		insertL1StandardBridgeETHDepositInitiatedStmt: q.insertL1StandardBridgeETHDepositInitiatedStmt,
+		insertL1WithdrawalFinalizedStmt:               q.insertL1WithdrawalFinalizedStmt,
 		insertL2StandardBridgeDepositFinalizedStmt:    q.insertL2StandardBridgeDepositFinalizedStmt,
+		insertLivenessIncidentStmt:                    q.insertLivenessIncidentStmt,
+		insertSecurityFindingStmt:                     q.insertSecurityFindingStmt,
+		insertSolvencySampleStmt:                      q.insertSolvencySampleStmt,
+		insertSyncStatusSampleStmt:                    q.insertSyncStatusSampleStmt,
+		listBatcherTransactionsStmt:                   q.listBatcherTransactionsStmt,
+		listBlockPointerHashesStmt:                    q.listBlockPointerHashesStmt,
+		listBlockPointersStmt:                         q.listBlockPointersStmt,
+		listCriticalDisputeGamesStmt:                  q.listCriticalDisputeGamesStmt,
+		listDepositLatenciesStmt:                      q.listDepositLatenciesStmt,
+		listDepositsInFlightStmt:                      q.listDepositsInFlightStmt,
+		listDisputeGamesStmt:                          q.listDisputeGamesStmt,
+		listIndexedEventSourcesStmt:                   q.listIndexedEventSourcesStmt,
+		listIndexedEventsStmt:                         q.listIndexedEventsStmt,
+		listL1AmountsInRangeStmt:                      q.listL1AmountsInRangeStmt,
+		listL1DepositAmountsUpToBlockStmt:             q.listL1DepositAmountsUpToBlockStmt,
+		listL1LogsInRangeStmt:                         q.listL1LogsInRangeStmt,
+		listL1WithdrawalAmountsUpToBlockStmt:          q.listL1WithdrawalAmountsUpToBlockStmt,
+		listL2AmountsInRangeStmt:                      q.listL2AmountsInRangeStmt,
+		listL2BlocksWithoutOriginStmt:                 q.listL2BlocksWithoutOriginStmt,
+		listL2LogsInRangeStmt:                         q.listL2LogsInRangeStmt,
+		listLivenessIncidentsStmt:                     q.listLivenessIncidentsStmt,
+		listMatchableHashesStmt:                       q.listMatchableHashesStmt,
+		listRecentConfirmationSecondsStmt:             q.listRecentConfirmationSecondsStmt,
+		listSecurityFindingsStmt:                      q.listSecurityFindingsStmt,
+		listSolvencySamplesStmt:                       q.listSolvencySamplesStmt,
+		listSyncStatusSamplesStmt:                     q.listSyncStatusSamplesStmt,
+		listUnmatchedL1DepositsByHashStmt:             q.listUnmatchedL1DepositsByHashStmt,
+		listUnmatchedL2FinalizationsByHashStmt:        q.listUnmatchedL2FinalizationsByHashStmt,
+		listUnresolvedDisputeGamesStmt:                q.listUnresolvedDisputeGamesStmt,
+		listUnverifiedDisputeGamesStmt:                q.listUnverifiedDisputeGamesStmt,
+		recordL1MatchStmt:                             q.recordL1MatchStmt,
 		updateBlockPointerStmt:                        q.updateBlockPointerStmt,
 		updateBlockPointerIfNullStmt:                  q.updateBlockPointerIfNullStmt,
+		updateDisputeGameOutputRootStmt:               q.updateDisputeGameOutputRootStmt,
+		updateDisputeGameOutputRootErrorStmt:          q.updateDisputeGameOutputRootErrorStmt,
+		updateDisputeGameStatusStmt:                   q.updateDisputeGameStatusStmt,
 		updateL1DepositWithMatchStmt:                  q.updateL1DepositWithMatchStmt,
 		updateL2DepositWithMatchStmt:                  q.updateL2DepositWithMatchStmt,
+		updateL2OriginStmt:                            q.updateL2OriginStmt,
+		updateL2OriginErrorStmt:                       q.updateL2OriginErrorStmt,
+		updateLivenessIncidentWorstStmt:               q.updateLivenessIncidentWorstStmt,
 	}
 }
@@ -0,0 +1,2 @@
+ALTER TABLE l1_standard_bridge_eth_deposit_initiated DROP COLUMN amount_wei;
+ALTER TABLE l2_standard_bridge_deposit_finalized DROP COLUMN amount_wei;
@@ -0,0 +1,14 @@
+-- Exact deposit amounts as 32-byte big-endian uint256 values. The REAL amount
+-- column is kept for display and aggregation in ETH.
+ALTER TABLE l1_standard_bridge_eth_deposit_initiated ADD COLUMN amount_wei BLOB;
+ALTER TABLE l2_standard_bridge_deposit_finalized ADD COLUMN amount_wei BLOB;
+
+-- ETHDepositInitiated data is (uint256 amount, bytes extraData)
+UPDATE l1_standard_bridge_eth_deposit_initiated
+SET amount_wei = unhex(substr(json_extract(CAST(event AS TEXT), '$.data'), 3, 64))
+WHERE amount_wei IS NULL;
+
+-- DepositFinalized data is (address to, uint256 amount, bytes extraData)
+UPDATE l2_standard_bridge_deposit_finalized
+SET amount_wei = unhex(substr(json_extract(CAST(event AS TEXT), '$.data'), 67, 64))
+WHERE amount_wei IS NULL;
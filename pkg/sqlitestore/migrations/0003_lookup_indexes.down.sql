@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_l1_standard_bridge_eth_deposit_initiated_tx_hash;
+DROP INDEX IF EXISTS idx_l1_standard_bridge_eth_deposit_initiated_from_address;
+DROP INDEX IF EXISTS idx_l1_standard_bridge_eth_deposit_initiated_to_address;
+
+DROP INDEX IF EXISTS idx_l2_standard_bridge_deposit_finalized_tx_hash;
+DROP INDEX IF EXISTS idx_l2_standard_bridge_deposit_finalized_from_address;
+DROP INDEX IF EXISTS idx_l2_standard_bridge_deposit_finalized_to_address;
@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS idx_l1_standard_bridge_eth_deposit_initiated_tx_hash ON l1_standard_bridge_eth_deposit_initiated(tx_hash);
+CREATE INDEX IF NOT EXISTS idx_l1_standard_bridge_eth_deposit_initiated_from_address ON l1_standard_bridge_eth_deposit_initiated(from_address);
+CREATE INDEX IF NOT EXISTS idx_l1_standard_bridge_eth_deposit_initiated_to_address ON l1_standard_bridge_eth_deposit_initiated(to_address);
+
+CREATE INDEX IF NOT EXISTS idx_l2_standard_bridge_deposit_finalized_tx_hash ON l2_standard_bridge_deposit_finalized(tx_hash);
+CREATE INDEX IF NOT EXISTS idx_l2_standard_bridge_deposit_finalized_from_address ON l2_standard_bridge_deposit_finalized(from_address);
+CREATE INDEX IF NOT EXISTS idx_l2_standard_bridge_deposit_finalized_to_address ON l2_standard_bridge_deposit_finalized(to_address);
@@ -0,0 +1,3 @@
+ALTER TABLE l1_standard_bridge_eth_deposit_initiated DROP COLUMN match_method;
+ALTER TABLE l1_standard_bridge_eth_deposit_initiated DROP COLUMN match_candidates;
+ALTER TABLE l1_standard_bridge_eth_deposit_initiated DROP COLUMN match_confidence;
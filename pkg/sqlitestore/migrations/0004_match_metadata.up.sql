@@ -0,0 +1,13 @@
+-- How each match was made by the matching engine, stored on the L1 side of
+-- the match. The method is "unique" when a single candidate was eligible and
+-- "fifo" when the earliest of several candidates was picked, the confidence
+-- is 1 divided by the number of candidates.
+ALTER TABLE l1_standard_bridge_eth_deposit_initiated ADD COLUMN match_method TEXT;
+ALTER TABLE l1_standard_bridge_eth_deposit_initiated ADD COLUMN match_candidates INTEGER;
+ALTER TABLE l1_standard_bridge_eth_deposit_initiated ADD COLUMN match_confidence REAL;
+
+-- Matches made before the matching engine existed, `bridgette rematch`
+-- recomputes them with their metadata
+UPDATE l1_standard_bridge_eth_deposit_initiated
+SET match_method = 'legacy'
+WHERE matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL;
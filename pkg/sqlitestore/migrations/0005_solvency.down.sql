@@ -0,0 +1,3 @@
+DELETE FROM BLOCK_POINTERS WHERE name = 'l1_standard_bridge_eth_withdrawal_finalized_last_processed_block';
+DROP TABLE IF EXISTS solvency_samples;
+DROP TABLE IF EXISTS l1_standard_bridge_eth_withdrawal_finalized;
@@ -0,0 +1,33 @@
+-- ETH withdrawals paid out by the L1 bridge, scanned by the solvency monitor
+-- over the same blocks as the L1 deposits
+CREATE TABLE IF NOT EXISTS l1_standard_bridge_eth_withdrawal_finalized (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    block_number UNSIGNED BIG INT NOT NULL,
+    tx_hash BLOB NOT NULL,
+    log_index INTEGER NOT NULL,
+    from_address BLOB NOT NULL,
+    to_address BLOB NOT NULL,
+    amount_wei BLOB NOT NULL,
+    UNIQUE (tx_hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_l1_standard_bridge_eth_withdrawal_finalized_block_number ON l1_standard_bridge_eth_withdrawal_finalized(block_number);
+
+-- The ETH held by the bridge contracts at an L1 block, compared with the
+-- deposits minus the withdrawals stored up to that block. Amounts are 32-byte
+-- big-endian wei, the gap is the balance minus the expected balance and is
+-- negative when the bridge holds less than it should.
+CREATE TABLE IF NOT EXISTS solvency_samples (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    block_number UNSIGNED BIG INT NOT NULL UNIQUE,
+    block_time UNSIGNED BIG INT NOT NULL,
+    balance_wei BLOB NOT NULL,
+    deposited_wei BLOB NOT NULL,
+    withdrawn_wei BLOB NOT NULL,
+    gap_eth REAL NOT NULL,
+    alerting BOOLEAN NOT NULL
+);
+
+INSERT OR IGNORE INTO BLOCK_POINTERS (name, block_number, block_time) VALUES ('l1_standard_bridge_eth_withdrawal_finalized_last_processed_block', NULL, NULL);
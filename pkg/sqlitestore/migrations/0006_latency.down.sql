@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_l2_standard_bridge_deposit_finalized_l1_origin_number;
+ALTER TABLE l2_standard_bridge_deposit_finalized DROP COLUMN l1_origin_timestamp;
+ALTER TABLE l2_standard_bridge_deposit_finalized DROP COLUMN l1_origin_number;
+DROP TABLE IF EXISTS chain_heads;
@@ -0,0 +1,23 @@
+-- Safe and finalized heads of both chains, recorded by the latency tracker
+-- each time they advance. The blocks after previous_block_number up to
+-- block_number became safe or finalized between the previous observation and
+-- observed_at. previous_block_number is NULL for the first observation of a
+-- tracker run, as the head may have advanced while nobody was watching.
+CREATE TABLE IF NOT EXISTS chain_heads (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    chain TEXT NOT NULL,
+    label TEXT NOT NULL,
+    block_number UNSIGNED BIG INT NOT NULL,
+    block_time UNSIGNED BIG INT NOT NULL,
+    previous_block_number UNSIGNED BIG INT,
+    observed_at UNSIGNED BIG INT NOT NULL,
+    UNIQUE (chain, label, block_number)
+);
+
+-- The L1 origin of the L2 block that finalized a deposit, read from the L1
+-- attributes transaction of the block
+ALTER TABLE l2_standard_bridge_deposit_finalized ADD COLUMN l1_origin_number INTEGER;
+ALTER TABLE l2_standard_bridge_deposit_finalized ADD COLUMN l1_origin_timestamp INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_l2_standard_bridge_deposit_finalized_l1_origin_number ON l2_standard_bridge_deposit_finalized(l1_origin_number);
@@ -0,0 +1,17 @@
+-- Periods during which the liveness monitor saw L2 misbehave. ended_at is
+-- NULL while the incident is ongoing. worst is the worst value measured
+-- during the incident, in seconds or L1 blocks depending on the kind, and
+-- detail describes the measurement it was taken from.
+CREATE TABLE IF NOT EXISTS liveness_incidents (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    kind TEXT NOT NULL,
+    started_at UNSIGNED BIG INT NOT NULL,
+    ended_at UNSIGNED BIG INT,
+    l2_block_number UNSIGNED BIG INT NOT NULL,
+    worst INTEGER NOT NULL,
+    detail TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_liveness_incidents_started_at ON liveness_incidents(started_at);
+CREATE INDEX IF NOT EXISTS idx_liveness_incidents_kind_ended_at ON liveness_incidents(kind, ended_at);
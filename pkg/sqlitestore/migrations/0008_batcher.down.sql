@@ -0,0 +1,3 @@
+DELETE FROM BLOCK_POINTERS WHERE name = 'batcher_transactions_last_processed_block';
+DROP TABLE IF EXISTS sync_status_samples;
+DROP TABLE IF EXISTS batcher_transactions;
@@ -0,0 +1,35 @@
+-- Batcher transactions sent to the batch inbox on L1, scanned by the batcher
+-- monitor. data_size is the calldata size, or the size of the blobs for blob
+-- transactions.
+CREATE TABLE IF NOT EXISTS batcher_transactions (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    block_number UNSIGNED BIG INT NOT NULL,
+    block_time UNSIGNED BIG INT NOT NULL,
+    tx_hash BLOB NOT NULL UNIQUE,
+    blob BOOLEAN NOT NULL,
+    blob_count INTEGER NOT NULL,
+    data_size INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_batcher_transactions_block_time ON batcher_transactions(block_time);
+
+-- The sync status of the rollup node, sampled by the batcher monitor. The
+-- unsafe L2 head is sequenced, the safe head derived from batches on L1.
+CREATE TABLE IF NOT EXISTS sync_status_samples (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    observed_at UNSIGNED BIG INT NOT NULL,
+    current_l1_number UNSIGNED BIG INT NOT NULL,
+    head_l1_number UNSIGNED BIG INT NOT NULL,
+    unsafe_l2_number UNSIGNED BIG INT NOT NULL,
+    unsafe_l2_time UNSIGNED BIG INT NOT NULL,
+    safe_l2_number UNSIGNED BIG INT NOT NULL,
+    safe_l2_time UNSIGNED BIG INT NOT NULL,
+    finalized_l2_number UNSIGNED BIG INT NOT NULL,
+    finalized_l2_time UNSIGNED BIG INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_status_samples_observed_at ON sync_status_samples(observed_at);
+
+INSERT OR IGNORE INTO BLOCK_POINTERS (name, block_number, block_time) VALUES ('batcher_transactions_last_processed_block', NULL, NULL);
@@ -0,0 +1,2 @@
+DELETE FROM BLOCK_POINTERS WHERE name = 'dispute_games_last_processed_block';
+DROP TABLE IF EXISTS dispute_games;
@@ -0,0 +1,27 @@
+-- Dispute games created by the DisputeGameFactory on L1. status is the
+-- GameStatus of the game (0 in progress, 1 challenger wins, 2 defender wins).
+-- expected_root is the output root computed from L2 at l2_block_number and
+-- valid whether the root claim matches it, both are NULL until L2 reached the
+-- block. critical_reason is set when the claim is invalid or the game resolved
+-- against the honest output.
+CREATE TABLE IF NOT EXISTS dispute_games (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    game_address BLOB NOT NULL UNIQUE,
+    game_type INTEGER NOT NULL,
+    root_claim BLOB NOT NULL,
+    l2_block_number UNSIGNED BIG INT NOT NULL,
+    block_number UNSIGNED BIG INT NOT NULL,
+    block_time UNSIGNED BIG INT NOT NULL,
+    tx_hash BLOB NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0,
+    resolved_at UNSIGNED BIG INT,
+    expected_root BLOB,
+    valid BOOLEAN,
+    critical_reason TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_dispute_games_block_number ON dispute_games(block_number);
+CREATE INDEX IF NOT EXISTS idx_dispute_games_status ON dispute_games(status);
+
+INSERT OR IGNORE INTO BLOCK_POINTERS (name, block_number, block_time) VALUES ('dispute_games_last_processed_block', NULL, NULL);
@@ -0,0 +1,2 @@
+DELETE FROM BLOCK_POINTERS WHERE name = 'security_events_last_processed_block';
+DROP TABLE IF EXISTS security_findings;
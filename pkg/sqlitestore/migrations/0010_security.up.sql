@@ -0,0 +1,25 @@
+-- Audit trail of the security relevant changes to the bridge contracts on L1:
+-- proxy upgrades, admin and ownership changes, pauses and configuration
+-- updates from events, and the EIP-1967 slots read by the security monitor.
+-- tx_hash and log_index are NULL for findings from slot and paused() reads.
+-- value is the new implementation, admin or owner address when there is one.
+CREATE TABLE IF NOT EXISTS security_findings (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    observed_at UNSIGNED BIG INT NOT NULL,
+    block_number UNSIGNED BIG INT NOT NULL,
+    tx_hash BLOB,
+    log_index INTEGER,
+    contract BLOB NOT NULL,
+    contract_name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    severity TEXT NOT NULL,
+    value BLOB,
+    detail TEXT NOT NULL,
+    UNIQUE(tx_hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_findings_observed_at ON security_findings(observed_at);
+CREATE INDEX IF NOT EXISTS idx_security_findings_contract_kind ON security_findings(contract, kind);
+
+INSERT OR IGNORE INTO BLOCK_POINTERS (name, block_number, block_time) VALUES ('security_events_last_processed_block', NULL, NULL);
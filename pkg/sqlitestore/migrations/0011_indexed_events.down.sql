@@ -0,0 +1,2 @@
+DELETE FROM BLOCK_POINTERS WHERE name LIKE 'indexed_events_%';
+DROP TABLE IF EXISTS indexed_events;
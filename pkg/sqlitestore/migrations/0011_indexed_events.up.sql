@@ -0,0 +1,20 @@
+-- Events of arbitrary contracts indexed from the event sources config. source
+-- is the name of the config entry, args the decoded arguments as a JSON
+-- object. The block pointers of each source are inserted when it is first
+-- indexed.
+CREATE TABLE IF NOT EXISTS indexed_events (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    source TEXT NOT NULL,
+    chain TEXT NOT NULL,
+    contract BLOB NOT NULL,
+    event_name TEXT NOT NULL,
+    block_number UNSIGNED BIG INT NOT NULL,
+    block_timestamp UNSIGNED BIG INT NOT NULL,
+    tx_hash BLOB NOT NULL,
+    log_index INTEGER NOT NULL,
+    args TEXT NOT NULL,
+    UNIQUE(source, tx_hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_indexed_events_source_block_number ON indexed_events(source, block_number);
@@ -0,0 +1 @@
+ALTER TABLE l2_standard_bridge_deposit_finalized DROP COLUMN l1_origin_error;
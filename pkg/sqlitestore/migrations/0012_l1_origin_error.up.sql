@@ -0,0 +1,4 @@
+-- Why the L1 origin of an L2 block could not be read, set when its L1
+-- attributes transaction is missing or cannot be decoded so that the block
+-- is not read again
+ALTER TABLE l2_standard_bridge_deposit_finalized ADD COLUMN l1_origin_error TEXT;
@@ -0,0 +1,9 @@
+-- Hashes of the blocks a last processed block pointer moved to, kept for the
+-- latest blocks only. A forward batch compares them with the chain to find
+-- the block a reorg forked at.
+CREATE TABLE IF NOT EXISTS block_pointer_hashes (
+    pointer TEXT NOT NULL,
+    block_number UNSIGNED BIG INT NOT NULL,
+    block_hash BLOB NOT NULL,
+    PRIMARY KEY (pointer, block_number)
+);
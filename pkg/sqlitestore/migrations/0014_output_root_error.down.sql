@@ -0,0 +1 @@
+ALTER TABLE dispute_games DROP COLUMN output_root_error;
@@ -0,0 +1,4 @@
+-- Why the output root of the L2 block of a dispute game could not be
+-- computed, set when the L2 node no longer has its state so that the game is
+-- not verified again and does not hold back newer games
+ALTER TABLE dispute_games ADD COLUMN output_root_error TEXT;
@@ -14,6 +14,66 @@ type BLOCKPOINTER struct {
 	BlockTime   *int64
 }
 
+type BlockPointerHash struct {
+	Pointer     string
+	BlockNumber int64
+	BlockHash   []byte
+}
+
+type BatcherTransaction struct {
+	ID          int64
+	CreatedAt   *time.Time
+	BlockNumber int64
+	BlockTime   int64
+	TxHash      []byte
+	Blob        bool
+	BlobCount   int64
+	DataSize    int64
+}
+
+type ChainHead struct {
+	ID                  int64
+	CreatedAt           *time.Time
+	Chain               string
+	Label               string
+	BlockNumber         int64
+	BlockTime           int64
+	PreviousBlockNumber *int64
+	ObservedAt          int64
+}
+
+type DisputeGame struct {
+	ID              int64
+	CreatedAt       *time.Time
+	GameAddress     []byte
+	GameType        int64
+	RootClaim       []byte
+	L2BlockNumber   int64
+	BlockNumber     int64
+	BlockTime       int64
+	TxHash          []byte
+	Status          int64
+	ResolvedAt      *int64
+	ExpectedRoot    []byte
+	Valid           *bool
+	CriticalReason  *string
+	OutputRootError *string
+}
+
+type IndexedEvent struct {
+	ID             int64
+	CreatedAt      *time.Time
+	Source         string
+	Chain          string
+	Contract       []byte
+	EventName      string
+	BlockNumber    int64
+	BlockTimestamp int64
+	TxHash         []byte
+	LogIndex       int64
+	Args           string
+}
+
 type L1StandardBridgeEthDepositInitiated struct {
 	ID                                        int64
 	CreatedAt                                 *time.Time
@@ -27,6 +87,21 @@ type L1StandardBridgeEthDepositInitiated struct {
 	Event                                     []byte
 	MatchingHash                              []byte
 	MatchedL2StandardBridgeDepositFinalizedID *int64
+	AmountWei                                 []byte
+	MatchMethod                               *string
+	MatchCandidates                           *int64
+	MatchConfidence                           *float64
+}
+
+type L1StandardBridgeEthWithdrawalFinalized struct {
+	ID          int64
+	CreatedAt   *time.Time
+	BlockNumber int64
+	TxHash      []byte
+	LogIndex    int64
+	FromAddress []byte
+	ToAddress   []byte
+	AmountWei   []byte
 }
 
 type L2StandardBridgeDepositFinalized struct {
@@ -43,4 +118,60 @@ type L2StandardBridgeDepositFinalized struct {
 	Event                                        []byte
 	MatchingHash                                 []byte
 	MatchedL1StandardBridgeEthDepositInitiatedID *int64
+	AmountWei                                    []byte
+	L1OriginNumber                               *int64
+	L1OriginTimestamp                            *int64
+	L1OriginError                                *string
+}
+
+type LivenessIncident struct {
+	ID            int64
+	CreatedAt     *time.Time
+	Kind          string
+	StartedAt     int64
+	EndedAt       *int64
+	L2BlockNumber int64
+	Worst         int64
+	Detail        string
+}
+
+type SecurityFinding struct {
+	ID           int64
+	CreatedAt    *time.Time
+	ObservedAt   int64
+	BlockNumber  int64
+	TxHash       []byte
+	LogIndex     *int64
+	Contract     []byte
+	ContractName string
+	Kind         string
+	Severity     string
+	Value        []byte
+	Detail       string
+}
+
+type SolvencySample struct {
+	ID           int64
+	CreatedAt    *time.Time
+	BlockNumber  int64
+	BlockTime    int64
+	BalanceWei   []byte
+	DepositedWei []byte
+	WithdrawnWei []byte
+	GapEth       float64
+	Alerting     bool
+}
+
+type SyncStatusSample struct {
+	ID                int64
+	CreatedAt         *time.Time
+	ObservedAt        int64
+	CurrentL1Number   int64
+	HeadL1Number      int64
+	UnsafeL2Number    int64
+	UnsafeL2Time      int64
+	SafeL2Number      int64
+	SafeL2Time        int64
+	FinalizedL2Number int64
+	FinalizedL2Time   int64
 }
@@ -6,6 +6,7 @@ INSERT INTO l1_standard_bridge_eth_deposit_initiated (
     from_address,
     to_address,
     amount,
+    amount_wei,
     event,
     matching_hash
 ) VALUES (
@@ -16,6 +17,7 @@ INSERT INTO l1_standard_bridge_eth_deposit_initiated (
     ?,
     ?,
     ?,
+    ?,
     ?
 ) RETURNING id;
 
@@ -37,6 +39,7 @@ INSERT INTO l2_standard_bridge_deposit_finalized (
     to_address,
     l1_token,
     amount,
+    amount_wei,
     event,
     matching_hash
 ) VALUES (
@@ -48,22 +51,10 @@ INSERT INTO l2_standard_bridge_deposit_finalized (
     ?,
     ?,
     ?,
+    ?,
     ?
 ) RETURNING id;
 
--- name: FindMatchingL2Deposits :many
-SELECT 
-    id,
-    block_timestamp,
-    matching_hash
-FROM l2_standard_bridge_deposit_finalized
-WHERE 
-    matching_hash = ? AND
-    block_timestamp >= ? AND
-    matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL
-ORDER BY block_timestamp ASC
-LIMIT 1;
-
 -- name: UpdateL2DepositWithMatch :exec
 UPDATE l2_standard_bridge_deposit_finalized
 SET 
@@ -78,51 +69,82 @@ SET
     updated_at = CURRENT_TIMESTAMP
 WHERE id = ?;
 
--- name: FindMatchingL1Deposits :many
+-- Web UI Queries
+
+-- name: GetMatchedDeposits :many
 SELECT 
     id,
-    block_timestamp,
-    matching_hash
-FROM l1_standard_bridge_eth_deposit_initiated
+    from_address,
+    to_address,
+    amount,
+    amount_wei,
+    l1_block_number,
+    l2_block_number,
+    l1_timestamp,
+    l2_timestamp,
+    time_diff_seconds,
+    tx_hash_l1,
+    tx_hash_l2,
+    sort_key
+FROM (
+    SELECT 
+        l1.id,
+        l1.from_address,
+        l1.to_address,
+        l1.amount,
+        l1.amount_wei,
+        l1.block_number as l1_block_number,
+        l2.block_number as l2_block_number,
+        l1.block_timestamp as l1_timestamp,
+        l2.block_timestamp as l2_timestamp,
+        (l2.block_timestamp - l1.block_timestamp) as time_diff_seconds,
+        l1.tx_hash as tx_hash_l1,
+        l2.tx_hash as tx_hash_l2,
+        CASE sqlc.arg(sort)
+            WHEN 'largest' THEN l1.amount_wei
+            WHEN 'slowest' THEN l2.block_timestamp - l1.block_timestamp
+            ELSE l1.block_timestamp
+        END as sort_key
+    FROM 
+        l1_standard_bridge_eth_deposit_initiated l1
+    JOIN 
+        l2_standard_bridge_deposit_finalized l2 
+    ON 
+        l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+    WHERE 
+        (sqlc.narg(address) IS NULL OR l1.from_address = sqlc.narg(address) OR l1.to_address = sqlc.narg(address)) AND
+        (sqlc.narg(since) IS NULL OR l1.block_timestamp >= sqlc.narg(since)) AND
+        (sqlc.narg(until) IS NULL OR l1.block_timestamp < sqlc.narg(until)) AND
+        (sqlc.narg(min_amount_wei) IS NULL OR l1.amount_wei >= sqlc.narg(min_amount_wei)) AND
+        (sqlc.narg(max_amount_wei) IS NULL OR l1.amount_wei <= sqlc.narg(max_amount_wei)) AND
+        (sqlc.narg(min_time_diff) IS NULL OR l2.block_timestamp - l1.block_timestamp >= sqlc.narg(min_time_diff)) AND
+        (sqlc.narg(max_time_diff) IS NULL OR l2.block_timestamp - l1.block_timestamp <= sqlc.narg(max_time_diff))
+)
 WHERE 
-    matching_hash = ? AND
-    block_timestamp <= ? AND
-    matched_l2_standard_bridge_deposit_finalized_id IS NULL
-ORDER BY block_timestamp DESC
-LIMIT 1;
-
--- Web UI Queries
+    sqlc.narg(cursor_id) IS NULL OR
+    sort_key < sqlc.narg(cursor_key) OR
+    (sort_key = sqlc.narg(cursor_key) AND id < sqlc.narg(cursor_id))
+ORDER BY 
+    sort_key DESC, id DESC
+LIMIT sqlc.arg(limit);
 
--- name: GetMatchedDeposits :many
+-- name: GetTotalMatchedDeposits :one
 SELECT 
-    l1.id,
-    l1.from_address,
-    l1.to_address,
-    l1.amount,
-    l1.block_number as l1_block_number,
-    l2.block_number as l2_block_number,
-    l1.block_timestamp as l1_timestamp,
-    l2.block_timestamp as l2_timestamp,
-    (l2.block_timestamp - l1.block_timestamp) as time_diff_seconds,
-    l1.tx_hash as tx_hash_l1,
-    l2.tx_hash as tx_hash_l2
+    COUNT(*)
 FROM 
     l1_standard_bridge_eth_deposit_initiated l1
 JOIN 
     l2_standard_bridge_deposit_finalized l2 
 ON 
     l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
-ORDER BY 
-    l1.block_timestamp DESC
-LIMIT ? OFFSET ?;
-
--- name: GetTotalMatchedDeposits :one
-SELECT 
-    COUNT(*)
-FROM 
-    l1_standard_bridge_eth_deposit_initiated
 WHERE 
-    matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL;
+    (sqlc.narg(address) IS NULL OR l1.from_address = sqlc.narg(address) OR l1.to_address = sqlc.narg(address)) AND
+    (sqlc.narg(since) IS NULL OR l1.block_timestamp >= sqlc.narg(since)) AND
+    (sqlc.narg(until) IS NULL OR l1.block_timestamp < sqlc.narg(until)) AND
+    (sqlc.narg(min_amount_wei) IS NULL OR l1.amount_wei >= sqlc.narg(min_amount_wei)) AND
+    (sqlc.narg(max_amount_wei) IS NULL OR l1.amount_wei <= sqlc.narg(max_amount_wei)) AND
+    (sqlc.narg(min_time_diff) IS NULL OR l2.block_timestamp - l1.block_timestamp >= sqlc.narg(min_time_diff)) AND
+    (sqlc.narg(max_time_diff) IS NULL OR l2.block_timestamp - l1.block_timestamp <= sqlc.narg(max_time_diff));
 
 -- name: GetTimeSeriesChartData :many
 SELECT 
@@ -186,23 +208,1164 @@ SELECT
     from_address,
     to_address,
     amount,
-    block_number as l1_block_number,
-    block_timestamp as l1_timestamp,
-    tx_hash as tx_hash_l1,
-    (strftime('%s', 'now') - block_timestamp) as time_since_seconds
+    amount_wei,
+    l1_block_number,
+    l1_timestamp,
+    tx_hash_l1,
+    time_since_seconds,
+    sort_key
+FROM (
+    SELECT 
+        id,
+        from_address,
+        to_address,
+        amount,
+        amount_wei,
+        block_number as l1_block_number,
+        block_timestamp as l1_timestamp,
+        tx_hash as tx_hash_l1,
+        (strftime('%s', 'now') - block_timestamp) as time_since_seconds,
+        CASE sqlc.arg(sort)
+            WHEN 'largest' THEN amount_wei
+            WHEN 'slowest' THEN -block_timestamp
+            ELSE block_timestamp
+        END as sort_key
+    FROM 
+        l1_standard_bridge_eth_deposit_initiated
+    WHERE 
+        matched_l2_standard_bridge_deposit_finalized_id IS NULL AND
+        (sqlc.narg(address) IS NULL OR from_address = sqlc.narg(address) OR to_address = sqlc.narg(address)) AND
+        (sqlc.narg(since) IS NULL OR block_timestamp >= sqlc.narg(since)) AND
+        (sqlc.narg(until) IS NULL OR block_timestamp < sqlc.narg(until)) AND
+        (sqlc.narg(min_amount_wei) IS NULL OR amount_wei >= sqlc.narg(min_amount_wei)) AND
+        (sqlc.narg(max_amount_wei) IS NULL OR amount_wei <= sqlc.narg(max_amount_wei))
+)
+WHERE 
+    sqlc.narg(cursor_id) IS NULL OR
+    sort_key < sqlc.narg(cursor_key) OR
+    (sort_key = sqlc.narg(cursor_key) AND id < sqlc.narg(cursor_id))
+ORDER BY 
+    sort_key DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: GetTotalUnmatchedDeposits :one
+SELECT 
+    COUNT(*) 
 FROM 
     l1_standard_bridge_eth_deposit_initiated
 WHERE 
-    matched_l2_standard_bridge_deposit_finalized_id IS NULL
+    matched_l2_standard_bridge_deposit_finalized_id IS NULL AND
+    (sqlc.narg(address) IS NULL OR from_address = sqlc.narg(address) OR to_address = sqlc.narg(address)) AND
+    (sqlc.narg(since) IS NULL OR block_timestamp >= sqlc.narg(since)) AND
+    (sqlc.narg(until) IS NULL OR block_timestamp < sqlc.narg(until)) AND
+    (sqlc.narg(min_amount_wei) IS NULL OR amount_wei >= sqlc.narg(min_amount_wei)) AND
+    (sqlc.narg(max_amount_wei) IS NULL OR amount_wei <= sqlc.narg(max_amount_wei));
+
+-- name: GetOrphanedFinalizations :many
+SELECT 
+    id,
+    from_address,
+    to_address,
+    amount,
+    amount_wei,
+    l2_block_number,
+    l2_timestamp,
+    tx_hash_l2,
+    time_since_seconds,
+    sort_key
+FROM (
+    SELECT 
+        id,
+        from_address,
+        to_address,
+        amount,
+        amount_wei,
+        block_number as l2_block_number,
+        block_timestamp as l2_timestamp,
+        tx_hash as tx_hash_l2,
+        (strftime('%s', 'now') - block_timestamp) as time_since_seconds,
+        CASE sqlc.arg(sort)
+            WHEN 'largest' THEN amount_wei
+            WHEN 'slowest' THEN -block_timestamp
+            ELSE block_timestamp
+        END as sort_key
+    FROM 
+        l2_standard_bridge_deposit_finalized
+    WHERE 
+        matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL AND
+        (sqlc.narg(address) IS NULL OR from_address = sqlc.narg(address) OR to_address = sqlc.narg(address)) AND
+        (sqlc.narg(since) IS NULL OR block_timestamp >= sqlc.narg(since)) AND
+        (sqlc.narg(until) IS NULL OR block_timestamp < sqlc.narg(until)) AND
+        (sqlc.narg(min_amount_wei) IS NULL OR amount_wei >= sqlc.narg(min_amount_wei)) AND
+        (sqlc.narg(max_amount_wei) IS NULL OR amount_wei <= sqlc.narg(max_amount_wei))
+)
+WHERE 
+    sqlc.narg(cursor_id) IS NULL OR
+    sort_key < sqlc.narg(cursor_key) OR
+    (sort_key = sqlc.narg(cursor_key) AND id < sqlc.narg(cursor_id))
 ORDER BY 
-    block_timestamp DESC
-LIMIT ? OFFSET ?;
+    sort_key DESC, id DESC
+LIMIT sqlc.arg(limit);
 
--- name: GetTotalUnmatchedDeposits :one
+-- name: GetTotalOrphanedFinalizations :one
 SELECT 
     COUNT(*) 
+FROM 
+    l2_standard_bridge_deposit_finalized
+WHERE 
+    matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL AND
+    (sqlc.narg(address) IS NULL OR from_address = sqlc.narg(address) OR to_address = sqlc.narg(address)) AND
+    (sqlc.narg(since) IS NULL OR block_timestamp >= sqlc.narg(since)) AND
+    (sqlc.narg(until) IS NULL OR block_timestamp < sqlc.narg(until)) AND
+    (sqlc.narg(min_amount_wei) IS NULL OR amount_wei >= sqlc.narg(min_amount_wei)) AND
+    (sqlc.narg(max_amount_wei) IS NULL OR amount_wei <= sqlc.narg(max_amount_wei));
+
+-- name: ListL1AmountsInRange :many
+SELECT 
+    block_timestamp,
+    amount_wei,
+    matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL as matched
 FROM 
     l1_standard_bridge_eth_deposit_initiated
 WHERE 
-    matched_l2_standard_bridge_deposit_finalized_id IS NULL;
+    block_timestamp >= sqlc.arg(since) AND block_timestamp < sqlc.arg(until);
+
+-- name: ListL2AmountsInRange :many
+SELECT 
+    block_timestamp,
+    amount_wei,
+    matched_l1_standard_bridge_eth_deposit_initiated_id IS NOT NULL as matched
+FROM 
+    l2_standard_bridge_deposit_finalized
+WHERE 
+    block_timestamp >= sqlc.arg(since) AND block_timestamp < sqlc.arg(until);
+
+-- API Queries
+
+-- name: GetDepositByID :one
+SELECT 
+    l1.id,
+    l1.from_address,
+    l1.to_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.tx_hash as tx_hash_l2
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.id = ?;
+
+-- name: GetDepositsByTxHash :many
+SELECT 
+    l1.id,
+    l1.from_address,
+    l1.to_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.tx_hash as tx_hash_l2
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.tx_hash = sqlc.arg(tx_hash) OR
+    l1.matched_l2_standard_bridge_deposit_finalized_id IN (
+        SELECT id FROM l2_standard_bridge_deposit_finalized WHERE tx_hash = sqlc.arg(tx_hash)
+    )
+ORDER BY 
+    l1.id ASC;
+
+-- name: GetDepositsByAddress :many
+SELECT 
+    l1.id,
+    l1.from_address,
+    l1.to_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.tx_hash as tx_hash_l2
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    (l1.from_address = sqlc.arg(address) OR l1.to_address = sqlc.arg(address)) AND
+    (sqlc.narg(cursor_id) IS NULL OR
+    l1.block_timestamp < sqlc.narg(cursor_key) OR
+    (l1.block_timestamp = sqlc.narg(cursor_key) AND l1.id < sqlc.narg(cursor_id)))
+ORDER BY 
+    l1.block_timestamp DESC, l1.id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: GetTotalDepositsByAddress :one
+SELECT 
+    COUNT(*)
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    from_address = sqlc.arg(address) OR to_address = sqlc.arg(address);
+
+-- name: ListBlockPointers :many
+SELECT name, block_number, block_time FROM BLOCK_POINTERS ORDER BY name;
+
+
+-- name: GetMatchedAmountsWei :many
+SELECT 
+    amount_wei
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL;
+
+-- name: GetDepositTimings :many
+SELECT 
+    l1.block_timestamp as l1_timestamp,
+    l2.block_timestamp as l2_timestamp,
+    l1.amount
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.block_timestamp < sqlc.arg(until) AND
+    (l2.id IS NULL OR l2.block_timestamp > sqlc.arg(since))
+ORDER BY 
+    l1.block_timestamp ASC;
+
+-- Export Queries
+
+-- name: ExportMatchedDeposits :many
+SELECT 
+    l1.id,
+    l1.from_address,
+    l1.to_address,
+    l1.amount_wei,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.tx_hash as tx_hash_l2
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.id > sqlc.arg(after_id) AND
+    (sqlc.narg(since) IS NULL OR l1.block_timestamp >= sqlc.narg(since)) AND
+    (sqlc.narg(until) IS NULL OR l1.block_timestamp < sqlc.narg(until))
+ORDER BY 
+    l1.id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: ExportUnmatchedL1Deposits :many
+SELECT 
+    id,
+    from_address,
+    to_address,
+    amount_wei,
+    block_number,
+    block_timestamp,
+    tx_hash
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    matched_l2_standard_bridge_deposit_finalized_id IS NULL AND
+    id > sqlc.arg(after_id) AND
+    (sqlc.narg(since) IS NULL OR block_timestamp >= sqlc.narg(since)) AND
+    (sqlc.narg(until) IS NULL OR block_timestamp < sqlc.narg(until))
+ORDER BY 
+    id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: ExportUnmatchedL2Finalizations :many
+SELECT 
+    id,
+    from_address,
+    to_address,
+    amount_wei,
+    block_number,
+    block_timestamp,
+    tx_hash
+FROM 
+    l2_standard_bridge_deposit_finalized
+WHERE 
+    matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL AND
+    id > sqlc.arg(after_id) AND
+    (sqlc.narg(since) IS NULL OR block_timestamp >= sqlc.narg(since)) AND
+    (sqlc.narg(until) IS NULL OR block_timestamp < sqlc.narg(until))
+ORDER BY 
+    id ASC
+LIMIT sqlc.arg(limit);
+
+-- Operations Queries
+
+-- name: ClearL2MatchesOfL1Range :exec
+UPDATE l2_standard_bridge_deposit_finalized
+SET 
+    matched_l1_standard_bridge_eth_deposit_initiated_id = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE matched_l1_standard_bridge_eth_deposit_initiated_id IN (
+    SELECT id FROM l1_standard_bridge_eth_deposit_initiated
+    WHERE block_number BETWEEN sqlc.arg(from_block) AND sqlc.arg(to_block)
+);
+
+-- name: DeleteL1DepositsInRange :execrows
+DELETE FROM l1_standard_bridge_eth_deposit_initiated
+WHERE block_number BETWEEN sqlc.arg(from_block) AND sqlc.arg(to_block);
+
+-- name: ClearL1MatchesOfL2Range :exec
+UPDATE l1_standard_bridge_eth_deposit_initiated
+SET 
+    matched_l2_standard_bridge_deposit_finalized_id = NULL,
+    match_method = NULL,
+    match_candidates = NULL,
+    match_confidence = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE matched_l2_standard_bridge_deposit_finalized_id IN (
+    SELECT id FROM l2_standard_bridge_deposit_finalized
+    WHERE block_number BETWEEN sqlc.arg(from_block) AND sqlc.arg(to_block)
+);
+
+-- name: DeleteL2FinalizationsInRange :execrows
+DELETE FROM l2_standard_bridge_deposit_finalized
+WHERE block_number BETWEEN sqlc.arg(from_block) AND sqlc.arg(to_block);
+
+-- name: ClearL1Matches :exec
+UPDATE l1_standard_bridge_eth_deposit_initiated
+SET 
+    matched_l2_standard_bridge_deposit_finalized_id = NULL,
+    match_method = NULL,
+    match_candidates = NULL,
+    match_confidence = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL;
+
+-- name: ClearL2Matches :exec
+UPDATE l2_standard_bridge_deposit_finalized
+SET 
+    matched_l1_standard_bridge_eth_deposit_initiated_id = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE matched_l1_standard_bridge_eth_deposit_initiated_id IS NOT NULL;
+
+-- name: GetDepositCounts :one
+SELECT 
+    (SELECT COUNT(*) FROM l1_standard_bridge_eth_deposit_initiated) as l1_deposits,
+    (SELECT COUNT(*) FROM l1_standard_bridge_eth_deposit_initiated WHERE matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL) as matched_deposits,
+    (SELECT COUNT(*) FROM l1_standard_bridge_eth_deposit_initiated WHERE matched_l2_standard_bridge_deposit_finalized_id IS NULL) as pending_deposits,
+    (SELECT COUNT(*) FROM l2_standard_bridge_deposit_finalized) as l2_finalizations,
+    (SELECT COUNT(*) FROM l2_standard_bridge_deposit_finalized WHERE matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL) as unmatched_l2_finalizations;
+
+-- Verification Queries
+
+-- name: ListL1LogsInRange :many
+SELECT 
+    id,
+    block_number,
+    tx_hash,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.logIndex'), '') as log_index,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.blockHash'), '') as block_hash,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.data'), '') as data
+FROM l1_standard_bridge_eth_deposit_initiated
+WHERE block_number BETWEEN sqlc.arg(from_block) AND sqlc.arg(to_block)
+ORDER BY block_number ASC, id ASC;
+
+-- name: ListL2LogsInRange :many
+SELECT 
+    id,
+    block_number,
+    tx_hash,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.logIndex'), '') as log_index,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.blockHash'), '') as block_hash,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.data'), '') as data
+FROM l2_standard_bridge_deposit_finalized
+WHERE block_number BETWEEN sqlc.arg(from_block) AND sqlc.arg(to_block)
+ORDER BY block_number ASC, id ASC;
+
+-- name: FindInconsistentL1Matches :many
+SELECT 
+    l1.id,
+    l1.matched_l2_standard_bridge_deposit_finalized_id as matched_id,
+    l2.id as counterpart_id,
+    l2.matched_l1_standard_bridge_eth_deposit_initiated_id as counterpart_matched_id,
+    COALESCE(l2.matching_hash = l1.matching_hash, 0) as same_matching_hash
+FROM l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN l2_standard_bridge_deposit_finalized l2 ON l2.id = l1.matched_l2_standard_bridge_deposit_finalized_id
+WHERE 
+    l1.matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL AND (
+        l2.id IS NULL OR
+        l2.matched_l1_standard_bridge_eth_deposit_initiated_id IS NOT l1.id OR
+        l2.matching_hash != l1.matching_hash
+    )
+ORDER BY l1.id ASC;
+
+-- name: FindInconsistentL2Matches :many
+SELECT 
+    l2.id,
+    l2.matched_l1_standard_bridge_eth_deposit_initiated_id as matched_id,
+    l1.id as counterpart_id,
+    l1.matched_l2_standard_bridge_deposit_finalized_id as counterpart_matched_id,
+    COALESCE(l1.matching_hash = l2.matching_hash, 0) as same_matching_hash
+FROM l2_standard_bridge_deposit_finalized l2
+LEFT JOIN l1_standard_bridge_eth_deposit_initiated l1 ON l1.id = l2.matched_l1_standard_bridge_eth_deposit_initiated_id
+WHERE 
+    l2.matched_l1_standard_bridge_eth_deposit_initiated_id IS NOT NULL AND (
+        l1.id IS NULL OR
+        l1.matched_l2_standard_bridge_deposit_finalized_id IS NOT l2.id OR
+        l1.matching_hash != l2.matching_hash
+    )
+ORDER BY l2.id ASC;
+
+-- Matching Queries
+
+-- name: ListMatchableHashes :many
+SELECT DISTINCT l1.matching_hash
+FROM l1_standard_bridge_eth_deposit_initiated l1
+JOIN l2_standard_bridge_deposit_finalized l2 ON l2.matching_hash = l1.matching_hash
+WHERE 
+    l1.matched_l2_standard_bridge_deposit_finalized_id IS NULL AND
+    l2.matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL AND
+    l2.block_timestamp >= l1.block_timestamp AND
+    l1.matching_hash > sqlc.arg(after_hash)
+ORDER BY l1.matching_hash ASC
+LIMIT sqlc.arg(limit);
+
+-- name: ListUnmatchedL1DepositsByHash :many
+SELECT 
+    id,
+    block_timestamp
+FROM l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    matching_hash = ? AND
+    matched_l2_standard_bridge_deposit_finalized_id IS NULL
+ORDER BY block_timestamp ASC, id ASC;
+
+-- name: ListUnmatchedL2FinalizationsByHash :many
+SELECT 
+    id,
+    block_timestamp
+FROM l2_standard_bridge_deposit_finalized
+WHERE 
+    matching_hash = ? AND
+    matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL
+ORDER BY block_timestamp ASC, id ASC;
 
+-- name: RecordL1Match :exec
+UPDATE l1_standard_bridge_eth_deposit_initiated
+SET 
+    matched_l2_standard_bridge_deposit_finalized_id = sqlc.arg(l2_id),
+    match_method = sqlc.arg(method),
+    match_candidates = sqlc.arg(candidates),
+    match_confidence = sqlc.arg(confidence),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id);
+
+-- name: GetAmbiguousGroups :many
+SELECT 
+    l1.id,
+    l1.matching_hash,
+    l1.from_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.l1_count,
+    l1.l1_unmatched,
+    COALESCE(l2.l2_count, 0) as l2_count,
+    COALESCE(l2.l2_unmatched, 0) as l2_unmatched,
+    l1.first_timestamp,
+    l1.last_timestamp,
+    l1.min_confidence
+FROM (
+    SELECT 
+        MIN(id) as id,
+        matching_hash,
+        MIN(from_address) as from_address,
+        MIN(amount) as amount,
+        MIN(amount_wei) as amount_wei,
+        COUNT(*) as l1_count,
+        SUM(CASE WHEN matched_l2_standard_bridge_deposit_finalized_id IS NULL THEN 1 ELSE 0 END) as l1_unmatched,
+        MIN(block_timestamp) as first_timestamp,
+        MAX(block_timestamp) as last_timestamp,
+        MIN(match_confidence) as min_confidence
+    FROM l1_standard_bridge_eth_deposit_initiated
+    GROUP BY matching_hash
+) l1
+LEFT JOIN (
+    SELECT 
+        matching_hash,
+        COUNT(*) as l2_count,
+        SUM(CASE WHEN matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL THEN 1 ELSE 0 END) as l2_unmatched
+    FROM l2_standard_bridge_deposit_finalized
+    GROUP BY matching_hash
+) l2 ON l2.matching_hash = l1.matching_hash
+WHERE 
+    (l1.l1_count > 1 OR l2.l2_count > 1) AND
+    (sqlc.narg(cursor_id) IS NULL OR
+    l1.last_timestamp < sqlc.narg(cursor_key) OR
+    (l1.last_timestamp = sqlc.narg(cursor_key) AND l1.id < sqlc.narg(cursor_id)))
+ORDER BY l1.last_timestamp DESC, l1.id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: GetTotalAmbiguousGroups :one
+SELECT COUNT(*) FROM (
+    SELECT l1.matching_hash
+    FROM l1_standard_bridge_eth_deposit_initiated l1
+    GROUP BY l1.matching_hash
+    HAVING 
+        COUNT(*) > 1 OR
+        (SELECT COUNT(*) FROM l2_standard_bridge_deposit_finalized l2 WHERE l2.matching_hash = l1.matching_hash) > 1
+);
+
+-- Solvency Queries
+
+-- name: InsertL1WithdrawalFinalized :exec
+INSERT OR IGNORE INTO l1_standard_bridge_eth_withdrawal_finalized (
+    block_number,
+    tx_hash,
+    log_index,
+    from_address,
+    to_address,
+    amount_wei
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListL1DepositAmountsUpToBlock :many
+SELECT 
+    amount_wei
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    block_number <= ?;
+
+-- name: ListL1WithdrawalAmountsUpToBlock :many
+SELECT 
+    amount_wei
+FROM 
+    l1_standard_bridge_eth_withdrawal_finalized
+WHERE 
+    block_number <= ?;
+
+-- name: InsertSolvencySample :exec
+INSERT OR IGNORE INTO solvency_samples (
+    block_number,
+    block_time,
+    balance_wei,
+    deposited_wei,
+    withdrawn_wei,
+    gap_eth,
+    alerting
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetLatestSolvencySample :one
+SELECT 
+    block_number, block_time, balance_wei, deposited_wei, withdrawn_wei, gap_eth, alerting
+FROM 
+    solvency_samples
+ORDER BY 
+    block_number DESC
+LIMIT 1;
+
+-- name: ListSolvencySamples :many
+SELECT 
+    block_number, block_time, balance_wei, deposited_wei, withdrawn_wei, gap_eth, alerting
+FROM 
+    solvency_samples
+WHERE 
+    block_time >= sqlc.arg(since) AND block_time < sqlc.arg(until)
+ORDER BY 
+    block_number DESC
+LIMIT sqlc.arg(limit);
+
+-- Latency Queries
+
+-- name: InsertChainHead :exec
+INSERT OR IGNORE INTO chain_heads (
+    chain,
+    label,
+    block_number,
+    block_time,
+    previous_block_number,
+    observed_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListL2BlocksWithoutOrigin :many
+SELECT DISTINCT 
+    block_number
+FROM 
+    l2_standard_bridge_deposit_finalized
+WHERE 
+    l1_origin_number IS NULL AND l1_origin_error IS NULL
+ORDER BY 
+    block_number DESC
+LIMIT ?;
+
+-- name: UpdateL2Origin :exec
+UPDATE l2_standard_bridge_deposit_finalized
+SET 
+    l1_origin_number = sqlc.arg(l1_origin_number),
+    l1_origin_timestamp = sqlc.arg(l1_origin_timestamp),
+    updated_at = CURRENT_TIMESTAMP
+WHERE 
+    block_number = sqlc.arg(block_number);
+
+-- name: UpdateL2OriginError :exec
+UPDATE l2_standard_bridge_deposit_finalized
+SET 
+    l1_origin_error = sqlc.arg(l1_origin_error),
+    updated_at = CURRENT_TIMESTAMP
+WHERE 
+    block_number = sqlc.arg(block_number);
+
+-- name: GetDepositLatency :one
+SELECT 
+    l1.id,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.l1_origin_number,
+    l2.l1_origin_timestamp,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l1' AND h.label = 'safe' AND
+            h.previous_block_number < l1.block_number AND h.block_number >= l1.block_number
+    ) as l1_safe_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l1' AND h.label = 'finalized' AND
+            h.previous_block_number < l1.block_number AND h.block_number >= l1.block_number
+    ) as l1_finalized_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l2' AND h.label = 'safe' AND
+            h.previous_block_number < l2.block_number AND h.block_number >= l2.block_number
+    ) as l2_safe_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l2' AND h.label = 'finalized' AND
+            h.previous_block_number < l2.block_number AND h.block_number >= l2.block_number
+    ) as l2_finalized_at
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.id = ?;
+
+-- name: ListDepositLatencies :many
+SELECT 
+    l1.id,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.l1_origin_number,
+    l2.l1_origin_timestamp,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l1' AND h.label = 'safe' AND
+            h.previous_block_number < l1.block_number AND h.block_number >= l1.block_number
+    ) as l1_safe_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l1' AND h.label = 'finalized' AND
+            h.previous_block_number < l1.block_number AND h.block_number >= l1.block_number
+    ) as l1_finalized_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l2' AND h.label = 'safe' AND
+            h.previous_block_number < l2.block_number AND h.block_number >= l2.block_number
+    ) as l2_safe_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l2' AND h.label = 'finalized' AND
+            h.previous_block_number < l2.block_number AND h.block_number >= l2.block_number
+    ) as l2_finalized_at
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.block_timestamp >= sqlc.arg(since) AND l1.block_timestamp < sqlc.arg(until)
+ORDER BY 
+    l1.block_timestamp DESC, l1.id DESC
+LIMIT sqlc.arg(limit);
+
+-- ETA Queries
+
+-- name: ListRecentConfirmationSeconds :many
+SELECT 
+    CAST(l2.block_timestamp - l1.block_timestamp AS INTEGER) as confirmation_seconds
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+ORDER BY 
+    l1.block_timestamp DESC
+LIMIT ?;
+
+-- name: GetLatestChainHead :one
+SELECT 
+    block_number, block_time, observed_at
+FROM 
+    chain_heads
+WHERE 
+    chain = ? AND label = ?
+ORDER BY 
+    block_number DESC
+LIMIT 1;
+
+-- Liveness Queries
+
+-- name: InsertLivenessIncident :one
+INSERT INTO liveness_incidents (
+    kind,
+    started_at,
+    l2_block_number,
+    worst,
+    detail
+) VALUES (
+    ?, ?, ?, ?, ?
+) RETURNING id;
+
+-- name: GetOpenLivenessIncident :one
+SELECT 
+    id, created_at, kind, started_at, ended_at, l2_block_number, worst, detail
+FROM 
+    liveness_incidents
+WHERE 
+    kind = ? AND ended_at IS NULL
+ORDER BY 
+    started_at DESC
+LIMIT 1;
+
+-- name: UpdateLivenessIncidentWorst :exec
+UPDATE liveness_incidents
+SET 
+    worst = sqlc.arg(worst),
+    detail = sqlc.arg(detail)
+WHERE 
+    id = sqlc.arg(id) AND worst < sqlc.arg(worst);
+
+-- name: CloseLivenessIncident :exec
+UPDATE liveness_incidents
+SET 
+    ended_at = ?
+WHERE 
+    id = ?;
+
+-- name: GetLivenessIncident :one
+SELECT 
+    id, created_at, kind, started_at, ended_at, l2_block_number, worst, detail
+FROM 
+    liveness_incidents
+WHERE 
+    id = ?;
+
+-- name: ListLivenessIncidents :many
+SELECT 
+    id, created_at, kind, started_at, ended_at, l2_block_number, worst, detail
+FROM 
+    liveness_incidents
+WHERE 
+    started_at < sqlc.arg(until) AND (ended_at IS NULL OR ended_at >= sqlc.arg(since))
+ORDER BY 
+    started_at DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: CountLivenessIncidents :one
+SELECT 
+    COUNT(*) AS total,
+    COUNT(ended_at) AS ended
+FROM 
+    liveness_incidents;
+
+-- name: ListDepositsInFlight :many
+SELECT 
+    l1.id,
+    l1.from_address,
+    l1.to_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.tx_hash as tx_hash_l2
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.block_timestamp <= sqlc.arg(end) AND (l2.id IS NULL OR l2.block_timestamp >= sqlc.arg(start)) AND
+    (sqlc.narg(cursor_id) IS NULL OR
+    l1.block_timestamp < sqlc.narg(cursor_key) OR
+    (l1.block_timestamp = sqlc.narg(cursor_key) AND l1.id < sqlc.narg(cursor_id)))
+ORDER BY 
+    l1.block_timestamp DESC, l1.id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: CountDepositsInFlight :one
+SELECT 
+    COUNT(*)
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.block_timestamp <= sqlc.arg(end) AND (l2.id IS NULL OR l2.block_timestamp >= sqlc.arg(start));
+
+-- Batcher Queries
+
+-- name: InsertBatcherTransaction :exec
+INSERT OR IGNORE INTO batcher_transactions (
+    block_number,
+    block_time,
+    tx_hash,
+    blob,
+    blob_count,
+    data_size
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetLatestBatcherTransaction :one
+SELECT 
+    id, created_at, block_number, block_time, tx_hash, blob, blob_count, data_size
+FROM 
+    batcher_transactions
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT 1;
+
+-- name: ListBatcherTransactions :many
+SELECT 
+    id, created_at, block_number, block_time, tx_hash, blob, blob_count, data_size
+FROM 
+    batcher_transactions
+WHERE 
+    block_time >= sqlc.arg(since) AND block_time < sqlc.arg(until)
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: InsertSyncStatusSample :exec
+INSERT INTO sync_status_samples (
+    observed_at,
+    current_l1_number,
+    head_l1_number,
+    unsafe_l2_number,
+    unsafe_l2_time,
+    safe_l2_number,
+    safe_l2_time,
+    finalized_l2_number,
+    finalized_l2_time
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetLatestSyncStatusSample :one
+SELECT 
+    id, created_at, observed_at, current_l1_number, head_l1_number, unsafe_l2_number, unsafe_l2_time, safe_l2_number, safe_l2_time, finalized_l2_number, finalized_l2_time
+FROM 
+    sync_status_samples
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListSyncStatusSamples :many
+SELECT 
+    id, created_at, observed_at, current_l1_number, head_l1_number, unsafe_l2_number, unsafe_l2_time, safe_l2_number, safe_l2_time, finalized_l2_number, finalized_l2_time
+FROM 
+    sync_status_samples
+WHERE 
+    observed_at >= sqlc.arg(since) AND observed_at < sqlc.arg(until)
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- Dispute Game Queries
+
+-- name: InsertDisputeGame :exec
+INSERT OR IGNORE INTO dispute_games (
+    game_address,
+    game_type,
+    root_claim,
+    l2_block_number,
+    block_number,
+    block_time,
+    tx_hash
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListUnresolvedDisputeGames :many
+SELECT 
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
+FROM 
+    dispute_games
+WHERE 
+    status = 0
+ORDER BY 
+    block_number ASC, id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: ListUnverifiedDisputeGames :many
+SELECT 
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
+FROM 
+    dispute_games
+WHERE 
+    expected_root IS NULL AND output_root_error IS NULL AND l2_block_number <= sqlc.arg(l2_block_number)
+ORDER BY 
+    l2_block_number ASC, id ASC
+LIMIT sqlc.arg(limit);
+
+-- name: UpdateDisputeGameStatus :exec
+UPDATE dispute_games
+SET 
+    status = sqlc.arg(status),
+    resolved_at = sqlc.arg(resolved_at)
+WHERE 
+    id = sqlc.arg(id);
+
+-- name: UpdateDisputeGameOutputRoot :exec
+UPDATE dispute_games
+SET 
+    expected_root = sqlc.arg(expected_root),
+    valid = sqlc.arg(valid)
+WHERE 
+    id = sqlc.arg(id);
+
+-- name: UpdateDisputeGameOutputRootError :exec
+UPDATE dispute_games
+SET 
+    output_root_error = sqlc.arg(output_root_error)
+WHERE 
+    id = sqlc.arg(id);
+
+-- name: FlagDisputeGame :exec
+UPDATE dispute_games
+SET 
+    critical_reason = sqlc.arg(critical_reason)
+WHERE 
+    id = sqlc.arg(id);
+
+-- name: GetDisputeGame :one
+SELECT 
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
+FROM 
+    dispute_games
+WHERE 
+    id = sqlc.arg(id);
+
+-- name: ListDisputeGames :many
+SELECT 
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
+FROM 
+    dispute_games
+WHERE 
+    sqlc.narg(cursor_id) IS NULL OR
+    block_number < sqlc.narg(cursor_key) OR
+    (block_number = sqlc.narg(cursor_key) AND id < sqlc.narg(cursor_id))
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListCriticalDisputeGames :many
+SELECT 
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
+FROM 
+    dispute_games
+WHERE 
+    critical_reason IS NOT NULL AND
+    (sqlc.narg(cursor_id) IS NULL OR
+    block_number < sqlc.narg(cursor_key) OR
+    (block_number = sqlc.narg(cursor_key) AND id < sqlc.narg(cursor_id)))
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: CountDisputeGames :one
+SELECT 
+    COUNT(*) AS total,
+    COUNT(CASE WHEN status = 0 THEN 1 END) AS in_progress,
+    COUNT(critical_reason) AS critical,
+    COUNT(valid) AS verified
+FROM 
+    dispute_games;
+
+-- Security Queries
+
+-- name: InsertSecurityFinding :exec
+INSERT OR IGNORE INTO security_findings (
+    observed_at,
+    block_number,
+    tx_hash,
+    log_index,
+    contract,
+    contract_name,
+    kind,
+    severity,
+    value,
+    detail
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetLatestSecurityFinding :one
+SELECT 
+    id, created_at, observed_at, block_number, tx_hash, log_index, contract, contract_name, kind, severity, value, detail
+FROM 
+    security_findings
+WHERE 
+    contract = sqlc.arg(contract) AND kind = sqlc.arg(kind)
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT 1;
+
+-- name: GetLatestPauseFinding :one
+SELECT 
+    id, created_at, observed_at, block_number, tx_hash, log_index, contract, contract_name, kind, severity, value, detail
+FROM 
+    security_findings
+WHERE 
+    kind IN ('paused', 'unpaused')
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListSecurityFindings :many
+SELECT 
+    id, created_at, observed_at, block_number, tx_hash, log_index, contract, contract_name, kind, severity, value, detail
+FROM 
+    security_findings
+WHERE 
+    sqlc.narg(cursor_id) IS NULL OR
+    observed_at < sqlc.narg(cursor_key) OR
+    (observed_at = sqlc.narg(cursor_key) AND id < sqlc.narg(cursor_id))
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: CountSecurityFindings :one
+SELECT 
+    COUNT(*)
+FROM 
+    security_findings;
+
+-- Indexed Event Queries
+
+-- name: InsertBlockPointer :exec
+INSERT OR IGNORE INTO BLOCK_POINTERS (name, block_number, block_time) VALUES (?, NULL, NULL);
+
+-- Block Pointer Hash Queries
+
+-- name: InsertBlockPointerHash :exec
+INSERT OR REPLACE INTO block_pointer_hashes (pointer, block_number, block_hash) VALUES (?, ?, ?);
+
+-- name: ListBlockPointerHashes :many
+SELECT block_number, block_hash FROM block_pointer_hashes WHERE pointer = ? AND block_number <= ? ORDER BY block_number DESC;
+
+-- name: DeleteBlockPointerHashesAfter :exec
+DELETE FROM block_pointer_hashes WHERE pointer = ? AND block_number > ?;
+
+-- name: DeleteBlockPointerHashesBefore :exec
+DELETE FROM block_pointer_hashes WHERE pointer = ? AND block_number < ?;
+
+-- name: InsertIndexedEvent :exec
+INSERT OR IGNORE INTO indexed_events (
+    source,
+    chain,
+    contract,
+    event_name,
+    block_number,
+    block_timestamp,
+    tx_hash,
+    log_index,
+    args
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: DeleteIndexedEventsInRange :execrows
+DELETE FROM indexed_events
+WHERE source = sqlc.arg(source) AND block_number BETWEEN sqlc.arg(from_block) AND sqlc.arg(to_block);
+
+-- name: ListIndexedEvents :many
+SELECT 
+    id, created_at, source, chain, contract, event_name, block_number, block_timestamp, tx_hash, log_index, args
+FROM 
+    indexed_events
+WHERE 
+    source = sqlc.arg(source) AND
+    (sqlc.narg(cursor_id) IS NULL OR
+    block_number < sqlc.narg(cursor_key) OR
+    (block_number = sqlc.narg(cursor_key) AND id < sqlc.narg(cursor_id)))
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: CountIndexedEvents :one
+SELECT 
+    COUNT(*)
+FROM 
+    indexed_events
+WHERE 
+    source = sqlc.arg(source);
+
+-- name: ListIndexedEventSources :many
+SELECT 
+    source, chain, contract, event_name, COUNT(*) AS count
+FROM 
+    indexed_events
+GROUP BY 
+    source, chain, contract, event_name
+ORDER BY 
+    source;
@@ -9,213 +9,300 @@ import (
 	"context"
 )
 
-const findMatchingL1Deposits = `-- name: FindMatchingL1Deposits :many
-SELECT 
-    id,
-    block_timestamp,
-    matching_hash
-FROM l1_standard_bridge_eth_deposit_initiated
-WHERE 
-    matching_hash = ? AND
-    block_timestamp <= ? AND
-    matched_l2_standard_bridge_deposit_finalized_id IS NULL
-ORDER BY block_timestamp DESC
-LIMIT 1
+const clearL1Matches = `-- name: ClearL1Matches :exec
+UPDATE l1_standard_bridge_eth_deposit_initiated
+SET 
+    matched_l2_standard_bridge_deposit_finalized_id = NULL,
+    match_method = NULL,
+    match_candidates = NULL,
+    match_confidence = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL
 `
 
-type FindMatchingL1DepositsParams struct {
-	MatchingHash   []byte
-	BlockTimestamp int64
+func (q *Queries) ClearL1Matches(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearL1MatchesStmt, clearL1Matches)
+	return err
 }
 
-type FindMatchingL1DepositsRow struct {
-	ID             int64
-	BlockTimestamp int64
-	MatchingHash   []byte
+const clearL1MatchesOfL2Range = `-- name: ClearL1MatchesOfL2Range :exec
+UPDATE l1_standard_bridge_eth_deposit_initiated
+SET 
+    matched_l2_standard_bridge_deposit_finalized_id = NULL,
+    match_method = NULL,
+    match_candidates = NULL,
+    match_confidence = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE matched_l2_standard_bridge_deposit_finalized_id IN (
+    SELECT id FROM l2_standard_bridge_deposit_finalized
+    WHERE block_number BETWEEN ?1 AND ?2
+)
+`
+
+type ClearL1MatchesOfL2RangeParams struct {
+	FromBlock int64
+	ToBlock   int64
 }
 
-func (q *Queries) FindMatchingL1Deposits(ctx context.Context, arg FindMatchingL1DepositsParams) ([]FindMatchingL1DepositsRow, error) {
-	rows, err := q.query(ctx, q.findMatchingL1DepositsStmt, findMatchingL1Deposits, arg.MatchingHash, arg.BlockTimestamp)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []FindMatchingL1DepositsRow
-	for rows.Next() {
-		var i FindMatchingL1DepositsRow
-		if err := rows.Scan(&i.ID, &i.BlockTimestamp, &i.MatchingHash); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+func (q *Queries) ClearL1MatchesOfL2Range(ctx context.Context, arg ClearL1MatchesOfL2RangeParams) error {
+	_, err := q.exec(ctx, q.clearL1MatchesOfL2RangeStmt, clearL1MatchesOfL2Range, arg.FromBlock, arg.ToBlock)
+	return err
 }
 
-const findMatchingL2Deposits = `-- name: FindMatchingL2Deposits :many
-SELECT 
-    id,
-    block_timestamp,
-    matching_hash
-FROM l2_standard_bridge_deposit_finalized
-WHERE 
-    matching_hash = ? AND
-    block_timestamp >= ? AND
-    matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL
-ORDER BY block_timestamp ASC
-LIMIT 1
+const clearL2Matches = `-- name: ClearL2Matches :exec
+UPDATE l2_standard_bridge_deposit_finalized
+SET 
+    matched_l1_standard_bridge_eth_deposit_initiated_id = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE matched_l1_standard_bridge_eth_deposit_initiated_id IS NOT NULL
 `
 
-type FindMatchingL2DepositsParams struct {
-	MatchingHash   []byte
-	BlockTimestamp int64
+func (q *Queries) ClearL2Matches(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearL2MatchesStmt, clearL2Matches)
+	return err
 }
 
-type FindMatchingL2DepositsRow struct {
-	ID             int64
-	BlockTimestamp int64
-	MatchingHash   []byte
+const clearL2MatchesOfL1Range = `-- name: ClearL2MatchesOfL1Range :exec
+
+UPDATE l2_standard_bridge_deposit_finalized
+SET 
+    matched_l1_standard_bridge_eth_deposit_initiated_id = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE matched_l1_standard_bridge_eth_deposit_initiated_id IN (
+    SELECT id FROM l1_standard_bridge_eth_deposit_initiated
+    WHERE block_number BETWEEN ?1 AND ?2
+)
+`
+
+type ClearL2MatchesOfL1RangeParams struct {
+	FromBlock int64
+	ToBlock   int64
 }
 
-func (q *Queries) FindMatchingL2Deposits(ctx context.Context, arg FindMatchingL2DepositsParams) ([]FindMatchingL2DepositsRow, error) {
-	rows, err := q.query(ctx, q.findMatchingL2DepositsStmt, findMatchingL2Deposits, arg.MatchingHash, arg.BlockTimestamp)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []FindMatchingL2DepositsRow
-	for rows.Next() {
-		var i FindMatchingL2DepositsRow
-		if err := rows.Scan(&i.ID, &i.BlockTimestamp, &i.MatchingHash); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+// Operations Queries
+func (q *Queries) ClearL2MatchesOfL1Range(ctx context.Context, arg ClearL2MatchesOfL1RangeParams) error {
+	_, err := q.exec(ctx, q.clearL2MatchesOfL1RangeStmt, clearL2MatchesOfL1Range, arg.FromBlock, arg.ToBlock)
+	return err
 }
 
-const getBlockPointer = `-- name: GetBlockPointer :one
-SELECT block_number, block_time FROM BLOCK_POINTERS WHERE name = ? LIMIT 1
+const closeLivenessIncident = `-- name: CloseLivenessIncident :exec
+UPDATE liveness_incidents
+SET 
+    ended_at = ?
+WHERE 
+    id = ?
 `
 
-type GetBlockPointerRow struct {
-	BlockNumber *int64
-	BlockTime   *int64
+type CloseLivenessIncidentParams struct {
+	EndedAt *int64
+	ID      int64
 }
 
-func (q *Queries) GetBlockPointer(ctx context.Context, name string) (GetBlockPointerRow, error) {
-	row := q.queryRow(ctx, q.getBlockPointerStmt, getBlockPointer, name)
-	var i GetBlockPointerRow
-	err := row.Scan(&i.BlockNumber, &i.BlockTime)
-	return i, err
+func (q *Queries) CloseLivenessIncident(ctx context.Context, arg CloseLivenessIncidentParams) error {
+	_, err := q.exec(ctx, q.closeLivenessIncidentStmt, closeLivenessIncident, arg.EndedAt, arg.ID)
+	return err
 }
 
-const getBridgeStats = `-- name: GetBridgeStats :one
+const countDepositsInFlight = `-- name: CountDepositsInFlight :one
 SELECT 
-    COUNT(*) as total_matched,
-    AVG(l2.block_timestamp - l1.block_timestamp) as avg_time_diff,
-    MIN(l2.block_timestamp - l1.block_timestamp) as min_time_diff,
-    MAX(l2.block_timestamp - l1.block_timestamp) as max_time_diff,
-    SUM(l1.amount) as total_bridged_eth
+    COUNT(*)
 FROM 
     l1_standard_bridge_eth_deposit_initiated l1
-JOIN 
+LEFT JOIN 
     l2_standard_bridge_deposit_finalized l2 
 ON 
     l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.block_timestamp <= ?1 AND (l2.id IS NULL OR l2.block_timestamp >= ?2)
 `
 
-type GetBridgeStatsRow struct {
-	TotalMatched    int64
-	AvgTimeDiff     *float64
-	MinTimeDiff     interface{}
-	MaxTimeDiff     interface{}
-	TotalBridgedEth *float64
+type CountDepositsInFlightParams struct {
+	End   int64
+	Start *int64
 }
 
-func (q *Queries) GetBridgeStats(ctx context.Context) (GetBridgeStatsRow, error) {
-	row := q.queryRow(ctx, q.getBridgeStatsStmt, getBridgeStats)
-	var i GetBridgeStatsRow
+func (q *Queries) CountDepositsInFlight(ctx context.Context, arg CountDepositsInFlightParams) (int64, error) {
+	row := q.queryRow(ctx, q.countDepositsInFlightStmt, countDepositsInFlight, arg.End, arg.Start)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countDisputeGames = `-- name: CountDisputeGames :one
+SELECT 
+    COUNT(*) AS total,
+    COUNT(CASE WHEN status = 0 THEN 1 END) AS in_progress,
+    COUNT(critical_reason) AS critical,
+    COUNT(valid) AS verified
+FROM 
+    dispute_games
+`
+
+type CountDisputeGamesRow struct {
+	Total      int64
+	InProgress int64
+	Critical   int64
+	Verified   int64
+}
+
+func (q *Queries) CountDisputeGames(ctx context.Context) (CountDisputeGamesRow, error) {
+	row := q.queryRow(ctx, q.countDisputeGamesStmt, countDisputeGames)
+	var i CountDisputeGamesRow
 	err := row.Scan(
-		&i.TotalMatched,
-		&i.AvgTimeDiff,
-		&i.MinTimeDiff,
-		&i.MaxTimeDiff,
-		&i.TotalBridgedEth,
+		&i.Total,
+		&i.InProgress,
+		&i.Critical,
+		&i.Verified,
 	)
 	return i, err
 }
 
-const getLatestL1Block = `-- name: GetLatestL1Block :one
+const countIndexedEvents = `-- name: CountIndexedEvents :one
 SELECT 
-    block_number,
-    block_time as block_timestamp
+    COUNT(*)
 FROM 
-    BLOCK_POINTERS
+    indexed_events
 WHERE 
-    name = 'l1_standard_bridge_eth_deposit_initiated_last_processed_block'
-LIMIT 1
+    source = ?1
 `
 
-type GetLatestL1BlockRow struct {
-	BlockNumber    *int64
-	BlockTimestamp *int64
+func (q *Queries) CountIndexedEvents(ctx context.Context, source string) (int64, error) {
+	row := q.queryRow(ctx, q.countIndexedEventsStmt, countIndexedEvents, source)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-func (q *Queries) GetLatestL1Block(ctx context.Context) (GetLatestL1BlockRow, error) {
-	row := q.queryRow(ctx, q.getLatestL1BlockStmt, getLatestL1Block)
-	var i GetLatestL1BlockRow
-	err := row.Scan(&i.BlockNumber, &i.BlockTimestamp)
+const countLivenessIncidents = `-- name: CountLivenessIncidents :one
+SELECT 
+    COUNT(*) AS total,
+    COUNT(ended_at) AS ended
+FROM 
+    liveness_incidents
+`
+
+type CountLivenessIncidentsRow struct {
+	Total int64
+	Ended int64
+}
+
+func (q *Queries) CountLivenessIncidents(ctx context.Context) (CountLivenessIncidentsRow, error) {
+	row := q.queryRow(ctx, q.countLivenessIncidentsStmt, countLivenessIncidents)
+	var i CountLivenessIncidentsRow
+	err := row.Scan(&i.Total, &i.Ended)
 	return i, err
 }
 
-const getLatestL2Block = `-- name: GetLatestL2Block :one
+const countSecurityFindings = `-- name: CountSecurityFindings :one
 SELECT 
-    block_number,
-    block_time as block_timestamp
+    COUNT(*)
 FROM 
-    BLOCK_POINTERS
-WHERE 
-    name = 'l2_standard_bridge_eth_deposit_finalized_last_processed_block'
-LIMIT 1
+    security_findings
 `
 
-type GetLatestL2BlockRow struct {
-	BlockNumber    *int64
-	BlockTimestamp *int64
+func (q *Queries) CountSecurityFindings(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countSecurityFindingsStmt, countSecurityFindings)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
-func (q *Queries) GetLatestL2Block(ctx context.Context) (GetLatestL2BlockRow, error) {
-	row := q.queryRow(ctx, q.getLatestL2BlockStmt, getLatestL2Block)
-	var i GetLatestL2BlockRow
-	err := row.Scan(&i.BlockNumber, &i.BlockTimestamp)
-	return i, err
+const deleteBlockPointerHashesAfter = `-- name: DeleteBlockPointerHashesAfter :exec
+DELETE FROM block_pointer_hashes WHERE pointer = ? AND block_number > ?
+`
+
+type DeleteBlockPointerHashesAfterParams struct {
+	Pointer     string
+	BlockNumber int64
 }
 
-const getMatchedDeposits = `-- name: GetMatchedDeposits :many
+func (q *Queries) DeleteBlockPointerHashesAfter(ctx context.Context, arg DeleteBlockPointerHashesAfterParams) error {
+	_, err := q.exec(ctx, q.deleteBlockPointerHashesAfterStmt, deleteBlockPointerHashesAfter, arg.Pointer, arg.BlockNumber)
+	return err
+}
+
+const deleteBlockPointerHashesBefore = `-- name: DeleteBlockPointerHashesBefore :exec
+DELETE FROM block_pointer_hashes WHERE pointer = ? AND block_number < ?
+`
+
+type DeleteBlockPointerHashesBeforeParams struct {
+	Pointer     string
+	BlockNumber int64
+}
+
+func (q *Queries) DeleteBlockPointerHashesBefore(ctx context.Context, arg DeleteBlockPointerHashesBeforeParams) error {
+	_, err := q.exec(ctx, q.deleteBlockPointerHashesBeforeStmt, deleteBlockPointerHashesBefore, arg.Pointer, arg.BlockNumber)
+	return err
+}
+
+const deleteIndexedEventsInRange = `-- name: DeleteIndexedEventsInRange :execrows
+DELETE FROM indexed_events
+WHERE source = ?1 AND block_number BETWEEN ?2 AND ?3
+`
+
+type DeleteIndexedEventsInRangeParams struct {
+	Source    string
+	FromBlock int64
+	ToBlock   int64
+}
+
+func (q *Queries) DeleteIndexedEventsInRange(ctx context.Context, arg DeleteIndexedEventsInRangeParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteIndexedEventsInRangeStmt, deleteIndexedEventsInRange, arg.Source, arg.FromBlock, arg.ToBlock)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteL1DepositsInRange = `-- name: DeleteL1DepositsInRange :execrows
+DELETE FROM l1_standard_bridge_eth_deposit_initiated
+WHERE block_number BETWEEN ?1 AND ?2
+`
+
+type DeleteL1DepositsInRangeParams struct {
+	FromBlock int64
+	ToBlock   int64
+}
+
+func (q *Queries) DeleteL1DepositsInRange(ctx context.Context, arg DeleteL1DepositsInRangeParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteL1DepositsInRangeStmt, deleteL1DepositsInRange, arg.FromBlock, arg.ToBlock)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteL2FinalizationsInRange = `-- name: DeleteL2FinalizationsInRange :execrows
+DELETE FROM l2_standard_bridge_deposit_finalized
+WHERE block_number BETWEEN ?1 AND ?2
+`
+
+type DeleteL2FinalizationsInRangeParams struct {
+	FromBlock int64
+	ToBlock   int64
+}
+
+func (q *Queries) DeleteL2FinalizationsInRange(ctx context.Context, arg DeleteL2FinalizationsInRangeParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteL2FinalizationsInRangeStmt, deleteL2FinalizationsInRange, arg.FromBlock, arg.ToBlock)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const exportMatchedDeposits = `-- name: ExportMatchedDeposits :many
 
 SELECT 
     l1.id,
     l1.from_address,
     l1.to_address,
-    l1.amount,
+    l1.amount_wei,
     l1.block_number as l1_block_number,
-    l2.block_number as l2_block_number,
     l1.block_timestamp as l1_timestamp,
-    l2.block_timestamp as l2_timestamp,
-    (l2.block_timestamp - l1.block_timestamp) as time_diff_seconds,
     l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
     l2.tx_hash as tx_hash_l2
 FROM 
     l1_standard_bridge_eth_deposit_initiated l1
@@ -223,51 +310,62 @@ JOIN
     l2_standard_bridge_deposit_finalized l2 
 ON 
     l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.id > ?1 AND
+    (?2 IS NULL OR l1.block_timestamp >= ?2) AND
+    (?3 IS NULL OR l1.block_timestamp < ?3)
 ORDER BY 
-    l1.block_timestamp DESC
-LIMIT ? OFFSET ?
+    l1.id ASC
+LIMIT ?4
 `
 
-type GetMatchedDepositsParams struct {
-	Limit  int64
-	Offset int64
+type ExportMatchedDepositsParams struct {
+	AfterID int64
+	Since   *int64
+	Until   *int64
+	Limit   int64
 }
 
-type GetMatchedDepositsRow struct {
-	ID              int64
-	FromAddress     []byte
-	ToAddress       []byte
-	Amount          float64
-	L1BlockNumber   int64
-	L2BlockNumber   int64
-	L1Timestamp     int64
-	L2Timestamp     int64
-	TimeDiffSeconds interface{}
-	TxHashL1        []byte
-	TxHashL2        []byte
+type ExportMatchedDepositsRow struct {
+	ID            int64
+	FromAddress   []byte
+	ToAddress     []byte
+	AmountWei     []byte
+	L1BlockNumber int64
+	L1Timestamp   int64
+	TxHashL1      []byte
+	L2ID          int64
+	L2BlockNumber int64
+	L2Timestamp   int64
+	TxHashL2      []byte
 }
 
-// Web UI Queries
-func (q *Queries) GetMatchedDeposits(ctx context.Context, arg GetMatchedDepositsParams) ([]GetMatchedDepositsRow, error) {
-	rows, err := q.query(ctx, q.getMatchedDepositsStmt, getMatchedDeposits, arg.Limit, arg.Offset)
+// Export Queries
+func (q *Queries) ExportMatchedDeposits(ctx context.Context, arg ExportMatchedDepositsParams) ([]ExportMatchedDepositsRow, error) {
+	rows, err := q.query(ctx, q.exportMatchedDepositsStmt, exportMatchedDeposits,
+		arg.AfterID,
+		arg.Since,
+		arg.Until,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetMatchedDepositsRow
+	var items []ExportMatchedDepositsRow
 	for rows.Next() {
-		var i GetMatchedDepositsRow
+		var i ExportMatchedDepositsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.FromAddress,
 			&i.ToAddress,
-			&i.Amount,
+			&i.AmountWei,
 			&i.L1BlockNumber,
-			&i.L2BlockNumber,
 			&i.L1Timestamp,
-			&i.L2Timestamp,
-			&i.TimeDiffSeconds,
 			&i.TxHashL1,
+			&i.L2ID,
+			&i.L2BlockNumber,
+			&i.L2Timestamp,
 			&i.TxHashL2,
 		); err != nil {
 			return nil, err
@@ -283,52 +381,3502 @@ func (q *Queries) GetMatchedDeposits(ctx context.Context, arg GetMatchedDeposits
 	return items, nil
 }
 
-const getPendingDeposits = `-- name: GetPendingDeposits :one
+const exportUnmatchedL1Deposits = `-- name: ExportUnmatchedL1Deposits :many
+SELECT 
+    id,
+    from_address,
+    to_address,
+    amount_wei,
+    block_number,
+    block_timestamp,
+    tx_hash
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    matched_l2_standard_bridge_deposit_finalized_id IS NULL AND
+    id > ?1 AND
+    (?2 IS NULL OR block_timestamp >= ?2) AND
+    (?3 IS NULL OR block_timestamp < ?3)
+ORDER BY 
+    id ASC
+LIMIT ?4
+`
+
+type ExportUnmatchedL1DepositsParams struct {
+	AfterID int64
+	Since   *int64
+	Until   *int64
+	Limit   int64
+}
+
+type ExportUnmatchedL1DepositsRow struct {
+	ID             int64
+	FromAddress    []byte
+	ToAddress      []byte
+	AmountWei      []byte
+	BlockNumber    int64
+	BlockTimestamp int64
+	TxHash         []byte
+}
+
+func (q *Queries) ExportUnmatchedL1Deposits(ctx context.Context, arg ExportUnmatchedL1DepositsParams) ([]ExportUnmatchedL1DepositsRow, error) {
+	rows, err := q.query(ctx, q.exportUnmatchedL1DepositsStmt, exportUnmatchedL1Deposits,
+		arg.AfterID,
+		arg.Since,
+		arg.Until,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExportUnmatchedL1DepositsRow
+	for rows.Next() {
+		var i ExportUnmatchedL1DepositsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAddress,
+			&i.ToAddress,
+			&i.AmountWei,
+			&i.BlockNumber,
+			&i.BlockTimestamp,
+			&i.TxHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportUnmatchedL2Finalizations = `-- name: ExportUnmatchedL2Finalizations :many
+SELECT 
+    id,
+    from_address,
+    to_address,
+    amount_wei,
+    block_number,
+    block_timestamp,
+    tx_hash
+FROM 
+    l2_standard_bridge_deposit_finalized
+WHERE 
+    matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL AND
+    id > ?1 AND
+    (?2 IS NULL OR block_timestamp >= ?2) AND
+    (?3 IS NULL OR block_timestamp < ?3)
+ORDER BY 
+    id ASC
+LIMIT ?4
+`
+
+type ExportUnmatchedL2FinalizationsParams struct {
+	AfterID int64
+	Since   *int64
+	Until   *int64
+	Limit   int64
+}
+
+type ExportUnmatchedL2FinalizationsRow struct {
+	ID             int64
+	FromAddress    []byte
+	ToAddress      []byte
+	AmountWei      []byte
+	BlockNumber    int64
+	BlockTimestamp int64
+	TxHash         []byte
+}
+
+func (q *Queries) ExportUnmatchedL2Finalizations(ctx context.Context, arg ExportUnmatchedL2FinalizationsParams) ([]ExportUnmatchedL2FinalizationsRow, error) {
+	rows, err := q.query(ctx, q.exportUnmatchedL2FinalizationsStmt, exportUnmatchedL2Finalizations,
+		arg.AfterID,
+		arg.Since,
+		arg.Until,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExportUnmatchedL2FinalizationsRow
+	for rows.Next() {
+		var i ExportUnmatchedL2FinalizationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAddress,
+			&i.ToAddress,
+			&i.AmountWei,
+			&i.BlockNumber,
+			&i.BlockTimestamp,
+			&i.TxHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findInconsistentL1Matches = `-- name: FindInconsistentL1Matches :many
+SELECT 
+    l1.id,
+    l1.matched_l2_standard_bridge_deposit_finalized_id as matched_id,
+    l2.id as counterpart_id,
+    l2.matched_l1_standard_bridge_eth_deposit_initiated_id as counterpart_matched_id,
+    COALESCE(l2.matching_hash = l1.matching_hash, 0) as same_matching_hash
+FROM l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN l2_standard_bridge_deposit_finalized l2 ON l2.id = l1.matched_l2_standard_bridge_deposit_finalized_id
+WHERE 
+    l1.matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL AND (
+        l2.id IS NULL OR
+        l2.matched_l1_standard_bridge_eth_deposit_initiated_id IS NOT l1.id OR
+        l2.matching_hash != l1.matching_hash
+    )
+ORDER BY l1.id ASC
+`
+
+type FindInconsistentL1MatchesRow struct {
+	ID                   int64
+	MatchedID            *int64
+	CounterpartID        *int64
+	CounterpartMatchedID *int64
+	SameMatchingHash     bool
+}
+
+func (q *Queries) FindInconsistentL1Matches(ctx context.Context) ([]FindInconsistentL1MatchesRow, error) {
+	rows, err := q.query(ctx, q.findInconsistentL1MatchesStmt, findInconsistentL1Matches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindInconsistentL1MatchesRow
+	for rows.Next() {
+		var i FindInconsistentL1MatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MatchedID,
+			&i.CounterpartID,
+			&i.CounterpartMatchedID,
+			&i.SameMatchingHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findInconsistentL2Matches = `-- name: FindInconsistentL2Matches :many
+SELECT 
+    l2.id,
+    l2.matched_l1_standard_bridge_eth_deposit_initiated_id as matched_id,
+    l1.id as counterpart_id,
+    l1.matched_l2_standard_bridge_deposit_finalized_id as counterpart_matched_id,
+    COALESCE(l1.matching_hash = l2.matching_hash, 0) as same_matching_hash
+FROM l2_standard_bridge_deposit_finalized l2
+LEFT JOIN l1_standard_bridge_eth_deposit_initiated l1 ON l1.id = l2.matched_l1_standard_bridge_eth_deposit_initiated_id
+WHERE 
+    l2.matched_l1_standard_bridge_eth_deposit_initiated_id IS NOT NULL AND (
+        l1.id IS NULL OR
+        l1.matched_l2_standard_bridge_deposit_finalized_id IS NOT l2.id OR
+        l1.matching_hash != l2.matching_hash
+    )
+ORDER BY l2.id ASC
+`
+
+type FindInconsistentL2MatchesRow struct {
+	ID                   int64
+	MatchedID            *int64
+	CounterpartID        *int64
+	CounterpartMatchedID *int64
+	SameMatchingHash     bool
+}
+
+func (q *Queries) FindInconsistentL2Matches(ctx context.Context) ([]FindInconsistentL2MatchesRow, error) {
+	rows, err := q.query(ctx, q.findInconsistentL2MatchesStmt, findInconsistentL2Matches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindInconsistentL2MatchesRow
+	for rows.Next() {
+		var i FindInconsistentL2MatchesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MatchedID,
+			&i.CounterpartID,
+			&i.CounterpartMatchedID,
+			&i.SameMatchingHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const flagDisputeGame = `-- name: FlagDisputeGame :exec
+UPDATE dispute_games
+SET 
+    critical_reason = ?1
+WHERE 
+    id = ?2
+`
+
+type FlagDisputeGameParams struct {
+	CriticalReason *string
+	ID             int64
+}
+
+func (q *Queries) FlagDisputeGame(ctx context.Context, arg FlagDisputeGameParams) error {
+	_, err := q.exec(ctx, q.flagDisputeGameStmt, flagDisputeGame, arg.CriticalReason, arg.ID)
+	return err
+}
+
+const getAmbiguousGroups = `-- name: GetAmbiguousGroups :many
+SELECT 
+    l1.id,
+    l1.matching_hash,
+    l1.from_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.l1_count,
+    l1.l1_unmatched,
+    COALESCE(l2.l2_count, 0) as l2_count,
+    COALESCE(l2.l2_unmatched, 0) as l2_unmatched,
+    l1.first_timestamp,
+    l1.last_timestamp,
+    l1.min_confidence
+FROM (
+    SELECT 
+        MIN(id) as id,
+        matching_hash,
+        MIN(from_address) as from_address,
+        MIN(amount) as amount,
+        MIN(amount_wei) as amount_wei,
+        COUNT(*) as l1_count,
+        SUM(CASE WHEN matched_l2_standard_bridge_deposit_finalized_id IS NULL THEN 1 ELSE 0 END) as l1_unmatched,
+        MIN(block_timestamp) as first_timestamp,
+        MAX(block_timestamp) as last_timestamp,
+        MIN(match_confidence) as min_confidence
+    FROM l1_standard_bridge_eth_deposit_initiated
+    GROUP BY matching_hash
+) l1
+LEFT JOIN (
+    SELECT 
+        matching_hash,
+        COUNT(*) as l2_count,
+        SUM(CASE WHEN matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL THEN 1 ELSE 0 END) as l2_unmatched
+    FROM l2_standard_bridge_deposit_finalized
+    GROUP BY matching_hash
+) l2 ON l2.matching_hash = l1.matching_hash
+WHERE 
+    (l1.l1_count > 1 OR l2.l2_count > 1) AND
+    (?1 IS NULL OR
+    l1.last_timestamp < ?2 OR
+    (l1.last_timestamp = ?2 AND l1.id < ?1))
+ORDER BY l1.last_timestamp DESC, l1.id DESC
+LIMIT ?3
+`
+
+type GetAmbiguousGroupsParams struct {
+	CursorID  *int64
+	CursorKey interface{}
+	Limit     int64
+}
+
+type GetAmbiguousGroupsRow struct {
+	ID             int64
+	MatchingHash   []byte
+	FromAddress    []byte
+	Amount         float64
+	AmountWei      []byte
+	L1Count        int64
+	L1Unmatched    int64
+	L2Count        int64
+	L2Unmatched    int64
+	FirstTimestamp int64
+	LastTimestamp  int64
+	MinConfidence  *float64
+}
+
+func (q *Queries) GetAmbiguousGroups(ctx context.Context, arg GetAmbiguousGroupsParams) ([]GetAmbiguousGroupsRow, error) {
+	rows, err := q.query(ctx, q.getAmbiguousGroupsStmt, getAmbiguousGroups, arg.CursorID, arg.CursorKey, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAmbiguousGroupsRow
+	for rows.Next() {
+		var i GetAmbiguousGroupsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MatchingHash,
+			&i.FromAddress,
+			&i.Amount,
+			&i.AmountWei,
+			&i.L1Count,
+			&i.L1Unmatched,
+			&i.L2Count,
+			&i.L2Unmatched,
+			&i.FirstTimestamp,
+			&i.LastTimestamp,
+			&i.MinConfidence,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlockPointer = `-- name: GetBlockPointer :one
+SELECT block_number, block_time FROM BLOCK_POINTERS WHERE name = ? LIMIT 1
+`
+
+type GetBlockPointerRow struct {
+	BlockNumber *int64
+	BlockTime   *int64
+}
+
+func (q *Queries) GetBlockPointer(ctx context.Context, name string) (GetBlockPointerRow, error) {
+	row := q.queryRow(ctx, q.getBlockPointerStmt, getBlockPointer, name)
+	var i GetBlockPointerRow
+	err := row.Scan(&i.BlockNumber, &i.BlockTime)
+	return i, err
+}
+
+const getBridgeStats = `-- name: GetBridgeStats :one
+SELECT 
+    COUNT(*) as total_matched,
+    AVG(l2.block_timestamp - l1.block_timestamp) as avg_time_diff,
+    MIN(l2.block_timestamp - l1.block_timestamp) as min_time_diff,
+    MAX(l2.block_timestamp - l1.block_timestamp) as max_time_diff,
+    SUM(l1.amount) as total_bridged_eth
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+`
+
+type GetBridgeStatsRow struct {
+	TotalMatched    int64
+	AvgTimeDiff     *float64
+	MinTimeDiff     interface{}
+	MaxTimeDiff     interface{}
+	TotalBridgedEth *float64
+}
+
+func (q *Queries) GetBridgeStats(ctx context.Context) (GetBridgeStatsRow, error) {
+	row := q.queryRow(ctx, q.getBridgeStatsStmt, getBridgeStats)
+	var i GetBridgeStatsRow
+	err := row.Scan(
+		&i.TotalMatched,
+		&i.AvgTimeDiff,
+		&i.MinTimeDiff,
+		&i.MaxTimeDiff,
+		&i.TotalBridgedEth,
+	)
+	return i, err
+}
+
+const getDepositByID = `-- name: GetDepositByID :one
+
+SELECT 
+    l1.id,
+    l1.from_address,
+    l1.to_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.tx_hash as tx_hash_l2
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.id = ?
+`
+
+type GetDepositByIDRow struct {
+	ID            int64
+	FromAddress   []byte
+	ToAddress     []byte
+	Amount        float64
+	AmountWei     []byte
+	L1BlockNumber int64
+	L1Timestamp   int64
+	TxHashL1      []byte
+	L2ID          *int64
+	L2BlockNumber *int64
+	L2Timestamp   *int64
+	TxHashL2      []byte
+}
+
+// API Queries
+func (q *Queries) GetDepositByID(ctx context.Context, id int64) (GetDepositByIDRow, error) {
+	row := q.queryRow(ctx, q.getDepositByIDStmt, getDepositByID, id)
+	var i GetDepositByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.FromAddress,
+		&i.ToAddress,
+		&i.Amount,
+		&i.AmountWei,
+		&i.L1BlockNumber,
+		&i.L1Timestamp,
+		&i.TxHashL1,
+		&i.L2ID,
+		&i.L2BlockNumber,
+		&i.L2Timestamp,
+		&i.TxHashL2,
+	)
+	return i, err
+}
+
+const getDepositCounts = `-- name: GetDepositCounts :one
+SELECT 
+    (SELECT COUNT(*) FROM l1_standard_bridge_eth_deposit_initiated) as l1_deposits,
+    (SELECT COUNT(*) FROM l1_standard_bridge_eth_deposit_initiated WHERE matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL) as matched_deposits,
+    (SELECT COUNT(*) FROM l1_standard_bridge_eth_deposit_initiated WHERE matched_l2_standard_bridge_deposit_finalized_id IS NULL) as pending_deposits,
+    (SELECT COUNT(*) FROM l2_standard_bridge_deposit_finalized) as l2_finalizations,
+    (SELECT COUNT(*) FROM l2_standard_bridge_deposit_finalized WHERE matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL) as unmatched_l2_finalizations
+`
+
+type GetDepositCountsRow struct {
+	L1Deposits               int64
+	MatchedDeposits          int64
+	PendingDeposits          int64
+	L2Finalizations          int64
+	UnmatchedL2Finalizations int64
+}
+
+func (q *Queries) GetDepositCounts(ctx context.Context) (GetDepositCountsRow, error) {
+	row := q.queryRow(ctx, q.getDepositCountsStmt, getDepositCounts)
+	var i GetDepositCountsRow
+	err := row.Scan(
+		&i.L1Deposits,
+		&i.MatchedDeposits,
+		&i.PendingDeposits,
+		&i.L2Finalizations,
+		&i.UnmatchedL2Finalizations,
+	)
+	return i, err
+}
+
+const getDepositLatency = `-- name: GetDepositLatency :one
+SELECT 
+    l1.id,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.l1_origin_number,
+    l2.l1_origin_timestamp,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l1' AND h.label = 'safe' AND
+            h.previous_block_number < l1.block_number AND h.block_number >= l1.block_number
+    ) as l1_safe_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l1' AND h.label = 'finalized' AND
+            h.previous_block_number < l1.block_number AND h.block_number >= l1.block_number
+    ) as l1_finalized_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l2' AND h.label = 'safe' AND
+            h.previous_block_number < l2.block_number AND h.block_number >= l2.block_number
+    ) as l2_safe_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l2' AND h.label = 'finalized' AND
+            h.previous_block_number < l2.block_number AND h.block_number >= l2.block_number
+    ) as l2_finalized_at
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.id = ?
+`
+
+type GetDepositLatencyRow struct {
+	ID                int64
+	L1BlockNumber     int64
+	L1Timestamp       int64
+	L2BlockNumber     *int64
+	L2Timestamp       *int64
+	L1OriginNumber    *int64
+	L1OriginTimestamp *int64
+	L1SafeAt          interface{}
+	L1FinalizedAt     interface{}
+	L2SafeAt          interface{}
+	L2FinalizedAt     interface{}
+}
+
+func (q *Queries) GetDepositLatency(ctx context.Context, id int64) (GetDepositLatencyRow, error) {
+	row := q.queryRow(ctx, q.getDepositLatencyStmt, getDepositLatency, id)
+	var i GetDepositLatencyRow
+	err := row.Scan(
+		&i.ID,
+		&i.L1BlockNumber,
+		&i.L1Timestamp,
+		&i.L2BlockNumber,
+		&i.L2Timestamp,
+		&i.L1OriginNumber,
+		&i.L1OriginTimestamp,
+		&i.L1SafeAt,
+		&i.L1FinalizedAt,
+		&i.L2SafeAt,
+		&i.L2FinalizedAt,
+	)
+	return i, err
+}
+
+const getDepositTimings = `-- name: GetDepositTimings :many
+SELECT 
+    l1.block_timestamp as l1_timestamp,
+    l2.block_timestamp as l2_timestamp,
+    l1.amount
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.block_timestamp < ?1 AND
+    (l2.id IS NULL OR l2.block_timestamp > ?2)
+ORDER BY 
+    l1.block_timestamp ASC
+`
+
+type GetDepositTimingsParams struct {
+	Until int64
+	Since int64
+}
+
+type GetDepositTimingsRow struct {
+	L1Timestamp int64
+	L2Timestamp *int64
+	Amount      float64
+}
+
+func (q *Queries) GetDepositTimings(ctx context.Context, arg GetDepositTimingsParams) ([]GetDepositTimingsRow, error) {
+	rows, err := q.query(ctx, q.getDepositTimingsStmt, getDepositTimings, arg.Until, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDepositTimingsRow
+	for rows.Next() {
+		var i GetDepositTimingsRow
+		if err := rows.Scan(&i.L1Timestamp, &i.L2Timestamp, &i.Amount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDepositsByAddress = `-- name: GetDepositsByAddress :many
+SELECT 
+    l1.id,
+    l1.from_address,
+    l1.to_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.tx_hash as tx_hash_l2
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    (l1.from_address = ?1 OR l1.to_address = ?1) AND
+    (?2 IS NULL OR
+    l1.block_timestamp < ?3 OR
+    (l1.block_timestamp = ?3 AND l1.id < ?2))
+ORDER BY 
+    l1.block_timestamp DESC, l1.id DESC
+LIMIT ?4
+`
+
+type GetDepositsByAddressParams struct {
+	Address   []byte
+	CursorID  *int64
+	CursorKey interface{}
+	Limit     int64
+}
+
+type GetDepositsByAddressRow struct {
+	ID            int64
+	FromAddress   []byte
+	ToAddress     []byte
+	Amount        float64
+	AmountWei     []byte
+	L1BlockNumber int64
+	L1Timestamp   int64
+	TxHashL1      []byte
+	L2ID          *int64
+	L2BlockNumber *int64
+	L2Timestamp   *int64
+	TxHashL2      []byte
+}
+
+func (q *Queries) GetDepositsByAddress(ctx context.Context, arg GetDepositsByAddressParams) ([]GetDepositsByAddressRow, error) {
+	rows, err := q.query(ctx, q.getDepositsByAddressStmt, getDepositsByAddress,
+		arg.Address,
+		arg.CursorID,
+		arg.CursorKey,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDepositsByAddressRow
+	for rows.Next() {
+		var i GetDepositsByAddressRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAddress,
+			&i.ToAddress,
+			&i.Amount,
+			&i.AmountWei,
+			&i.L1BlockNumber,
+			&i.L1Timestamp,
+			&i.TxHashL1,
+			&i.L2ID,
+			&i.L2BlockNumber,
+			&i.L2Timestamp,
+			&i.TxHashL2,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDepositsByTxHash = `-- name: GetDepositsByTxHash :many
+SELECT 
+    l1.id,
+    l1.from_address,
+    l1.to_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.tx_hash as tx_hash_l2
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.tx_hash = ?1 OR
+    l1.matched_l2_standard_bridge_deposit_finalized_id IN (
+        SELECT id FROM l2_standard_bridge_deposit_finalized WHERE tx_hash = ?1
+    )
+ORDER BY 
+    l1.id ASC
+`
+
+type GetDepositsByTxHashRow struct {
+	ID            int64
+	FromAddress   []byte
+	ToAddress     []byte
+	Amount        float64
+	AmountWei     []byte
+	L1BlockNumber int64
+	L1Timestamp   int64
+	TxHashL1      []byte
+	L2ID          *int64
+	L2BlockNumber *int64
+	L2Timestamp   *int64
+	TxHashL2      []byte
+}
+
+func (q *Queries) GetDepositsByTxHash(ctx context.Context, txHash []byte) ([]GetDepositsByTxHashRow, error) {
+	rows, err := q.query(ctx, q.getDepositsByTxHashStmt, getDepositsByTxHash, txHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDepositsByTxHashRow
+	for rows.Next() {
+		var i GetDepositsByTxHashRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAddress,
+			&i.ToAddress,
+			&i.Amount,
+			&i.AmountWei,
+			&i.L1BlockNumber,
+			&i.L1Timestamp,
+			&i.TxHashL1,
+			&i.L2ID,
+			&i.L2BlockNumber,
+			&i.L2Timestamp,
+			&i.TxHashL2,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDisputeGame = `-- name: GetDisputeGame :one
+SELECT 
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
+FROM 
+    dispute_games
+WHERE 
+    id = ?1
+`
+
+func (q *Queries) GetDisputeGame(ctx context.Context, id int64) (DisputeGame, error) {
+	row := q.queryRow(ctx, q.getDisputeGameStmt, getDisputeGame, id)
+	var i DisputeGame
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.GameAddress,
+		&i.GameType,
+		&i.RootClaim,
+		&i.L2BlockNumber,
+		&i.BlockNumber,
+		&i.BlockTime,
+		&i.TxHash,
+		&i.Status,
+		&i.ResolvedAt,
+		&i.ExpectedRoot,
+		&i.Valid,
+		&i.CriticalReason,
+		&i.OutputRootError,
+	)
+	return i, err
+}
+
+const getLatestBatcherTransaction = `-- name: GetLatestBatcherTransaction :one
+SELECT 
+    id, created_at, block_number, block_time, tx_hash, blob, blob_count, data_size
+FROM 
+    batcher_transactions
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestBatcherTransaction(ctx context.Context) (BatcherTransaction, error) {
+	row := q.queryRow(ctx, q.getLatestBatcherTransactionStmt, getLatestBatcherTransaction)
+	var i BatcherTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.BlockNumber,
+		&i.BlockTime,
+		&i.TxHash,
+		&i.Blob,
+		&i.BlobCount,
+		&i.DataSize,
+	)
+	return i, err
+}
+
+const getLatestChainHead = `-- name: GetLatestChainHead :one
+SELECT 
+    block_number, block_time, observed_at
+FROM 
+    chain_heads
+WHERE 
+    chain = ? AND label = ?
+ORDER BY 
+    block_number DESC
+LIMIT 1
+`
+
+type GetLatestChainHeadParams struct {
+	Chain string
+	Label string
+}
+
+type GetLatestChainHeadRow struct {
+	BlockNumber int64
+	BlockTime   int64
+	ObservedAt  int64
+}
+
+func (q *Queries) GetLatestChainHead(ctx context.Context, arg GetLatestChainHeadParams) (GetLatestChainHeadRow, error) {
+	row := q.queryRow(ctx, q.getLatestChainHeadStmt, getLatestChainHead, arg.Chain, arg.Label)
+	var i GetLatestChainHeadRow
+	err := row.Scan(&i.BlockNumber, &i.BlockTime, &i.ObservedAt)
+	return i, err
+}
+
+const getLatestL1Block = `-- name: GetLatestL1Block :one
+SELECT 
+    block_number,
+    block_time as block_timestamp
+FROM 
+    BLOCK_POINTERS
+WHERE 
+    name = 'l1_standard_bridge_eth_deposit_initiated_last_processed_block'
+LIMIT 1
+`
+
+type GetLatestL1BlockRow struct {
+	BlockNumber    *int64
+	BlockTimestamp *int64
+}
+
+func (q *Queries) GetLatestL1Block(ctx context.Context) (GetLatestL1BlockRow, error) {
+	row := q.queryRow(ctx, q.getLatestL1BlockStmt, getLatestL1Block)
+	var i GetLatestL1BlockRow
+	err := row.Scan(&i.BlockNumber, &i.BlockTimestamp)
+	return i, err
+}
+
+const getLatestL2Block = `-- name: GetLatestL2Block :one
+SELECT 
+    block_number,
+    block_time as block_timestamp
+FROM 
+    BLOCK_POINTERS
+WHERE 
+    name = 'l2_standard_bridge_eth_deposit_finalized_last_processed_block'
+LIMIT 1
+`
+
+type GetLatestL2BlockRow struct {
+	BlockNumber    *int64
+	BlockTimestamp *int64
+}
+
+func (q *Queries) GetLatestL2Block(ctx context.Context) (GetLatestL2BlockRow, error) {
+	row := q.queryRow(ctx, q.getLatestL2BlockStmt, getLatestL2Block)
+	var i GetLatestL2BlockRow
+	err := row.Scan(&i.BlockNumber, &i.BlockTimestamp)
+	return i, err
+}
+
+const getLatestPauseFinding = `-- name: GetLatestPauseFinding :one
+SELECT 
+    id, created_at, observed_at, block_number, tx_hash, log_index, contract, contract_name, kind, severity, value, detail
+FROM 
+    security_findings
+WHERE 
+    kind IN ('paused', 'unpaused')
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestPauseFinding(ctx context.Context) (SecurityFinding, error) {
+	row := q.queryRow(ctx, q.getLatestPauseFindingStmt, getLatestPauseFinding)
+	var i SecurityFinding
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ObservedAt,
+		&i.BlockNumber,
+		&i.TxHash,
+		&i.LogIndex,
+		&i.Contract,
+		&i.ContractName,
+		&i.Kind,
+		&i.Severity,
+		&i.Value,
+		&i.Detail,
+	)
+	return i, err
+}
+
+const getLatestSecurityFinding = `-- name: GetLatestSecurityFinding :one
+SELECT 
+    id, created_at, observed_at, block_number, tx_hash, log_index, contract, contract_name, kind, severity, value, detail
+FROM 
+    security_findings
+WHERE 
+    contract = ?1 AND kind = ?2
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT 1
+`
+
+type GetLatestSecurityFindingParams struct {
+	Contract []byte
+	Kind     string
+}
+
+func (q *Queries) GetLatestSecurityFinding(ctx context.Context, arg GetLatestSecurityFindingParams) (SecurityFinding, error) {
+	row := q.queryRow(ctx, q.getLatestSecurityFindingStmt, getLatestSecurityFinding, arg.Contract, arg.Kind)
+	var i SecurityFinding
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ObservedAt,
+		&i.BlockNumber,
+		&i.TxHash,
+		&i.LogIndex,
+		&i.Contract,
+		&i.ContractName,
+		&i.Kind,
+		&i.Severity,
+		&i.Value,
+		&i.Detail,
+	)
+	return i, err
+}
+
+const getLatestSolvencySample = `-- name: GetLatestSolvencySample :one
+SELECT 
+    block_number, block_time, balance_wei, deposited_wei, withdrawn_wei, gap_eth, alerting
+FROM 
+    solvency_samples
+ORDER BY 
+    block_number DESC
+LIMIT 1
+`
+
+type GetLatestSolvencySampleRow struct {
+	BlockNumber  int64
+	BlockTime    int64
+	BalanceWei   []byte
+	DepositedWei []byte
+	WithdrawnWei []byte
+	GapEth       float64
+	Alerting     bool
+}
+
+func (q *Queries) GetLatestSolvencySample(ctx context.Context) (GetLatestSolvencySampleRow, error) {
+	row := q.queryRow(ctx, q.getLatestSolvencySampleStmt, getLatestSolvencySample)
+	var i GetLatestSolvencySampleRow
+	err := row.Scan(
+		&i.BlockNumber,
+		&i.BlockTime,
+		&i.BalanceWei,
+		&i.DepositedWei,
+		&i.WithdrawnWei,
+		&i.GapEth,
+		&i.Alerting,
+	)
+	return i, err
+}
+
+const getLatestSyncStatusSample = `-- name: GetLatestSyncStatusSample :one
+SELECT 
+    id, created_at, observed_at, current_l1_number, head_l1_number, unsafe_l2_number, unsafe_l2_time, safe_l2_number, safe_l2_time, finalized_l2_number, finalized_l2_time
+FROM 
+    sync_status_samples
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestSyncStatusSample(ctx context.Context) (SyncStatusSample, error) {
+	row := q.queryRow(ctx, q.getLatestSyncStatusSampleStmt, getLatestSyncStatusSample)
+	var i SyncStatusSample
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ObservedAt,
+		&i.CurrentL1Number,
+		&i.HeadL1Number,
+		&i.UnsafeL2Number,
+		&i.UnsafeL2Time,
+		&i.SafeL2Number,
+		&i.SafeL2Time,
+		&i.FinalizedL2Number,
+		&i.FinalizedL2Time,
+	)
+	return i, err
+}
+
+const getLivenessIncident = `-- name: GetLivenessIncident :one
+SELECT 
+    id, created_at, kind, started_at, ended_at, l2_block_number, worst, detail
+FROM 
+    liveness_incidents
+WHERE 
+    id = ?
+`
+
+func (q *Queries) GetLivenessIncident(ctx context.Context, id int64) (LivenessIncident, error) {
+	row := q.queryRow(ctx, q.getLivenessIncidentStmt, getLivenessIncident, id)
+	var i LivenessIncident
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Kind,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.L2BlockNumber,
+		&i.Worst,
+		&i.Detail,
+	)
+	return i, err
+}
+
+const getMatchedAmountsWei = `-- name: GetMatchedAmountsWei :many
+SELECT 
+    amount_wei
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL
+`
+
+func (q *Queries) GetMatchedAmountsWei(ctx context.Context) ([][]byte, error) {
+	rows, err := q.query(ctx, q.getMatchedAmountsWeiStmt, getMatchedAmountsWei)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items [][]byte
+	for rows.Next() {
+		var amount_wei []byte
+		if err := rows.Scan(&amount_wei); err != nil {
+			return nil, err
+		}
+		items = append(items, amount_wei)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMatchedDeposits = `-- name: GetMatchedDeposits :many
+
+SELECT 
+    id,
+    from_address,
+    to_address,
+    amount,
+    amount_wei,
+    l1_block_number,
+    l2_block_number,
+    l1_timestamp,
+    l2_timestamp,
+    time_diff_seconds,
+    tx_hash_l1,
+    tx_hash_l2,
+    sort_key
+FROM (
+    SELECT 
+        l1.id,
+        l1.from_address,
+        l1.to_address,
+        l1.amount,
+        l1.amount_wei,
+        l1.block_number as l1_block_number,
+        l2.block_number as l2_block_number,
+        l1.block_timestamp as l1_timestamp,
+        l2.block_timestamp as l2_timestamp,
+        (l2.block_timestamp - l1.block_timestamp) as time_diff_seconds,
+        l1.tx_hash as tx_hash_l1,
+        l2.tx_hash as tx_hash_l2,
+        CASE ?1
+            WHEN 'largest' THEN l1.amount_wei
+            WHEN 'slowest' THEN l2.block_timestamp - l1.block_timestamp
+            ELSE l1.block_timestamp
+        END as sort_key
+    FROM 
+        l1_standard_bridge_eth_deposit_initiated l1
+    JOIN 
+        l2_standard_bridge_deposit_finalized l2 
+    ON 
+        l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+    WHERE 
+        (?2 IS NULL OR l1.from_address = ?2 OR l1.to_address = ?2) AND
+        (?3 IS NULL OR l1.block_timestamp >= ?3) AND
+        (?4 IS NULL OR l1.block_timestamp < ?4) AND
+        (?5 IS NULL OR l1.amount_wei >= ?5) AND
+        (?6 IS NULL OR l1.amount_wei <= ?6) AND
+        (?7 IS NULL OR l2.block_timestamp - l1.block_timestamp >= ?7) AND
+        (?8 IS NULL OR l2.block_timestamp - l1.block_timestamp <= ?8)
+)
+WHERE 
+    ?9 IS NULL OR
+    sort_key < ?10 OR
+    (sort_key = ?10 AND id < ?9)
+ORDER BY 
+    sort_key DESC, id DESC
+LIMIT ?11
+`
+
+type GetMatchedDepositsParams struct {
+	Sort         string
+	Address      []byte
+	Since        *int64
+	Until        *int64
+	MinAmountWei []byte
+	MaxAmountWei []byte
+	MinTimeDiff  interface{}
+	MaxTimeDiff  interface{}
+	CursorID     *int64
+	CursorKey    interface{}
+	Limit        int64
+}
+
+type GetMatchedDepositsRow struct {
+	ID              int64
+	FromAddress     []byte
+	ToAddress       []byte
+	Amount          float64
+	AmountWei       []byte
+	L1BlockNumber   int64
+	L2BlockNumber   int64
+	L1Timestamp     int64
+	L2Timestamp     int64
+	TimeDiffSeconds interface{}
+	TxHashL1        []byte
+	TxHashL2        []byte
+	SortKey         interface{}
+}
+
+// Web UI Queries
+func (q *Queries) GetMatchedDeposits(ctx context.Context, arg GetMatchedDepositsParams) ([]GetMatchedDepositsRow, error) {
+	rows, err := q.query(ctx, q.getMatchedDepositsStmt, getMatchedDeposits,
+		arg.Sort,
+		arg.Address,
+		arg.Since,
+		arg.Until,
+		arg.MinAmountWei,
+		arg.MaxAmountWei,
+		arg.MinTimeDiff,
+		arg.MaxTimeDiff,
+		arg.CursorID,
+		arg.CursorKey,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMatchedDepositsRow
+	for rows.Next() {
+		var i GetMatchedDepositsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAddress,
+			&i.ToAddress,
+			&i.Amount,
+			&i.AmountWei,
+			&i.L1BlockNumber,
+			&i.L2BlockNumber,
+			&i.L1Timestamp,
+			&i.L2Timestamp,
+			&i.TimeDiffSeconds,
+			&i.TxHashL1,
+			&i.TxHashL2,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpenLivenessIncident = `-- name: GetOpenLivenessIncident :one
+SELECT 
+    id, created_at, kind, started_at, ended_at, l2_block_number, worst, detail
+FROM 
+    liveness_incidents
+WHERE 
+    kind = ? AND ended_at IS NULL
+ORDER BY 
+    started_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetOpenLivenessIncident(ctx context.Context, kind string) (LivenessIncident, error) {
+	row := q.queryRow(ctx, q.getOpenLivenessIncidentStmt, getOpenLivenessIncident, kind)
+	var i LivenessIncident
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Kind,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.L2BlockNumber,
+		&i.Worst,
+		&i.Detail,
+	)
+	return i, err
+}
+
+const getOrphanedFinalizations = `-- name: GetOrphanedFinalizations :many
+SELECT 
+    id,
+    from_address,
+    to_address,
+    amount,
+    amount_wei,
+    l2_block_number,
+    l2_timestamp,
+    tx_hash_l2,
+    time_since_seconds,
+    sort_key
+FROM (
+    SELECT 
+        id,
+        from_address,
+        to_address,
+        amount,
+        amount_wei,
+        block_number as l2_block_number,
+        block_timestamp as l2_timestamp,
+        tx_hash as tx_hash_l2,
+        (strftime('%s', 'now') - block_timestamp) as time_since_seconds,
+        CASE ?1
+            WHEN 'largest' THEN amount_wei
+            WHEN 'slowest' THEN -block_timestamp
+            ELSE block_timestamp
+        END as sort_key
+    FROM 
+        l2_standard_bridge_deposit_finalized
+    WHERE 
+        matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL AND
+        (?2 IS NULL OR from_address = ?2 OR to_address = ?2) AND
+        (?3 IS NULL OR block_timestamp >= ?3) AND
+        (?4 IS NULL OR block_timestamp < ?4) AND
+        (?5 IS NULL OR amount_wei >= ?5) AND
+        (?6 IS NULL OR amount_wei <= ?6)
+)
+WHERE 
+    ?7 IS NULL OR
+    sort_key < ?8 OR
+    (sort_key = ?8 AND id < ?7)
+ORDER BY 
+    sort_key DESC, id DESC
+LIMIT ?9
+`
+
+type GetOrphanedFinalizationsParams struct {
+	Sort         string
+	Address      []byte
+	Since        *int64
+	Until        *int64
+	MinAmountWei []byte
+	MaxAmountWei []byte
+	CursorID     *int64
+	CursorKey    interface{}
+	Limit        int64
+}
+
+type GetOrphanedFinalizationsRow struct {
+	ID               int64
+	FromAddress      []byte
+	ToAddress        []byte
+	Amount           float64
+	AmountWei        []byte
+	L2BlockNumber    int64
+	L2Timestamp      int64
+	TxHashL2         []byte
+	TimeSinceSeconds interface{}
+	SortKey          interface{}
+}
+
+func (q *Queries) GetOrphanedFinalizations(ctx context.Context, arg GetOrphanedFinalizationsParams) ([]GetOrphanedFinalizationsRow, error) {
+	rows, err := q.query(ctx, q.getOrphanedFinalizationsStmt, getOrphanedFinalizations,
+		arg.Sort,
+		arg.Address,
+		arg.Since,
+		arg.Until,
+		arg.MinAmountWei,
+		arg.MaxAmountWei,
+		arg.CursorID,
+		arg.CursorKey,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOrphanedFinalizationsRow
+	for rows.Next() {
+		var i GetOrphanedFinalizationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAddress,
+			&i.ToAddress,
+			&i.Amount,
+			&i.AmountWei,
+			&i.L2BlockNumber,
+			&i.L2Timestamp,
+			&i.TxHashL2,
+			&i.TimeSinceSeconds,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingDeposits = `-- name: GetPendingDeposits :one
+SELECT 
+    COUNT(*) 
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    matched_l2_standard_bridge_deposit_finalized_id IS NULL
+`
+
+func (q *Queries) GetPendingDeposits(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.getPendingDepositsStmt, getPendingDeposits)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getTimeSeriesChartData = `-- name: GetTimeSeriesChartData :many
+SELECT 
+    l1.block_timestamp as timestamp,
+    (l2.block_timestamp - l1.block_timestamp) as time_diff_seconds
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+ORDER BY 
+    l1.block_timestamp DESC
+LIMIT ?
+`
+
+type GetTimeSeriesChartDataRow struct {
+	Timestamp       int64
+	TimeDiffSeconds interface{}
+}
+
+func (q *Queries) GetTimeSeriesChartData(ctx context.Context, limit int64) ([]GetTimeSeriesChartDataRow, error) {
+	rows, err := q.query(ctx, q.getTimeSeriesChartDataStmt, getTimeSeriesChartData, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTimeSeriesChartDataRow
+	for rows.Next() {
+		var i GetTimeSeriesChartDataRow
+		if err := rows.Scan(&i.Timestamp, &i.TimeDiffSeconds); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTotalAmbiguousGroups = `-- name: GetTotalAmbiguousGroups :one
+SELECT COUNT(*) FROM (
+    SELECT l1.matching_hash
+    FROM l1_standard_bridge_eth_deposit_initiated l1
+    GROUP BY l1.matching_hash
+    HAVING 
+        COUNT(*) > 1 OR
+        (SELECT COUNT(*) FROM l2_standard_bridge_deposit_finalized l2 WHERE l2.matching_hash = l1.matching_hash) > 1
+)
+`
+
+func (q *Queries) GetTotalAmbiguousGroups(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.getTotalAmbiguousGroupsStmt, getTotalAmbiguousGroups)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getTotalDepositsByAddress = `-- name: GetTotalDepositsByAddress :one
+SELECT 
+    COUNT(*)
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    from_address = ?1 OR to_address = ?1
+`
+
+func (q *Queries) GetTotalDepositsByAddress(ctx context.Context, address []byte) (int64, error) {
+	row := q.queryRow(ctx, q.getTotalDepositsByAddressStmt, getTotalDepositsByAddress, address)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getTotalMatchedDeposits = `-- name: GetTotalMatchedDeposits :one
+SELECT 
+    COUNT(*)
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    (?1 IS NULL OR l1.from_address = ?1 OR l1.to_address = ?1) AND
+    (?2 IS NULL OR l1.block_timestamp >= ?2) AND
+    (?3 IS NULL OR l1.block_timestamp < ?3) AND
+    (?4 IS NULL OR l1.amount_wei >= ?4) AND
+    (?5 IS NULL OR l1.amount_wei <= ?5) AND
+    (?6 IS NULL OR l2.block_timestamp - l1.block_timestamp >= ?6) AND
+    (?7 IS NULL OR l2.block_timestamp - l1.block_timestamp <= ?7)
+`
+
+type GetTotalMatchedDepositsParams struct {
+	Address      []byte
+	Since        *int64
+	Until        *int64
+	MinAmountWei []byte
+	MaxAmountWei []byte
+	MinTimeDiff  interface{}
+	MaxTimeDiff  interface{}
+}
+
+func (q *Queries) GetTotalMatchedDeposits(ctx context.Context, arg GetTotalMatchedDepositsParams) (int64, error) {
+	row := q.queryRow(ctx, q.getTotalMatchedDepositsStmt, getTotalMatchedDeposits,
+		arg.Address,
+		arg.Since,
+		arg.Until,
+		arg.MinAmountWei,
+		arg.MaxAmountWei,
+		arg.MinTimeDiff,
+		arg.MaxTimeDiff,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getTotalOrphanedFinalizations = `-- name: GetTotalOrphanedFinalizations :one
+SELECT 
+    COUNT(*) 
+FROM 
+    l2_standard_bridge_deposit_finalized
+WHERE 
+    matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL AND
+    (?1 IS NULL OR from_address = ?1 OR to_address = ?1) AND
+    (?2 IS NULL OR block_timestamp >= ?2) AND
+    (?3 IS NULL OR block_timestamp < ?3) AND
+    (?4 IS NULL OR amount_wei >= ?4) AND
+    (?5 IS NULL OR amount_wei <= ?5)
+`
+
+type GetTotalOrphanedFinalizationsParams struct {
+	Address      []byte
+	Since        *int64
+	Until        *int64
+	MinAmountWei []byte
+	MaxAmountWei []byte
+}
+
+func (q *Queries) GetTotalOrphanedFinalizations(ctx context.Context, arg GetTotalOrphanedFinalizationsParams) (int64, error) {
+	row := q.queryRow(ctx, q.getTotalOrphanedFinalizationsStmt, getTotalOrphanedFinalizations,
+		arg.Address,
+		arg.Since,
+		arg.Until,
+		arg.MinAmountWei,
+		arg.MaxAmountWei,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getTotalUnmatchedDeposits = `-- name: GetTotalUnmatchedDeposits :one
+SELECT 
+    COUNT(*) 
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    matched_l2_standard_bridge_deposit_finalized_id IS NULL AND
+    (?1 IS NULL OR from_address = ?1 OR to_address = ?1) AND
+    (?2 IS NULL OR block_timestamp >= ?2) AND
+    (?3 IS NULL OR block_timestamp < ?3) AND
+    (?4 IS NULL OR amount_wei >= ?4) AND
+    (?5 IS NULL OR amount_wei <= ?5)
+`
+
+type GetTotalUnmatchedDepositsParams struct {
+	Address      []byte
+	Since        *int64
+	Until        *int64
+	MinAmountWei []byte
+	MaxAmountWei []byte
+}
+
+func (q *Queries) GetTotalUnmatchedDeposits(ctx context.Context, arg GetTotalUnmatchedDepositsParams) (int64, error) {
+	row := q.queryRow(ctx, q.getTotalUnmatchedDepositsStmt, getTotalUnmatchedDeposits,
+		arg.Address,
+		arg.Since,
+		arg.Until,
+		arg.MinAmountWei,
+		arg.MaxAmountWei,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getUnmatchedDeposits = `-- name: GetUnmatchedDeposits :many
+SELECT 
+    id,
+    from_address,
+    to_address,
+    amount,
+    amount_wei,
+    l1_block_number,
+    l1_timestamp,
+    tx_hash_l1,
+    time_since_seconds,
+    sort_key
+FROM (
+    SELECT 
+        id,
+        from_address,
+        to_address,
+        amount,
+        amount_wei,
+        block_number as l1_block_number,
+        block_timestamp as l1_timestamp,
+        tx_hash as tx_hash_l1,
+        (strftime('%s', 'now') - block_timestamp) as time_since_seconds,
+        CASE ?1
+            WHEN 'largest' THEN amount_wei
+            WHEN 'slowest' THEN -block_timestamp
+            ELSE block_timestamp
+        END as sort_key
+    FROM 
+        l1_standard_bridge_eth_deposit_initiated
+    WHERE 
+        matched_l2_standard_bridge_deposit_finalized_id IS NULL AND
+        (?2 IS NULL OR from_address = ?2 OR to_address = ?2) AND
+        (?3 IS NULL OR block_timestamp >= ?3) AND
+        (?4 IS NULL OR block_timestamp < ?4) AND
+        (?5 IS NULL OR amount_wei >= ?5) AND
+        (?6 IS NULL OR amount_wei <= ?6)
+)
+WHERE 
+    ?7 IS NULL OR
+    sort_key < ?8 OR
+    (sort_key = ?8 AND id < ?7)
+ORDER BY 
+    sort_key DESC, id DESC
+LIMIT ?9
+`
+
+type GetUnmatchedDepositsParams struct {
+	Sort         string
+	Address      []byte
+	Since        *int64
+	Until        *int64
+	MinAmountWei []byte
+	MaxAmountWei []byte
+	CursorID     *int64
+	CursorKey    interface{}
+	Limit        int64
+}
+
+type GetUnmatchedDepositsRow struct {
+	ID               int64
+	FromAddress      []byte
+	ToAddress        []byte
+	Amount           float64
+	AmountWei        []byte
+	L1BlockNumber    int64
+	L1Timestamp      int64
+	TxHashL1         []byte
+	TimeSinceSeconds interface{}
+	SortKey          interface{}
+}
+
+func (q *Queries) GetUnmatchedDeposits(ctx context.Context, arg GetUnmatchedDepositsParams) ([]GetUnmatchedDepositsRow, error) {
+	rows, err := q.query(ctx, q.getUnmatchedDepositsStmt, getUnmatchedDeposits,
+		arg.Sort,
+		arg.Address,
+		arg.Since,
+		arg.Until,
+		arg.MinAmountWei,
+		arg.MaxAmountWei,
+		arg.CursorID,
+		arg.CursorKey,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUnmatchedDepositsRow
+	for rows.Next() {
+		var i GetUnmatchedDepositsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAddress,
+			&i.ToAddress,
+			&i.Amount,
+			&i.AmountWei,
+			&i.L1BlockNumber,
+			&i.L1Timestamp,
+			&i.TxHashL1,
+			&i.TimeSinceSeconds,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertBatcherTransaction = `-- name: InsertBatcherTransaction :exec
+
+INSERT OR IGNORE INTO batcher_transactions (
+    block_number,
+    block_time,
+    tx_hash,
+    blob,
+    blob_count,
+    data_size
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertBatcherTransactionParams struct {
+	BlockNumber int64
+	BlockTime   int64
+	TxHash      []byte
+	Blob        bool
+	BlobCount   int64
+	DataSize    int64
+}
+
+// Batcher Queries
+func (q *Queries) InsertBatcherTransaction(ctx context.Context, arg InsertBatcherTransactionParams) error {
+	_, err := q.exec(ctx, q.insertBatcherTransactionStmt, insertBatcherTransaction,
+		arg.BlockNumber,
+		arg.BlockTime,
+		arg.TxHash,
+		arg.Blob,
+		arg.BlobCount,
+		arg.DataSize,
+	)
+	return err
+}
+
+const insertBlockPointer = `-- name: InsertBlockPointer :exec
+
+INSERT OR IGNORE INTO BLOCK_POINTERS (name, block_number, block_time) VALUES (?, NULL, NULL)
+`
+
+// Indexed Event Queries
+func (q *Queries) InsertBlockPointer(ctx context.Context, name string) error {
+	_, err := q.exec(ctx, q.insertBlockPointerStmt, insertBlockPointer, name)
+	return err
+}
+
+const insertBlockPointerHash = `-- name: InsertBlockPointerHash :exec
+
+INSERT OR REPLACE INTO block_pointer_hashes (pointer, block_number, block_hash) VALUES (?, ?, ?)
+`
+
+type InsertBlockPointerHashParams struct {
+	Pointer     string
+	BlockNumber int64
+	BlockHash   []byte
+}
+
+// Block Pointer Hash Queries
+func (q *Queries) InsertBlockPointerHash(ctx context.Context, arg InsertBlockPointerHashParams) error {
+	_, err := q.exec(ctx, q.insertBlockPointerHashStmt, insertBlockPointerHash, arg.Pointer, arg.BlockNumber, arg.BlockHash)
+	return err
+}
+
+const insertChainHead = `-- name: InsertChainHead :exec
+
+INSERT OR IGNORE INTO chain_heads (
+    chain,
+    label,
+    block_number,
+    block_time,
+    previous_block_number,
+    observed_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertChainHeadParams struct {
+	Chain               string
+	Label               string
+	BlockNumber         int64
+	BlockTime           int64
+	PreviousBlockNumber *int64
+	ObservedAt          int64
+}
+
+// Latency Queries
+func (q *Queries) InsertChainHead(ctx context.Context, arg InsertChainHeadParams) error {
+	_, err := q.exec(ctx, q.insertChainHeadStmt, insertChainHead,
+		arg.Chain,
+		arg.Label,
+		arg.BlockNumber,
+		arg.BlockTime,
+		arg.PreviousBlockNumber,
+		arg.ObservedAt,
+	)
+	return err
+}
+
+const insertDisputeGame = `-- name: InsertDisputeGame :exec
+
+INSERT OR IGNORE INTO dispute_games (
+    game_address,
+    game_type,
+    root_claim,
+    l2_block_number,
+    block_number,
+    block_time,
+    tx_hash
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertDisputeGameParams struct {
+	GameAddress   []byte
+	GameType      int64
+	RootClaim     []byte
+	L2BlockNumber int64
+	BlockNumber   int64
+	BlockTime     int64
+	TxHash        []byte
+}
+
+// Dispute Game Queries
+func (q *Queries) InsertDisputeGame(ctx context.Context, arg InsertDisputeGameParams) error {
+	_, err := q.exec(ctx, q.insertDisputeGameStmt, insertDisputeGame,
+		arg.GameAddress,
+		arg.GameType,
+		arg.RootClaim,
+		arg.L2BlockNumber,
+		arg.BlockNumber,
+		arg.BlockTime,
+		arg.TxHash,
+	)
+	return err
+}
+
+const insertIndexedEvent = `-- name: InsertIndexedEvent :exec
+INSERT OR IGNORE INTO indexed_events (
+    source,
+    chain,
+    contract,
+    event_name,
+    block_number,
+    block_timestamp,
+    tx_hash,
+    log_index,
+    args
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertIndexedEventParams struct {
+	Source         string
+	Chain          string
+	Contract       []byte
+	EventName      string
+	BlockNumber    int64
+	BlockTimestamp int64
+	TxHash         []byte
+	LogIndex       int64
+	Args           string
+}
+
+func (q *Queries) InsertIndexedEvent(ctx context.Context, arg InsertIndexedEventParams) error {
+	_, err := q.exec(ctx, q.insertIndexedEventStmt, insertIndexedEvent,
+		arg.Source,
+		arg.Chain,
+		arg.Contract,
+		arg.EventName,
+		arg.BlockNumber,
+		arg.BlockTimestamp,
+		arg.TxHash,
+		arg.LogIndex,
+		arg.Args,
+	)
+	return err
+}
+
+const insertL1StandardBridgeETHDepositInitiated = `-- name: InsertL1StandardBridgeETHDepositInitiated :one
+INSERT INTO l1_standard_bridge_eth_deposit_initiated (
+    block_number,
+    block_timestamp,
+    tx_hash,
+    from_address,
+    to_address,
+    amount,
+    amount_wei,
+    event,
+    matching_hash
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+) RETURNING id
+`
+
+type InsertL1StandardBridgeETHDepositInitiatedParams struct {
+	BlockNumber    int64
+	BlockTimestamp int64
+	TxHash         []byte
+	FromAddress    []byte
+	ToAddress      []byte
+	Amount         float64
+	AmountWei      []byte
+	Event          []byte
+	MatchingHash   []byte
+}
+
+func (q *Queries) InsertL1StandardBridgeETHDepositInitiated(ctx context.Context, arg InsertL1StandardBridgeETHDepositInitiatedParams) (int64, error) {
+	row := q.queryRow(ctx, q.insertL1StandardBridgeETHDepositInitiatedStmt, insertL1StandardBridgeETHDepositInitiated,
+		arg.BlockNumber,
+		arg.BlockTimestamp,
+		arg.TxHash,
+		arg.FromAddress,
+		arg.ToAddress,
+		arg.Amount,
+		arg.AmountWei,
+		arg.Event,
+		arg.MatchingHash,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertL1WithdrawalFinalized = `-- name: InsertL1WithdrawalFinalized :exec
+
+INSERT OR IGNORE INTO l1_standard_bridge_eth_withdrawal_finalized (
+    block_number,
+    tx_hash,
+    log_index,
+    from_address,
+    to_address,
+    amount_wei
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertL1WithdrawalFinalizedParams struct {
+	BlockNumber int64
+	TxHash      []byte
+	LogIndex    int64
+	FromAddress []byte
+	ToAddress   []byte
+	AmountWei   []byte
+}
+
+// Solvency Queries
+func (q *Queries) InsertL1WithdrawalFinalized(ctx context.Context, arg InsertL1WithdrawalFinalizedParams) error {
+	_, err := q.exec(ctx, q.insertL1WithdrawalFinalizedStmt, insertL1WithdrawalFinalized,
+		arg.BlockNumber,
+		arg.TxHash,
+		arg.LogIndex,
+		arg.FromAddress,
+		arg.ToAddress,
+		arg.AmountWei,
+	)
+	return err
+}
+
+const insertL2StandardBridgeDepositFinalized = `-- name: InsertL2StandardBridgeDepositFinalized :one
+INSERT INTO l2_standard_bridge_deposit_finalized (
+    block_number,
+    block_timestamp,
+    tx_hash,
+    from_address,
+    to_address,
+    l1_token,
+    amount,
+    amount_wei,
+    event,
+    matching_hash
+) VALUES (
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?,
+    ?
+) RETURNING id
+`
+
+type InsertL2StandardBridgeDepositFinalizedParams struct {
+	BlockNumber    int64
+	BlockTimestamp int64
+	TxHash         []byte
+	FromAddress    []byte
+	ToAddress      []byte
+	L1Token        []byte
+	Amount         float64
+	AmountWei      []byte
+	Event          []byte
+	MatchingHash   []byte
+}
+
+func (q *Queries) InsertL2StandardBridgeDepositFinalized(ctx context.Context, arg InsertL2StandardBridgeDepositFinalizedParams) (int64, error) {
+	row := q.queryRow(ctx, q.insertL2StandardBridgeDepositFinalizedStmt, insertL2StandardBridgeDepositFinalized,
+		arg.BlockNumber,
+		arg.BlockTimestamp,
+		arg.TxHash,
+		arg.FromAddress,
+		arg.ToAddress,
+		arg.L1Token,
+		arg.Amount,
+		arg.AmountWei,
+		arg.Event,
+		arg.MatchingHash,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertLivenessIncident = `-- name: InsertLivenessIncident :one
+
+INSERT INTO liveness_incidents (
+    kind,
+    started_at,
+    l2_block_number,
+    worst,
+    detail
+) VALUES (
+    ?, ?, ?, ?, ?
+) RETURNING id
+`
+
+type InsertLivenessIncidentParams struct {
+	Kind          string
+	StartedAt     int64
+	L2BlockNumber int64
+	Worst         int64
+	Detail        string
+}
+
+// Liveness Queries
+func (q *Queries) InsertLivenessIncident(ctx context.Context, arg InsertLivenessIncidentParams) (int64, error) {
+	row := q.queryRow(ctx, q.insertLivenessIncidentStmt, insertLivenessIncident,
+		arg.Kind,
+		arg.StartedAt,
+		arg.L2BlockNumber,
+		arg.Worst,
+		arg.Detail,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertSecurityFinding = `-- name: InsertSecurityFinding :exec
+
+INSERT OR IGNORE INTO security_findings (
+    observed_at,
+    block_number,
+    tx_hash,
+    log_index,
+    contract,
+    contract_name,
+    kind,
+    severity,
+    value,
+    detail
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertSecurityFindingParams struct {
+	ObservedAt   int64
+	BlockNumber  int64
+	TxHash       []byte
+	LogIndex     *int64
+	Contract     []byte
+	ContractName string
+	Kind         string
+	Severity     string
+	Value        []byte
+	Detail       string
+}
+
+// Security Queries
+func (q *Queries) InsertSecurityFinding(ctx context.Context, arg InsertSecurityFindingParams) error {
+	_, err := q.exec(ctx, q.insertSecurityFindingStmt, insertSecurityFinding,
+		arg.ObservedAt,
+		arg.BlockNumber,
+		arg.TxHash,
+		arg.LogIndex,
+		arg.Contract,
+		arg.ContractName,
+		arg.Kind,
+		arg.Severity,
+		arg.Value,
+		arg.Detail,
+	)
+	return err
+}
+
+const insertSolvencySample = `-- name: InsertSolvencySample :exec
+INSERT OR IGNORE INTO solvency_samples (
+    block_number,
+    block_time,
+    balance_wei,
+    deposited_wei,
+    withdrawn_wei,
+    gap_eth,
+    alerting
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertSolvencySampleParams struct {
+	BlockNumber  int64
+	BlockTime    int64
+	BalanceWei   []byte
+	DepositedWei []byte
+	WithdrawnWei []byte
+	GapEth       float64
+	Alerting     bool
+}
+
+func (q *Queries) InsertSolvencySample(ctx context.Context, arg InsertSolvencySampleParams) error {
+	_, err := q.exec(ctx, q.insertSolvencySampleStmt, insertSolvencySample,
+		arg.BlockNumber,
+		arg.BlockTime,
+		arg.BalanceWei,
+		arg.DepositedWei,
+		arg.WithdrawnWei,
+		arg.GapEth,
+		arg.Alerting,
+	)
+	return err
+}
+
+const insertSyncStatusSample = `-- name: InsertSyncStatusSample :exec
+INSERT INTO sync_status_samples (
+    observed_at,
+    current_l1_number,
+    head_l1_number,
+    unsafe_l2_number,
+    unsafe_l2_time,
+    safe_l2_number,
+    safe_l2_time,
+    finalized_l2_number,
+    finalized_l2_time
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type InsertSyncStatusSampleParams struct {
+	ObservedAt        int64
+	CurrentL1Number   int64
+	HeadL1Number      int64
+	UnsafeL2Number    int64
+	UnsafeL2Time      int64
+	SafeL2Number      int64
+	SafeL2Time        int64
+	FinalizedL2Number int64
+	FinalizedL2Time   int64
+}
+
+func (q *Queries) InsertSyncStatusSample(ctx context.Context, arg InsertSyncStatusSampleParams) error {
+	_, err := q.exec(ctx, q.insertSyncStatusSampleStmt, insertSyncStatusSample,
+		arg.ObservedAt,
+		arg.CurrentL1Number,
+		arg.HeadL1Number,
+		arg.UnsafeL2Number,
+		arg.UnsafeL2Time,
+		arg.SafeL2Number,
+		arg.SafeL2Time,
+		arg.FinalizedL2Number,
+		arg.FinalizedL2Time,
+	)
+	return err
+}
+
+const listBatcherTransactions = `-- name: ListBatcherTransactions :many
+SELECT 
+    id, created_at, block_number, block_time, tx_hash, blob, blob_count, data_size
+FROM 
+    batcher_transactions
+WHERE 
+    block_time >= ?1 AND block_time < ?2
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT ?3
+`
+
+type ListBatcherTransactionsParams struct {
+	Since int64
+	Until int64
+	Limit int64
+}
+
+func (q *Queries) ListBatcherTransactions(ctx context.Context, arg ListBatcherTransactionsParams) ([]BatcherTransaction, error) {
+	rows, err := q.query(ctx, q.listBatcherTransactionsStmt, listBatcherTransactions, arg.Since, arg.Until, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BatcherTransaction
+	for rows.Next() {
+		var i BatcherTransaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.BlockNumber,
+			&i.BlockTime,
+			&i.TxHash,
+			&i.Blob,
+			&i.BlobCount,
+			&i.DataSize,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlockPointerHashes = `-- name: ListBlockPointerHashes :many
+SELECT block_number, block_hash FROM block_pointer_hashes WHERE pointer = ? AND block_number <= ? ORDER BY block_number DESC
+`
+
+type ListBlockPointerHashesParams struct {
+	Pointer     string
+	BlockNumber int64
+}
+
+type ListBlockPointerHashesRow struct {
+	BlockNumber int64
+	BlockHash   []byte
+}
+
+func (q *Queries) ListBlockPointerHashes(ctx context.Context, arg ListBlockPointerHashesParams) ([]ListBlockPointerHashesRow, error) {
+	rows, err := q.query(ctx, q.listBlockPointerHashesStmt, listBlockPointerHashes, arg.Pointer, arg.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBlockPointerHashesRow
+	for rows.Next() {
+		var i ListBlockPointerHashesRow
+		if err := rows.Scan(&i.BlockNumber, &i.BlockHash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlockPointers = `-- name: ListBlockPointers :many
+SELECT name, block_number, block_time FROM BLOCK_POINTERS ORDER BY name
+`
+
+func (q *Queries) ListBlockPointers(ctx context.Context) ([]BLOCKPOINTER, error) {
+	rows, err := q.query(ctx, q.listBlockPointersStmt, listBlockPointers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BLOCKPOINTER
+	for rows.Next() {
+		var i BLOCKPOINTER
+		if err := rows.Scan(&i.Name, &i.BlockNumber, &i.BlockTime); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCriticalDisputeGames = `-- name: ListCriticalDisputeGames :many
+SELECT 
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
+FROM 
+    dispute_games
+WHERE 
+    critical_reason IS NOT NULL AND
+    (?1 IS NULL OR
+    block_number < ?2 OR
+    (block_number = ?2 AND id < ?1))
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT ?3
+`
+
+type ListCriticalDisputeGamesParams struct {
+	CursorID  *int64
+	CursorKey interface{}
+	Limit     int64
+}
+
+func (q *Queries) ListCriticalDisputeGames(ctx context.Context, arg ListCriticalDisputeGamesParams) ([]DisputeGame, error) {
+	rows, err := q.query(ctx, q.listCriticalDisputeGamesStmt, listCriticalDisputeGames, arg.CursorID, arg.CursorKey, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DisputeGame
+	for rows.Next() {
+		var i DisputeGame
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.GameAddress,
+			&i.GameType,
+			&i.RootClaim,
+			&i.L2BlockNumber,
+			&i.BlockNumber,
+			&i.BlockTime,
+			&i.TxHash,
+			&i.Status,
+			&i.ResolvedAt,
+			&i.ExpectedRoot,
+			&i.Valid,
+			&i.CriticalReason,
+			&i.OutputRootError,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDepositLatencies = `-- name: ListDepositLatencies :many
+SELECT 
+    l1.id,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.l1_origin_number,
+    l2.l1_origin_timestamp,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l1' AND h.label = 'safe' AND
+            h.previous_block_number < l1.block_number AND h.block_number >= l1.block_number
+    ) as l1_safe_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l1' AND h.label = 'finalized' AND
+            h.previous_block_number < l1.block_number AND h.block_number >= l1.block_number
+    ) as l1_finalized_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l2' AND h.label = 'safe' AND
+            h.previous_block_number < l2.block_number AND h.block_number >= l2.block_number
+    ) as l2_safe_at,
+    (
+        SELECT MIN(h.observed_at) FROM chain_heads h
+        WHERE h.chain = 'l2' AND h.label = 'finalized' AND
+            h.previous_block_number < l2.block_number AND h.block_number >= l2.block_number
+    ) as l2_finalized_at
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.block_timestamp >= ?1 AND l1.block_timestamp < ?2
+ORDER BY 
+    l1.block_timestamp DESC, l1.id DESC
+LIMIT ?3
+`
+
+type ListDepositLatenciesParams struct {
+	Since int64
+	Until int64
+	Limit int64
+}
+
+type ListDepositLatenciesRow struct {
+	ID                int64
+	L1BlockNumber     int64
+	L1Timestamp       int64
+	L2BlockNumber     *int64
+	L2Timestamp       *int64
+	L1OriginNumber    *int64
+	L1OriginTimestamp *int64
+	L1SafeAt          interface{}
+	L1FinalizedAt     interface{}
+	L2SafeAt          interface{}
+	L2FinalizedAt     interface{}
+}
+
+func (q *Queries) ListDepositLatencies(ctx context.Context, arg ListDepositLatenciesParams) ([]ListDepositLatenciesRow, error) {
+	rows, err := q.query(ctx, q.listDepositLatenciesStmt, listDepositLatencies, arg.Since, arg.Until, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDepositLatenciesRow
+	for rows.Next() {
+		var i ListDepositLatenciesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.L1BlockNumber,
+			&i.L1Timestamp,
+			&i.L2BlockNumber,
+			&i.L2Timestamp,
+			&i.L1OriginNumber,
+			&i.L1OriginTimestamp,
+			&i.L1SafeAt,
+			&i.L1FinalizedAt,
+			&i.L2SafeAt,
+			&i.L2FinalizedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDepositsInFlight = `-- name: ListDepositsInFlight :many
+SELECT 
+    l1.id,
+    l1.from_address,
+    l1.to_address,
+    l1.amount,
+    l1.amount_wei,
+    l1.block_number as l1_block_number,
+    l1.block_timestamp as l1_timestamp,
+    l1.tx_hash as tx_hash_l1,
+    l2.id as l2_id,
+    l2.block_number as l2_block_number,
+    l2.block_timestamp as l2_timestamp,
+    l2.tx_hash as tx_hash_l2
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+LEFT JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+WHERE 
+    l1.block_timestamp <= ?1 AND (l2.id IS NULL OR l2.block_timestamp >= ?2) AND
+    (?3 IS NULL OR
+    l1.block_timestamp < ?4 OR
+    (l1.block_timestamp = ?4 AND l1.id < ?3))
+ORDER BY 
+    l1.block_timestamp DESC, l1.id DESC
+LIMIT ?5
+`
+
+type ListDepositsInFlightParams struct {
+	End       int64
+	Start     *int64
+	CursorID  *int64
+	CursorKey interface{}
+	Limit     int64
+}
+
+type ListDepositsInFlightRow struct {
+	ID            int64
+	FromAddress   []byte
+	ToAddress     []byte
+	Amount        float64
+	AmountWei     []byte
+	L1BlockNumber int64
+	L1Timestamp   int64
+	TxHashL1      []byte
+	L2ID          *int64
+	L2BlockNumber *int64
+	L2Timestamp   *int64
+	TxHashL2      []byte
+}
+
+func (q *Queries) ListDepositsInFlight(ctx context.Context, arg ListDepositsInFlightParams) ([]ListDepositsInFlightRow, error) {
+	rows, err := q.query(ctx, q.listDepositsInFlightStmt, listDepositsInFlight,
+		arg.End,
+		arg.Start,
+		arg.CursorID,
+		arg.CursorKey,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDepositsInFlightRow
+	for rows.Next() {
+		var i ListDepositsInFlightRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAddress,
+			&i.ToAddress,
+			&i.Amount,
+			&i.AmountWei,
+			&i.L1BlockNumber,
+			&i.L1Timestamp,
+			&i.TxHashL1,
+			&i.L2ID,
+			&i.L2BlockNumber,
+			&i.L2Timestamp,
+			&i.TxHashL2,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDisputeGames = `-- name: ListDisputeGames :many
+SELECT 
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
+FROM 
+    dispute_games
+WHERE 
+    ?1 IS NULL OR
+    block_number < ?2 OR
+    (block_number = ?2 AND id < ?1)
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT ?3
+`
+
+type ListDisputeGamesParams struct {
+	CursorID  *int64
+	CursorKey interface{}
+	Limit     int64
+}
+
+func (q *Queries) ListDisputeGames(ctx context.Context, arg ListDisputeGamesParams) ([]DisputeGame, error) {
+	rows, err := q.query(ctx, q.listDisputeGamesStmt, listDisputeGames, arg.CursorID, arg.CursorKey, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DisputeGame
+	for rows.Next() {
+		var i DisputeGame
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.GameAddress,
+			&i.GameType,
+			&i.RootClaim,
+			&i.L2BlockNumber,
+			&i.BlockNumber,
+			&i.BlockTime,
+			&i.TxHash,
+			&i.Status,
+			&i.ResolvedAt,
+			&i.ExpectedRoot,
+			&i.Valid,
+			&i.CriticalReason,
+			&i.OutputRootError,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIndexedEventSources = `-- name: ListIndexedEventSources :many
+SELECT 
+    source, chain, contract, event_name, COUNT(*) AS count
+FROM 
+    indexed_events
+GROUP BY 
+    source, chain, contract, event_name
+ORDER BY 
+    source
+`
+
+type ListIndexedEventSourcesRow struct {
+	Source    string
+	Chain     string
+	Contract  []byte
+	EventName string
+	Count     int64
+}
+
+func (q *Queries) ListIndexedEventSources(ctx context.Context) ([]ListIndexedEventSourcesRow, error) {
+	rows, err := q.query(ctx, q.listIndexedEventSourcesStmt, listIndexedEventSources)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListIndexedEventSourcesRow
+	for rows.Next() {
+		var i ListIndexedEventSourcesRow
+		if err := rows.Scan(
+			&i.Source,
+			&i.Chain,
+			&i.Contract,
+			&i.EventName,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIndexedEvents = `-- name: ListIndexedEvents :many
+SELECT 
+    id, created_at, source, chain, contract, event_name, block_number, block_timestamp, tx_hash, log_index, args
+FROM 
+    indexed_events
+WHERE 
+    source = ?1 AND
+    (?2 IS NULL OR
+    block_number < ?3 OR
+    (block_number = ?3 AND id < ?2))
+ORDER BY 
+    block_number DESC, id DESC
+LIMIT ?4
+`
+
+type ListIndexedEventsParams struct {
+	Source    string
+	CursorID  *int64
+	CursorKey interface{}
+	Limit     int64
+}
+
+func (q *Queries) ListIndexedEvents(ctx context.Context, arg ListIndexedEventsParams) ([]IndexedEvent, error) {
+	rows, err := q.query(ctx, q.listIndexedEventsStmt, listIndexedEvents,
+		arg.Source,
+		arg.CursorID,
+		arg.CursorKey,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []IndexedEvent
+	for rows.Next() {
+		var i IndexedEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Source,
+			&i.Chain,
+			&i.Contract,
+			&i.EventName,
+			&i.BlockNumber,
+			&i.BlockTimestamp,
+			&i.TxHash,
+			&i.LogIndex,
+			&i.Args,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listL1AmountsInRange = `-- name: ListL1AmountsInRange :many
+SELECT 
+    block_timestamp,
+    amount_wei,
+    matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL as matched
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    block_timestamp >= ?1 AND block_timestamp < ?2
+`
+
+type ListL1AmountsInRangeParams struct {
+	Since int64
+	Until int64
+}
+
+type ListL1AmountsInRangeRow struct {
+	BlockTimestamp int64
+	AmountWei      []byte
+	Matched        bool
+}
+
+func (q *Queries) ListL1AmountsInRange(ctx context.Context, arg ListL1AmountsInRangeParams) ([]ListL1AmountsInRangeRow, error) {
+	rows, err := q.query(ctx, q.listL1AmountsInRangeStmt, listL1AmountsInRange, arg.Since, arg.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListL1AmountsInRangeRow
+	for rows.Next() {
+		var i ListL1AmountsInRangeRow
+		if err := rows.Scan(&i.BlockTimestamp, &i.AmountWei, &i.Matched); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listL1DepositAmountsUpToBlock = `-- name: ListL1DepositAmountsUpToBlock :many
+SELECT 
+    amount_wei
+FROM 
+    l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    block_number <= ?
+`
+
+func (q *Queries) ListL1DepositAmountsUpToBlock(ctx context.Context, blockNumber int64) ([][]byte, error) {
+	rows, err := q.query(ctx, q.listL1DepositAmountsUpToBlockStmt, listL1DepositAmountsUpToBlock, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items [][]byte
+	for rows.Next() {
+		var amount_wei []byte
+		if err := rows.Scan(&amount_wei); err != nil {
+			return nil, err
+		}
+		items = append(items, amount_wei)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listL1LogsInRange = `-- name: ListL1LogsInRange :many
+
+SELECT 
+    id,
+    block_number,
+    tx_hash,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.logIndex'), '') as log_index,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.blockHash'), '') as block_hash,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.data'), '') as data
+FROM l1_standard_bridge_eth_deposit_initiated
+WHERE block_number BETWEEN ?1 AND ?2
+ORDER BY block_number ASC, id ASC
+`
+
+type ListL1LogsInRangeParams struct {
+	FromBlock int64
+	ToBlock   int64
+}
+
+type ListL1LogsInRangeRow struct {
+	ID          int64
+	BlockNumber int64
+	TxHash      []byte
+	LogIndex    string
+	BlockHash   string
+	Data        string
+}
+
+// Verification Queries
+func (q *Queries) ListL1LogsInRange(ctx context.Context, arg ListL1LogsInRangeParams) ([]ListL1LogsInRangeRow, error) {
+	rows, err := q.query(ctx, q.listL1LogsInRangeStmt, listL1LogsInRange, arg.FromBlock, arg.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListL1LogsInRangeRow
+	for rows.Next() {
+		var i ListL1LogsInRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BlockNumber,
+			&i.TxHash,
+			&i.LogIndex,
+			&i.BlockHash,
+			&i.Data,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listL1WithdrawalAmountsUpToBlock = `-- name: ListL1WithdrawalAmountsUpToBlock :many
+SELECT 
+    amount_wei
+FROM 
+    l1_standard_bridge_eth_withdrawal_finalized
+WHERE 
+    block_number <= ?
+`
+
+func (q *Queries) ListL1WithdrawalAmountsUpToBlock(ctx context.Context, blockNumber int64) ([][]byte, error) {
+	rows, err := q.query(ctx, q.listL1WithdrawalAmountsUpToBlockStmt, listL1WithdrawalAmountsUpToBlock, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items [][]byte
+	for rows.Next() {
+		var amount_wei []byte
+		if err := rows.Scan(&amount_wei); err != nil {
+			return nil, err
+		}
+		items = append(items, amount_wei)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listL2AmountsInRange = `-- name: ListL2AmountsInRange :many
+SELECT 
+    block_timestamp,
+    amount_wei,
+    matched_l1_standard_bridge_eth_deposit_initiated_id IS NOT NULL as matched
+FROM 
+    l2_standard_bridge_deposit_finalized
+WHERE 
+    block_timestamp >= ?1 AND block_timestamp < ?2
+`
+
+type ListL2AmountsInRangeParams struct {
+	Since int64
+	Until int64
+}
+
+type ListL2AmountsInRangeRow struct {
+	BlockTimestamp int64
+	AmountWei      []byte
+	Matched        bool
+}
+
+func (q *Queries) ListL2AmountsInRange(ctx context.Context, arg ListL2AmountsInRangeParams) ([]ListL2AmountsInRangeRow, error) {
+	rows, err := q.query(ctx, q.listL2AmountsInRangeStmt, listL2AmountsInRange, arg.Since, arg.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListL2AmountsInRangeRow
+	for rows.Next() {
+		var i ListL2AmountsInRangeRow
+		if err := rows.Scan(&i.BlockTimestamp, &i.AmountWei, &i.Matched); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listL2BlocksWithoutOrigin = `-- name: ListL2BlocksWithoutOrigin :many
+SELECT DISTINCT 
+    block_number
+FROM 
+    l2_standard_bridge_deposit_finalized
+WHERE 
+    l1_origin_number IS NULL AND l1_origin_error IS NULL
+ORDER BY 
+    block_number DESC
+LIMIT ?
+`
+
+func (q *Queries) ListL2BlocksWithoutOrigin(ctx context.Context, limit int64) ([]int64, error) {
+	rows, err := q.query(ctx, q.listL2BlocksWithoutOriginStmt, listL2BlocksWithoutOrigin, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var block_number int64
+		if err := rows.Scan(&block_number); err != nil {
+			return nil, err
+		}
+		items = append(items, block_number)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listL2LogsInRange = `-- name: ListL2LogsInRange :many
+SELECT 
+    id,
+    block_number,
+    tx_hash,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.logIndex'), '') as log_index,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.blockHash'), '') as block_hash,
+    COALESCE(json_extract(CAST(event AS TEXT), '$.data'), '') as data
+FROM l2_standard_bridge_deposit_finalized
+WHERE block_number BETWEEN ?1 AND ?2
+ORDER BY block_number ASC, id ASC
+`
+
+type ListL2LogsInRangeParams struct {
+	FromBlock int64
+	ToBlock   int64
+}
+
+type ListL2LogsInRangeRow struct {
+	ID          int64
+	BlockNumber int64
+	TxHash      []byte
+	LogIndex    string
+	BlockHash   string
+	Data        string
+}
+
+func (q *Queries) ListL2LogsInRange(ctx context.Context, arg ListL2LogsInRangeParams) ([]ListL2LogsInRangeRow, error) {
+	rows, err := q.query(ctx, q.listL2LogsInRangeStmt, listL2LogsInRange, arg.FromBlock, arg.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListL2LogsInRangeRow
+	for rows.Next() {
+		var i ListL2LogsInRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BlockNumber,
+			&i.TxHash,
+			&i.LogIndex,
+			&i.BlockHash,
+			&i.Data,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLivenessIncidents = `-- name: ListLivenessIncidents :many
+SELECT 
+    id, created_at, kind, started_at, ended_at, l2_block_number, worst, detail
+FROM 
+    liveness_incidents
+WHERE 
+    started_at < ?1 AND (ended_at IS NULL OR ended_at >= ?2)
+ORDER BY 
+    started_at DESC, id DESC
+LIMIT ?3
+`
+
+type ListLivenessIncidentsParams struct {
+	Until int64
+	Since *int64
+	Limit int64
+}
+
+func (q *Queries) ListLivenessIncidents(ctx context.Context, arg ListLivenessIncidentsParams) ([]LivenessIncident, error) {
+	rows, err := q.query(ctx, q.listLivenessIncidentsStmt, listLivenessIncidents, arg.Until, arg.Since, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LivenessIncident
+	for rows.Next() {
+		var i LivenessIncident
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Kind,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.L2BlockNumber,
+			&i.Worst,
+			&i.Detail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMatchableHashes = `-- name: ListMatchableHashes :many
+
+SELECT DISTINCT l1.matching_hash
+FROM l1_standard_bridge_eth_deposit_initiated l1
+JOIN l2_standard_bridge_deposit_finalized l2 ON l2.matching_hash = l1.matching_hash
+WHERE 
+    l1.matched_l2_standard_bridge_deposit_finalized_id IS NULL AND
+    l2.matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL AND
+    l2.block_timestamp >= l1.block_timestamp AND
+    l1.matching_hash > ?1
+ORDER BY l1.matching_hash ASC
+LIMIT ?2
+`
+
+type ListMatchableHashesParams struct {
+	AfterHash []byte
+	Limit     int64
+}
+
+// Matching Queries
+func (q *Queries) ListMatchableHashes(ctx context.Context, arg ListMatchableHashesParams) ([][]byte, error) {
+	rows, err := q.query(ctx, q.listMatchableHashesStmt, listMatchableHashes, arg.AfterHash, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items [][]byte
+	for rows.Next() {
+		var matching_hash []byte
+		if err := rows.Scan(&matching_hash); err != nil {
+			return nil, err
+		}
+		items = append(items, matching_hash)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentConfirmationSeconds = `-- name: ListRecentConfirmationSeconds :many
+
+SELECT 
+    CAST(l2.block_timestamp - l1.block_timestamp AS INTEGER) as confirmation_seconds
+FROM 
+    l1_standard_bridge_eth_deposit_initiated l1
+JOIN 
+    l2_standard_bridge_deposit_finalized l2 
+ON 
+    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+ORDER BY 
+    l1.block_timestamp DESC
+LIMIT ?
+`
+
+// ETA Queries
+func (q *Queries) ListRecentConfirmationSeconds(ctx context.Context, limit int64) ([]int64, error) {
+	rows, err := q.query(ctx, q.listRecentConfirmationSecondsStmt, listRecentConfirmationSeconds, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var confirmation_seconds int64
+		if err := rows.Scan(&confirmation_seconds); err != nil {
+			return nil, err
+		}
+		items = append(items, confirmation_seconds)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSecurityFindings = `-- name: ListSecurityFindings :many
+SELECT 
+    id, created_at, observed_at, block_number, tx_hash, log_index, contract, contract_name, kind, severity, value, detail
+FROM 
+    security_findings
+WHERE 
+    ?1 IS NULL OR
+    observed_at < ?2 OR
+    (observed_at = ?2 AND id < ?1)
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT ?3
+`
+
+type ListSecurityFindingsParams struct {
+	CursorID  *int64
+	CursorKey interface{}
+	Limit     int64
+}
+
+func (q *Queries) ListSecurityFindings(ctx context.Context, arg ListSecurityFindingsParams) ([]SecurityFinding, error) {
+	rows, err := q.query(ctx, q.listSecurityFindingsStmt, listSecurityFindings, arg.CursorID, arg.CursorKey, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SecurityFinding
+	for rows.Next() {
+		var i SecurityFinding
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ObservedAt,
+			&i.BlockNumber,
+			&i.TxHash,
+			&i.LogIndex,
+			&i.Contract,
+			&i.ContractName,
+			&i.Kind,
+			&i.Severity,
+			&i.Value,
+			&i.Detail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSolvencySamples = `-- name: ListSolvencySamples :many
+SELECT 
+    block_number, block_time, balance_wei, deposited_wei, withdrawn_wei, gap_eth, alerting
+FROM 
+    solvency_samples
+WHERE 
+    block_time >= ?1 AND block_time < ?2
+ORDER BY 
+    block_number DESC
+LIMIT ?3
+`
+
+type ListSolvencySamplesParams struct {
+	Since int64
+	Until int64
+	Limit int64
+}
+
+type ListSolvencySamplesRow struct {
+	BlockNumber  int64
+	BlockTime    int64
+	BalanceWei   []byte
+	DepositedWei []byte
+	WithdrawnWei []byte
+	GapEth       float64
+	Alerting     bool
+}
+
+func (q *Queries) ListSolvencySamples(ctx context.Context, arg ListSolvencySamplesParams) ([]ListSolvencySamplesRow, error) {
+	rows, err := q.query(ctx, q.listSolvencySamplesStmt, listSolvencySamples, arg.Since, arg.Until, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSolvencySamplesRow
+	for rows.Next() {
+		var i ListSolvencySamplesRow
+		if err := rows.Scan(
+			&i.BlockNumber,
+			&i.BlockTime,
+			&i.BalanceWei,
+			&i.DepositedWei,
+			&i.WithdrawnWei,
+			&i.GapEth,
+			&i.Alerting,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSyncStatusSamples = `-- name: ListSyncStatusSamples :many
+SELECT 
+    id, created_at, observed_at, current_l1_number, head_l1_number, unsafe_l2_number, unsafe_l2_time, safe_l2_number, safe_l2_time, finalized_l2_number, finalized_l2_time
+FROM 
+    sync_status_samples
+WHERE 
+    observed_at >= ?1 AND observed_at < ?2
+ORDER BY 
+    observed_at DESC, id DESC
+LIMIT ?3
+`
+
+type ListSyncStatusSamplesParams struct {
+	Since int64
+	Until int64
+	Limit int64
+}
+
+func (q *Queries) ListSyncStatusSamples(ctx context.Context, arg ListSyncStatusSamplesParams) ([]SyncStatusSample, error) {
+	rows, err := q.query(ctx, q.listSyncStatusSamplesStmt, listSyncStatusSamples, arg.Since, arg.Until, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SyncStatusSample
+	for rows.Next() {
+		var i SyncStatusSample
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ObservedAt,
+			&i.CurrentL1Number,
+			&i.HeadL1Number,
+			&i.UnsafeL2Number,
+			&i.UnsafeL2Time,
+			&i.SafeL2Number,
+			&i.SafeL2Time,
+			&i.FinalizedL2Number,
+			&i.FinalizedL2Time,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnmatchedL1DepositsByHash = `-- name: ListUnmatchedL1DepositsByHash :many
+SELECT 
+    id,
+    block_timestamp
+FROM l1_standard_bridge_eth_deposit_initiated
+WHERE 
+    matching_hash = ? AND
+    matched_l2_standard_bridge_deposit_finalized_id IS NULL
+ORDER BY block_timestamp ASC, id ASC
+`
+
+type ListUnmatchedL1DepositsByHashRow struct {
+	ID             int64
+	BlockTimestamp int64
+}
+
+func (q *Queries) ListUnmatchedL1DepositsByHash(ctx context.Context, matchingHash []byte) ([]ListUnmatchedL1DepositsByHashRow, error) {
+	rows, err := q.query(ctx, q.listUnmatchedL1DepositsByHashStmt, listUnmatchedL1DepositsByHash, matchingHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUnmatchedL1DepositsByHashRow
+	for rows.Next() {
+		var i ListUnmatchedL1DepositsByHashRow
+		if err := rows.Scan(&i.ID, &i.BlockTimestamp); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnmatchedL2FinalizationsByHash = `-- name: ListUnmatchedL2FinalizationsByHash :many
+SELECT 
+    id,
+    block_timestamp
+FROM l2_standard_bridge_deposit_finalized
+WHERE 
+    matching_hash = ? AND
+    matched_l1_standard_bridge_eth_deposit_initiated_id IS NULL
+ORDER BY block_timestamp ASC, id ASC
+`
+
+type ListUnmatchedL2FinalizationsByHashRow struct {
+	ID             int64
+	BlockTimestamp int64
+}
+
+func (q *Queries) ListUnmatchedL2FinalizationsByHash(ctx context.Context, matchingHash []byte) ([]ListUnmatchedL2FinalizationsByHashRow, error) {
+	rows, err := q.query(ctx, q.listUnmatchedL2FinalizationsByHashStmt, listUnmatchedL2FinalizationsByHash, matchingHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUnmatchedL2FinalizationsByHashRow
+	for rows.Next() {
+		var i ListUnmatchedL2FinalizationsByHashRow
+		if err := rows.Scan(&i.ID, &i.BlockTimestamp); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnresolvedDisputeGames = `-- name: ListUnresolvedDisputeGames :many
 SELECT 
-    COUNT(*) 
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
 FROM 
-    l1_standard_bridge_eth_deposit_initiated
+    dispute_games
 WHERE 
-    matched_l2_standard_bridge_deposit_finalized_id IS NULL
-`
-
-func (q *Queries) GetPendingDeposits(ctx context.Context) (int64, error) {
-	row := q.queryRow(ctx, q.getPendingDepositsStmt, getPendingDeposits)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
-const getTimeSeriesChartData = `-- name: GetTimeSeriesChartData :many
-SELECT 
-    l1.block_timestamp as timestamp,
-    (l2.block_timestamp - l1.block_timestamp) as time_diff_seconds
-FROM 
-    l1_standard_bridge_eth_deposit_initiated l1
-JOIN 
-    l2_standard_bridge_deposit_finalized l2 
-ON 
-    l1.matched_l2_standard_bridge_deposit_finalized_id = l2.id
+    status = 0
 ORDER BY 
-    l1.block_timestamp DESC
-LIMIT ?
+    block_number ASC, id ASC
+LIMIT ?1
 `
 
-type GetTimeSeriesChartDataRow struct {
-	Timestamp       int64
-	TimeDiffSeconds interface{}
-}
-
-func (q *Queries) GetTimeSeriesChartData(ctx context.Context, limit int64) ([]GetTimeSeriesChartDataRow, error) {
-	rows, err := q.query(ctx, q.getTimeSeriesChartDataStmt, getTimeSeriesChartData, limit)
+func (q *Queries) ListUnresolvedDisputeGames(ctx context.Context, limit int64) ([]DisputeGame, error) {
+	rows, err := q.query(ctx, q.listUnresolvedDisputeGamesStmt, listUnresolvedDisputeGames, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetTimeSeriesChartDataRow
+	var items []DisputeGame
 	for rows.Next() {
-		var i GetTimeSeriesChartDataRow
-		if err := rows.Scan(&i.Timestamp, &i.TimeDiffSeconds); err != nil {
+		var i DisputeGame
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.GameAddress,
+			&i.GameType,
+			&i.RootClaim,
+			&i.L2BlockNumber,
+			&i.BlockNumber,
+			&i.BlockTime,
+			&i.TxHash,
+			&i.Status,
+			&i.ResolvedAt,
+			&i.ExpectedRoot,
+			&i.Valid,
+			&i.CriticalReason,
+			&i.OutputRootError,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -342,91 +3890,48 @@ func (q *Queries) GetTimeSeriesChartData(ctx context.Context, limit int64) ([]Ge
 	return items, nil
 }
 
-const getTotalMatchedDeposits = `-- name: GetTotalMatchedDeposits :one
-SELECT 
-    COUNT(*)
-FROM 
-    l1_standard_bridge_eth_deposit_initiated
-WHERE 
-    matched_l2_standard_bridge_deposit_finalized_id IS NOT NULL
-`
-
-func (q *Queries) GetTotalMatchedDeposits(ctx context.Context) (int64, error) {
-	row := q.queryRow(ctx, q.getTotalMatchedDepositsStmt, getTotalMatchedDeposits)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
-const getTotalUnmatchedDeposits = `-- name: GetTotalUnmatchedDeposits :one
-SELECT 
-    COUNT(*) 
-FROM 
-    l1_standard_bridge_eth_deposit_initiated
-WHERE 
-    matched_l2_standard_bridge_deposit_finalized_id IS NULL
-`
-
-func (q *Queries) GetTotalUnmatchedDeposits(ctx context.Context) (int64, error) {
-	row := q.queryRow(ctx, q.getTotalUnmatchedDepositsStmt, getTotalUnmatchedDeposits)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
-const getUnmatchedDeposits = `-- name: GetUnmatchedDeposits :many
+const listUnverifiedDisputeGames = `-- name: ListUnverifiedDisputeGames :many
 SELECT 
-    id,
-    from_address,
-    to_address,
-    amount,
-    block_number as l1_block_number,
-    block_timestamp as l1_timestamp,
-    tx_hash as tx_hash_l1,
-    (strftime('%s', 'now') - block_timestamp) as time_since_seconds
+    id, created_at, game_address, game_type, root_claim, l2_block_number, block_number, block_time, tx_hash, status, resolved_at, expected_root, valid, critical_reason, output_root_error
 FROM 
-    l1_standard_bridge_eth_deposit_initiated
+    dispute_games
 WHERE 
-    matched_l2_standard_bridge_deposit_finalized_id IS NULL
+    expected_root IS NULL AND output_root_error IS NULL AND l2_block_number <= ?1
 ORDER BY 
-    block_timestamp DESC
-LIMIT ? OFFSET ?
+    l2_block_number ASC, id ASC
+LIMIT ?2
 `
 
-type GetUnmatchedDepositsParams struct {
-	Limit  int64
-	Offset int64
-}
-
-type GetUnmatchedDepositsRow struct {
-	ID               int64
-	FromAddress      []byte
-	ToAddress        []byte
-	Amount           float64
-	L1BlockNumber    int64
-	L1Timestamp      int64
-	TxHashL1         []byte
-	TimeSinceSeconds interface{}
+type ListUnverifiedDisputeGamesParams struct {
+	L2BlockNumber int64
+	Limit         int64
 }
 
-func (q *Queries) GetUnmatchedDeposits(ctx context.Context, arg GetUnmatchedDepositsParams) ([]GetUnmatchedDepositsRow, error) {
-	rows, err := q.query(ctx, q.getUnmatchedDepositsStmt, getUnmatchedDeposits, arg.Limit, arg.Offset)
+func (q *Queries) ListUnverifiedDisputeGames(ctx context.Context, arg ListUnverifiedDisputeGamesParams) ([]DisputeGame, error) {
+	rows, err := q.query(ctx, q.listUnverifiedDisputeGamesStmt, listUnverifiedDisputeGames, arg.L2BlockNumber, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetUnmatchedDepositsRow
+	var items []DisputeGame
 	for rows.Next() {
-		var i GetUnmatchedDepositsRow
+		var i DisputeGame
 		if err := rows.Scan(
 			&i.ID,
-			&i.FromAddress,
-			&i.ToAddress,
-			&i.Amount,
-			&i.L1BlockNumber,
-			&i.L1Timestamp,
-			&i.TxHashL1,
-			&i.TimeSinceSeconds,
+			&i.CreatedAt,
+			&i.GameAddress,
+			&i.GameType,
+			&i.RootClaim,
+			&i.L2BlockNumber,
+			&i.BlockNumber,
+			&i.BlockTime,
+			&i.TxHash,
+			&i.Status,
+			&i.ResolvedAt,
+			&i.ExpectedRoot,
+			&i.Valid,
+			&i.CriticalReason,
+			&i.OutputRootError,
 		); err != nil {
 			return nil, err
 		}
@@ -441,106 +3946,34 @@ func (q *Queries) GetUnmatchedDeposits(ctx context.Context, arg GetUnmatchedDepo
 	return items, nil
 }
 
-const insertL1StandardBridgeETHDepositInitiated = `-- name: InsertL1StandardBridgeETHDepositInitiated :one
-INSERT INTO l1_standard_bridge_eth_deposit_initiated (
-    block_number,
-    block_timestamp,
-    tx_hash,
-    from_address,
-    to_address,
-    amount,
-    event,
-    matching_hash
-) VALUES (
-    ?,
-    ?,
-    ?,
-    ?,
-    ?,
-    ?,
-    ?,
-    ?
-) RETURNING id
-`
-
-type InsertL1StandardBridgeETHDepositInitiatedParams struct {
-	BlockNumber    int64
-	BlockTimestamp int64
-	TxHash         []byte
-	FromAddress    []byte
-	ToAddress      []byte
-	Amount         float64
-	Event          []byte
-	MatchingHash   []byte
-}
-
-func (q *Queries) InsertL1StandardBridgeETHDepositInitiated(ctx context.Context, arg InsertL1StandardBridgeETHDepositInitiatedParams) (int64, error) {
-	row := q.queryRow(ctx, q.insertL1StandardBridgeETHDepositInitiatedStmt, insertL1StandardBridgeETHDepositInitiated,
-		arg.BlockNumber,
-		arg.BlockTimestamp,
-		arg.TxHash,
-		arg.FromAddress,
-		arg.ToAddress,
-		arg.Amount,
-		arg.Event,
-		arg.MatchingHash,
-	)
-	var id int64
-	err := row.Scan(&id)
-	return id, err
-}
-
-const insertL2StandardBridgeDepositFinalized = `-- name: InsertL2StandardBridgeDepositFinalized :one
-INSERT INTO l2_standard_bridge_deposit_finalized (
-    block_number,
-    block_timestamp,
-    tx_hash,
-    from_address,
-    to_address,
-    l1_token,
-    amount,
-    event,
-    matching_hash
-) VALUES (
-    ?,
-    ?,
-    ?,
-    ?,
-    ?,
-    ?,
-    ?,
-    ?,
-    ?
-) RETURNING id
+const recordL1Match = `-- name: RecordL1Match :exec
+UPDATE l1_standard_bridge_eth_deposit_initiated
+SET 
+    matched_l2_standard_bridge_deposit_finalized_id = ?1,
+    match_method = ?2,
+    match_candidates = ?3,
+    match_confidence = ?4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ?5
 `
 
-type InsertL2StandardBridgeDepositFinalizedParams struct {
-	BlockNumber    int64
-	BlockTimestamp int64
-	TxHash         []byte
-	FromAddress    []byte
-	ToAddress      []byte
-	L1Token        []byte
-	Amount         float64
-	Event          []byte
-	MatchingHash   []byte
+type RecordL1MatchParams struct {
+	L2ID       *int64
+	Method     *string
+	Candidates *int64
+	Confidence *float64
+	ID         int64
 }
 
-func (q *Queries) InsertL2StandardBridgeDepositFinalized(ctx context.Context, arg InsertL2StandardBridgeDepositFinalizedParams) (int64, error) {
-	row := q.queryRow(ctx, q.insertL2StandardBridgeDepositFinalizedStmt, insertL2StandardBridgeDepositFinalized,
-		arg.BlockNumber,
-		arg.BlockTimestamp,
-		arg.TxHash,
-		arg.FromAddress,
-		arg.ToAddress,
-		arg.L1Token,
-		arg.Amount,
-		arg.Event,
-		arg.MatchingHash,
+func (q *Queries) RecordL1Match(ctx context.Context, arg RecordL1MatchParams) error {
+	_, err := q.exec(ctx, q.recordL1MatchStmt, recordL1Match,
+		arg.L2ID,
+		arg.Method,
+		arg.Candidates,
+		arg.Confidence,
+		arg.ID,
 	)
-	var id int64
-	err := row.Scan(&id)
-	return id, err
+	return err
 }
 
 const updateBlockPointer = `-- name: UpdateBlockPointer :exec
@@ -573,6 +4006,64 @@ func (q *Queries) UpdateBlockPointerIfNull(ctx context.Context, arg UpdateBlockP
 	return err
 }
 
+const updateDisputeGameOutputRoot = `-- name: UpdateDisputeGameOutputRoot :exec
+UPDATE dispute_games
+SET 
+    expected_root = ?1,
+    valid = ?2
+WHERE 
+    id = ?3
+`
+
+type UpdateDisputeGameOutputRootParams struct {
+	ExpectedRoot []byte
+	Valid        *bool
+	ID           int64
+}
+
+func (q *Queries) UpdateDisputeGameOutputRoot(ctx context.Context, arg UpdateDisputeGameOutputRootParams) error {
+	_, err := q.exec(ctx, q.updateDisputeGameOutputRootStmt, updateDisputeGameOutputRoot, arg.ExpectedRoot, arg.Valid, arg.ID)
+	return err
+}
+
+const updateDisputeGameOutputRootError = `-- name: UpdateDisputeGameOutputRootError :exec
+UPDATE dispute_games
+SET 
+    output_root_error = ?1
+WHERE 
+    id = ?2
+`
+
+type UpdateDisputeGameOutputRootErrorParams struct {
+	OutputRootError *string
+	ID              int64
+}
+
+func (q *Queries) UpdateDisputeGameOutputRootError(ctx context.Context, arg UpdateDisputeGameOutputRootErrorParams) error {
+	_, err := q.exec(ctx, q.updateDisputeGameOutputRootErrorStmt, updateDisputeGameOutputRootError, arg.OutputRootError, arg.ID)
+	return err
+}
+
+const updateDisputeGameStatus = `-- name: UpdateDisputeGameStatus :exec
+UPDATE dispute_games
+SET 
+    status = ?1,
+    resolved_at = ?2
+WHERE 
+    id = ?3
+`
+
+type UpdateDisputeGameStatusParams struct {
+	Status     int64
+	ResolvedAt *int64
+	ID         int64
+}
+
+func (q *Queries) UpdateDisputeGameStatus(ctx context.Context, arg UpdateDisputeGameStatusParams) error {
+	_, err := q.exec(ctx, q.updateDisputeGameStatusStmt, updateDisputeGameStatus, arg.Status, arg.ResolvedAt, arg.ID)
+	return err
+}
+
 const updateL1DepositWithMatch = `-- name: UpdateL1DepositWithMatch :exec
 UPDATE l1_standard_bridge_eth_deposit_initiated
 SET 
@@ -608,3 +4099,63 @@ func (q *Queries) UpdateL2DepositWithMatch(ctx context.Context, arg UpdateL2Depo
 	_, err := q.exec(ctx, q.updateL2DepositWithMatchStmt, updateL2DepositWithMatch, arg.MatchedL1StandardBridgeEthDepositInitiatedID, arg.ID)
 	return err
 }
+
+const updateL2Origin = `-- name: UpdateL2Origin :exec
+UPDATE l2_standard_bridge_deposit_finalized
+SET 
+    l1_origin_number = ?1,
+    l1_origin_timestamp = ?2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE 
+    block_number = ?3
+`
+
+type UpdateL2OriginParams struct {
+	L1OriginNumber    *int64
+	L1OriginTimestamp *int64
+	BlockNumber       int64
+}
+
+func (q *Queries) UpdateL2Origin(ctx context.Context, arg UpdateL2OriginParams) error {
+	_, err := q.exec(ctx, q.updateL2OriginStmt, updateL2Origin, arg.L1OriginNumber, arg.L1OriginTimestamp, arg.BlockNumber)
+	return err
+}
+
+const updateL2OriginError = `-- name: UpdateL2OriginError :exec
+UPDATE l2_standard_bridge_deposit_finalized
+SET 
+    l1_origin_error = ?1,
+    updated_at = CURRENT_TIMESTAMP
+WHERE 
+    block_number = ?2
+`
+
+type UpdateL2OriginErrorParams struct {
+	L1OriginError *string
+	BlockNumber   int64
+}
+
+func (q *Queries) UpdateL2OriginError(ctx context.Context, arg UpdateL2OriginErrorParams) error {
+	_, err := q.exec(ctx, q.updateL2OriginErrorStmt, updateL2OriginError, arg.L1OriginError, arg.BlockNumber)
+	return err
+}
+
+const updateLivenessIncidentWorst = `-- name: UpdateLivenessIncidentWorst :exec
+UPDATE liveness_incidents
+SET 
+    worst = ?1,
+    detail = ?2
+WHERE 
+    id = ?3 AND worst < ?1
+`
+
+type UpdateLivenessIncidentWorstParams struct {
+	Worst  int64
+	Detail string
+	ID     int64
+}
+
+func (q *Queries) UpdateLivenessIncidentWorst(ctx context.Context, arg UpdateLivenessIncidentWorstParams) error {
+	_, err := q.exec(ctx, q.updateLivenessIncidentWorstStmt, updateLivenessIncidentWorst, arg.Worst, arg.Detail, arg.ID)
+	return err
+}
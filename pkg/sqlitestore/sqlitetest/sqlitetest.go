@@ -0,0 +1,26 @@
+// Package sqlitetest opens the migrated in-memory databases that tests run
+// against.
+package sqlitetest
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+// Open returns a migrated in-memory database that is closed when the test
+// ends. It is limited to one connection, since every connection to
+// ":memory:" opens a database of its own.
+func Open(t testing.TB) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+	require.NoError(t, sqlitestore.Migrate(db))
+	return db
+}
@@ -3,8 +3,10 @@ package sqlitestore
 import (
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
 	"io/fs"
+	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
@@ -16,26 +18,35 @@ import (
 //go:embed migrations/*.sql
 var migrationsFS embed.FS
 
-func Migrate(db *sql.DB) error {
-	// Run migrations
+// newMigrator creates a migrator for the embedded migrations
+func newMigrator(db *sql.DB) (*migrate.Migrate, error) {
 	migrationFS, err := fs.Sub(migrationsFS, "migrations")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	d, err := iofs.New(migrationFS, ".")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	driver, err := sqlite3.WithInstance(db, &sqlite3.Config{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	m, err := migrate.NewWithInstance("iofs", d, "sqlite3", driver)
 	if err != nil {
-		return fmt.Errorf("failed to create migrator: %w", err)
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	return m, nil
+}
+
+func Migrate(db *sql.DB) error {
+	// Run migrations
+	m, err := newMigrator(db)
+	if err != nil {
+		return err
 	}
 
 	err = m.Up()
@@ -47,6 +58,85 @@ func Migrate(db *sql.DB) error {
 	default:
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
+}
+
+// MigrateDown rolls back the given number of migrations, or all of them if
+// steps is not positive
+func MigrateDown(db *sql.DB, steps int) error {
+	m, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
 
-	return nil
+	if steps > 0 {
+		err = m.Steps(-steps)
+	} else {
+		err = m.Down()
+	}
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, migrate.ErrNoChange), errors.Is(err, os.ErrNotExist):
+		// Nothing left to roll back
+		return nil
+	default:
+		return fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+}
+
+// MigrationVersion returns the version of the last applied migration, 0 if
+// none was applied yet. dirty is set if that migration failed halfway.
+func MigrationVersion(db *sql.DB) (version uint, dirty bool, err error) {
+	m, err := newMigrator(db)
+	if err != nil {
+		return 0, false, err
+	}
+
+	version, dirty, err = m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// PendingMigrations returns the applied schema version and the number of
+// embedded migrations not applied yet. Unlike the migrator it only reads the
+// database, so it never creates the schema_migrations table.
+func PendingMigrations(db *sql.DB) (version uint, pending int, err error) {
+	var tables int
+	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'").Scan(&tables)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to look up the migrations table: %w", err)
+	}
+	if tables > 0 {
+		err = db.QueryRow("SELECT version FROM schema_migrations LIMIT 1").Scan(&version)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, fmt.Errorf("failed to get migration version: %w", err)
+		}
+	}
+
+	migrationFS, err := fs.Sub(migrationsFS, "migrations")
+	if err != nil {
+		return 0, 0, err
+	}
+	d, err := iofs.New(migrationFS, ".")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer d.Close()
+
+	next, err := d.First()
+	for err == nil {
+		if next > version {
+			pending++
+		}
+		next, err = d.Next(next)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return 0, 0, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	return version, pending, nil
 }
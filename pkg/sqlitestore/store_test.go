@@ -0,0 +1,101 @@
+package sqlitestore_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDownAndVersion(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	// Counting pending migrations leaves a new database untouched
+	_, pending, err := sqlitestore.PendingMigrations(db)
+	require.NoError(t, err)
+	require.Positive(t, pending)
+	var tables int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master").Scan(&tables))
+	require.Zero(t, tables)
+
+	version, _, err := sqlitestore.MigrationVersion(db)
+	require.NoError(t, err)
+	require.Zero(t, version)
+
+	require.NoError(t, sqlitestore.Migrate(db))
+	latest, dirty, err := sqlitestore.MigrationVersion(db)
+	require.NoError(t, err)
+	require.False(t, dirty)
+	require.NotZero(t, latest)
+
+	require.NoError(t, sqlitestore.MigrateDown(db, 1))
+	version, _, err = sqlitestore.MigrationVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, latest-1, version)
+	version, pending, err = sqlitestore.PendingMigrations(db)
+	require.NoError(t, err)
+	require.Equal(t, latest-1, version)
+	require.Equal(t, 1, pending)
+
+	require.NoError(t, sqlitestore.MigrateDown(db, 0))
+	version, _, err = sqlitestore.MigrationVersion(db)
+	require.NoError(t, err)
+	require.Zero(t, version)
+}
+
+func TestDeleteL1DepositsInRangeUnmatches(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	l1ID, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+		BlockNumber:    10,
+		BlockTimestamp: 100,
+		TxHash:         []byte{1},
+		FromAddress:    []byte{2},
+		ToAddress:      []byte{2},
+		AmountWei:      make([]byte, 32),
+		Event:          []byte("{}"),
+		MatchingHash:   []byte{3},
+	})
+	require.NoError(t, err)
+	l2ID, err := queries.InsertL2StandardBridgeDepositFinalized(ctx, sqlitestore.InsertL2StandardBridgeDepositFinalizedParams{
+		BlockNumber:    20,
+		BlockTimestamp: 200,
+		TxHash:         []byte{4},
+		FromAddress:    []byte{2},
+		ToAddress:      []byte{2},
+		L1Token:        []byte{0},
+		AmountWei:      make([]byte, 32),
+		Event:          []byte("{}"),
+		MatchingHash:   []byte{3},
+	})
+	require.NoError(t, err)
+	require.NoError(t, queries.UpdateL1DepositWithMatch(ctx, sqlitestore.UpdateL1DepositWithMatchParams{
+		MatchedL2StandardBridgeDepositFinalizedID: &l2ID,
+		ID: l1ID,
+	}))
+	require.NoError(t, queries.UpdateL2DepositWithMatch(ctx, sqlitestore.UpdateL2DepositWithMatchParams{
+		MatchedL1StandardBridgeEthDepositInitiatedID: &l1ID,
+		ID: l2ID,
+	}))
+
+	require.NoError(t, queries.ClearL2MatchesOfL1Range(ctx, sqlitestore.ClearL2MatchesOfL1RangeParams{FromBlock: 10, ToBlock: 10}))
+	deleted, err := queries.DeleteL1DepositsInRange(ctx, sqlitestore.DeleteL1DepositsInRangeParams{FromBlock: 10, ToBlock: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	counts, err := queries.GetDepositCounts(ctx)
+	require.NoError(t, err)
+	require.Zero(t, counts.L1Deposits)
+	require.Equal(t, int64(1), counts.L2Finalizations)
+	require.Equal(t, int64(1), counts.UnmatchedL2Finalizations)
+}
@@ -0,0 +1,34 @@
+package sqlitestore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewTracedRecordsQuerySpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	db := sqlitetest.Open(t)
+
+	queries := sqlitestore.NewTraced(db)
+	_, err := queries.GetBlockPointer(context.Background(), "l1_standard_bridge_eth_deposit_initiated_last_processed_block")
+	require.NoError(t, err)
+	_, err = queries.GetPendingDeposits(context.Background())
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "sqlite GetBlockPointer", spans[0].Name())
+	require.Equal(t, "sqlite GetPendingDeposits", spans[1].Name())
+}
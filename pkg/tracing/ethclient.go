@@ -0,0 +1,150 @@
+package tracing
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EthClient wraps an ethclient.Client and records a span for every RPC call
+// used by the indexer
+type EthClient struct {
+	*ethclient.Client
+	chain string
+}
+
+// NewEthClient wraps client, tagging spans with the given chain name (l1 or l2)
+func NewEthClient(client *ethclient.Client, chain string) *EthClient {
+	return &EthClient{
+		Client: client,
+		chain:  chain,
+	}
+}
+
+func (c *EthClient) spanOptions(attrs ...attribute.KeyValue) trace.SpanStartOption {
+	attrs = append(attrs, attribute.String("chain", c.chain))
+	return trace.WithAttributes(attrs...)
+}
+
+// BlockNumber returns the most recent block number
+func (c *EthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var blockNumber uint64
+	err := Run(ctx, "eth_blockNumber", func(ctx context.Context) error {
+		var err error
+		blockNumber, err = c.Client.BlockNumber(ctx)
+		return err
+	}, trace.WithSpanKind(trace.SpanKindClient), c.spanOptions())
+	return blockNumber, err
+}
+
+// HeaderByNumber returns the block header with the given number
+func (c *EthClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := Run(ctx, "eth_getBlockByNumber", func(ctx context.Context) error {
+		var err error
+		header, err = c.Client.HeaderByNumber(ctx, number)
+		return err
+	}, trace.WithSpanKind(trace.SpanKindClient), c.spanOptions(attribute.String("block_number", number.String())))
+	return header, err
+}
+
+// BlockByNumber returns the block with the given number and its transactions
+func (c *EthClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	var block *types.Block
+	err := Run(ctx, "eth_getBlockByNumber", func(ctx context.Context) error {
+		var err error
+		block, err = c.Client.BlockByNumber(ctx, number)
+		return err
+	}, trace.WithSpanKind(trace.SpanKindClient), c.spanOptions(attribute.String("block_number", number.String()), attribute.Bool("full", true)))
+	return block, err
+}
+
+// BalanceAt returns the wei balance of an account at the given block
+func (c *EthClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var balance *big.Int
+	err := Run(ctx, "eth_getBalance", func(ctx context.Context) error {
+		var err error
+		balance, err = c.Client.BalanceAt(ctx, account, blockNumber)
+		return err
+	}, trace.WithSpanKind(trace.SpanKindClient), c.spanOptions(
+		attribute.String("account", account.Hex()),
+		attribute.String("block_number", blockNumber.String()),
+	))
+	return balance, err
+}
+
+// FilterLogs executes a filter query
+func (c *EthClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := Run(ctx, "eth_getLogs", func(ctx context.Context) error {
+		var err error
+		logs, err = c.Client.FilterLogs(ctx, q)
+		if err == nil {
+			SetAttributes(ctx, attribute.Int("log_count", len(logs)))
+		}
+		return err
+	}, trace.WithSpanKind(trace.SpanKindClient), c.spanOptions(
+		attribute.String("from_block", q.FromBlock.String()),
+		attribute.String("to_block", q.ToBlock.String()),
+	))
+	return logs, err
+}
+
+// StorageHash returns the storage root of an account at the given block,
+// from an eth_getProof without storage keys
+func (c *EthClient) StorageHash(ctx context.Context, account common.Address, blockNumber *big.Int) (common.Hash, error) {
+	var proof struct {
+		StorageHash common.Hash `json:"storageHash"`
+	}
+	err := Run(ctx, "eth_getProof", func(ctx context.Context) error {
+		return c.Client.Client().CallContext(ctx, &proof, "eth_getProof", account, []string{}, hexutil.EncodeBig(blockNumber))
+	}, trace.WithSpanKind(trace.SpanKindClient), c.spanOptions(
+		attribute.String("account", account.Hex()),
+		attribute.String("block_number", blockNumber.String()),
+	))
+	return proof.StorageHash, err
+}
+
+// StorageAt returns the value of a storage slot of an account at the given block
+func (c *EthClient) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var value []byte
+	err := Run(ctx, "eth_getStorageAt", func(ctx context.Context) error {
+		var err error
+		value, err = c.Client.StorageAt(ctx, account, key, blockNumber)
+		return err
+	}, trace.WithSpanKind(trace.SpanKindClient), c.spanOptions(
+		attribute.String("account", account.Hex()),
+		attribute.String("key", key.Hex()),
+		attribute.String("block_number", blockNumber.String()),
+	))
+	return value, err
+}
+
+// TransactionInput returns the input of the transaction at index in the given
+// block. Only the input is decoded, so that it also works for the deposit
+// transactions of OP Stack chains, which go-ethereum cannot decode.
+func (c *EthClient) TransactionInput(ctx context.Context, blockNumber *big.Int, index uint) ([]byte, error) {
+	var tx *struct {
+		Input hexutil.Bytes `json:"input"`
+	}
+	err := Run(ctx, "eth_getTransactionByBlockNumberAndIndex", func(ctx context.Context) error {
+		return c.Client.Client().CallContext(ctx, &tx, "eth_getTransactionByBlockNumberAndIndex", hexutil.EncodeBig(blockNumber), hexutil.Uint(index))
+	}, trace.WithSpanKind(trace.SpanKindClient), c.spanOptions(
+		attribute.String("block_number", blockNumber.String()),
+		attribute.Int("index", int(index)),
+	))
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, ethereum.NotFound
+	}
+	return tx.Input, nil
+}
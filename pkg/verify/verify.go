@@ -0,0 +1,491 @@
+package verify
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultBatchSize is the number of blocks fetched with one eth_getLogs call
+const DefaultBatchSize = 10000
+
+// LogFilterer fetches logs from an execution layer node
+type LogFilterer interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// Layer selects the table the events of a chain are stored in
+type Layer string
+
+const (
+	// L1 events are stored in l1_standard_bridge_eth_deposit_initiated
+	L1 Layer = "l1"
+	// L2 events are stored in l2_standard_bridge_deposit_finalized
+	L2 Layer = "l2"
+)
+
+// Chain describes the indexed events of one side of the bridge
+type Chain struct {
+	Layer Layer
+	// Client is used to fetch the logs again, the comparison with the chain
+	// is skipped when it is nil
+	Client LogFilterer
+	// History lists the versions of the bridge contract whose logs are fetched
+	History     registry.History
+	LowPointer  string
+	LastPointer string
+}
+
+// Options select the blocks whose logs are fetched again
+type Options struct {
+	// BatchSize is the number of blocks fetched with one eth_getLogs call
+	BatchSize uint64
+	// Samples is the number of randomly picked batches checked per chain,
+	// every batch of the indexed range is checked when it is 0
+	Samples int
+	// FromBlock and ToBlock optionally narrow the checked blocks down, only
+	// blocks within the indexed range are checked
+	FromBlock *uint64
+	ToBlock   *uint64
+}
+
+// Reasons a record is reported
+const (
+	// ReasonNotStored is a log found on chain but not in the database
+	ReasonNotStored = "not_stored"
+	// ReasonNotOnChain is a stored event the node did not return
+	ReasonNotOnChain = "not_on_chain"
+	// ReasonOutOfRange is a stored event outside of the range covered by the block pointers
+	ReasonOutOfRange = "out_of_range"
+	// ReasonDuplicate is a log stored more than once
+	ReasonDuplicate = "duplicate"
+	// ReasonBlockNumber, ReasonBlockHash and ReasonData are stored events
+	// differing from the log returned by the node
+	ReasonBlockNumber = "block_number"
+	ReasonBlockHash   = "block_hash"
+	ReasonData        = "data"
+	// ReasonEvent is a stored event whose log index cannot be read
+	ReasonEvent = "event"
+	// ReasonPointer is an inconsistent pair of block pointers
+	ReasonPointer = "pointer"
+	// ReasonMatch is a match that is not recorded on both sides
+	ReasonMatch = "match"
+)
+
+// Record identifies a missing, extra or inconsistent record
+type Record struct {
+	Chain       Layer        `json:"chain"`
+	Reason      string       `json:"reason"`
+	ID          *int64       `json:"id,omitempty"`
+	BlockNumber *uint64      `json:"block_number,omitempty"`
+	TxHash      *common.Hash `json:"tx_hash,omitempty"`
+	LogIndex    *uint        `json:"log_index,omitempty"`
+	Detail      string       `json:"detail,omitempty"`
+}
+
+// BlockRange is an inclusive range of blocks
+type BlockRange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// ChainReport describes what was checked on one chain
+type ChainReport struct {
+	Chain Layer `json:"chain"`
+	// LowBlock and LastBlock are the indexed range according to the block pointers
+	LowBlock      *uint64      `json:"low_block"`
+	LastBlock     *uint64      `json:"last_block"`
+	CheckedRanges []BlockRange `json:"checked_ranges"`
+	CheckedLogs   int          `json:"checked_logs"`
+	// Skipped explains why the logs were not compared with the chain
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// Report is the result of a verification run
+type Report struct {
+	StartedAt    time.Time     `json:"started_at"`
+	FinishedAt   time.Time     `json:"finished_at"`
+	Chains       []ChainReport `json:"chains"`
+	Missing      []Record      `json:"missing"`
+	Extra        []Record      `json:"extra"`
+	Inconsistent []Record      `json:"inconsistent"`
+}
+
+// Problems returns the number of reported records
+func (r *Report) Problems() int {
+	return len(r.Missing) + len(r.Extra) + len(r.Inconsistent)
+}
+
+// OK reports whether the database is consistent with the chain
+func (r *Report) OK() bool {
+	return r.Problems() == 0
+}
+
+// Run audits the database: it fetches the logs of the indexed ranges again
+// and diffs them with the stored events by tx hash and log index, checks the
+// block pointers and checks that every match is recorded on both sides
+func Run(ctx context.Context, db *sql.DB, chains []Chain, opts Options) (*Report, error) {
+	if opts.BatchSize == 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+
+	store := sqlitestore.New(db)
+	report := &Report{
+		StartedAt:    time.Now().UTC(),
+		Missing:      []Record{},
+		Extra:        []Record{},
+		Inconsistent: []Record{},
+	}
+
+	for _, c := range chains {
+		cr, err := verifyChain(ctx, store, c, opts, report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify %s: %w", c.Layer, err)
+		}
+		report.Chains = append(report.Chains, *cr)
+	}
+
+	err := verifyMatches(ctx, store, report)
+	if err != nil {
+		return nil, err
+	}
+
+	report.FinishedAt = time.Now().UTC()
+	return report, nil
+}
+
+func verifyChain(ctx context.Context, store *sqlitestore.Queries, c Chain, opts Options, report *Report) (*ChainReport, error) {
+	cr := &ChainReport{Chain: c.Layer, CheckedRanges: []BlockRange{}}
+
+	low, err := store.GetBlockPointer(ctx, c.LowPointer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lowest processed block: %w", err)
+	}
+	last, err := store.GetBlockPointer(ctx, c.LastPointer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last processed block: %w", err)
+	}
+
+	// Every stored event must lie within the single range the pointers cover
+	if low.BlockNumber == nil || last.BlockNumber == nil {
+		if low.BlockNumber != nil || last.BlockNumber != nil {
+			report.Inconsistent = append(report.Inconsistent, Record{
+				Chain:  c.Layer,
+				Reason: ReasonPointer,
+				Detail: fmt.Sprintf("only one of %s and %s is set", c.LowPointer, c.LastPointer),
+			})
+		}
+		err = reportOutOfRange(ctx, store, c.Layer, 0, math.MaxInt64, report)
+		if err != nil {
+			return nil, err
+		}
+		cr.Skipped = "not indexed yet"
+		return cr, nil
+	}
+
+	lowBlock, lastBlock := uint64(*low.BlockNumber), uint64(*last.BlockNumber)
+	cr.LowBlock, cr.LastBlock = &lowBlock, &lastBlock
+
+	if lowBlock > lastBlock {
+		report.Inconsistent = append(report.Inconsistent, Record{
+			Chain:  c.Layer,
+			Reason: ReasonPointer,
+			Detail: fmt.Sprintf("lowest processed block %d is after last processed block %d", lowBlock, lastBlock),
+		})
+		cr.Skipped = "inconsistent block pointers"
+		return cr, nil
+	}
+	if low.BlockTime != nil && last.BlockTime != nil && *low.BlockTime > *last.BlockTime {
+		report.Inconsistent = append(report.Inconsistent, Record{
+			Chain:  c.Layer,
+			Reason: ReasonPointer,
+			Detail: fmt.Sprintf("lowest processed block time %d is after last processed block time %d", *low.BlockTime, *last.BlockTime),
+		})
+	}
+
+	if lowBlock > 0 {
+		err = reportOutOfRange(ctx, store, c.Layer, 0, int64(lowBlock)-1, report)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = reportOutOfRange(ctx, store, c.Layer, int64(lastBlock)+1, math.MaxInt64, report)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Client == nil {
+		cr.Skipped = "no execution layer client"
+		return cr, nil
+	}
+
+	from, to := lowBlock, lastBlock
+	if opts.FromBlock != nil {
+		from = max(from, *opts.FromBlock)
+	}
+	if opts.ToBlock != nil {
+		to = min(to, *opts.ToBlock)
+	}
+	if from > to {
+		cr.Skipped = "requested blocks are not indexed"
+		return cr, nil
+	}
+
+	for _, r := range pickBatches(from, to, opts.BatchSize, opts.Samples) {
+		n, err := verifyRange(ctx, store, c, r, report)
+		if err != nil {
+			return nil, err
+		}
+		cr.CheckedRanges = append(cr.CheckedRanges, r)
+		cr.CheckedLogs += n
+	}
+
+	return cr, nil
+}
+
+// pickBatches splits the block range into batches and returns all of them,
+// or the given number of randomly picked ones in ascending order
+func pickBatches(from, to, batchSize uint64, samples int) []BlockRange {
+	n := (to-from)/batchSize + 1
+	batch := func(i uint64) BlockRange {
+		start := from + i*batchSize
+		return BlockRange{From: start, To: min(start+batchSize-1, to)}
+	}
+
+	var indexes []uint64
+	if samples <= 0 || uint64(samples) >= n {
+		for i := uint64(0); i < n; i++ {
+			indexes = append(indexes, i)
+		}
+	} else {
+		picked := make(map[uint64]bool, samples)
+		for len(picked) < samples {
+			picked[rand.Uint64N(n)] = true
+		}
+		for i := range picked {
+			indexes = append(indexes, i)
+		}
+		slices.Sort(indexes)
+	}
+
+	ranges := make([]BlockRange, 0, len(indexes))
+	for _, i := range indexes {
+		ranges = append(ranges, batch(i))
+	}
+	return ranges
+}
+
+// storedLog is a stored event in the form shared by both tables
+type storedLog struct {
+	ID          int64
+	BlockNumber int64
+	TxHash      []byte
+	LogIndex    string
+	BlockHash   string
+	Data        string
+}
+
+func listStoredLogs(ctx context.Context, store *sqlitestore.Queries, layer Layer, fromBlock, toBlock int64) ([]storedLog, error) {
+	var stored []storedLog
+	switch layer {
+	case L1:
+		rows, err := store.ListL1LogsInRange(ctx, sqlitestore.ListL1LogsInRangeParams{FromBlock: fromBlock, ToBlock: toBlock})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list L1 deposits: %w", err)
+		}
+		for _, r := range rows {
+			stored = append(stored, storedLog(r))
+		}
+	case L2:
+		rows, err := store.ListL2LogsInRange(ctx, sqlitestore.ListL2LogsInRangeParams{FromBlock: fromBlock, ToBlock: toBlock})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list L2 finalizations: %w", err)
+		}
+		for _, r := range rows {
+			stored = append(stored, storedLog(r))
+		}
+	default:
+		return nil, fmt.Errorf("unknown layer %q", layer)
+	}
+	return stored, nil
+}
+
+// reportOutOfRange reports the events stored for blocks that the block pointers do not cover
+func reportOutOfRange(ctx context.Context, store *sqlitestore.Queries, layer Layer, fromBlock, toBlock int64, report *Report) error {
+	stored, err := listStoredLogs(ctx, store, layer, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+	for _, s := range stored {
+		record := s.record(layer, ReasonOutOfRange)
+		record.Detail = "stored for a block outside of the indexed range"
+		report.Extra = append(report.Extra, record)
+	}
+	return nil
+}
+
+// verifyRange diffs the logs of a block range with the stored events and
+// returns the number of logs found on chain
+func verifyRange(ctx context.Context, store *sqlitestore.Queries, c Chain, r BlockRange, report *Report) (int, error) {
+	var logs []types.Log
+	for _, segment := range c.History.Segments(r.From, r.To) {
+		segmentLogs, err := c.Client.FilterLogs(ctx, segment.Query())
+		if err != nil {
+			return 0, fmt.Errorf("failed to filter logs: %w", err)
+		}
+		logs = append(logs, segmentLogs...)
+	}
+
+	stored, err := listStoredLogs(ctx, store, c.Layer, int64(r.From), int64(r.To))
+	if err != nil {
+		return 0, err
+	}
+
+	type key struct {
+		txHash   common.Hash
+		logIndex uint
+	}
+
+	storedByKey := make(map[key]storedLog, len(stored))
+	for _, s := range stored {
+		logIndex, err := hexutil.DecodeUint64(s.LogIndex)
+		if err != nil {
+			record := s.record(c.Layer, ReasonEvent)
+			record.Detail = fmt.Sprintf("stored event has no valid log index: %q", s.LogIndex)
+			report.Inconsistent = append(report.Inconsistent, record)
+			continue
+		}
+		k := key{common.BytesToHash(s.TxHash), uint(logIndex)}
+		if _, exists := storedByKey[k]; exists {
+			record := s.record(c.Layer, ReasonDuplicate)
+			record.LogIndex = &k.logIndex
+			record.Detail = "log stored more than once"
+			report.Inconsistent = append(report.Inconsistent, record)
+			continue
+		}
+		storedByKey[k] = s
+	}
+
+	onChain := make(map[key]bool, len(logs))
+	for _, lg := range logs {
+		if lg.Removed {
+			continue
+		}
+		k := key{lg.TxHash, lg.Index}
+		onChain[k] = true
+
+		record := Record{
+			Chain:       c.Layer,
+			BlockNumber: &lg.BlockNumber,
+			TxHash:      &lg.TxHash,
+			LogIndex:    &lg.Index,
+		}
+
+		s, exists := storedByKey[k]
+		if !exists {
+			record.Reason = ReasonNotStored
+			report.Missing = append(report.Missing, record)
+			continue
+		}
+		record.ID = &s.ID
+
+		switch {
+		case uint64(s.BlockNumber) != lg.BlockNumber:
+			record.Reason = ReasonBlockNumber
+			record.Detail = fmt.Sprintf("stored block %d, chain has block %d", s.BlockNumber, lg.BlockNumber)
+		case s.BlockHash != lg.BlockHash.Hex():
+			record.Reason = ReasonBlockHash
+			record.Detail = fmt.Sprintf("stored block hash %s, chain has %s", s.BlockHash, lg.BlockHash.Hex())
+		case s.Data != hexutil.Encode(lg.Data):
+			record.Reason = ReasonData
+			record.Detail = "stored log data differs from the chain"
+		default:
+			continue
+		}
+		report.Inconsistent = append(report.Inconsistent, record)
+	}
+
+	for _, s := range stored {
+		logIndex, err := hexutil.DecodeUint64(s.LogIndex)
+		if err != nil {
+			continue
+		}
+		k := key{common.BytesToHash(s.TxHash), uint(logIndex)}
+		if onChain[k] || storedByKey[k].ID != s.ID {
+			continue
+		}
+		record := s.record(c.Layer, ReasonNotOnChain)
+		record.LogIndex = &k.logIndex
+		report.Extra = append(report.Extra, record)
+	}
+
+	return len(logs), nil
+}
+
+func (s storedLog) record(layer Layer, reason string) Record {
+	blockNumber := uint64(s.BlockNumber)
+	txHash := common.BytesToHash(s.TxHash)
+	return Record{
+		Chain:       layer,
+		Reason:      reason,
+		ID:          &s.ID,
+		BlockNumber: &blockNumber,
+		TxHash:      &txHash,
+	}
+}
+
+// verifyMatches reports matches that are not recorded identically on both
+// sides or that link events with different matching hashes
+func verifyMatches(ctx context.Context, store *sqlitestore.Queries, report *Report) error {
+	l1, err := store.FindInconsistentL1Matches(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find inconsistent L1 matches: %w", err)
+	}
+	for _, m := range l1 {
+		report.Inconsistent = append(report.Inconsistent, Record{
+			Chain:  L1,
+			Reason: ReasonMatch,
+			ID:     &m.ID,
+			Detail: matchDetail("L2 finalization", m.MatchedID, m.CounterpartID, m.CounterpartMatchedID, m.SameMatchingHash),
+		})
+	}
+
+	l2, err := store.FindInconsistentL2Matches(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to find inconsistent L2 matches: %w", err)
+	}
+	for _, m := range l2 {
+		report.Inconsistent = append(report.Inconsistent, Record{
+			Chain:  L2,
+			Reason: ReasonMatch,
+			ID:     &m.ID,
+			Detail: matchDetail("L1 deposit", m.MatchedID, m.CounterpartID, m.CounterpartMatchedID, m.SameMatchingHash),
+		})
+	}
+
+	return nil
+}
+
+func matchDetail(counterpart string, matchedID, counterpartID, counterpartMatchedID *int64, sameMatchingHash bool) string {
+	switch {
+	case counterpartID == nil:
+		return fmt.Sprintf("matched %s %d does not exist", counterpart, *matchedID)
+	case counterpartMatchedID == nil:
+		return fmt.Sprintf("matched %s %d is not matched back", counterpart, *matchedID)
+	case !sameMatchingHash:
+		return fmt.Sprintf("matched %s %d has a different matching hash", counterpart, *matchedID)
+	default:
+		return fmt.Sprintf("matched %s %d is matched with %d instead", counterpart, *matchedID, *counterpartMatchedID)
+	}
+}
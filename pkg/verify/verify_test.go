@@ -0,0 +1,153 @@
+package verify_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/registry"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/Golem-Base/bridgette/pkg/verify"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	lowPointer  = "l1_standard_bridge_eth_deposit_initiated_lowest_processed_block"
+	lastPointer = "l1_standard_bridge_eth_deposit_initiated_last_processed_block"
+)
+
+// bridge is a single version of the L1 bridge for all blocks
+var bridge = registry.History{{Chain: registry.L1, Address: common.Address{0xb1}}}
+
+// fakeChain returns its logs that fall in the requested block range
+type fakeChain []types.Log
+
+func (f fakeChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	for _, lg := range f {
+		if lg.BlockNumber >= q.FromBlock.Uint64() && lg.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, lg)
+		}
+	}
+	return logs, nil
+}
+
+func newLog(blockNumber uint64, tx byte, index uint) types.Log {
+	return types.Log{
+		Topics:      []common.Hash{{0x01}},
+		Data:        []byte{0x02},
+		BlockNumber: blockNumber,
+		TxHash:      common.Hash{tx},
+		BlockHash:   common.Hash{byte(blockNumber)},
+		Index:       index,
+	}
+}
+
+func TestRun(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	store := func(lg types.Log) int64 {
+		event, err := json.Marshal(lg)
+		require.NoError(t, err)
+		id, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+			BlockNumber:  int64(lg.BlockNumber),
+			TxHash:       lg.TxHash.Bytes(),
+			FromAddress:  []byte{},
+			ToAddress:    []byte{},
+			AmountWei:    make([]byte, 32),
+			Event:        event,
+			MatchingHash: []byte{1},
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	low, last := int64(10), int64(30)
+	require.NoError(t, queries.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{BlockNumber: &low, Name: lowPointer}))
+	require.NoError(t, queries.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{BlockNumber: &last, Name: lastPointer}))
+
+	ok := newLog(12, 0xa, 0)
+	missing := newLog(15, 0xb, 3)
+	reorged := newLog(20, 0xc, 1)
+	storedReorged := reorged
+	storedReorged.BlockHash = common.Hash{0xff}
+
+	okID := store(ok)
+	store(storedReorged)
+	store(newLog(25, 0xd, 0)) // not on chain
+	store(newLog(40, 0xe, 0)) // above the last processed block
+
+	// Matched on the L1 side only
+	missingL2 := int64(99)
+	require.NoError(t, queries.UpdateL1DepositWithMatch(ctx, sqlitestore.UpdateL1DepositWithMatchParams{
+		MatchedL2StandardBridgeDepositFinalizedID: &missingL2,
+		ID: okID,
+	}))
+
+	report, err := verify.Run(ctx, db, []verify.Chain{{
+		Layer:       verify.L1,
+		History:     bridge,
+		Client:      fakeChain{ok, missing, reorged},
+		LowPointer:  lowPointer,
+		LastPointer: lastPointer,
+	}}, verify.Options{BatchSize: 7})
+	require.NoError(t, err)
+
+	require.False(t, report.OK())
+	require.Len(t, report.Chains, 1)
+	require.Equal(t, []verify.BlockRange{{From: 10, To: 16}, {From: 17, To: 23}, {From: 24, To: 30}}, report.Chains[0].CheckedRanges)
+	require.Equal(t, 3, report.Chains[0].CheckedLogs)
+
+	require.Len(t, report.Missing, 1)
+	require.Equal(t, verify.ReasonNotStored, report.Missing[0].Reason)
+	require.Equal(t, missing.TxHash, *report.Missing[0].TxHash)
+	require.Equal(t, uint(3), *report.Missing[0].LogIndex)
+
+	require.Len(t, report.Extra, 2)
+	require.Equal(t, verify.ReasonOutOfRange, report.Extra[0].Reason)
+	require.Equal(t, uint64(40), *report.Extra[0].BlockNumber)
+	require.Equal(t, verify.ReasonNotOnChain, report.Extra[1].Reason)
+	require.Equal(t, uint64(25), *report.Extra[1].BlockNumber)
+
+	require.Len(t, report.Inconsistent, 2)
+	require.Equal(t, verify.ReasonBlockHash, report.Inconsistent[0].Reason)
+	require.Equal(t, verify.ReasonMatch, report.Inconsistent[1].Reason)
+	require.Equal(t, okID, *report.Inconsistent[1].ID)
+}
+
+func TestRunSamples(t *testing.T) {
+	db := sqlitetest.Open(t)
+
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+	low, last := int64(0), int64(999)
+	require.NoError(t, queries.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{BlockNumber: &low, Name: lowPointer}))
+	require.NoError(t, queries.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{BlockNumber: &last, Name: lastPointer}))
+
+	report, err := verify.Run(ctx, db, []verify.Chain{{
+		Layer:       verify.L1,
+		History:     bridge,
+		Client:      fakeChain{},
+		LowPointer:  lowPointer,
+		LastPointer: lastPointer,
+	}}, verify.Options{BatchSize: 10, Samples: 5})
+	require.NoError(t, err)
+	require.True(t, report.OK())
+
+	ranges := report.Chains[0].CheckedRanges
+	require.Len(t, ranges, 5)
+	for i, r := range ranges {
+		require.Equal(t, uint64(9), r.To-r.From)
+		require.Zero(t, r.From%10)
+		if i > 0 {
+			require.Greater(t, r.From, ranges[i-1].From)
+		}
+	}
+}
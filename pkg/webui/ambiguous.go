@@ -0,0 +1,49 @@
+package webui
+
+import (
+	"net/http"
+)
+
+// AmbiguousGroups holds a page of ambiguous matching groups
+type AmbiguousGroups struct {
+	Groups []AmbiguousGroup
+	Total  int
+	// Cursor is the cursor the page starts after, Next the one of the next page
+	Cursor string
+	Next   string
+}
+
+// handleAmbiguousGroups lists the groups of deposits that could be matched in
+// more than one way, so operators can review the matches picked by the engine
+func (s *Server) handleAmbiguousGroups(w http.ResponseWriter, r *http.Request) {
+	page, err := ParsePage(r, ItemsPerPage, MaxItemsPerPage)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	groups, next, err := GetAmbiguousGroups(r.Context(), s.db, page)
+	if err != nil {
+		s.logger.Error("failed to get ambiguous groups", "error", err)
+		http.Error(w, "Failed to get ambiguous groups", http.StatusInternalServerError)
+		return
+	}
+
+	total, err := GetTotalAmbiguousGroups(r.Context(), s.db)
+	if err != nil {
+		s.logger.Error("failed to get total ambiguous groups", "error", err)
+		http.Error(w, "Failed to get total count", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = AmbiguousGroupsPage(AmbiguousGroups{
+		Groups: groups,
+		Total:  total,
+		Cursor: encodeCursor(page.After),
+		Next:   encodeCursor(next),
+	}, s.pathPrefix).Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render ambiguous groups", "error", err)
+	}
+}
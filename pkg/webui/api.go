@@ -0,0 +1,1265 @@
+package webui
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// DefaultAPILimit is the page size used when the limit parameter is absent
+	DefaultAPILimit = 50
+	// MaxAPILimit is the largest page size accepted by list endpoints
+	MaxAPILimit = 500
+)
+
+// Deposit statuses reported by the API
+const (
+	StatusMatched = "matched"
+	StatusPending = "pending"
+)
+
+// apiError is the body of every non-2xx API response
+type apiError struct {
+	Error apiErrorDetail `json:"error"`
+}
+
+type apiErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// apiList is the envelope for paginated list responses
+type apiList[T any] struct {
+	Data       []T           `json:"data"`
+	Pagination apiPagination `json:"pagination"`
+}
+
+type apiPagination struct {
+	Limit int `json:"limit"`
+	// NextCursor is passed as the cursor parameter to get the next page,
+	// it is null on the last page
+	NextCursor *string `json:"next_cursor"`
+	Total      int     `json:"total"`
+}
+
+// nextCursor encodes the cursor of the next page, if any
+func nextCursor(c *Cursor) *string {
+	if c == nil {
+		return nil
+	}
+	encoded := c.Encode()
+	return &encoded
+}
+
+// apiDeposit is the JSON representation of an L1 deposit and its L2 confirmation
+type apiDeposit struct {
+	ID                  int64        `json:"id"`
+	Status              string       `json:"status"`
+	From                string       `json:"from"`
+	To                  string       `json:"to"`
+	AmountWei           string       `json:"amount_wei"`
+	L1                  apiChainLog  `json:"l1"`
+	L2                  *apiChainLog `json:"l2"`
+	ConfirmationSeconds *int64       `json:"confirmation_seconds"`
+	WaitingSeconds      *int64       `json:"waiting_seconds,omitempty"`
+	// Latency is only returned for single deposit lookups
+	Latency *apiDepositLatency `json:"latency,omitempty"`
+	// ETA is only returned for pending deposits
+	ETA *apiDepositETA `json:"eta,omitempty"`
+}
+
+// apiDepositETA is the JSON representation of the estimated arrival of a pending deposit
+type apiDepositETA struct {
+	ExpectedAt    string `json:"expected_at"`
+	EarliestAt    string `json:"earliest_at"`
+	LatestAt      string `json:"latest_at"`
+	OverdueAt     string `json:"overdue_at"`
+	Overdue       bool   `json:"overdue"`
+	OriginReached bool   `json:"origin_reached"`
+}
+
+func newAPIDepositETA(eta *DepositETA) *apiDepositETA {
+	if eta == nil {
+		return nil
+	}
+	return &apiDepositETA{
+		ExpectedAt:    formatAPITime(eta.Expected),
+		EarliestAt:    formatAPITime(eta.Earliest),
+		LatestAt:      formatAPITime(eta.Latest),
+		OverdueAt:     formatAPITime(eta.OverdueAt),
+		Overdue:       eta.Overdue,
+		OriginReached: eta.OriginReached,
+	}
+}
+
+// apiDepositLatency is the JSON representation of the milestones of a deposit
+type apiDepositLatency struct {
+	L1SafeAt       *string `json:"l1_safe_at"`
+	L1FinalizedAt  *string `json:"l1_finalized_at"`
+	L1OriginNumber *int64  `json:"l1_origin_number"`
+	L2SafeAt       *string `json:"l2_safe_at"`
+	L2FinalizedAt  *string `json:"l2_finalized_at"`
+	// PhaseSeconds maps each phase to its duration, null when unknown
+	PhaseSeconds map[string]*int64 `json:"phase_seconds"`
+}
+
+func newAPIDepositLatency(d DepositLatency) *apiDepositLatency {
+	format := func(t *time.Time) *string {
+		if t == nil {
+			return nil
+		}
+		formatted := formatAPITime(*t)
+		return &formatted
+	}
+	return &apiDepositLatency{
+		L1SafeAt:       format(d.L1SafeAt),
+		L1FinalizedAt:  format(d.L1FinalizedAt),
+		L1OriginNumber: d.L1OriginNumber,
+		L2SafeAt:       format(d.L2SafeAt),
+		L2FinalizedAt:  format(d.L2FinalizedAt),
+		PhaseSeconds:   d.Phases(),
+	}
+}
+
+// apiChainLog locates a bridge event on one of the chains
+type apiChainLog struct {
+	BlockNumber int64  `json:"block_number"`
+	Timestamp   string `json:"timestamp"`
+	TxHash      string `json:"tx_hash"`
+}
+
+type apiStats struct {
+	BridgeStats
+	TotalBridgedWei string `json:"total_bridged_wei"`
+}
+
+type apiStatus struct {
+	Pointers []apiBlockPointer `json:"pointers"`
+}
+
+type apiBlockPointer struct {
+	Name        string  `json:"name"`
+	BlockNumber *int64  `json:"block_number"`
+	BlockTime   *string `json:"block_time"`
+	LagSeconds  *int64  `json:"lag_seconds"`
+}
+
+// apiOrphanedFinalization is the JSON representation of an L2 finalization without a known L1 deposit
+type apiOrphanedFinalization struct {
+	ID        int64       `json:"id"`
+	From      string      `json:"from"`
+	To        string      `json:"to"`
+	AmountWei string      `json:"amount_wei"`
+	L2        apiChainLog `json:"l2"`
+	// OrphanedSeconds is how long ago the deposit was finalized on L2
+	OrphanedSeconds int64 `json:"orphaned_seconds"`
+}
+
+// apiValueTotal is the number and exact value of a set of bridge events
+type apiValueTotal struct {
+	Count     int    `json:"count"`
+	AmountWei string `json:"amount_wei"`
+}
+
+func newAPIValueTotal(v ValueTotal) apiValueTotal {
+	return apiValueTotal{Count: v.Count, AmountWei: v.Wei.String()}
+}
+
+type apiReconciliationWindow struct {
+	Start     string        `json:"start"`
+	End       string        `json:"end"`
+	Initiated apiValueTotal `json:"initiated"`
+	Finalized apiValueTotal `json:"finalized"`
+	// DifferenceWei is the value initiated minus the value finalized, it may be negative
+	DifferenceWei string        `json:"difference_wei"`
+	InFlight      apiValueTotal `json:"in_flight"`
+	Orphaned      apiValueTotal `json:"orphaned"`
+	Discrepancy   bool          `json:"discrepancy"`
+}
+
+func newAPIReconciliationWindow(w ReconciliationWindow) apiReconciliationWindow {
+	return apiReconciliationWindow{
+		Start:         formatAPITime(w.Start),
+		End:           formatAPITime(w.End),
+		Initiated:     newAPIValueTotal(w.Initiated),
+		Finalized:     newAPIValueTotal(w.Finalized),
+		DifferenceWei: w.DifferenceWei().String(),
+		InFlight:      newAPIValueTotal(w.InFlight),
+		Orphaned:      newAPIValueTotal(w.Orphaned),
+		Discrepancy:   w.Discrepancy(),
+	}
+}
+
+type apiReconciliation struct {
+	Window  string                    `json:"window"`
+	Windows []apiReconciliationWindow `json:"windows"`
+	Total   apiReconciliationWindow   `json:"total"`
+}
+
+// apiSolvencySample is the JSON representation of a solvency sample
+type apiSolvencySample struct {
+	BlockNumber  int64  `json:"block_number"`
+	BlockTime    string `json:"block_time"`
+	BalanceWei   string `json:"balance_wei"`
+	DepositedWei string `json:"deposited_wei"`
+	WithdrawnWei string `json:"withdrawn_wei"`
+	ExpectedWei  string `json:"expected_wei"`
+	// GapWei is the balance minus the expected balance, negative when the bridge is short
+	GapWei   string  `json:"gap_wei"`
+	GapEth   float64 `json:"gap_eth"`
+	Alerting bool    `json:"alerting"`
+}
+
+func newAPISolvencySample(s SolvencySample) apiSolvencySample {
+	return apiSolvencySample{
+		BlockNumber:  s.BlockNumber,
+		BlockTime:    formatAPITime(s.BlockTime),
+		BalanceWei:   s.BalanceWei.String(),
+		DepositedWei: s.DepositedWei.String(),
+		WithdrawnWei: s.WithdrawnWei.String(),
+		ExpectedWei:  s.ExpectedWei().String(),
+		GapWei:       s.GapWei().String(),
+		GapEth:       s.GapEth,
+		Alerting:     s.Alerting,
+	}
+}
+
+type apiSolvency struct {
+	Latest  *apiSolvencySample  `json:"latest"`
+	Samples []apiSolvencySample `json:"samples"`
+}
+
+// apiPhaseStats summarizes the known durations of a latency phase
+type apiPhaseStats struct {
+	Phase      string `json:"phase"`
+	Count      int    `json:"count"`
+	P50Seconds *int64 `json:"p50_seconds"`
+	P90Seconds *int64 `json:"p90_seconds"`
+	MaxSeconds *int64 `json:"max_seconds"`
+}
+
+// apiLatencyDeposit is the latency breakdown of a deposit in a latency report
+type apiLatencyDeposit struct {
+	ID            int64   `json:"id"`
+	L1BlockNumber int64   `json:"l1_block_number"`
+	L1Timestamp   string  `json:"l1_timestamp"`
+	L2BlockNumber *int64  `json:"l2_block_number"`
+	L2Timestamp   *string `json:"l2_timestamp"`
+	*apiDepositLatency
+}
+
+type apiLatency struct {
+	Since    string              `json:"since"`
+	Until    string              `json:"until"`
+	Phases   []apiPhaseStats     `json:"phases"`
+	Deposits []apiLatencyDeposit `json:"deposits"`
+}
+
+// apiAmbiguousGroup is the JSON representation of an ambiguous matching group
+type apiAmbiguousGroup struct {
+	MatchingHash   string   `json:"matching_hash"`
+	From           string   `json:"from"`
+	AmountWei      string   `json:"amount_wei"`
+	L1Count        int      `json:"l1_count"`
+	L1Unmatched    int      `json:"l1_unmatched"`
+	L2Count        int      `json:"l2_count"`
+	L2Unmatched    int      `json:"l2_unmatched"`
+	FirstTimestamp string   `json:"first_timestamp"`
+	LastTimestamp  string   `json:"last_timestamp"`
+	MinConfidence  *float64 `json:"min_confidence"`
+}
+
+func formatAPITime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// writeJSON writes v as a JSON response with the given status code
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		s.logger.Error("failed to encode API response", "error", err)
+	}
+}
+
+// writeAPIError writes a structured error body
+func (s *Server) writeAPIError(w http.ResponseWriter, status int, code, message string) {
+	s.writeJSON(w, status, apiError{Error: apiErrorDetail{Code: code, Message: message}})
+}
+
+// writeInternalError logs err and writes a generic internal error body
+func (s *Server) writeInternalError(w http.ResponseWriter, message string, err error) {
+	s.logger.Error(message, "error", err)
+	s.writeAPIError(w, http.StatusInternalServerError, "internal_error", message)
+}
+
+func apiDepositFromPair(d DepositPair) apiDeposit {
+	confirmation := d.TimeDiffSeconds
+	return apiDeposit{
+		ID:        d.ID,
+		Status:    StatusMatched,
+		From:      d.FromAddress,
+		To:        d.ToAddress,
+		AmountWei: d.AmountWei,
+		L1: apiChainLog{
+			BlockNumber: d.L1BlockNumber,
+			Timestamp:   formatAPITime(d.L1Timestamp),
+			TxHash:      d.TxHashL1,
+		},
+		L2: &apiChainLog{
+			BlockNumber: d.L2BlockNumber,
+			Timestamp:   formatAPITime(d.L2Timestamp),
+			TxHash:      d.TxHashL2,
+		},
+		ConfirmationSeconds: &confirmation,
+	}
+}
+
+func apiDepositFromUnmatched(d UnmatchedDeposit) apiDeposit {
+	waiting := d.TimeSinceSeconds
+	return apiDeposit{
+		ID:        d.ID,
+		Status:    StatusPending,
+		From:      d.FromAddress,
+		To:        d.ToAddress,
+		AmountWei: d.AmountWei,
+		L1: apiChainLog{
+			BlockNumber: d.L1BlockNumber,
+			Timestamp:   formatAPITime(d.L1Timestamp),
+			TxHash:      d.TxHashL1,
+		},
+		WaitingSeconds: &waiting,
+		ETA:            newAPIDepositETA(d.ETA),
+	}
+}
+
+func apiDepositFromDeposit(d Deposit) apiDeposit {
+	deposit := apiDeposit{
+		ID:        d.ID,
+		Status:    StatusPending,
+		From:      d.FromAddress,
+		To:        d.ToAddress,
+		AmountWei: d.AmountWei,
+		L1: apiChainLog{
+			BlockNumber: d.L1BlockNumber,
+			Timestamp:   formatAPITime(d.L1Timestamp),
+			TxHash:      d.TxHashL1,
+		},
+	}
+	if d.L2 != nil {
+		confirmation := d.L2.TimeDiffSeconds
+		deposit.Status = StatusMatched
+		deposit.L2 = &apiChainLog{
+			BlockNumber: d.L2.BlockNumber,
+			Timestamp:   formatAPITime(d.L2.Timestamp),
+			TxHash:      d.L2.TxHash,
+		}
+		deposit.ConfirmationSeconds = &confirmation
+	} else {
+		waiting := int64(time.Since(d.L1Timestamp).Seconds())
+		deposit.WaitingSeconds = &waiting
+		deposit.ETA = newAPIDepositETA(d.ETA)
+	}
+	if d.Latency != nil {
+		deposit.Latency = newAPIDepositLatency(*d.Latency)
+	}
+	return deposit
+}
+
+// handleAPIMatchedDeposits lists matched deposit pairs
+func (s *Server) handleAPIMatchedDeposits(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseListQuery(r, DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	deposits, next, err := GetMatchedDeposits(r.Context(), s.db, q.Filter, q.Page)
+	if err != nil {
+		s.writeInternalError(w, "failed to get matched deposits", err)
+		return
+	}
+
+	total, err := GetTotalMatchedDeposits(r.Context(), s.db, q.Filter)
+	if err != nil {
+		s.writeInternalError(w, "failed to get total matched deposits", err)
+		return
+	}
+
+	data := make([]apiDeposit, 0, len(deposits))
+	for _, d := range deposits {
+		data = append(data, apiDepositFromPair(d))
+	}
+
+	s.writeJSON(w, http.StatusOK, apiList[apiDeposit]{
+		Data:       data,
+		Pagination: apiPagination{Limit: q.Page.Limit, NextCursor: nextCursor(next), Total: total},
+	})
+}
+
+// handleAPIUnmatchedDeposits lists L1 deposits waiting for their L2 confirmation
+func (s *Server) handleAPIUnmatchedDeposits(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseListQuery(r, DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+	if q.Filter.MinConfirmationSeconds != nil || q.Filter.MaxConfirmationSeconds != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "min_confirmation and max_confirmation only apply to matched deposits")
+		return
+	}
+
+	deposits, next, err := GetUnmatchedDeposits(r.Context(), s.db, q.Filter, q.Page)
+	if err != nil {
+		s.writeInternalError(w, "failed to get unmatched deposits", err)
+		return
+	}
+
+	total, err := GetTotalUnmatchedDeposits(r.Context(), s.db, q.Filter)
+	if err != nil {
+		s.writeInternalError(w, "failed to get total unmatched deposits", err)
+		return
+	}
+
+	model, err := GetETAModel(r.Context(), s.db, time.Now())
+	if err != nil {
+		s.writeInternalError(w, "failed to get ETA model", err)
+		return
+	}
+	model.LoadUnmatchedDepositETAs(deposits)
+
+	data := make([]apiDeposit, 0, len(deposits))
+	for _, d := range deposits {
+		data = append(data, apiDepositFromUnmatched(d))
+	}
+
+	s.writeJSON(w, http.StatusOK, apiList[apiDeposit]{
+		Data:       data,
+		Pagination: apiPagination{Limit: q.Page.Limit, NextCursor: nextCursor(next), Total: total},
+	})
+}
+
+// handleAPIOrphanedFinalizations lists L2 finalizations without a known L1 deposit
+func (s *Server) handleAPIOrphanedFinalizations(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseListQuery(r, DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+	if q.Filter.MinConfirmationSeconds != nil || q.Filter.MaxConfirmationSeconds != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "min_confirmation and max_confirmation only apply to matched deposits")
+		return
+	}
+
+	finalizations, next, err := GetOrphanedFinalizations(r.Context(), s.db, q.Filter, q.Page)
+	if err != nil {
+		s.writeInternalError(w, "failed to get orphaned finalizations", err)
+		return
+	}
+
+	total, err := GetTotalOrphanedFinalizations(r.Context(), s.db, q.Filter)
+	if err != nil {
+		s.writeInternalError(w, "failed to get total orphaned finalizations", err)
+		return
+	}
+
+	data := make([]apiOrphanedFinalization, 0, len(finalizations))
+	for _, f := range finalizations {
+		data = append(data, apiOrphanedFinalization{
+			ID:        f.ID,
+			From:      f.FromAddress,
+			To:        f.ToAddress,
+			AmountWei: f.AmountWei,
+			L2: apiChainLog{
+				BlockNumber: f.L2BlockNumber,
+				Timestamp:   formatAPITime(f.L2Timestamp),
+				TxHash:      f.TxHashL2,
+			},
+			OrphanedSeconds: f.TimeSinceSeconds,
+		})
+	}
+
+	s.writeJSON(w, http.StatusOK, apiList[apiOrphanedFinalization]{
+		Data:       data,
+		Pagination: apiPagination{Limit: q.Page.Limit, NextCursor: nextCursor(next), Total: total},
+	})
+}
+
+// handleAPIReconciliation compares the value initiated on L1 with the value finalized on L2 per window
+func (s *Server) handleAPIReconciliation(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseReconciliationQuery(r, time.Now())
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	reconciliation, err := GetReconciliation(r.Context(), s.db, q)
+	if err != nil {
+		s.writeInternalError(w, "failed to get reconciliation", err)
+		return
+	}
+
+	windows := make([]apiReconciliationWindow, 0, len(reconciliation.Windows))
+	for _, w := range reconciliation.Windows {
+		windows = append(windows, newAPIReconciliationWindow(w))
+	}
+
+	s.writeJSON(w, http.StatusOK, apiReconciliation{
+		Window:  reconciliation.Window,
+		Windows: windows,
+		Total:   newAPIReconciliationWindow(reconciliation.Total),
+	})
+}
+
+// handleAPISolvency returns the latest solvency sample and the samples of a time range
+func (s *Server) handleAPISolvency(w http.ResponseWriter, r *http.Request) {
+	since, until, err := ParseSolvencyRange(r, time.Now())
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	latest, err := GetLatestSolvencySample(r.Context(), s.db)
+	if err != nil {
+		s.writeInternalError(w, "failed to get latest solvency sample", err)
+		return
+	}
+
+	samples, err := GetSolvencySamples(r.Context(), s.db, since, until)
+	if err != nil {
+		s.writeInternalError(w, "failed to get solvency samples", err)
+		return
+	}
+
+	result := apiSolvency{Samples: make([]apiSolvencySample, 0, len(samples))}
+	if latest != nil {
+		sample := newAPISolvencySample(*latest)
+		result.Latest = &sample
+	}
+	for _, sample := range samples {
+		result.Samples = append(result.Samples, newAPISolvencySample(sample))
+	}
+
+	s.writeJSON(w, http.StatusOK, result)
+}
+
+// handleAPILatency breaks the latency of the deposits initiated in a time
+// range down by phase. The newest deposits are listed up to limit.
+func (s *Server) handleAPILatency(w http.ResponseWriter, r *http.Request) {
+	since, until, err := ParseLatencyRange(r, time.Now())
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	limit, err := parseLimit(r.URL.Query().Get("limit"), DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	report, err := GetLatencyReport(r.Context(), s.db, since, until)
+	if err != nil {
+		s.writeInternalError(w, "failed to get latency report", err)
+		return
+	}
+
+	result := apiLatency{
+		Since:    formatAPITime(report.Since),
+		Until:    formatAPITime(report.Until),
+		Phases:   make([]apiPhaseStats, 0, len(report.Phases)),
+		Deposits: make([]apiLatencyDeposit, 0, min(limit, len(report.Deposits))),
+	}
+	for _, p := range report.Phases {
+		result.Phases = append(result.Phases, apiPhaseStats{
+			Phase:      p.Phase,
+			Count:      p.Count,
+			P50Seconds: p.P50,
+			P90Seconds: p.P90,
+			MaxSeconds: p.Max,
+		})
+	}
+	for _, d := range report.Deposits[:min(limit, len(report.Deposits))] {
+		deposit := apiLatencyDeposit{
+			ID:                d.ID,
+			L1BlockNumber:     d.L1BlockNumber,
+			L1Timestamp:       formatAPITime(d.L1Timestamp),
+			L2BlockNumber:     d.L2BlockNumber,
+			apiDepositLatency: newAPIDepositLatency(d),
+		}
+		if d.L2Timestamp != nil {
+			l2Timestamp := formatAPITime(*d.L2Timestamp)
+			deposit.L2Timestamp = &l2Timestamp
+		}
+		result.Deposits = append(result.Deposits, deposit)
+	}
+
+	s.writeJSON(w, http.StatusOK, result)
+}
+
+// handleAPIDeposit returns a single deposit by its ID
+func (s *Server) handleAPIDeposit(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "id must be an integer")
+		return
+	}
+
+	deposit, err := GetDeposit(r.Context(), s.db, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("deposit %d not found", id))
+		return
+	}
+	if err != nil {
+		s.writeInternalError(w, "failed to get deposit", err)
+		return
+	}
+
+	latency, err := GetDepositLatency(r.Context(), s.db, id)
+	if err != nil {
+		s.writeInternalError(w, "failed to get deposit latency", err)
+		return
+	}
+	deposit.Latency = &latency
+
+	if deposit.L2 == nil {
+		model, err := GetETAModel(r.Context(), s.db, time.Now())
+		if err != nil {
+			s.writeInternalError(w, "failed to get ETA model", err)
+			return
+		}
+		deposit.ETA = model.Estimate(deposit.L1BlockNumber, deposit.L1Timestamp)
+	}
+
+	s.writeJSON(w, http.StatusOK, apiDepositFromDeposit(deposit))
+}
+
+// handleAPIDepositsByTxHash returns the deposits initiated or finalized in an L1 or L2 transaction
+func (s *Server) handleAPIDepositsByTxHash(w http.ResponseWriter, r *http.Request) {
+	txHash, err := hexutil.Decode(r.PathValue("hash"))
+	if err != nil || len(txHash) != common.HashLength {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "hash must be a 0x-prefixed 32 byte hex string")
+		return
+	}
+
+	deposits, err := GetDepositsByTxHash(r.Context(), s.db, txHash)
+	if err != nil {
+		s.writeInternalError(w, "failed to get deposits by transaction hash", err)
+		return
+	}
+	if len(deposits) == 0 {
+		s.writeAPIError(w, http.StatusNotFound, "not_found", "no deposits found for transaction")
+		return
+	}
+
+	err = LoadDepositLatencies(r.Context(), s.db, deposits)
+	if err != nil {
+		s.writeInternalError(w, "failed to get deposit latencies", err)
+		return
+	}
+
+	model, err := GetETAModel(r.Context(), s.db, time.Now())
+	if err != nil {
+		s.writeInternalError(w, "failed to get ETA model", err)
+		return
+	}
+	model.LoadDepositETAs(deposits)
+
+	data := make([]apiDeposit, 0, len(deposits))
+	for _, d := range deposits {
+		data = append(data, apiDepositFromDeposit(d))
+	}
+
+	s.writeJSON(w, http.StatusOK, apiList[apiDeposit]{
+		Data:       data,
+		Pagination: apiPagination{Limit: len(data), Total: len(data)},
+	})
+}
+
+// handleAPIStats returns aggregate bridge statistics
+func (s *Server) handleAPIStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := GetBridgeStats(r.Context(), s.db)
+	if err != nil {
+		s.writeInternalError(w, "failed to get bridge stats", err)
+		return
+	}
+
+	totalBridgedWei, err := GetTotalBridgedWei(r.Context(), s.db)
+	if err != nil {
+		s.writeInternalError(w, "failed to get total bridged amount", err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, apiStats{
+		BridgeStats:     stats,
+		TotalBridgedWei: totalBridgedWei.String(),
+	})
+}
+
+// handleAPIStatus returns the indexer block pointers and how far behind they are
+func (s *Server) handleAPIStatus(w http.ResponseWriter, r *http.Request) {
+	pointers, err := GetBlockPointers(r.Context(), s.db)
+	if err != nil {
+		s.writeInternalError(w, "failed to get block pointers", err)
+		return
+	}
+
+	status := apiStatus{Pointers: make([]apiBlockPointer, 0, len(pointers))}
+	for _, p := range pointers {
+		pointer := apiBlockPointer{
+			Name:        p.Name,
+			BlockNumber: p.BlockNumber,
+		}
+		if p.BlockTime != nil {
+			blockTime := formatAPITime(*p.BlockTime)
+			lag := int64(time.Since(*p.BlockTime).Seconds())
+			pointer.BlockTime = &blockTime
+			pointer.LagSeconds = &lag
+		}
+		status.Pointers = append(status.Pointers, pointer)
+	}
+
+	s.writeJSON(w, http.StatusOK, status)
+}
+
+// handleAPIAmbiguousGroups lists the matching groups with more than one event
+// on either side, most recent first
+func (s *Server) handleAPIAmbiguousGroups(w http.ResponseWriter, r *http.Request) {
+	page, err := ParsePage(r, DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	groups, next, err := GetAmbiguousGroups(r.Context(), s.db, page)
+	if err != nil {
+		s.writeInternalError(w, "failed to get ambiguous groups", err)
+		return
+	}
+
+	total, err := GetTotalAmbiguousGroups(r.Context(), s.db)
+	if err != nil {
+		s.writeInternalError(w, "failed to get total ambiguous groups", err)
+		return
+	}
+
+	data := make([]apiAmbiguousGroup, 0, len(groups))
+	for _, g := range groups {
+		data = append(data, apiAmbiguousGroup{
+			MatchingHash:   g.MatchingHash,
+			From:           g.FromAddress,
+			AmountWei:      g.AmountWei,
+			L1Count:        g.L1Count,
+			L1Unmatched:    g.L1Unmatched,
+			L2Count:        g.L2Count,
+			L2Unmatched:    g.L2Unmatched,
+			FirstTimestamp: formatAPITime(g.FirstTimestamp),
+			LastTimestamp:  formatAPITime(g.LastTimestamp),
+			MinConfidence:  g.MinConfidence,
+		})
+	}
+
+	s.writeJSON(w, http.StatusOK, apiList[apiAmbiguousGroup]{
+		Data:       data,
+		Pagination: apiPagination{Limit: page.Limit, NextCursor: nextCursor(next), Total: total},
+	})
+}
+
+// apiIncident is the JSON representation of a liveness incident
+type apiIncident struct {
+	ID            int64   `json:"id"`
+	Kind          string  `json:"kind"`
+	L2BlockNumber int64   `json:"l2_block_number"`
+	StartedAt     string  `json:"started_at"`
+	EndedAt       *string `json:"ended_at"`
+	// DurationSeconds is how long the incident lasted, or has lasted so far
+	DurationSeconds int64 `json:"duration_seconds"`
+	// Worst is in seconds, or in L1 blocks for origin_lag incidents
+	Worst            int64  `json:"worst"`
+	Detail           string `json:"detail"`
+	AffectedDeposits int    `json:"affected_deposits"`
+}
+
+func newAPIIncident(i Incident, now time.Time) apiIncident {
+	incident := apiIncident{
+		ID:               i.ID,
+		Kind:             i.Kind,
+		L2BlockNumber:    i.L2BlockNumber,
+		StartedAt:        formatAPITime(i.StartedAt),
+		DurationSeconds:  i.DurationSeconds(now),
+		Worst:            i.Worst,
+		Detail:           i.Detail,
+		AffectedDeposits: i.AffectedDeposits,
+	}
+	if i.EndedAt != nil {
+		endedAt := formatAPITime(*i.EndedAt)
+		incident.EndedAt = &endedAt
+	}
+	return incident
+}
+
+type apiIncidents struct {
+	Since     string        `json:"since"`
+	Until     string        `json:"until"`
+	Incidents []apiIncident `json:"incidents"`
+}
+
+// handleAPIIncidents returns the liveness incidents overlapping a time range, newest first
+func (s *Server) handleAPIIncidents(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	since, until, err := ParseIncidentRange(r, now)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	timeline, err := GetIncidentTimeline(r.Context(), s.db, since, until, now)
+	if err != nil {
+		s.writeInternalError(w, "failed to get incidents", err)
+		return
+	}
+
+	result := apiIncidents{
+		Since:     formatAPITime(timeline.Since),
+		Until:     formatAPITime(timeline.Until),
+		Incidents: make([]apiIncident, 0, len(timeline.Incidents)),
+	}
+	for _, i := range timeline.Incidents {
+		result.Incidents = append(result.Incidents, newAPIIncident(i, now))
+	}
+	s.writeJSON(w, http.StatusOK, result)
+}
+
+// handleAPIIncidentDeposits lists the deposits in flight during a liveness incident
+func (s *Server) handleAPIIncidentDeposits(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "id must be an integer")
+		return
+	}
+
+	page, err := ParsePage(r, DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	now := time.Now()
+	incident, err := GetIncident(r.Context(), s.db, id, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("incident %d not found", id))
+		return
+	}
+	if err != nil {
+		s.writeInternalError(w, "failed to get incident", err)
+		return
+	}
+
+	deposits, next, err := GetIncidentDeposits(r.Context(), s.db, incident, now, page)
+	if err != nil {
+		s.writeInternalError(w, "failed to get incident deposits", err)
+		return
+	}
+
+	data := make([]apiDeposit, 0, len(deposits))
+	for _, d := range deposits {
+		data = append(data, apiDepositFromDeposit(d))
+	}
+
+	s.writeJSON(w, http.StatusOK, apiList[apiDeposit]{
+		Data:       data,
+		Pagination: apiPagination{Limit: page.Limit, NextCursor: nextCursor(next), Total: incident.AffectedDeposits},
+	})
+}
+
+// apiBatcherTransaction is the JSON representation of a batcher transaction
+type apiBatcherTransaction struct {
+	BlockNumber int64  `json:"block_number"`
+	BlockTime   string `json:"block_time"`
+	TxHash      string `json:"tx_hash"`
+	Blob        bool   `json:"blob"`
+	BlobCount   int64  `json:"blob_count"`
+	DataSize    int64  `json:"data_size"`
+}
+
+// apiSyncStatus is the JSON representation of a sync status sample of the rollup node
+type apiSyncStatus struct {
+	ObservedAt        string `json:"observed_at"`
+	CurrentL1Number   int64  `json:"current_l1_number"`
+	HeadL1Number      int64  `json:"head_l1_number"`
+	UnsafeL2Number    int64  `json:"unsafe_l2_number"`
+	UnsafeL2Time      string `json:"unsafe_l2_time"`
+	SafeL2Number      int64  `json:"safe_l2_number"`
+	SafeL2Time        string `json:"safe_l2_time"`
+	FinalizedL2Number int64  `json:"finalized_l2_number"`
+	FinalizedL2Time   string `json:"finalized_l2_time"`
+	SafeLagSeconds    int64  `json:"safe_lag_seconds"`
+	// Stale is set when the sample is too old to be checked by the liveness monitor
+	Stale bool `json:"stale"`
+}
+
+type apiBatcher struct {
+	Since              string                  `json:"since"`
+	Until              string                  `json:"until"`
+	Count              int                     `json:"count"`
+	BlobCount          int                     `json:"blob_count"`
+	CalldataCount      int                     `json:"calldata_count"`
+	DataSize           int64                   `json:"data_size"`
+	AvgIntervalSeconds *int64                  `json:"avg_interval_seconds"`
+	MaxGapSeconds      *int64                  `json:"max_gap_seconds"`
+	ScannedUntil       *string                 `json:"scanned_until"`
+	SyncStatus         *apiSyncStatus          `json:"sync_status"`
+	Transactions       []apiBatcherTransaction `json:"transactions"`
+}
+
+// handleAPIBatcher summarizes the batcher transactions posted in a time range
+// with the latest sync status of the rollup node. The newest transactions are
+// listed up to limit.
+func (s *Server) handleAPIBatcher(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	since, until, err := ParseBatcherRange(r, now)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	limit, err := parseLimit(r.URL.Query().Get("limit"), DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	report, err := GetBatcherReport(r.Context(), s.db, since, until)
+	if err != nil {
+		s.writeInternalError(w, "failed to get batcher report", err)
+		return
+	}
+
+	result := apiBatcher{
+		Since:              formatAPITime(report.Since),
+		Until:              formatAPITime(report.Until),
+		Count:              len(report.Transactions),
+		BlobCount:          report.BlobCount,
+		CalldataCount:      report.CalldataCount,
+		DataSize:           report.DataSize,
+		AvgIntervalSeconds: report.AvgIntervalSeconds,
+		MaxGapSeconds:      report.MaxGapSeconds,
+		Transactions:       make([]apiBatcherTransaction, 0, min(limit, len(report.Transactions))),
+	}
+	if report.ScannedUntil != nil {
+		scannedUntil := formatAPITime(*report.ScannedUntil)
+		result.ScannedUntil = &scannedUntil
+	}
+	if status := report.SyncStatus; status != nil {
+		result.SyncStatus = &apiSyncStatus{
+			ObservedAt:        formatAPITime(status.ObservedAt),
+			CurrentL1Number:   status.CurrentL1Number,
+			HeadL1Number:      status.HeadL1Number,
+			UnsafeL2Number:    status.UnsafeL2Number,
+			UnsafeL2Time:      formatAPITime(status.UnsafeL2Time),
+			SafeL2Number:      status.SafeL2Number,
+			SafeL2Time:        formatAPITime(status.SafeL2Time),
+			FinalizedL2Number: status.FinalizedL2Number,
+			FinalizedL2Time:   formatAPITime(status.FinalizedL2Time),
+			SafeLagSeconds:    status.SafeLagSeconds(),
+			Stale:             status.Stale(now),
+		}
+	}
+	for _, t := range report.Transactions[:min(limit, len(report.Transactions))] {
+		result.Transactions = append(result.Transactions, apiBatcherTransaction{
+			BlockNumber: t.BlockNumber,
+			BlockTime:   formatAPITime(t.BlockTime),
+			TxHash:      t.TxHash,
+			Blob:        t.Blob,
+			BlobCount:   t.BlobCount,
+			DataSize:    t.DataSize,
+		})
+	}
+
+	s.writeJSON(w, http.StatusOK, result)
+}
+
+// apiDisputeGame is the JSON representation of a dispute game
+type apiDisputeGame struct {
+	ID            int64   `json:"id"`
+	Address       string  `json:"address"`
+	GameType      int64   `json:"game_type"`
+	RootClaim     string  `json:"root_claim"`
+	L2BlockNumber int64   `json:"l2_block_number"`
+	L1BlockNumber int64   `json:"l1_block_number"`
+	CreatedAt     string  `json:"created_at"`
+	TxHash        string  `json:"tx_hash"`
+	Status        string  `json:"status"`
+	ResolvedAt    *string `json:"resolved_at"`
+	// ExpectedRoot and Valid are null until L2 reached the block of the claim
+	ExpectedRoot   *string `json:"expected_root"`
+	Valid          *bool   `json:"valid"`
+	Critical       bool    `json:"critical"`
+	CriticalReason *string `json:"critical_reason"`
+	// OutputRootError is set when L2 no longer has the state to verify the claim
+	OutputRootError *string `json:"output_root_error"`
+}
+
+func newAPIDisputeGame(g DisputeGame) apiDisputeGame {
+	game := apiDisputeGame{
+		ID:            g.ID,
+		Address:       g.Address,
+		GameType:      g.GameType,
+		RootClaim:     g.RootClaim,
+		L2BlockNumber: g.L2BlockNumber,
+		L1BlockNumber: g.L1BlockNumber,
+		CreatedAt:     formatAPITime(g.CreatedAt),
+		TxHash:        g.TxHash,
+		Status:        disputeGameStatuses[g.Status],
+		Valid:         g.Valid,
+		Critical:      g.CriticalReason != "",
+	}
+	if g.ResolvedAt != nil {
+		resolvedAt := formatAPITime(*g.ResolvedAt)
+		game.ResolvedAt = &resolvedAt
+	}
+	if g.ExpectedRoot != "" {
+		game.ExpectedRoot = &g.ExpectedRoot
+	}
+	if g.CriticalReason != "" {
+		game.CriticalReason = &g.CriticalReason
+	}
+	if g.OutputRootError != "" {
+		game.OutputRootError = &g.OutputRootError
+	}
+	return game
+}
+
+// handleAPIDisputeGames lists the dispute games, newest first. With
+// critical=true only the critical games are listed.
+func (s *Server) handleAPIDisputeGames(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+
+	page, err := ParsePage(r, DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	critical := false
+	if v := values.Get("critical"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "critical must be true or false")
+			return
+		}
+		critical = parsed
+	}
+
+	total, err := CountDisputeGames(r.Context(), s.db, critical)
+	if err != nil {
+		s.writeInternalError(w, "failed to count dispute games", err)
+		return
+	}
+
+	games, next, err := GetDisputeGames(r.Context(), s.db, critical, page)
+	if err != nil {
+		s.writeInternalError(w, "failed to get dispute games", err)
+		return
+	}
+
+	data := make([]apiDisputeGame, 0, len(games))
+	for _, g := range games {
+		data = append(data, newAPIDisputeGame(g))
+	}
+
+	s.writeJSON(w, http.StatusOK, apiList[apiDisputeGame]{
+		Data:       data,
+		Pagination: apiPagination{Limit: page.Limit, NextCursor: nextCursor(next), Total: total},
+	})
+}
+
+// apiSecurityEvent is the JSON representation of an entry of the security audit trail
+type apiSecurityEvent struct {
+	ID           int64  `json:"id"`
+	ObservedAt   string `json:"observed_at"`
+	BlockNumber  int64  `json:"block_number"`
+	Contract     string `json:"contract"`
+	ContractName string `json:"contract_name"`
+	Kind         string `json:"kind"`
+	Severity     string `json:"severity"`
+	Detail       string `json:"detail"`
+	// TxHash is null for findings read from storage or paused()
+	TxHash *string `json:"tx_hash"`
+	Value  *string `json:"value"`
+}
+
+func newAPISecurityEvent(f SecurityFinding) apiSecurityEvent {
+	event := apiSecurityEvent{
+		ID:           f.ID,
+		ObservedAt:   formatAPITime(f.ObservedAt),
+		BlockNumber:  f.BlockNumber,
+		Contract:     f.Contract,
+		ContractName: f.ContractName,
+		Kind:         f.Kind,
+		Severity:     f.Severity,
+		Detail:       f.Detail,
+	}
+	if f.TxHash != "" {
+		event.TxHash = &f.TxHash
+	}
+	if f.Value != "" {
+		event.Value = &f.Value
+	}
+	return event
+}
+
+// apiSecurityEvents is a page of the security audit trail with the pause state of the bridge
+type apiSecurityEvents struct {
+	apiList[apiSecurityEvent]
+	Paused bool `json:"paused"`
+	// Pause is the event that paused the bridge, null while it is not paused
+	Pause *apiSecurityEvent `json:"pause"`
+}
+
+// handleAPISecurityEvents lists the security audit trail of the bridge
+// contracts, newest first, with the pause state
+func (s *Server) handleAPISecurityEvents(w http.ResponseWriter, r *http.Request) {
+	page, err := ParsePage(r, DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	total, err := CountSecurityFindings(r.Context(), s.db)
+	if err != nil {
+		s.writeInternalError(w, "failed to count security events", err)
+		return
+	}
+
+	findings, next, err := GetSecurityFindings(r.Context(), s.db, page)
+	if err != nil {
+		s.writeInternalError(w, "failed to get security events", err)
+		return
+	}
+
+	pause, err := GetPause(r.Context(), s.db)
+	if err != nil {
+		s.writeInternalError(w, "failed to get pause state", err)
+		return
+	}
+
+	data := make([]apiSecurityEvent, 0, len(findings))
+	for _, f := range findings {
+		data = append(data, newAPISecurityEvent(f))
+	}
+
+	result := apiSecurityEvents{
+		apiList: apiList[apiSecurityEvent]{
+			Data:       data,
+			Pagination: apiPagination{Limit: page.Limit, NextCursor: nextCursor(next), Total: total},
+		},
+		Paused: pause != nil,
+	}
+	if pause != nil {
+		event := newAPISecurityEvent(*pause)
+		result.Pause = &event
+	}
+	s.writeJSON(w, http.StatusOK, result)
+}
+
+// apiEventSource is the JSON representation of a source of the events config
+type apiEventSource struct {
+	Name     string `json:"name"`
+	Chain    string `json:"chain"`
+	Contract string `json:"contract"`
+	Event    string `json:"event"`
+	Count    int    `json:"count"`
+}
+
+type apiEventSources struct {
+	Sources []apiEventSource `json:"sources"`
+}
+
+// handleAPIEventSources lists the sources of the events config that indexed events
+func (s *Server) handleAPIEventSources(w http.ResponseWriter, r *http.Request) {
+	sources, err := GetEventSources(r.Context(), s.db)
+	if err != nil {
+		s.writeInternalError(w, "failed to get event sources", err)
+		return
+	}
+
+	result := apiEventSources{Sources: make([]apiEventSource, 0, len(sources))}
+	for _, src := range sources {
+		result.Sources = append(result.Sources, apiEventSource{
+			Name:     src.Name,
+			Chain:    src.Chain,
+			Contract: src.Contract,
+			Event:    src.Event,
+			Count:    src.Count,
+		})
+	}
+	s.writeJSON(w, http.StatusOK, result)
+}
+
+// apiIndexedEvent is the JSON representation of an event indexed for a source
+type apiIndexedEvent struct {
+	ID             int64           `json:"id"`
+	Source         string          `json:"source"`
+	Chain          string          `json:"chain"`
+	Contract       string          `json:"contract"`
+	Event          string          `json:"event"`
+	BlockNumber    int64           `json:"block_number"`
+	BlockTimestamp string          `json:"block_timestamp"`
+	TxHash         string          `json:"tx_hash"`
+	LogIndex       int64           `json:"log_index"`
+	Args           json.RawMessage `json:"args"`
+}
+
+// handleAPIIndexedEvents lists the events indexed for a source, newest first
+func (s *Server) handleAPIIndexedEvents(w http.ResponseWriter, r *http.Request) {
+	source := r.PathValue("source")
+
+	page, err := ParsePage(r, DefaultAPILimit, MaxAPILimit)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	total, err := CountIndexedEvents(r.Context(), s.db, source)
+	if err != nil {
+		s.writeInternalError(w, "failed to count indexed events", err)
+		return
+	}
+
+	indexed, next, err := GetIndexedEvents(r.Context(), s.db, source, page)
+	if err != nil {
+		s.writeInternalError(w, "failed to get indexed events", err)
+		return
+	}
+
+	data := make([]apiIndexedEvent, 0, len(indexed))
+	for _, e := range indexed {
+		data = append(data, apiIndexedEvent{
+			ID:             e.ID,
+			Source:         e.Source,
+			Chain:          e.Chain,
+			Contract:       e.Contract,
+			Event:          e.Event,
+			BlockNumber:    e.BlockNumber,
+			BlockTimestamp: formatAPITime(e.BlockTimestamp),
+			TxHash:         e.TxHash,
+			LogIndex:       e.LogIndex,
+			Args:           json.RawMessage(e.Args),
+		})
+	}
+
+	s.writeJSON(w, http.StatusOK, apiList[apiIndexedEvent]{
+		Data:       data,
+		Pagination: apiPagination{Limit: page.Limit, NextCursor: nextCursor(next), Total: total},
+	})
+}
@@ -0,0 +1,648 @@
+package webui_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Golem-Base/bridgette/pkg/batcher"
+	"github.com/Golem-Base/bridgette/pkg/disputegame"
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/liveness"
+	"github.com/Golem-Base/bridgette/pkg/security"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/Golem-Base/bridgette/pkg/webui"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	l1TxHash = common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	l2TxHash = common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	sender   = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+)
+
+func newTestServer(t *testing.T) http.Handler {
+	t.Helper()
+
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	// One wei above 1.5 ETH, which the REAL amount column cannot represent
+	amount, _ := new(big.Int).SetString("1500000000000000001", 10)
+
+	l1ID, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+		BlockNumber:    100,
+		BlockTimestamp: 1700000000,
+		TxHash:         l1TxHash.Bytes(),
+		FromAddress:    sender.Bytes(),
+		ToAddress:      sender.Bytes(),
+		Amount:         1.5,
+		AmountWei:      common.LeftPadBytes(amount.Bytes(), 32),
+		Event:          []byte("{}"),
+		MatchingHash:   []byte{1},
+	})
+	require.NoError(t, err)
+
+	l2ID, err := queries.InsertL2StandardBridgeDepositFinalized(ctx, sqlitestore.InsertL2StandardBridgeDepositFinalizedParams{
+		BlockNumber:    200,
+		BlockTimestamp: 1700000060,
+		TxHash:         l2TxHash.Bytes(),
+		FromAddress:    sender.Bytes(),
+		ToAddress:      sender.Bytes(),
+		L1Token:        common.Address{}.Bytes(),
+		Amount:         1.5,
+		AmountWei:      common.LeftPadBytes(amount.Bytes(), 32),
+		Event:          []byte("{}"),
+		MatchingHash:   []byte{1},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, queries.UpdateL1DepositWithMatch(ctx, sqlitestore.UpdateL1DepositWithMatchParams{
+		MatchedL2StandardBridgeDepositFinalizedID: &l2ID,
+		ID: l1ID,
+	}))
+	require.NoError(t, queries.UpdateL2DepositWithMatch(ctx, sqlitestore.UpdateL2DepositWithMatchParams{
+		MatchedL1StandardBridgeEthDepositInitiatedID: &l1ID,
+		ID: l2ID,
+	}))
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return webui.NewServer(db, events.NewBus(), logger, "", "").Handler()
+}
+
+func getJSON(t *testing.T, handler http.Handler, path string, status int) map[string]any {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, status, rec.Code, rec.Body.String())
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body
+}
+
+func TestAPIMatchedDeposits(t *testing.T) {
+	handler := newTestServer(t)
+
+	body := getJSON(t, handler, "/api/v1/deposits/matched?address="+sender.Hex(), http.StatusOK)
+	require.Equal(t, float64(1), body["pagination"].(map[string]any)["total"])
+
+	deposit := body["data"].([]any)[0].(map[string]any)
+	require.Equal(t, "matched", deposit["status"])
+	require.Equal(t, "1500000000000000001", deposit["amount_wei"])
+	require.Equal(t, float64(60), deposit["confirmation_seconds"])
+	require.Equal(t, "2023-11-14T22:13:20Z", deposit["l1"].(map[string]any)["timestamp"])
+	require.Equal(t, l2TxHash.Hex(), deposit["l2"].(map[string]any)["tx_hash"])
+
+	body = getJSON(t, handler, "/api/v1/deposits/matched?since=2024-01-01T00:00:00Z", http.StatusOK)
+	require.Empty(t, body["data"])
+}
+
+func TestAPIDepositLookup(t *testing.T) {
+	handler := newTestServer(t)
+
+	body := getJSON(t, handler, "/api/v1/deposits/by-tx/"+l2TxHash.Hex(), http.StatusOK)
+	require.Len(t, body["data"], 1)
+
+	body = getJSON(t, handler, "/api/v1/deposits/42", http.StatusNotFound)
+	require.Equal(t, "not_found", body["error"].(map[string]any)["code"])
+
+	body = getJSON(t, handler, "/api/v1/deposits/matched?limit=0", http.StatusBadRequest)
+	require.Equal(t, "invalid_parameter", body["error"].(map[string]any)["code"])
+}
+
+func TestAPIStats(t *testing.T) {
+	handler := newTestServer(t)
+
+	body := getJSON(t, handler, "/api/v1/stats", http.StatusOK)
+	require.Equal(t, float64(1), body["total_matched"])
+	require.Equal(t, float64(0), body["pending_deposits"])
+	require.Equal(t, "1500000000000000001", body["total_bridged_wei"])
+	require.Equal(t, float64(60), body["avg_confirmation_seconds"])
+}
+
+func TestAPIExport(t *testing.T) {
+	handler := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/export?format=csv&status=matched", nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	require.Contains(t, rec.Header().Get("Content-Disposition"), ".csv")
+	require.Contains(t, rec.Body.String(), "matched,1,100,2023-11-14T22:13:20Z,"+l1TxHash.Hex())
+	require.Contains(t, rec.Body.String(), "1500000000000000001,60")
+
+	body := getJSON(t, handler, "/api/v1/export?format=xlsx", http.StatusBadRequest)
+	require.Equal(t, "invalid_parameter", body["error"].(map[string]any)["code"])
+}
+
+func TestAPIAmbiguousGroups(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	amount, _ := new(big.Int).SetString("1500000000000000001", 10)
+	for i := range 2 {
+		_, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+			BlockNumber:    int64(100 + i),
+			BlockTimestamp: int64(1700000000 + i),
+			TxHash:         []byte{byte(i)},
+			FromAddress:    sender.Bytes(),
+			ToAddress:      sender.Bytes(),
+			Amount:         1.5,
+			AmountWei:      common.BigToHash(amount).Bytes(),
+			Event:          []byte("{}"),
+			MatchingHash:   []byte{9},
+		})
+		require.NoError(t, err)
+	}
+
+	handler := webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()
+
+	body := getJSON(t, handler, "/api/v1/matches/ambiguous", http.StatusOK)
+	require.Equal(t, float64(1), body["pagination"].(map[string]any)["total"])
+	require.Nil(t, body["pagination"].(map[string]any)["next_cursor"])
+
+	group := body["data"].([]any)[0].(map[string]any)
+	require.Equal(t, "0x09", group["matching_hash"])
+	require.Equal(t, "1500000000000000001", group["amount_wei"])
+	require.NotContains(t, group, "amount")
+	require.Equal(t, float64(2), group["l1_count"])
+	require.Equal(t, float64(2), group["l1_unmatched"])
+	require.Equal(t, float64(0), group["l2_count"])
+	require.Nil(t, group["min_confidence"])
+
+	body = getJSON(t, handler, "/api/v1/matches/ambiguous?cursor=-1", http.StatusBadRequest)
+	require.Equal(t, "invalid_parameter", body["error"].(map[string]any)["code"])
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/matches/ambiguous", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "2 (2 unmatched)")
+}
+
+func TestAPIOrphanedFinalizationsAndReconciliation(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	oneEth := common.LeftPadBytes(big.NewInt(1e18).Bytes(), 32)
+
+	// A deposit still in flight on 2023-11-14 and an orphaned finalization on 2023-11-15
+	_, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+		BlockNumber:    100,
+		BlockTimestamp: 1700000000,
+		TxHash:         l1TxHash.Bytes(),
+		FromAddress:    sender.Bytes(),
+		ToAddress:      sender.Bytes(),
+		Amount:         1,
+		AmountWei:      oneEth,
+		Event:          []byte("{}"),
+		MatchingHash:   []byte{1},
+	})
+	require.NoError(t, err)
+	_, err = queries.InsertL2StandardBridgeDepositFinalized(ctx, sqlitestore.InsertL2StandardBridgeDepositFinalizedParams{
+		BlockNumber:    200,
+		BlockTimestamp: 1700060000,
+		TxHash:         l2TxHash.Bytes(),
+		FromAddress:    sender.Bytes(),
+		ToAddress:      sender.Bytes(),
+		L1Token:        common.Address{}.Bytes(),
+		Amount:         2,
+		AmountWei:      common.LeftPadBytes(big.NewInt(2e18).Bytes(), 32),
+		Event:          []byte("{}"),
+		MatchingHash:   []byte{2},
+	})
+	require.NoError(t, err)
+
+	handler := webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()
+
+	body := getJSON(t, handler, "/api/v1/finalizations/orphaned", http.StatusOK)
+	require.Equal(t, float64(1), body["pagination"].(map[string]any)["total"])
+	orphan := body["data"].([]any)[0].(map[string]any)
+	require.Equal(t, "2000000000000000000", orphan["amount_wei"])
+	require.Equal(t, l2TxHash.Hex(), orphan["l2"].(map[string]any)["tx_hash"])
+
+	body = getJSON(t, handler, "/api/v1/reconciliation?window=day&since=2023-11-14&until=2023-11-16", http.StatusOK)
+	windows := body["windows"].([]any)
+	require.Len(t, windows, 2)
+
+	newest := windows[0].(map[string]any)
+	require.Equal(t, "2023-11-15T00:00:00Z", newest["start"])
+	require.Equal(t, true, newest["discrepancy"])
+	require.Equal(t, "-2000000000000000000", newest["difference_wei"])
+	require.Equal(t, "2000000000000000000", newest["orphaned"].(map[string]any)["amount_wei"])
+
+	oldest := windows[1].(map[string]any)
+	require.Equal(t, false, oldest["discrepancy"])
+	require.Equal(t, "1000000000000000000", oldest["in_flight"].(map[string]any)["amount_wei"])
+
+	total := body["total"].(map[string]any)
+	require.Equal(t, float64(1), total["initiated"].(map[string]any)["count"])
+	require.Equal(t, float64(1), total["finalized"].(map[string]any)["count"])
+
+	body = getJSON(t, handler, "/api/v1/reconciliation?window=month", http.StatusBadRequest)
+	require.Equal(t, "invalid_parameter", body["error"].(map[string]any)["code"])
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/reconciliation?since=2023-11-14&until=2023-11-16", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "2.0000 ETH (1)")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/orphaned", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), l2TxHash.Hex())
+}
+
+func TestAPISolvency(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+	handler := webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()
+
+	body := getJSON(t, handler, "/api/v1/solvency", http.StatusOK)
+	require.Nil(t, body["latest"])
+	require.Empty(t, body["samples"])
+
+	wei := func(v int64) []byte {
+		return common.LeftPadBytes(big.NewInt(v).Bytes(), 32)
+	}
+	for i, balance := range []int64{3e18, 1e18} {
+		err := queries.InsertSolvencySample(ctx, sqlitestore.InsertSolvencySampleParams{
+			BlockNumber:  int64(100 + i),
+			BlockTime:    int64(1700000000 + i*12),
+			BalanceWei:   wei(balance),
+			DepositedWei: wei(4e18),
+			WithdrawnWei: wei(1e18),
+			GapEth:       float64(balance-3e18) / 1e18,
+			Alerting:     balance < 3e18,
+		})
+		require.NoError(t, err)
+	}
+
+	body = getJSON(t, handler, "/api/v1/solvency?since=2023-11-14&until=2023-11-15", http.StatusOK)
+	latest := body["latest"].(map[string]any)
+	require.Equal(t, float64(101), latest["block_number"])
+	require.Equal(t, "3000000000000000000", latest["expected_wei"])
+	require.Equal(t, "-2000000000000000000", latest["gap_wei"])
+	require.Equal(t, true, latest["alerting"])
+
+	samples := body["samples"].([]any)
+	require.Len(t, samples, 2)
+	require.Equal(t, float64(100), samples[0].(map[string]any)["block_number"])
+
+	body = getJSON(t, handler, "/api/v1/solvency?since=2023-11-15&until=2023-11-14", http.StatusBadRequest)
+	require.Equal(t, "invalid_parameter", body["error"].(map[string]any)["code"])
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/solvency", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "-2.0000 ETH")
+}
+
+func TestAPILatency(t *testing.T) {
+	handler := newTestServer(t)
+
+	body := getJSON(t, handler, "/api/v1/latency?since=2023-11-14&until=2023-11-15", http.StatusOK)
+	phases := body["phases"].([]any)
+	require.Len(t, phases, len(webui.LatencyPhases))
+
+	// Without a running latency tracker only the L2 inclusion is known
+	for _, p := range phases {
+		phase := p.(map[string]any)
+		if phase["phase"] == webui.PhaseL2Inclusion {
+			require.Equal(t, float64(1), phase["count"])
+			require.Equal(t, float64(60), phase["p50_seconds"])
+		} else {
+			require.Equal(t, float64(0), phase["count"])
+			require.Nil(t, phase["p50_seconds"])
+		}
+	}
+
+	deposits := body["deposits"].([]any)
+	require.Len(t, deposits, 1)
+	require.Equal(t, float64(200), deposits[0].(map[string]any)["l2_block_number"])
+
+	body = getJSON(t, handler, "/api/v1/deposits/1", http.StatusOK)
+	latency := body["latency"].(map[string]any)
+	require.Equal(t, float64(60), latency["phase_seconds"].(map[string]any)[webui.PhaseL2Inclusion])
+	require.Nil(t, latency["l1_finalized_at"])
+
+	body = getJSON(t, handler, "/api/v1/latency?limit=-1", http.StatusBadRequest)
+	require.Equal(t, "invalid_parameter", body["error"].(map[string]any)["code"])
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/deposit/"+l1TxHash.Hex(), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "Latency by Phase")
+}
+
+func TestAPIIncidents(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	l1ID, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+		BlockNumber:    100,
+		BlockTimestamp: 1700000000,
+		TxHash:         l1TxHash.Bytes(),
+		FromAddress:    sender.Bytes(),
+		ToAddress:      sender.Bytes(),
+		AmountWei:      make([]byte, 32),
+		Event:          []byte("{}"),
+		MatchingHash:   []byte{1},
+	})
+	require.NoError(t, err)
+
+	// A stall while the deposit was pending, and one before it was initiated
+	endedAt := int64(1700000100)
+	for _, startedAt := range []int64{1700000050, 1699990000} {
+		id, err := queries.InsertLivenessIncident(ctx, sqlitestore.InsertLivenessIncidentParams{
+			Kind:          liveness.Stall,
+			StartedAt:     startedAt,
+			L2BlockNumber: 200,
+			Worst:         50,
+			Detail:        "L2 head stuck at block 200 for 50s",
+		})
+		require.NoError(t, err)
+		end := min(endedAt, startedAt+50)
+		require.NoError(t, queries.CloseLivenessIncident(ctx, sqlitestore.CloseLivenessIncidentParams{EndedAt: &end, ID: id}))
+	}
+
+	handler := webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()
+
+	body := getJSON(t, handler, "/api/v1/incidents?since=2023-11-14&until=2023-11-15", http.StatusOK)
+	incidents := body["incidents"].([]any)
+	require.Len(t, incidents, 2)
+	incident := incidents[0].(map[string]any)
+	require.Equal(t, liveness.Stall, incident["kind"])
+	require.Equal(t, float64(50), incident["duration_seconds"])
+	require.Equal(t, float64(1), incident["affected_deposits"])
+	require.Equal(t, float64(0), incidents[1].(map[string]any)["affected_deposits"])
+
+	body = getJSON(t, handler, fmt.Sprintf("/api/v1/incidents/%d/deposits", int64(incident["id"].(float64))), http.StatusOK)
+	data := body["data"].([]any)
+	require.Len(t, data, 1)
+	require.Equal(t, float64(l1ID), data[0].(map[string]any)["id"])
+
+	getJSON(t, handler, "/api/v1/incidents/99/deposits", http.StatusNotFound)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/incidents?since=2023-11-14&until=2023-11-15", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "L2 head stalled")
+	require.Contains(t, rec.Body.String(), "position: absolute")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/incident/1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), l1TxHash.Hex())
+}
+
+func TestAPIBatcher(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	for i, blob := range []bool{false, true, true} {
+		params := sqlitestore.InsertBatcherTransactionParams{
+			BlockNumber: int64(100 + 10*i),
+			BlockTime:   int64(1700000000 + 120*i),
+			TxHash:      []byte{byte(i)},
+			DataSize:    1000,
+		}
+		if blob {
+			params.Blob, params.BlobCount, params.DataSize = true, 1, batcher.BlobSize
+		}
+		require.NoError(t, queries.InsertBatcherTransaction(ctx, params))
+	}
+	// The batcher has been silent for 10 minutes up to the last scanned block
+	blockNumber, blockTime := int64(170), int64(1700000840)
+	require.NoError(t, queries.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{BlockNumber: &blockNumber, BlockTime: &blockTime, Name: batcher.LastBlock}))
+	require.NoError(t, queries.InsertSyncStatusSample(ctx, sqlitestore.InsertSyncStatusSampleParams{
+		ObservedAt:     1700000840,
+		UnsafeL2Number: 500,
+		UnsafeL2Time:   1700000840,
+		SafeL2Number:   200,
+		SafeL2Time:     1700000240,
+	}))
+
+	handler := webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()
+
+	body := getJSON(t, handler, "/api/v1/batcher?since=2023-11-14&until=2023-11-15&limit=2", http.StatusOK)
+	require.Equal(t, float64(3), body["count"])
+	require.Equal(t, float64(2), body["blob_count"])
+	require.Equal(t, float64(1), body["calldata_count"])
+	require.Equal(t, float64(1000+2*batcher.BlobSize), body["data_size"])
+	require.Equal(t, float64(120), body["avg_interval_seconds"])
+	require.Equal(t, float64(600), body["max_gap_seconds"])
+	require.Len(t, body["transactions"], 2)
+	require.Equal(t, "0x02", body["transactions"].([]any)[0].(map[string]any)["tx_hash"])
+	status := body["sync_status"].(map[string]any)
+	require.Equal(t, float64(600), status["safe_lag_seconds"])
+	require.Equal(t, true, status["stale"])
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/batcher", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "Unsafe to safe lag")
+}
+
+func TestAPIDisputeGames(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	for i := range 3 {
+		require.NoError(t, queries.InsertDisputeGame(ctx, sqlitestore.InsertDisputeGameParams{
+			GameAddress:   common.Address{byte(i + 1)}.Bytes(),
+			RootClaim:     common.Hash{byte(i + 1)}.Bytes(),
+			L2BlockNumber: int64(1000 * (i + 1)),
+			BlockNumber:   int64(100 + i),
+			BlockTime:     1700000000 + int64(12*i),
+			TxHash:        l1TxHash.Bytes(),
+		}))
+	}
+	// The oldest game resolved for an invalid claim
+	valid, reason, resolvedAt := false, "Invalid root claim resolved in favour of the defender", int64(1700100000)
+	require.NoError(t, queries.UpdateDisputeGameStatus(ctx, sqlitestore.UpdateDisputeGameStatusParams{Status: disputegame.DefenderWins, ResolvedAt: &resolvedAt, ID: 1}))
+	require.NoError(t, queries.UpdateDisputeGameOutputRoot(ctx, sqlitestore.UpdateDisputeGameOutputRootParams{ExpectedRoot: common.Hash{0xaa}.Bytes(), Valid: &valid, ID: 1}))
+	require.NoError(t, queries.FlagDisputeGame(ctx, sqlitestore.FlagDisputeGameParams{CriticalReason: &reason, ID: 1}))
+
+	handler := webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()
+
+	body := getJSON(t, handler, "/api/v1/dispute-games?limit=2", http.StatusOK)
+	require.Len(t, body["data"], 2)
+	require.Equal(t, float64(3), body["pagination"].(map[string]any)["total"])
+	newest := body["data"].([]any)[0].(map[string]any)
+	require.Equal(t, common.Address{3}.Hex(), newest["address"])
+	require.Equal(t, "in_progress", newest["status"])
+	require.Nil(t, newest["valid"])
+
+	// A game created meanwhile doesn't shift the next page
+	require.NoError(t, queries.InsertDisputeGame(ctx, sqlitestore.InsertDisputeGameParams{
+		GameAddress:   common.Address{4}.Bytes(),
+		RootClaim:     common.Hash{4}.Bytes(),
+		L2BlockNumber: 4000,
+		BlockNumber:   103,
+		BlockTime:     1700000036,
+		TxHash:        l1TxHash.Bytes(),
+	}))
+	cursor := body["pagination"].(map[string]any)["next_cursor"].(string)
+	body = getJSON(t, handler, "/api/v1/dispute-games?limit=2&cursor="+cursor, http.StatusOK)
+	require.Len(t, body["data"], 1)
+	require.Equal(t, common.Address{1}.Hex(), body["data"].([]any)[0].(map[string]any)["address"])
+	require.Nil(t, body["pagination"].(map[string]any)["next_cursor"])
+
+	getJSON(t, handler, "/api/v1/dispute-games?cursor=2", http.StatusBadRequest)
+
+	body = getJSON(t, handler, "/api/v1/dispute-games?critical=true", http.StatusOK)
+	require.Len(t, body["data"], 1)
+	game := body["data"].([]any)[0].(map[string]any)
+	require.Equal(t, "defender_wins", game["status"])
+	require.Equal(t, false, game["valid"])
+	require.Equal(t, true, game["critical"])
+	require.Equal(t, reason, game["critical_reason"])
+
+	getJSON(t, handler, "/api/v1/dispute-games?critical=maybe", http.StatusBadRequest)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/dispute-games", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "1 critical dispute games")
+	require.Contains(t, rec.Body.String(), reason)
+}
+
+func TestAPISecurityEvents(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	portal := common.Address{0x0a}
+	implementation := common.Address{0x1a}
+	logIndex := int64(0)
+	require.NoError(t, queries.InsertSecurityFinding(ctx, sqlitestore.InsertSecurityFindingParams{
+		ObservedAt:   1700000000,
+		BlockNumber:  100,
+		Contract:     portal.Bytes(),
+		ContractName: "OptimismPortal",
+		Kind:         security.Implementation,
+		Severity:     security.Info,
+		Value:        implementation.Bytes(),
+		Detail:       "Implementation is " + implementation.Hex(),
+	}))
+	require.NoError(t, queries.InsertSecurityFinding(ctx, sqlitestore.InsertSecurityFindingParams{
+		ObservedAt:   1700000120,
+		BlockNumber:  110,
+		TxHash:       l1TxHash.Bytes(),
+		LogIndex:     &logIndex,
+		Contract:     portal.Bytes(),
+		ContractName: "OptimismPortal",
+		Kind:         security.Paused,
+		Severity:     security.Critical,
+		Detail:       "Paused by \"guardian\"",
+	}))
+
+	handler := webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()
+
+	body := getJSON(t, handler, "/api/v1/security-events?limit=1", http.StatusOK)
+	require.Equal(t, true, body["paused"])
+	require.Len(t, body["data"], 1)
+	cursor := body["pagination"].(map[string]any)["next_cursor"].(string)
+	newest := body["data"].([]any)[0].(map[string]any)
+	require.Equal(t, security.Paused, newest["kind"])
+	require.Equal(t, l1TxHash.Hex(), newest["tx_hash"])
+	require.Equal(t, newest, body["pause"])
+
+	body = getJSON(t, handler, "/api/v1/security-events?cursor="+cursor, http.StatusOK)
+	oldest := body["data"].([]any)[0].(map[string]any)
+	require.Nil(t, oldest["tx_hash"])
+	require.Equal(t, implementation.Hex(), oldest["value"])
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/pause-banner", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "The bridge is paused")
+
+	// Unpausing removes the banner
+	logIndex = 1
+	require.NoError(t, queries.InsertSecurityFinding(ctx, sqlitestore.InsertSecurityFindingParams{
+		ObservedAt:   1700000240,
+		BlockNumber:  120,
+		TxHash:       l1TxHash.Bytes(),
+		LogIndex:     &logIndex,
+		Contract:     portal.Bytes(),
+		ContractName: "OptimismPortal",
+		Kind:         security.Unpaused,
+		Severity:     security.Warning,
+		Detail:       "Unpaused",
+	}))
+	body = getJSON(t, handler, "/api/v1/security-events", http.StatusOK)
+	require.Equal(t, false, body["paused"])
+	require.Nil(t, body["pause"])
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/pause-banner", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "The bridge is paused")
+}
+
+func TestAPIIndexedEvents(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+
+	token := common.Address{0x42}
+	for i, args := range []string{`{"value":"1"}`, `{"value":"2"}`} {
+		require.NoError(t, queries.InsertIndexedEvent(ctx, sqlitestore.InsertIndexedEventParams{
+			Source:         "token_transfers",
+			Chain:          "l2",
+			Contract:       token.Bytes(),
+			EventName:      "Transfer",
+			BlockNumber:    int64(100 + i),
+			BlockTimestamp: 1700000000 + int64(i),
+			TxHash:         l2TxHash.Bytes(),
+			LogIndex:       int64(i),
+			Args:           args,
+		}))
+	}
+
+	handler := webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()
+
+	body := getJSON(t, handler, "/api/v1/events", http.StatusOK)
+	require.Equal(t, []any{map[string]any{
+		"name":     "token_transfers",
+		"chain":    "l2",
+		"contract": token.Hex(),
+		"event":    "Transfer",
+		"count":    float64(2),
+	}}, body["sources"])
+
+	body = getJSON(t, handler, "/api/v1/events/token_transfers?limit=1", http.StatusOK)
+	newest := body["data"].([]any)[0].(map[string]any)
+	require.Equal(t, float64(101), newest["block_number"])
+	require.Equal(t, l2TxHash.Hex(), newest["tx_hash"])
+	require.Equal(t, map[string]any{"value": "2"}, newest["args"])
+
+	cursor := body["pagination"].(map[string]any)["next_cursor"].(string)
+	body = getJSON(t, handler, "/api/v1/events/token_transfers?limit=1&cursor="+cursor, http.StatusOK)
+	require.Equal(t, float64(100), body["data"].([]any)[0].(map[string]any)["block_number"])
+	require.Nil(t, body["pagination"].(map[string]any)["next_cursor"])
+
+	body = getJSON(t, handler, "/api/v1/events/unknown", http.StatusOK)
+	require.Empty(t, body["data"])
+
+	getJSON(t, handler, "/api/v1/events/token_transfers?cursor=-1", http.StatusBadRequest)
+}
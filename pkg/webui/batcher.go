@@ -0,0 +1,186 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/batcher"
+	"github.com/Golem-Base/bridgette/pkg/liveness"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// DefaultBatcherRange is the range of batcher transactions summarized when no since is given
+	DefaultBatcherRange = 24 * time.Hour
+	// MaxBatcherTransactions bounds the number of batcher transactions summarized, the newest are kept
+	MaxBatcherTransactions = 10000
+)
+
+// BatcherTransaction is a transaction posted by the batcher to the batch inbox on L1
+type BatcherTransaction struct {
+	BlockNumber int64
+	BlockTime   time.Time
+	TxHash      string
+	Blob        bool
+	BlobCount   int64
+	// DataSize is the calldata size, or the size of the blobs of a blob transaction
+	DataSize int64
+}
+
+// SyncStatus is a sample of the sync status of the rollup node
+type SyncStatus struct {
+	ObservedAt        time.Time
+	CurrentL1Number   int64
+	HeadL1Number      int64
+	UnsafeL2Number    int64
+	UnsafeL2Time      time.Time
+	SafeL2Number      int64
+	SafeL2Time        time.Time
+	FinalizedL2Number int64
+	FinalizedL2Time   time.Time
+}
+
+// SafeLagSeconds is how far the safe head is behind the unsafe head
+func (s SyncStatus) SafeLagSeconds() int64 {
+	return int64(s.UnsafeL2Time.Sub(s.SafeL2Time).Seconds())
+}
+
+// Stale reports whether the sample is too old to be checked by the liveness monitor
+func (s SyncStatus) Stale(now time.Time) bool {
+	return now.Sub(s.ObservedAt) > liveness.MaxSyncStatusAge
+}
+
+// BatcherReport summarizes the batcher transactions posted in a time range
+// and the latest sync status of the rollup node
+type BatcherReport struct {
+	Since time.Time
+	Until time.Time
+	// Transactions are ordered newest first
+	Transactions  []BatcherTransaction
+	BlobCount     int
+	CalldataCount int
+	DataSize      int64
+	// AvgIntervalSeconds is the average time between batches, nil with less than two
+	AvgIntervalSeconds *int64
+	// MaxGapSeconds is the longest time between batches, including the time
+	// since the last batch up to ScannedUntil
+	MaxGapSeconds *int64
+	// ScannedUntil is the time of the last L1 block scanned, nil before the first scan
+	ScannedUntil *time.Time
+	// SyncStatus is the latest sample, nil when no rollup node is configured
+	SyncStatus *SyncStatus
+}
+
+// ParseBatcherRange reads the since and until parameters. Until defaults to
+// now and since to DefaultBatcherRange before until.
+func ParseBatcherRange(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	values := r.URL.Query()
+
+	until := now
+	t, err := parseTimeParam(values.Get("until"), "until")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if t != nil {
+		until = *t
+	}
+
+	since := until.Add(-DefaultBatcherRange)
+	t, err = parseTimeParam(values.Get("since"), "since")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if t != nil {
+		since = *t
+	}
+
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("since must be before until")
+	}
+	return since, until, nil
+}
+
+// GetBatcherReport summarizes the batcher transactions posted in [since,
+// until). At most MaxBatcherTransactions of the newest are summarized.
+func GetBatcherReport(ctx context.Context, db *sql.DB, since, until time.Time) (BatcherReport, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	rows, err := queries.ListBatcherTransactions(ctx, sqlitestore.ListBatcherTransactionsParams{
+		Since: since.Unix(),
+		Until: until.Unix(),
+		Limit: MaxBatcherTransactions,
+	})
+	if err != nil {
+		return BatcherReport{}, err
+	}
+	pointer, err := queries.GetBlockPointer(ctx, batcher.LastBlock)
+	if err != nil {
+		return BatcherReport{}, err
+	}
+
+	report := BatcherReport{Since: since, Until: until, Transactions: make([]BatcherTransaction, 0, len(rows))}
+	for _, row := range rows {
+		report.Transactions = append(report.Transactions, BatcherTransaction{
+			BlockNumber: row.BlockNumber,
+			BlockTime:   time.Unix(row.BlockTime, 0),
+			TxHash:      hexutil.Encode(row.TxHash),
+			Blob:        row.Blob,
+			BlobCount:   row.BlobCount,
+			DataSize:    row.DataSize,
+		})
+		if row.Blob {
+			report.BlobCount++
+		} else {
+			report.CalldataCount++
+		}
+		report.DataSize += row.DataSize
+	}
+
+	if pointer.BlockTime != nil {
+		scannedUntil := time.Unix(*pointer.BlockTime, 0)
+		report.ScannedUntil = &scannedUntil
+	}
+
+	if n := len(report.Transactions); n > 0 {
+		newest, oldest := report.Transactions[0].BlockTime, report.Transactions[n-1].BlockTime
+		if n > 1 {
+			avg := int64(newest.Sub(oldest).Seconds()) / int64(n-1)
+			report.AvgIntervalSeconds = &avg
+		}
+
+		var maxGap int64
+		for i := 1; i < n; i++ {
+			maxGap = max(maxGap, int64(report.Transactions[i-1].BlockTime.Sub(report.Transactions[i].BlockTime).Seconds()))
+		}
+		if report.ScannedUntil != nil {
+			end := min(report.ScannedUntil.Unix(), until.Unix())
+			maxGap = max(maxGap, end-newest.Unix())
+		}
+		report.MaxGapSeconds = &maxGap
+	}
+
+	status, err := queries.GetLatestSyncStatusSample(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return BatcherReport{}, err
+	}
+	if err == nil {
+		report.SyncStatus = &SyncStatus{
+			ObservedAt:        time.Unix(status.ObservedAt, 0),
+			CurrentL1Number:   status.CurrentL1Number,
+			HeadL1Number:      status.HeadL1Number,
+			UnsafeL2Number:    status.UnsafeL2Number,
+			UnsafeL2Time:      time.Unix(status.UnsafeL2Time, 0),
+			SafeL2Number:      status.SafeL2Number,
+			SafeL2Time:        time.Unix(status.SafeL2Time, 0),
+			FinalizedL2Number: status.FinalizedL2Number,
+			FinalizedL2Time:   time.Unix(status.FinalizedL2Time, 0),
+		}
+	}
+
+	return report, nil
+}
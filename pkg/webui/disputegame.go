@@ -0,0 +1,161 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/disputegame"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RecentDisputeGames is the number of games listed on the dashboard
+const RecentDisputeGames = 10
+
+// disputeGameStatusNames are the labels of the game statuses
+var disputeGameStatusNames = map[int64]string{
+	disputegame.InProgress:     "In progress",
+	disputegame.ChallengerWins: "Challenger wins",
+	disputegame.DefenderWins:   "Defender wins",
+}
+
+// disputeGameStatuses are the API names of the game statuses
+var disputeGameStatuses = map[int64]string{
+	disputegame.InProgress:     "in_progress",
+	disputegame.ChallengerWins: "challenger_wins",
+	disputegame.DefenderWins:   "defender_wins",
+}
+
+// DisputeGame is a game created by the DisputeGameFactory on L1
+type DisputeGame struct {
+	ID            int64
+	Address       string
+	GameType      int64
+	RootClaim     string
+	L2BlockNumber int64
+	L1BlockNumber int64
+	CreatedAt     time.Time
+	TxHash        string
+	Status        int64
+	StatusName    string
+	// ResolvedAt is nil while the game is in progress
+	ResolvedAt *time.Time
+	// ExpectedRoot is the output root computed from L2, empty until L2 reached the block
+	ExpectedRoot string
+	// Valid is nil until the root claim was verified
+	Valid *bool
+	// CriticalReason is empty unless the game threatens withdrawals
+	CriticalReason string
+	// OutputRootError is set when L2 no longer has the state to verify the game
+	OutputRootError string
+}
+
+func newDisputeGame(row sqlitestore.DisputeGame) DisputeGame {
+	game := DisputeGame{
+		ID:            row.ID,
+		Address:       common.BytesToAddress(row.GameAddress).Hex(),
+		GameType:      row.GameType,
+		RootClaim:     hexutil.Encode(row.RootClaim),
+		L2BlockNumber: row.L2BlockNumber,
+		L1BlockNumber: row.BlockNumber,
+		CreatedAt:     time.Unix(row.BlockTime, 0),
+		TxHash:        common.BytesToHash(row.TxHash).Hex(),
+		Status:        row.Status,
+		StatusName:    disputeGameStatusNames[row.Status],
+		Valid:         row.Valid,
+	}
+	if row.ResolvedAt != nil {
+		resolvedAt := time.Unix(*row.ResolvedAt, 0)
+		game.ResolvedAt = &resolvedAt
+	}
+	if row.ExpectedRoot != nil {
+		game.ExpectedRoot = hexutil.Encode(row.ExpectedRoot)
+	}
+	if row.CriticalReason != nil {
+		game.CriticalReason = *row.CriticalReason
+	}
+	if row.OutputRootError != nil {
+		game.OutputRootError = *row.OutputRootError
+	}
+	return game
+}
+
+// DisputeGameSummary counts the indexed games and lists the recent and the critical ones
+type DisputeGameSummary struct {
+	Total      int64
+	InProgress int64
+	Critical   int64
+	// Recent and CriticalGames are ordered newest first
+	Recent        []DisputeGame
+	CriticalGames []DisputeGame
+}
+
+// GetDisputeGameSummary returns the counts of the indexed games, the
+// RecentDisputeGames newest games and the newest critical games
+func GetDisputeGameSummary(ctx context.Context, db *sql.DB) (DisputeGameSummary, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	counts, err := queries.CountDisputeGames(ctx)
+	if err != nil {
+		return DisputeGameSummary{}, err
+	}
+	summary := DisputeGameSummary{Total: counts.Total, InProgress: counts.InProgress, Critical: counts.Critical}
+
+	summary.Recent, _, err = GetDisputeGames(ctx, db, false, PageRequest{Sort: SortNewest, Limit: RecentDisputeGames})
+	if err != nil {
+		return DisputeGameSummary{}, err
+	}
+	summary.CriticalGames, _, err = GetDisputeGames(ctx, db, true, PageRequest{Sort: SortNewest, Limit: RecentDisputeGames})
+	if err != nil {
+		return DisputeGameSummary{}, err
+	}
+	return summary, nil
+}
+
+// GetDisputeGames returns a page of the indexed games, or of the critical
+// games only, newest first, and the cursor of the next page or nil if this is
+// the last one
+func GetDisputeGames(ctx context.Context, db *sql.DB, critical bool, page PageRequest) ([]DisputeGame, *Cursor, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	cursorID, cursorKey := page.cursorArgs()
+	// Fetch one extra row to find out whether there is a next page
+	limit := int64(page.Limit) + 1
+	var rows []sqlitestore.DisputeGame
+	var err error
+	if critical {
+		rows, err = queries.ListCriticalDisputeGames(ctx, sqlitestore.ListCriticalDisputeGamesParams{CursorID: cursorID, CursorKey: cursorKey, Limit: limit})
+	} else {
+		rows, err = queries.ListDisputeGames(ctx, sqlitestore.ListDisputeGamesParams{CursorID: cursorID, CursorKey: cursorKey, Limit: limit})
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{Sort: page.Sort, Key: last.BlockNumber, ID: last.ID}
+	}
+
+	games := make([]DisputeGame, 0, len(rows))
+	for _, row := range rows {
+		games = append(games, newDisputeGame(row))
+	}
+	return games, next, nil
+}
+
+// CountDisputeGames returns the number of indexed games, or of critical games
+func CountDisputeGames(ctx context.Context, db *sql.DB, critical bool) (int, error) {
+	counts, err := sqlitestore.NewTraced(db).CountDisputeGames(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if critical {
+		return int(counts.Critical), nil
+	}
+	return int(counts.Total), nil
+}
@@ -0,0 +1,159 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/latency"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+)
+
+const (
+	// ETASamples is the number of the newest matched deposits the confirmation
+	// time distribution is computed from
+	ETASamples = 1000
+	// MaxOriginAge is how long ago the L1 origin of L2 must have been recorded
+	// to be used, older observations mean the latency tracker is not running
+	MaxOriginAge = 5 * time.Minute
+)
+
+// L2Origin is the L1 origin of the latest L2 block, as recorded by the latency tracker
+type L2Origin struct {
+	BlockNumber int64
+	BlockTime   time.Time
+	ObservedAt  time.Time
+}
+
+// Lag is how far the sequencer's L1 origin was behind L1 when it was observed
+func (o L2Origin) Lag() time.Duration {
+	return o.ObservedAt.Sub(o.BlockTime)
+}
+
+// ETAModel estimates the arrival of pending deposits on L2 from the
+// confirmation times of recently matched deposits and the current L1 origin of L2
+type ETAModel struct {
+	// Count is the number of matched deposits the percentiles are computed from
+	Count int
+	P10   int64
+	P50   int64
+	P90   int64
+	P99   int64
+	// Origin is nil when it was not recorded within MaxOriginAge
+	Origin *L2Origin
+	now    time.Time
+}
+
+// DepositETA is the estimated arrival of a pending deposit on L2
+type DepositETA struct {
+	Expected time.Time
+	// Earliest and Latest bound the band between the 10th and 90th percentile
+	// of confirmation times, widened to include the expected arrival
+	Earliest time.Time
+	Latest   time.Time
+	// OverdueAt is the arrival at the 99th percentile of confirmation times
+	OverdueAt time.Time
+	Overdue   bool
+	// OriginReached is set when the sequencer already adopted the L1 block of
+	// the deposit as its origin, so the deposit should be on L2 already
+	OriginReached bool
+}
+
+// GetETAModel loads the confirmation time distribution and the current L1
+// origin of L2. It returns nil when no deposit was matched yet.
+func GetETAModel(ctx context.Context, db *sql.DB, now time.Time) (*ETAModel, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	seconds, err := queries.ListRecentConfirmationSeconds(ctx, ETASamples)
+	if err != nil {
+		return nil, err
+	}
+	if len(seconds) == 0 {
+		return nil, nil
+	}
+	slices.Sort(seconds)
+
+	model := &ETAModel{
+		Count: len(seconds),
+		P10:   *percentile(seconds, 10),
+		P50:   *percentile(seconds, 50),
+		P90:   *percentile(seconds, 90),
+		P99:   *percentile(seconds, 99),
+		now:   now,
+	}
+
+	row, err := queries.GetLatestChainHead(ctx, sqlitestore.GetLatestChainHeadParams{Chain: "l1", Label: latency.Origin})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil && now.Sub(time.Unix(row.ObservedAt, 0)) <= MaxOriginAge {
+		model.Origin = &L2Origin{
+			BlockNumber: row.BlockNumber,
+			BlockTime:   time.Unix(row.BlockTime, 0),
+			ObservedAt:  time.Unix(row.ObservedAt, 0),
+		}
+	}
+	return model, nil
+}
+
+// Estimate returns the ETA of a deposit initiated in an L1 block, nil when
+// the model is nil. Without an origin the expected arrival is the median
+// confirmation time after the L1 block. Otherwise the current origin lag is
+// assumed to hold until the sequencer reaches the block.
+func (m *ETAModel) Estimate(l1BlockNumber int64, l1Timestamp time.Time) *DepositETA {
+	if m == nil {
+		return nil
+	}
+
+	at := func(seconds int64) time.Time {
+		return l1Timestamp.Add(time.Duration(seconds) * time.Second)
+	}
+	eta := &DepositETA{
+		Expected:  at(m.P50),
+		Earliest:  at(m.P10),
+		Latest:    at(m.P90),
+		OverdueAt: at(m.P99),
+	}
+	eta.Overdue = m.now.After(eta.OverdueAt)
+
+	if m.Origin != nil {
+		if m.Origin.BlockNumber >= l1BlockNumber {
+			eta.OriginReached = true
+			if eta.Expected.After(m.now) {
+				eta.Expected = m.now
+			}
+		} else {
+			eta.Expected = l1Timestamp.Add(m.Origin.Lag())
+			// The sequencer has not reached the block yet
+			if eta.Expected.Before(m.now) {
+				eta.Expected = m.now
+			}
+		}
+	}
+
+	if eta.Expected.Before(eta.Earliest) {
+		eta.Earliest = eta.Expected
+	}
+	if eta.Expected.After(eta.Latest) {
+		eta.Latest = eta.Expected
+	}
+	return eta
+}
+
+// LoadDepositETAs sets the ETA of each pending deposit
+func (m *ETAModel) LoadDepositETAs(deposits []Deposit) {
+	for i := range deposits {
+		if deposits[i].L2 == nil {
+			deposits[i].ETA = m.Estimate(deposits[i].L1BlockNumber, deposits[i].L1Timestamp)
+		}
+	}
+}
+
+// LoadUnmatchedDepositETAs sets the ETA of each unmatched deposit
+func (m *ETAModel) LoadUnmatchedDepositETAs(deposits []UnmatchedDeposit) {
+	for i := range deposits {
+		deposits[i].ETA = m.Estimate(deposits[i].L1BlockNumber, deposits[i].L1Timestamp)
+	}
+}
@@ -0,0 +1,115 @@
+package webui_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/latency"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/Golem-Base/bridgette/pkg/webui"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETA(t *testing.T) {
+	db := sqlitetest.Open(t)
+	ctx := context.Background()
+	queries := sqlitestore.New(db)
+	now := time.Now()
+
+	model, err := webui.GetETAModel(ctx, db, now)
+	require.NoError(t, err)
+	require.Nil(t, model)
+	require.Nil(t, model.Estimate(1, now))
+
+	insertL1 := func(block int64, timestamp int64) int64 {
+		id, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+			BlockNumber:    block,
+			BlockTimestamp: timestamp,
+			TxHash:         common.Hash{byte(block)}.Bytes(),
+			FromAddress:    sender.Bytes(),
+			ToAddress:      sender.Bytes(),
+			AmountWei:      make([]byte, 32),
+			Event:          []byte("{}"),
+			MatchingHash:   []byte{byte(block)},
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	// Matched deposits confirmed after 10 to 100 seconds
+	for i := int64(1); i <= 10; i++ {
+		l1ID := insertL1(i, 1700000000+i)
+		l2ID, err := queries.InsertL2StandardBridgeDepositFinalized(ctx, sqlitestore.InsertL2StandardBridgeDepositFinalizedParams{
+			BlockNumber:    100 + i,
+			BlockTimestamp: 1700000000 + i + i*10,
+			TxHash:         common.Hash{byte(100 + i)}.Bytes(),
+			FromAddress:    sender.Bytes(),
+			ToAddress:      sender.Bytes(),
+			L1Token:        common.Address{}.Bytes(),
+			AmountWei:      make([]byte, 32),
+			Event:          []byte("{}"),
+			MatchingHash:   []byte{byte(i)},
+		})
+		require.NoError(t, err)
+		require.NoError(t, queries.UpdateL1DepositWithMatch(ctx, sqlitestore.UpdateL1DepositWithMatchParams{MatchedL2StandardBridgeDepositFinalizedID: &l2ID, ID: l1ID}))
+		require.NoError(t, queries.UpdateL2DepositWithMatch(ctx, sqlitestore.UpdateL2DepositWithMatchParams{MatchedL1StandardBridgeEthDepositInitiatedID: &l1ID, ID: l2ID}))
+	}
+
+	model, err = webui.GetETAModel(ctx, db, now)
+	require.NoError(t, err)
+	require.Equal(t, 10, model.Count)
+	require.Equal(t, int64(10), model.P10)
+	require.Equal(t, int64(50), model.P50)
+	require.Equal(t, int64(90), model.P90)
+	require.Equal(t, int64(100), model.P99)
+	require.Nil(t, model.Origin)
+
+	// Without an origin the band follows the distribution
+	l1Time := now.Add(-20 * time.Second)
+	eta := model.Estimate(50, l1Time)
+	require.Equal(t, l1Time.Add(50*time.Second), eta.Expected)
+	require.Equal(t, l1Time.Add(10*time.Second), eta.Earliest)
+	require.Equal(t, l1Time.Add(90*time.Second), eta.Latest)
+	require.False(t, eta.Overdue)
+
+	require.True(t, model.Estimate(50, now.Add(-101*time.Second)).Overdue)
+
+	// The sequencer currently lags 120 seconds behind L1 at block 40
+	require.NoError(t, queries.InsertChainHead(ctx, sqlitestore.InsertChainHeadParams{
+		Chain:       "l1",
+		Label:       latency.Origin,
+		BlockNumber: 40,
+		BlockTime:   now.Add(-120 * time.Second).Unix(),
+		ObservedAt:  now.Unix(),
+	}))
+	model, err = webui.GetETAModel(ctx, db, now)
+	require.NoError(t, err)
+	require.NotNil(t, model.Origin)
+
+	eta = model.Estimate(50, l1Time)
+	require.False(t, eta.OriginReached)
+	require.Equal(t, l1Time.Add(120*time.Second).Unix(), eta.Expected.Unix())
+	require.Equal(t, eta.Expected, eta.Latest)
+
+	eta = model.Estimate(30, l1Time)
+	require.True(t, eta.OriginReached)
+	require.Equal(t, now, eta.Expected)
+
+	// Pending deposits carry their ETA in the API
+	insertL1(60, now.Unix())
+	handler := webui.NewServer(db, events.NewBus(), slog.New(slog.NewTextHandler(io.Discard, nil)), "", "").Handler()
+	body := getJSON(t, handler, "/api/v1/deposits/unmatched", http.StatusOK)
+	data := body["data"].([]any)
+	require.Len(t, data, 1)
+	apiETA := data[0].(map[string]any)["eta"].(map[string]any)
+	require.Equal(t, false, apiETA["overdue"])
+	require.Equal(t, false, apiETA["origin_reached"])
+	require.NotEmpty(t, apiETA["expected_at"])
+}
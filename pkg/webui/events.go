@@ -0,0 +1,63 @@
+package webui
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// eventsKeepAlive is how often a comment is sent on an idle event stream so
+// that proxies don't close it
+const eventsKeepAlive = 15 * time.Second
+
+// handleEvents streams indexer events to the browser as server-sent events.
+// The event name is the event type, one of the events.Type constants.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	ch, unsubscribe := s.bus.Subscribe()
+	defer unsubscribe()
+
+	// Tell the browser how long to wait before reconnecting
+	_, err := fmt.Fprint(w, "retry: 5000\n\n")
+	if err == nil {
+		err = rc.Flush()
+	}
+	if err != nil {
+		s.logger.Error("failed to start event stream", "error", err)
+		return
+	}
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		case event := <-ch:
+			var data []byte
+			data, err = json.Marshal(event)
+			if err != nil {
+				s.logger.Error("failed to encode event", "error", err)
+				continue
+			}
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			// The client went away
+			return
+		}
+	}
+}
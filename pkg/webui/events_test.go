@@ -0,0 +1,58 @@
+package webui_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/webui"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsStream(t *testing.T) {
+	bus := events.NewBus()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := httptest.NewServer(webui.NewServer(nil, bus, logger, "", "").Handler())
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "retry: 5000\n", line)
+
+	// The handler subscribes before writing the retry line, so this is not lost
+	bus.Publish(events.Event{Type: events.Match, BlockNumber: 7, Count: 1})
+
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		if strings.HasPrefix(line, "event:") || strings.HasPrefix(line, "data:") {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+		if len(lines) == 2 {
+			break
+		}
+	}
+	require.Equal(t, []string{
+		"event: match",
+		`data: {"type":"match","block_number":7,"count":1}`,
+	}, lines)
+}
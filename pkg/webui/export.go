@@ -0,0 +1,54 @@
+package webui
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/export"
+)
+
+// handleAPIExport streams matched and unmatched deposits as CSV, JSON Lines or Parquet
+func (s *Server) handleAPIExport(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	formatName := q.Get("format")
+	if formatName == "" {
+		formatName = string(export.CSV)
+	}
+	format, err := export.ParseFormat(formatName)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	statuses, err := export.ParseStatuses(q.Get("status"))
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	opts := export.Options{Statuses: statuses}
+	opts.Since, err = parseTimeParam(q.Get("since"), "since")
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+	opts.Until, err = parseTimeParam(q.Get("until"), "until")
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("bridgette-deposits-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	err = export.Export(r.Context(), s.db, w, format, opts)
+	if err != nil {
+		// The status line has most likely been sent already, abort the
+		// response so the client does not mistake it for a complete file
+		s.logger.Error("failed to export deposits", "error", err)
+		panic(http.ErrAbortHandler)
+	}
+}
@@ -0,0 +1,294 @@
+package webui
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// Time series served to Grafana's JSON datasource
+const (
+	// GrafanaDeposits is the number of L1 deposits initiated per interval
+	GrafanaDeposits = "deposits"
+	// GrafanaVolume is the ETH deposited on L1 per interval
+	GrafanaVolume = "volume_eth"
+	// GrafanaPending is the number of deposits waiting for L2 at the end of each interval
+	GrafanaPending = "pending"
+	// GrafanaConfirmationP50 and the other percentiles are computed over the
+	// confirmation times of the deposits initiated in each interval
+	GrafanaConfirmationP50 = "confirmation_p50_seconds"
+	GrafanaConfirmationP90 = "confirmation_p90_seconds"
+	GrafanaConfirmationP99 = "confirmation_p99_seconds"
+	// GrafanaSolvencyGap and GrafanaSolvencyBalance are the raw solvency
+	// samples, the gap is negative when the bridge holds less than expected
+	GrafanaSolvencyGap     = "solvency_gap_eth"
+	GrafanaSolvencyBalance = "solvency_balance_eth"
+)
+
+// GrafanaMetrics lists the series returned by the search endpoint
+var GrafanaMetrics = []string{
+	GrafanaDeposits,
+	GrafanaVolume,
+	GrafanaPending,
+	GrafanaConfirmationP50,
+	GrafanaConfirmationP90,
+	GrafanaConfirmationP99,
+	GrafanaSolvencyGap,
+	GrafanaSolvencyBalance,
+}
+
+const (
+	// MaxGrafanaDataPoints bounds the number of intervals of a series, the
+	// interval is widened for longer ranges
+	MaxGrafanaDataPoints = 10000
+	// MaxGrafanaAnnotations bounds the number of deposits returned as annotations
+	MaxGrafanaAnnotations = 1000
+)
+
+type grafanaRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type grafanaTarget struct {
+	Target string `json:"target"`
+	RefID  string `json:"refId"`
+	Hide   bool   `json:"hide"`
+}
+
+type grafanaQueryRequest struct {
+	Range         grafanaRange    `json:"range"`
+	IntervalMs    int64           `json:"intervalMs"`
+	MaxDataPoints int             `json:"maxDataPoints"`
+	Targets       []grafanaTarget `json:"targets"`
+}
+
+// grafanaSeries is a time series in the JSON datasource format, each data
+// point is a [value, unix milliseconds] pair
+type grafanaSeries struct {
+	Target     string   `json:"target"`
+	Datapoints [][2]any `json:"datapoints"`
+}
+
+type grafanaAnnotationRequest struct {
+	Range      grafanaRange `json:"range"`
+	Annotation struct {
+		Name string `json:"name"`
+		// Query optionally restricts the annotations to matched or pending deposits
+		Query string `json:"query"`
+	} `json:"annotation"`
+}
+
+type grafanaAnnotation struct {
+	Time    int64    `json:"time"`
+	TimeEnd int64    `json:"timeEnd,omitempty"`
+	Title   string   `json:"title"`
+	Text    string   `json:"text"`
+	Tags    []string `json:"tags"`
+}
+
+// handleGrafanaTest answers the datasource connection test
+func (s *Server) handleGrafanaTest(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleGrafanaSearch lists the available time series
+func (s *Server) handleGrafanaSearch(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, http.StatusOK, GrafanaMetrics)
+}
+
+// handleGrafanaQuery computes the requested time series over the dashboard range
+func (s *Server) handleGrafanaQuery(w http.ResponseWriter, r *http.Request) {
+	var req grafanaQueryRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "request body must be a JSON datasource query")
+		return
+	}
+	if !req.Range.From.Before(req.Range.To) {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "range.from must be before range.to")
+		return
+	}
+	for _, target := range req.Targets {
+		if !slices.Contains(GrafanaMetrics, target.Target) {
+			s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", fmt.Sprintf("unknown target %q", target.Target))
+			return
+		}
+	}
+
+	timings, err := GetDepositTimings(r.Context(), s.db, req.Range.From, req.Range.To)
+	if err != nil {
+		s.writeInternalError(w, "failed to get deposit timings", err)
+		return
+	}
+
+	interval := grafanaInterval(req.Range.From, req.Range.To, time.Duration(req.IntervalMs)*time.Millisecond, req.MaxDataPoints)
+	series := DepositSeries(timings, req.Range.From, req.Range.To, interval)
+
+	if slices.ContainsFunc(req.Targets, func(t grafanaTarget) bool {
+		return t.Target == GrafanaSolvencyGap || t.Target == GrafanaSolvencyBalance
+	}) {
+		samples, err := GetSolvencySamples(r.Context(), s.db, req.Range.From, req.Range.To)
+		if err != nil {
+			s.writeInternalError(w, "failed to get solvency samples", err)
+			return
+		}
+		for _, sample := range samples {
+			ts := sample.BlockTime.UnixMilli()
+			series[GrafanaSolvencyGap] = append(series[GrafanaSolvencyGap], [2]any{sample.GapEth, ts})
+			series[GrafanaSolvencyBalance] = append(series[GrafanaSolvencyBalance], [2]any{sample.BalanceEth(), ts})
+		}
+	}
+
+	result := []grafanaSeries{}
+	for _, target := range req.Targets {
+		if target.Hide {
+			continue
+		}
+		result = append(result, grafanaSeries{Target: target.Target, Datapoints: series[target.Target]})
+	}
+
+	s.writeJSON(w, http.StatusOK, result)
+}
+
+// handleGrafanaAnnotations returns the deposits initiated in the dashboard
+// range, spanning until their L2 confirmation
+func (s *Server) handleGrafanaAnnotations(w http.ResponseWriter, r *http.Request) {
+	var req grafanaAnnotationRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "request body must be a JSON datasource annotation query")
+		return
+	}
+	switch req.Annotation.Query {
+	case "", StatusMatched, StatusPending:
+	default:
+		s.writeAPIError(w, http.StatusBadRequest, "invalid_parameter", "annotation query must be empty, matched or pending")
+		return
+	}
+
+	filter := DepositFilter{Since: &req.Range.From, Until: &req.Range.To}
+	page := PageRequest{Sort: SortNewest, Limit: MaxGrafanaAnnotations}
+	annotations := []grafanaAnnotation{}
+
+	if req.Annotation.Query == "" || req.Annotation.Query == StatusMatched {
+		deposits, _, err := GetMatchedDeposits(r.Context(), s.db, filter, page)
+		if err != nil {
+			s.writeInternalError(w, "failed to get matched deposits", err)
+			return
+		}
+		for _, d := range deposits {
+			annotations = append(annotations, grafanaAnnotation{
+				Time:    d.L1Timestamp.UnixMilli(),
+				TimeEnd: d.L2Timestamp.UnixMilli(),
+				Title:   fmt.Sprintf("Deposit of %.4f ETH", d.Amount),
+				Text:    fmt.Sprintf("From %s to %s, confirmed on L2 after %s. L1 tx %s", d.FromAddress, d.ToAddress, formatTimeDiff(d.TimeDiffSeconds), d.TxHashL1),
+				Tags:    []string{"deposit", StatusMatched},
+			})
+		}
+	}
+
+	if req.Annotation.Query == "" || req.Annotation.Query == StatusPending {
+		deposits, _, err := GetUnmatchedDeposits(r.Context(), s.db, filter, page)
+		if err != nil {
+			s.writeInternalError(w, "failed to get unmatched deposits", err)
+			return
+		}
+		for _, d := range deposits {
+			annotations = append(annotations, grafanaAnnotation{
+				Time:  d.L1Timestamp.UnixMilli(),
+				Title: fmt.Sprintf("Pending deposit of %.4f ETH", d.Amount),
+				Text:  fmt.Sprintf("From %s to %s, waiting for %s. L1 tx %s", d.FromAddress, d.ToAddress, formatTimeDiff(d.TimeSinceSeconds), d.TxHashL1),
+				Tags:  []string{"deposit", StatusPending},
+			})
+		}
+	}
+
+	s.writeJSON(w, http.StatusOK, annotations)
+}
+
+// grafanaInterval returns the requested interval rounded up to whole seconds,
+// widened so the range fits in maxDataPoints intervals
+func grafanaInterval(from, to time.Time, interval time.Duration, maxDataPoints int) time.Duration {
+	if maxDataPoints <= 0 || maxDataPoints > MaxGrafanaDataPoints {
+		maxDataPoints = MaxGrafanaDataPoints
+	}
+	span := to.Sub(from)
+	minInterval := (span + time.Duration(maxDataPoints) - 1) / time.Duration(maxDataPoints)
+	interval = max(interval, minInterval, time.Second)
+	return (interval + time.Second - 1).Truncate(time.Second)
+}
+
+// DepositSeries buckets deposits into consecutive intervals starting at from
+// and returns every Grafana series keyed by name. Each data point is stamped
+// with the start of its interval, intervals without confirmed deposits have
+// null percentiles.
+func DepositSeries(timings []DepositTiming, from, to time.Time, interval time.Duration) map[string][][2]any {
+	start := from.Unix()
+	step := int64(interval / time.Second)
+	n := int((to.Unix() - start + step - 1) / step)
+
+	counts := make([]int, n)
+	volumes := make([]float64, n)
+	confirmations := make([][]int64, n)
+	// pendingDelta[i] is the change in pending deposits at the end of interval i
+	pendingDelta := make([]int, n+1)
+
+	for _, t := range timings {
+		first := 0
+		if t.L1Timestamp >= start {
+			first = int((t.L1Timestamp - start) / step)
+		}
+		if first >= n {
+			continue
+		}
+
+		if t.L1Timestamp >= start {
+			counts[first]++
+			volumes[first] += t.Amount
+			if t.L2Timestamp != nil {
+				confirmations[first] = append(confirmations[first], *t.L2Timestamp-t.L1Timestamp)
+			}
+		}
+
+		// A deposit is pending at the end of every interval from the one it
+		// was initiated in up to, excluding, the one it was confirmed in
+		last := n
+		if t.L2Timestamp != nil {
+			last = min(n, int((*t.L2Timestamp-start)/step))
+		}
+		if first < last {
+			pendingDelta[first]++
+			pendingDelta[last]--
+		}
+	}
+
+	series := make(map[string][][2]any, len(GrafanaMetrics))
+	pending := 0
+	for i := 0; i < n; i++ {
+		ts := (start + int64(i)*step) * 1000
+		pending += pendingDelta[i]
+		slices.Sort(confirmations[i])
+
+		series[GrafanaDeposits] = append(series[GrafanaDeposits], [2]any{counts[i], ts})
+		series[GrafanaVolume] = append(series[GrafanaVolume], [2]any{volumes[i], ts})
+		series[GrafanaPending] = append(series[GrafanaPending], [2]any{pending, ts})
+		series[GrafanaConfirmationP50] = append(series[GrafanaConfirmationP50], [2]any{percentile(confirmations[i], 50), ts})
+		series[GrafanaConfirmationP90] = append(series[GrafanaConfirmationP90], [2]any{percentile(confirmations[i], 90), ts})
+		series[GrafanaConfirmationP99] = append(series[GrafanaConfirmationP99], [2]any{percentile(confirmations[i], 99), ts})
+	}
+
+	return series
+}
+
+// percentile returns the nearest-rank percentile of sorted values, or nil if there are none
+func percentile(sorted []int64, p float64) *int64 {
+	if len(sorted) == 0 {
+		return nil
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return &sorted[max(rank, 1)-1]
+}
@@ -0,0 +1,71 @@
+package webui_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/webui"
+	"github.com/stretchr/testify/require"
+)
+
+func postGrafana(t *testing.T, handler http.Handler, path, body string) []map[string]any {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var result []map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	return result
+}
+
+func TestGrafanaQuery(t *testing.T) {
+	handler := newTestServer(t)
+
+	result := postGrafana(t, handler, "/grafana/query", `{
+		"range": {"from": "2023-11-14T22:00:00Z", "to": "2023-11-14T23:00:00Z"},
+		"intervalMs": 600000,
+		"targets": [{"target": "deposits", "refId": "A"}, {"target": "confirmation_p99_seconds", "refId": "B"}]
+	}`)
+	require.Len(t, result, 2)
+
+	deposits := result[0]["datapoints"].([]any)
+	require.Len(t, deposits, 6)
+	require.Equal(t, []any{float64(1), float64(1699999800000)}, deposits[1])
+
+	p99 := result[1]["datapoints"].([]any)
+	require.Equal(t, []any{float64(60), float64(1699999800000)}, p99[1])
+	require.Nil(t, p99[0].([]any)[0])
+
+	annotations := postGrafana(t, handler, "/grafana/annotations", `{
+		"range": {"from": "2023-11-14T22:00:00Z", "to": "2023-11-14T23:00:00Z"},
+		"annotation": {"name": "deposits", "query": "matched"}
+	}`)
+	require.Len(t, annotations, 1)
+	require.Equal(t, float64(1700000060000), annotations[0]["timeEnd"])
+}
+
+func TestDepositSeriesPending(t *testing.T) {
+	from := time.Unix(1000, 0)
+	confirmed := int64(1250)
+	timings := []webui.DepositTiming{
+		// Initiated before the range, confirmed in the third interval
+		{L1Timestamp: 900, L2Timestamp: &confirmed, Amount: 1},
+		// Initiated in the second interval and never confirmed
+		{L1Timestamp: 1150, Amount: 2},
+	}
+
+	series := webui.DepositSeries(timings, from, from.Add(400*time.Second), 100*time.Second)
+
+	var pending []any
+	for _, point := range series[webui.GrafanaPending] {
+		pending = append(pending, point[0])
+	}
+	require.Equal(t, []any{1, 2, 1, 1}, pending)
+	require.Equal(t, 2.0, series[webui.GrafanaVolume][1][0])
+}
@@ -2,7 +2,11 @@ package webui
 
 import (
 	"fmt"
+	"math/big"
 	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/liveness"
+	"github.com/Golem-Base/bridgette/pkg/security"
 )
 
 // shortenAddress shortens an Ethereum address for display
@@ -16,6 +20,12 @@ func formatTime(t time.Time) string {
 	return t.Format("Jan 02, 2006 15:04:05")
 }
 
+// formatWei formats an exact wei amount as ETH with 4 decimals
+func formatWei(wei *big.Int) string {
+	eth := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18))
+	return eth.Text('f', 4) + " ETH"
+}
+
 // formatTimeDiff formats a time difference in seconds for display
 func formatTimeDiff(seconds int64) string {
 	if seconds < 60 {
@@ -26,3 +36,111 @@ func formatTimeDiff(seconds int64) string {
 		return fmt.Sprintf("%.1f hours", float64(seconds)/3600)
 	}
 }
+
+// formatBytes formats a data size in bytes for display
+func formatBytes(size int64) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	} else if size < 1024*1024 {
+		return fmt.Sprintf("%.1f KiB", float64(size)/1024)
+	} else {
+		return fmt.Sprintf("%.1f MiB", float64(size)/(1024*1024))
+	}
+}
+
+// formatPhase formats the duration of a latency phase, which may be unknown
+func formatPhase(seconds *int64) string {
+	if seconds == nil {
+		return "unknown"
+	}
+	return formatTimeDiff(*seconds)
+}
+
+// depositElapsedSeconds returns how long a deposit took to reach L2, or how
+// long it has been waiting if it is still pending
+func depositElapsedSeconds(deposit Deposit) int64 {
+	if deposit.L2 != nil {
+		return deposit.L2.TimeDiffSeconds
+	}
+	return int64(time.Since(deposit.L1Timestamp).Seconds())
+}
+
+// etaDescription describes when a pending deposit should appear on L2
+func etaDescription(eta *DepositETA) string {
+	if eta == nil {
+		return "No confirmation history to estimate from yet"
+	}
+	now := time.Now()
+	band := fmt.Sprintf(", likely between %s and %s", eta.Earliest.Format("15:04:05"), eta.Latest.Format("15:04:05"))
+	switch {
+	case eta.Overdue:
+		return "Overdue: waiting longer than 99% of recent deposits"
+	case eta.OriginReached:
+		return "L2 already reached its L1 block, expected any moment" + band
+	case eta.Expected.After(now):
+		return "Expected on L2 in about " + formatTimeDiff(int64(eta.Expected.Sub(now).Seconds())) + band
+	default:
+		return "Expected on L2 any moment" + band
+	}
+}
+
+// disputeGameVerification describes whether the root claim of a game matches L2
+func disputeGameVerification(game DisputeGame) string {
+	switch {
+	case game.OutputRootError != "":
+		return "Unverifiable"
+	case game.Valid == nil:
+		return "Pending"
+	case *game.Valid:
+		return "Valid"
+	default:
+		return "Invalid"
+	}
+}
+
+// incidentColors are the colors of the incident kinds on the timeline
+var incidentColors = map[string]string{
+	liveness.Stall:      "var(--arkiv-orange)",
+	liveness.HeadLag:    "var(--arkiv-orange)",
+	liveness.BlockTime:  "var(--arkiv-blue)",
+	liveness.OriginLag:  "var(--black)",
+	liveness.BatcherGap: "var(--gray-neutral)",
+	liveness.SafeLag:    "var(--gray-neutral)",
+}
+
+// securitySeverityColors are the colors of the security finding severities
+var securitySeverityColors = map[string]string{
+	security.Critical: "var(--arkiv-orange)",
+	security.Warning:  "var(--arkiv-blue)",
+	security.Info:     "var(--gray-neutral)",
+}
+
+// incidentsOfKind returns the incidents of a kind on the timeline
+func incidentsOfKind(timeline IncidentTimeline, kind string) []Incident {
+	var incidents []Incident
+	for _, incident := range timeline.Incidents {
+		if incident.Kind == kind {
+			incidents = append(incidents, incident)
+		}
+	}
+	return incidents
+}
+
+// incidentBarStyle positions an incident on the timeline, clipped to its
+// range. Short incidents are widened to stay visible.
+func incidentBarStyle(timeline IncidentTimeline, incident Incident, now time.Time) string {
+	span := timeline.Until.Sub(timeline.Since).Seconds()
+	start := max(incident.StartedAt.Sub(timeline.Since).Seconds(), 0)
+	end := min(incident.End(now).Sub(timeline.Since).Seconds(), span)
+	left := start / span * 100
+	width := max((end-start)/span*100, 0.5)
+	return fmt.Sprintf("position: absolute; top: 0; bottom: 0; left: %.2f%%; width: %.2f%%; background: %s; border-radius: 2px;", left, width, incidentColors[incident.Kind])
+}
+
+// incidentEnd formats when an incident ended
+func incidentEnd(incident Incident) string {
+	if incident.EndedAt == nil {
+		return "Ongoing"
+	}
+	return formatTime(*incident.EndedAt)
+}
@@ -0,0 +1,100 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EventSource summarizes the events indexed for a source of the events config
+type EventSource struct {
+	Name     string
+	Chain    string
+	Contract string
+	Event    string
+	Count    int
+}
+
+// IndexedEvent is an event indexed for a source of the events config
+type IndexedEvent struct {
+	ID             int64
+	Source         string
+	Chain          string
+	Contract       string
+	Event          string
+	BlockNumber    int64
+	BlockTimestamp time.Time
+	TxHash         string
+	LogIndex       int64
+	// Args are the decoded arguments as a JSON object
+	Args string
+}
+
+// GetEventSources returns the sources that indexed events
+func GetEventSources(ctx context.Context, db *sql.DB) ([]EventSource, error) {
+	rows, err := sqlitestore.NewTraced(db).ListIndexedEventSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]EventSource, 0, len(rows))
+	for _, row := range rows {
+		sources = append(sources, EventSource{
+			Name:     row.Source,
+			Chain:    row.Chain,
+			Contract: common.BytesToAddress(row.Contract).Hex(),
+			Event:    row.EventName,
+			Count:    int(row.Count),
+		})
+	}
+	return sources, nil
+}
+
+// GetIndexedEvents returns a page of the events of a source, newest first,
+// and the cursor of the next page or nil if this is the last one
+func GetIndexedEvents(ctx context.Context, db *sql.DB, source string, page PageRequest) ([]IndexedEvent, *Cursor, error) {
+	cursorID, cursorKey := page.cursorArgs()
+	rows, err := sqlitestore.NewTraced(db).ListIndexedEvents(ctx, sqlitestore.ListIndexedEventsParams{
+		Source:    source,
+		CursorID:  cursorID,
+		CursorKey: cursorKey,
+		// Fetch one extra row to find out whether there is a next page
+		Limit: int64(page.Limit) + 1,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{Sort: page.Sort, Key: last.BlockNumber, ID: last.ID}
+	}
+
+	indexed := make([]IndexedEvent, 0, len(rows))
+	for _, row := range rows {
+		indexed = append(indexed, IndexedEvent{
+			ID:             row.ID,
+			Source:         row.Source,
+			Chain:          row.Chain,
+			Contract:       common.BytesToAddress(row.Contract).Hex(),
+			Event:          row.EventName,
+			BlockNumber:    row.BlockNumber,
+			BlockTimestamp: time.Unix(row.BlockTimestamp, 0),
+			TxHash:         common.BytesToHash(row.TxHash).Hex(),
+			LogIndex:       row.LogIndex,
+			Args:           row.Args,
+		})
+	}
+	return indexed, next, nil
+}
+
+// CountIndexedEvents returns the number of events indexed for a source
+func CountIndexedEvents(ctx context.Context, db *sql.DB, source string) (int, error) {
+	count, err := sqlitestore.NewTraced(db).CountIndexedEvents(ctx, source)
+	return int(count), err
+}
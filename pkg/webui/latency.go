@@ -0,0 +1,232 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+)
+
+// Latency phases of a deposit. The L1 phases are measured from the L1
+// inclusion of the deposit, the L2 safe and finalized phases from its L2
+// inclusion. L2 inclusion spans from the L1 inclusion until the sequencer
+// adopted the L1 block as the origin of an L2 block, which includes the
+// deposit.
+const (
+	PhaseL1Safe      = "l1_safe"
+	PhaseL1Finalized = "l1_finalized"
+	PhaseL2Inclusion = "l2_inclusion"
+	PhaseL2Safe      = "l2_safe"
+	PhaseL2Finalized = "l2_finalized"
+)
+
+// LatencyPhases lists the phases in the order they are shown
+var LatencyPhases = []string{
+	PhaseL1Safe,
+	PhaseL1Finalized,
+	PhaseL2Inclusion,
+	PhaseL2Safe,
+	PhaseL2Finalized,
+}
+
+// latencyPhaseNames are the labels of the phases on the dashboard
+var latencyPhaseNames = map[string]string{
+	PhaseL1Safe:      "L1 safe",
+	PhaseL1Finalized: "L1 finalized",
+	PhaseL2Inclusion: "L2 inclusion (origin lag)",
+	PhaseL2Safe:      "L2 safe (derivation)",
+	PhaseL2Finalized: "L2 finalized",
+}
+
+const (
+	// DefaultLatencyRange is the range of deposits covered when no since is given
+	DefaultLatencyRange = 24 * time.Hour
+	// MaxLatencyDeposits bounds the number of deposits a latency report is computed over
+	MaxLatencyDeposits = 10000
+)
+
+// DepositLatency holds the milestones of a deposit. The times blocks became
+// safe or finalized are only known for blocks that advanced while the latency
+// tracker was running, the other milestones are nil until they happen.
+type DepositLatency struct {
+	ID            int64
+	L1BlockNumber int64
+	L1Timestamp   time.Time
+	L1SafeAt      *time.Time
+	L1FinalizedAt *time.Time
+	L2BlockNumber *int64
+	L2Timestamp   *time.Time
+	// L1OriginNumber is the L1 origin of the L2 block, normally the L1 block of the deposit
+	L1OriginNumber *int64
+	L2SafeAt       *time.Time
+	L2FinalizedAt  *time.Time
+}
+
+// Phases returns the seconds spent in each phase, nil when unknown
+func (d DepositLatency) Phases() map[string]*int64 {
+	since := func(start time.Time, end *time.Time) *int64 {
+		if end == nil {
+			return nil
+		}
+		seconds := int64(end.Sub(start).Seconds())
+		return &seconds
+	}
+
+	phases := map[string]*int64{
+		PhaseL1Safe:      since(d.L1Timestamp, d.L1SafeAt),
+		PhaseL1Finalized: since(d.L1Timestamp, d.L1FinalizedAt),
+		PhaseL2Inclusion: since(d.L1Timestamp, d.L2Timestamp),
+		PhaseL2Safe:      nil,
+		PhaseL2Finalized: nil,
+	}
+	if d.L2Timestamp != nil {
+		phases[PhaseL2Safe] = since(*d.L2Timestamp, d.L2SafeAt)
+		phases[PhaseL2Finalized] = since(*d.L2Timestamp, d.L2FinalizedAt)
+	}
+	return phases
+}
+
+// PhaseStats summarizes the known durations of a phase
+type PhaseStats struct {
+	Phase string
+	Name  string
+	Count int
+	P50   *int64
+	P90   *int64
+	Max   *int64
+}
+
+// LatencyReport breaks the latency of the deposits initiated in a time range down by phase
+type LatencyReport struct {
+	Since  time.Time
+	Until  time.Time
+	Phases []PhaseStats
+	// Deposits are the deposits of the range, newest first
+	Deposits []DepositLatency
+}
+
+// unixTimeOrNil converts a nullable unix timestamp computed by SQLite
+func unixTimeOrNil(v interface{}) *time.Time {
+	var unix int64
+	switch v := v.(type) {
+	case int64:
+		unix = v
+	case float64:
+		unix = int64(v)
+	default:
+		return nil
+	}
+	t := time.Unix(unix, 0)
+	return &t
+}
+
+func newDepositLatency(id, l1BlockNumber, l1Timestamp int64, l2BlockNumber, l2Timestamp, l1OriginNumber *int64, l1SafeAt, l1FinalizedAt, l2SafeAt, l2FinalizedAt interface{}) DepositLatency {
+	d := DepositLatency{
+		ID:             id,
+		L1BlockNumber:  l1BlockNumber,
+		L1Timestamp:    time.Unix(l1Timestamp, 0),
+		L1SafeAt:       unixTimeOrNil(l1SafeAt),
+		L1FinalizedAt:  unixTimeOrNil(l1FinalizedAt),
+		L2BlockNumber:  l2BlockNumber,
+		L1OriginNumber: l1OriginNumber,
+	}
+	if l2Timestamp != nil {
+		d.L2Timestamp = unixTimeOrNil(*l2Timestamp)
+		d.L2SafeAt = unixTimeOrNil(l2SafeAt)
+		d.L2FinalizedAt = unixTimeOrNil(l2FinalizedAt)
+	}
+	return d
+}
+
+// ParseLatencyRange reads the since and until parameters. Until defaults to
+// now and since to DefaultLatencyRange before until.
+func ParseLatencyRange(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	values := r.URL.Query()
+
+	until := now
+	t, err := parseTimeParam(values.Get("until"), "until")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if t != nil {
+		until = *t
+	}
+
+	since := until.Add(-DefaultLatencyRange)
+	t, err = parseTimeParam(values.Get("since"), "since")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if t != nil {
+		since = *t
+	}
+
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("since must be before until")
+	}
+	return since, until, nil
+}
+
+// GetDepositLatency returns the milestones of a deposit by its L1 deposit ID
+func GetDepositLatency(ctx context.Context, db *sql.DB, id int64) (DepositLatency, error) {
+	row, err := sqlitestore.NewTraced(db).GetDepositLatency(ctx, id)
+	if err != nil {
+		return DepositLatency{}, err
+	}
+	return newDepositLatency(row.ID, row.L1BlockNumber, row.L1Timestamp, row.L2BlockNumber, row.L2Timestamp, row.L1OriginNumber, row.L1SafeAt, row.L1FinalizedAt, row.L2SafeAt, row.L2FinalizedAt), nil
+}
+
+// LoadDepositLatencies sets the latency breakdown of each deposit
+func LoadDepositLatencies(ctx context.Context, db *sql.DB, deposits []Deposit) error {
+	for i := range deposits {
+		latency, err := GetDepositLatency(ctx, db, deposits[i].ID)
+		if err != nil {
+			return err
+		}
+		deposits[i].Latency = &latency
+	}
+	return nil
+}
+
+// GetLatencyReport computes the phase statistics of the deposits initiated in
+// [since, until). At most MaxLatencyDeposits of the newest deposits are included.
+func GetLatencyReport(ctx context.Context, db *sql.DB, since, until time.Time) (LatencyReport, error) {
+	rows, err := sqlitestore.NewTraced(db).ListDepositLatencies(ctx, sqlitestore.ListDepositLatenciesParams{
+		Since: since.Unix(),
+		Until: until.Unix(),
+		Limit: MaxLatencyDeposits,
+	})
+	if err != nil {
+		return LatencyReport{}, err
+	}
+
+	report := LatencyReport{Since: since, Until: until, Deposits: make([]DepositLatency, 0, len(rows))}
+	durations := map[string][]int64{}
+	for _, row := range rows {
+		d := newDepositLatency(row.ID, row.L1BlockNumber, row.L1Timestamp, row.L2BlockNumber, row.L2Timestamp, row.L1OriginNumber, row.L1SafeAt, row.L1FinalizedAt, row.L2SafeAt, row.L2FinalizedAt)
+		report.Deposits = append(report.Deposits, d)
+		for phase, seconds := range d.Phases() {
+			if seconds != nil {
+				durations[phase] = append(durations[phase], *seconds)
+			}
+		}
+	}
+
+	for _, phase := range LatencyPhases {
+		values := durations[phase]
+		slices.Sort(values)
+		report.Phases = append(report.Phases, PhaseStats{
+			Phase: phase,
+			Name:  latencyPhaseNames[phase],
+			Count: len(values),
+			P50:   percentile(values, 50),
+			P90:   percentile(values, 90),
+			Max:   percentile(values, 100),
+		})
+	}
+	return report, nil
+}
@@ -0,0 +1,311 @@
+package webui
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/export"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// SortOrder selects the order of deposit lists
+type SortOrder string
+
+const (
+	// SortNewest orders deposits by L1 block timestamp, newest first
+	SortNewest SortOrder = "newest"
+	// SortLargest orders deposits by amount, largest first
+	SortLargest SortOrder = "largest"
+	// SortSlowest orders matched deposits by confirmation time and unmatched
+	// deposits by time waited, slowest first
+	SortSlowest SortOrder = "slowest"
+)
+
+// listParams are the query parameters understood by ParseListQuery
+var listParams = []string{
+	"sort", "limit", "address", "since", "until",
+	"min_amount", "max_amount", "min_amount_wei", "max_amount_wei",
+	"min_confirmation", "max_confirmation",
+}
+
+// Cursor points at the last deposit of a page. The next page starts right
+// after it in the given sort order, so pages don't shift when new deposits
+// are indexed.
+type Cursor struct {
+	Sort SortOrder
+	// Key is the sort key of the deposit: an int64 for newest and slowest,
+	// the 32-byte amount for largest
+	Key any
+	ID  int64
+}
+
+// Encode returns the opaque string form of the cursor
+func (c Cursor) Encode() string {
+	var key string
+	switch v := c.Key.(type) {
+	case []byte:
+		key = hex.EncodeToString(v)
+	default:
+		key = fmt.Sprint(v)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s:%d", c.Sort, key, c.ID)))
+}
+
+// encodeCursor encodes c, or returns an empty string for a nil cursor
+func encodeCursor(c *Cursor) string {
+	if c == nil {
+		return ""
+	}
+	return c.Encode()
+}
+
+// DecodeCursor parses a cursor returned by Encode
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	cursor := &Cursor{Sort: SortOrder(parts[0])}
+	cursor.ID, err = strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	switch cursor.Sort {
+	case SortLargest:
+		cursor.Key, err = hex.DecodeString(parts[1])
+	case SortNewest, SortSlowest:
+		cursor.Key, err = strconv.ParseInt(parts[1], 10, 64)
+	default:
+		err = fmt.Errorf("unknown sort order")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return cursor, nil
+}
+
+// PageRequest selects one page of a deposit list
+type PageRequest struct {
+	Sort  SortOrder
+	Limit int
+	// After is the cursor of the previous page, nil for the first page
+	After *Cursor
+}
+
+// cursorArgs returns the cursor query arguments, or nils for the first page
+func (p PageRequest) cursorArgs() (*int64, any) {
+	if p.After == nil {
+		return nil, nil
+	}
+	return &p.After.ID, p.After.Key
+}
+
+// ListQuery is a parsed deposit list request shared by the JSON API and the
+// dashboard sections
+type ListQuery struct {
+	Filter DepositFilter
+	Page   PageRequest
+	// params holds the recognised raw parameters, without the cursor, so
+	// that links to other pages keep the same filters
+	params url.Values
+}
+
+// Get returns the raw value of a list parameter, e.g. to prefill a form
+func (q ListQuery) Get(name string) string {
+	return q.params.Get(name)
+}
+
+// URL returns path with the query's filters and the given cursor
+func (q ListQuery) URL(path string, cursor string) string {
+	values := url.Values{}
+	for name, v := range q.params {
+		values[name] = v
+	}
+	if cursor != "" {
+		values.Set("cursor", cursor)
+	}
+	if len(values) == 0 {
+		return path
+	}
+	return path + "?" + values.Encode()
+}
+
+// Cursor returns the encoded cursor the query's page starts after
+func (q ListQuery) Cursor() string {
+	if q.Page.After == nil {
+		return ""
+	}
+	return q.Page.After.Encode()
+}
+
+// ParseListQuery reads the filter, sort, page size and cursor parameters of a
+// deposit list request. maxLimit bounds the page size.
+func ParseListQuery(r *http.Request, defaultLimit, maxLimit int) (ListQuery, error) {
+	values := r.URL.Query()
+	q := ListQuery{
+		Page:   PageRequest{Sort: SortNewest, Limit: defaultLimit},
+		params: url.Values{},
+	}
+	for _, name := range listParams {
+		if v := strings.TrimSpace(values.Get(name)); v != "" {
+			q.params.Set(name, v)
+		}
+	}
+
+	if v := q.Get("sort"); v != "" {
+		switch SortOrder(v) {
+		case SortNewest, SortLargest, SortSlowest:
+			q.Page.Sort = SortOrder(v)
+		default:
+			return q, fmt.Errorf("sort must be one of newest, largest or slowest")
+		}
+	}
+
+	limit, err := parseLimit(q.Get("limit"), defaultLimit, maxLimit)
+	if err != nil {
+		return q, err
+	}
+	q.Page.Limit = limit
+
+	if v := values.Get("cursor"); v != "" {
+		cursor, err := DecodeCursor(v)
+		if err != nil {
+			return q, err
+		}
+		if cursor.Sort != q.Page.Sort {
+			return q, fmt.Errorf("cursor was issued for sort order %s", cursor.Sort)
+		}
+		q.Page.After = cursor
+	}
+
+	if v := q.Get("address"); v != "" {
+		if !common.IsHexAddress(v) {
+			return q, fmt.Errorf("address must be a hex encoded address")
+		}
+		q.Filter.Address = common.HexToAddress(v).Bytes()
+	}
+
+	if q.Filter.Since, err = parseTimeParam(q.Get("since"), "since"); err != nil {
+		return q, err
+	}
+	if q.Filter.Until, err = parseTimeParam(q.Get("until"), "until"); err != nil {
+		return q, err
+	}
+
+	if q.Filter.MinAmountWei, err = parseAmountParams(q, "min_amount"); err != nil {
+		return q, err
+	}
+	if q.Filter.MaxAmountWei, err = parseAmountParams(q, "max_amount"); err != nil {
+		return q, err
+	}
+
+	if q.Filter.MinConfirmationSeconds, err = parseSecondsParam(q.Get("min_confirmation"), "min_confirmation"); err != nil {
+		return q, err
+	}
+	if q.Filter.MaxConfirmationSeconds, err = parseSecondsParam(q.Get("max_confirmation"), "max_confirmation"); err != nil {
+		return q, err
+	}
+
+	return q, nil
+}
+
+// ParsePage reads the page size and cursor parameters of a list that is only
+// ordered newest first, such as the dispute games or the security events. Like
+// the deposit list cursors, its cursors point at the sort key and id of the
+// last row of the previous page.
+func ParsePage(r *http.Request, defaultLimit, maxLimit int) (PageRequest, error) {
+	values := r.URL.Query()
+	page := PageRequest{Sort: SortNewest}
+
+	var err error
+	page.Limit, err = parseLimit(strings.TrimSpace(values.Get("limit")), defaultLimit, maxLimit)
+	if err != nil {
+		return page, err
+	}
+
+	if v := values.Get("cursor"); v != "" {
+		cursor, err := DecodeCursor(v)
+		if err != nil {
+			return page, err
+		}
+		if cursor.Sort != SortNewest {
+			return page, fmt.Errorf("invalid cursor")
+		}
+		page.After = cursor
+	}
+	return page, nil
+}
+
+// parseLimit reads a page size between 1 and maxLimit
+func parseLimit(v string, defaultLimit, maxLimit int) (int, error) {
+	if v == "" {
+		return defaultLimit, nil
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit < 1 || limit > maxLimit {
+		return 0, fmt.Errorf("limit must be an integer between 1 and %d", maxLimit)
+	}
+	return limit, nil
+}
+
+// parseTimeParam accepts an RFC3339 timestamp, a YYYY-MM-DD date or unix seconds
+func parseTimeParam(v, name string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := export.ParseTime(v)
+	if err == nil {
+		return &t, nil
+	}
+	return nil, fmt.Errorf("%s must be an RFC3339 timestamp, a YYYY-MM-DD date or unix seconds", name)
+}
+
+// parseAmountParams reads an amount given either in wei (<name>_wei) or in ETH (<name>)
+func parseAmountParams(q ListQuery, name string) (*big.Int, error) {
+	if v := q.Get(name + "_wei"); v != "" {
+		wei, ok := new(big.Int).SetString(v, 10)
+		if !ok || wei.Sign() < 0 || wei.BitLen() > 256 {
+			return nil, fmt.Errorf("%s_wei must be a non-negative 256-bit integer", name)
+		}
+		return wei, nil
+	}
+	if v := q.Get(name); v != "" {
+		eth, ok := new(big.Rat).SetString(v)
+		if !ok || eth.Sign() < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative ETH amount", name)
+		}
+		wei := eth.Mul(eth, new(big.Rat).SetInt64(params.Ether))
+		if !wei.IsInt() || wei.Num().BitLen() > 256 {
+			return nil, fmt.Errorf("%s must have at most 18 decimals and fit in 256 bits of wei", name)
+		}
+		return wei.Num(), nil
+	}
+	return nil, nil
+}
+
+// parseSecondsParam reads a non-negative number of seconds
+func parseSecondsParam(v, name string) (*int64, error) {
+	if v == "" {
+		return nil, nil
+	}
+	seconds, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || seconds < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative number of seconds", name)
+	}
+	return &seconds, nil
+}
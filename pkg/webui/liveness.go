@@ -0,0 +1,208 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/liveness"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+)
+
+const (
+	// DefaultIncidentRange is the range of incidents shown when no since is given
+	DefaultIncidentRange = 7 * 24 * time.Hour
+	// MaxIncidents bounds the number of incidents returned, the newest are kept
+	MaxIncidents = 1000
+)
+
+// incidentKindNames are the labels of the incident kinds on the dashboard
+var incidentKindNames = map[string]string{
+	liveness.Stall:      "L2 head stalled",
+	liveness.HeadLag:    "L2 head behind wall clock",
+	liveness.BlockTime:  "Slow L2 blocks",
+	liveness.OriginLag:  "L1 origin lagging",
+	liveness.BatcherGap: "Batcher not posting",
+	liveness.SafeLag:    "L2 safe head lagging",
+}
+
+// Incident is a period during which the liveness monitor saw L2 misbehave
+type Incident struct {
+	ID   int64
+	Kind string
+	Name string
+	// L2BlockNumber is the L2 head when the incident started
+	L2BlockNumber int64
+	StartedAt     time.Time
+	// EndedAt is nil while the incident is ongoing
+	EndedAt *time.Time
+	// Worst is the worst value measured, in seconds or L1 blocks for origin lag
+	Worst  int64
+	Detail string
+	// AffectedDeposits is the number of deposits in flight during the incident
+	AffectedDeposits int
+}
+
+// End returns when the incident ended, or now if it is ongoing
+func (i Incident) End(now time.Time) time.Time {
+	if i.EndedAt == nil {
+		return now
+	}
+	return *i.EndedAt
+}
+
+// DurationSeconds returns how long the incident lasted, or has lasted so far
+func (i Incident) DurationSeconds(now time.Time) int64 {
+	return int64(i.End(now).Sub(i.StartedAt).Seconds())
+}
+
+// FormatWorst formats the worst value in the unit of the incident kind
+func (i Incident) FormatWorst() string {
+	if i.Kind == liveness.OriginLag {
+		return fmt.Sprintf("%d L1 blocks", i.Worst)
+	}
+	return formatTimeDiff(i.Worst)
+}
+
+func newIncident(row sqlitestore.LivenessIncident) Incident {
+	incident := Incident{
+		ID:            row.ID,
+		Kind:          row.Kind,
+		Name:          incidentKindNames[row.Kind],
+		L2BlockNumber: row.L2BlockNumber,
+		StartedAt:     time.Unix(row.StartedAt, 0),
+		Worst:         row.Worst,
+		Detail:        row.Detail,
+	}
+	if incident.Name == "" {
+		incident.Name = row.Kind
+	}
+	if row.EndedAt != nil {
+		endedAt := time.Unix(*row.EndedAt, 0)
+		incident.EndedAt = &endedAt
+	}
+	return incident
+}
+
+// IncidentTimeline holds the incidents overlapping a time range
+type IncidentTimeline struct {
+	Since time.Time
+	Until time.Time
+	// Incidents are ordered newest first
+	Incidents []Incident
+}
+
+// ParseIncidentRange reads the since and until parameters. Until defaults to
+// now and since to DefaultIncidentRange before until.
+func ParseIncidentRange(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	values := r.URL.Query()
+
+	until := now
+	t, err := parseTimeParam(values.Get("until"), "until")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if t != nil {
+		until = *t
+	}
+
+	since := until.Add(-DefaultIncidentRange)
+	t, err = parseTimeParam(values.Get("since"), "since")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if t != nil {
+		since = *t
+	}
+
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("since must be before until")
+	}
+	return since, until, nil
+}
+
+// GetIncidentTimeline returns the incidents overlapping [since, until) with
+// the number of deposits each affected. At most MaxIncidents of the newest
+// incidents are returned.
+func GetIncidentTimeline(ctx context.Context, db *sql.DB, since, until, now time.Time) (IncidentTimeline, error) {
+	sinceUnix := since.Unix()
+	rows, err := sqlitestore.NewTraced(db).ListLivenessIncidents(ctx, sqlitestore.ListLivenessIncidentsParams{
+		Until: until.Unix(),
+		Since: &sinceUnix,
+		Limit: MaxIncidents,
+	})
+	if err != nil {
+		return IncidentTimeline{}, err
+	}
+
+	timeline := IncidentTimeline{Since: since, Until: until, Incidents: make([]Incident, 0, len(rows))}
+	for _, row := range rows {
+		incident := newIncident(row)
+		incident.AffectedDeposits, err = countIncidentDeposits(ctx, db, incident, now)
+		if err != nil {
+			return IncidentTimeline{}, err
+		}
+		timeline.Incidents = append(timeline.Incidents, incident)
+	}
+	return timeline, nil
+}
+
+// GetIncident returns an incident by ID with the number of deposits it affected
+func GetIncident(ctx context.Context, db *sql.DB, id int64, now time.Time) (Incident, error) {
+	row, err := sqlitestore.NewTraced(db).GetLivenessIncident(ctx, id)
+	if err != nil {
+		return Incident{}, err
+	}
+	incident := newIncident(row)
+	incident.AffectedDeposits, err = countIncidentDeposits(ctx, db, incident, now)
+	if err != nil {
+		return Incident{}, err
+	}
+	return incident, nil
+}
+
+func countIncidentDeposits(ctx context.Context, db *sql.DB, incident Incident, now time.Time) (int, error) {
+	start := incident.StartedAt.Unix()
+	count, err := sqlitestore.NewTraced(db).CountDepositsInFlight(ctx, sqlitestore.CountDepositsInFlightParams{
+		End:   incident.End(now).Unix(),
+		Start: &start,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// GetIncidentDeposits returns a page of the deposits affected by an incident:
+// those initiated before it ended and not confirmed on L2 before it started,
+// newest first, and the cursor of the next page or nil if this is the last one
+func GetIncidentDeposits(ctx context.Context, db *sql.DB, incident Incident, now time.Time, page PageRequest) ([]Deposit, *Cursor, error) {
+	start := incident.StartedAt.Unix()
+	cursorID, cursorKey := page.cursorArgs()
+	rows, err := sqlitestore.NewTraced(db).ListDepositsInFlight(ctx, sqlitestore.ListDepositsInFlightParams{
+		End:       incident.End(now).Unix(),
+		Start:     &start,
+		CursorID:  cursorID,
+		CursorKey: cursorKey,
+		// Fetch one extra row to find out whether there is a next page
+		Limit: int64(page.Limit) + 1,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{Sort: page.Sort, Key: last.L1Timestamp, ID: last.ID}
+	}
+
+	deposits := make([]Deposit, 0, len(rows))
+	for _, row := range rows {
+		deposits = append(deposits, newDeposit(row.ID, row.FromAddress, row.ToAddress, row.Amount, row.AmountWei, row.L1BlockNumber, row.L1Timestamp, row.TxHashL1, row.L2ID, row.L2BlockNumber, row.L2Timestamp, row.TxHashL2))
+	}
+	return deposits, next, nil
+}
@@ -0,0 +1,235 @@
+package webui
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DepositLookup holds everything rendered on a deposit or address lookup page
+type DepositLookup struct {
+	// Query is shown in the search box
+	Query       string
+	Title       string
+	Description string
+	// Error replaces the results when the lookup could not be performed
+	Error    string
+	Deposits []Deposit
+	// Path is the unprefixed page path used for pagination links
+	Path  string
+	Total int
+	// Cursor is the cursor the page starts after, Next the one of the next page
+	Cursor string
+	Next   string
+}
+
+// isTxHash reports whether s is a 0x-prefixed 32 byte hex string
+func isTxHash(s string) bool {
+	b, err := hexutil.Decode(s)
+	return err == nil && len(b) == common.HashLength
+}
+
+// handleSearch redirects the search box query to the matching lookup page
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	switch {
+	case isTxHash(query):
+		http.Redirect(w, r, s.prefixPath("/deposit/"+strings.ToLower(query)), http.StatusSeeOther)
+	case common.IsHexAddress(query):
+		http.Redirect(w, r, s.prefixPath("/address/"+common.HexToAddress(query).Hex()), http.StatusSeeOther)
+	default:
+		s.renderLookup(w, r, http.StatusBadRequest, DepositLookup{
+			Query: query,
+			Title: "Search",
+			Error: "Enter a 0x-prefixed L1 or L2 transaction hash, or an address",
+		})
+	}
+}
+
+// handleDepositLookup shows the deposits initiated or finalized in an L1 or L2 transaction
+func (s *Server) handleDepositLookup(w http.ResponseWriter, r *http.Request) {
+	txHash := r.PathValue("txhash")
+	lookup := DepositLookup{
+		Query:       txHash,
+		Title:       "Deposit",
+		Description: "Transaction " + txHash,
+	}
+
+	if !isTxHash(txHash) {
+		lookup.Error = "Not a valid transaction hash"
+		s.renderLookup(w, r, http.StatusBadRequest, lookup)
+		return
+	}
+
+	deposits, err := GetDepositsByTxHash(r.Context(), s.db, common.HexToHash(txHash).Bytes())
+	if err != nil {
+		s.logger.Error("failed to get deposits by transaction hash", "error", err)
+		http.Error(w, "Failed to get deposits", http.StatusInternalServerError)
+		return
+	}
+
+	err = LoadDepositLatencies(r.Context(), s.db, deposits)
+	if err != nil {
+		s.logger.Error("failed to get deposit latencies", "error", err)
+		http.Error(w, "Failed to get deposit latencies", http.StatusInternalServerError)
+		return
+	}
+
+	lookup.Deposits = deposits
+	err = s.loadDepositETAs(r, deposits)
+	if err != nil {
+		s.logger.Error("failed to get ETA model", "error", err)
+		http.Error(w, "Failed to get ETA model", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if len(deposits) == 0 {
+		// The transaction may simply not be indexed yet
+		lookup.Error = "No deposit found for this transaction. If it was sent recently, it may not be indexed yet."
+		status = http.StatusNotFound
+	}
+	s.renderLookup(w, r, status, lookup)
+}
+
+// handleAddressLookup shows every deposit sent from or to an address, newest first
+func (s *Server) handleAddressLookup(w http.ResponseWriter, r *http.Request) {
+	addr := r.PathValue("addr")
+	lookup := DepositLookup{
+		Query:       addr,
+		Title:       "Address",
+		Description: "Deposits sent from or to " + addr,
+		Path:        "/address/" + addr,
+	}
+
+	if !common.IsHexAddress(addr) {
+		lookup.Error = "Not a valid address"
+		s.renderLookup(w, r, http.StatusBadRequest, lookup)
+		return
+	}
+	address := common.HexToAddress(addr).Bytes()
+
+	page, err := ParsePage(r, ItemsPerPage, MaxItemsPerPage)
+	if err != nil {
+		lookup.Error = err.Error()
+		s.renderLookup(w, r, http.StatusBadRequest, lookup)
+		return
+	}
+
+	deposits, next, err := GetDepositsByAddress(r.Context(), s.db, address, page)
+	if err != nil {
+		s.logger.Error("failed to get deposits by address", "error", err)
+		http.Error(w, "Failed to get deposits", http.StatusInternalServerError)
+		return
+	}
+
+	totalCount, err := GetTotalDepositsByAddress(r.Context(), s.db, address)
+	if err != nil {
+		s.logger.Error("failed to get total deposits by address", "error", err)
+		http.Error(w, "Failed to get total count", http.StatusInternalServerError)
+		return
+	}
+
+	lookup.Deposits = deposits
+	lookup.Total = totalCount
+	lookup.Cursor = encodeCursor(page.After)
+	lookup.Next = encodeCursor(next)
+	err = s.loadDepositETAs(r, deposits)
+	if err != nil {
+		s.logger.Error("failed to get ETA model", "error", err)
+		http.Error(w, "Failed to get ETA model", http.StatusInternalServerError)
+		return
+	}
+
+	s.renderLookup(w, r, http.StatusOK, lookup)
+}
+
+// handleIncidentLookup shows the deposits in flight during a liveness incident, newest first
+func (s *Server) handleIncidentLookup(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	lookup := DepositLookup{
+		Title: "Incident",
+		Path:  "/incident/" + idStr,
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		lookup.Error = "Not a valid incident ID"
+		s.renderLookup(w, r, http.StatusBadRequest, lookup)
+		return
+	}
+
+	now := time.Now()
+	incident, err := GetIncident(r.Context(), s.db, id, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		lookup.Error = "Incident not found"
+		s.renderLookup(w, r, http.StatusNotFound, lookup)
+		return
+	}
+	if err != nil {
+		s.logger.Error("failed to get incident", "error", err)
+		http.Error(w, "Failed to get incident", http.StatusInternalServerError)
+		return
+	}
+
+	end := "ongoing"
+	if incident.EndedAt != nil {
+		end = formatTime(*incident.EndedAt)
+	}
+	lookup.Title = incident.Name
+	lookup.Description = fmt.Sprintf("%s to %s (%s). %s. Deposits initiated before the incident ended and not confirmed on L2 before it started.", formatTime(incident.StartedAt), end, formatTimeDiff(incident.DurationSeconds(now)), incident.Detail)
+
+	page, err := ParsePage(r, ItemsPerPage, MaxItemsPerPage)
+	if err != nil {
+		lookup.Error = err.Error()
+		s.renderLookup(w, r, http.StatusBadRequest, lookup)
+		return
+	}
+
+	deposits, next, err := GetIncidentDeposits(r.Context(), s.db, incident, now, page)
+	if err != nil {
+		s.logger.Error("failed to get incident deposits", "error", err)
+		http.Error(w, "Failed to get deposits", http.StatusInternalServerError)
+		return
+	}
+
+	lookup.Deposits = deposits
+	lookup.Total = incident.AffectedDeposits
+	lookup.Cursor = encodeCursor(page.After)
+	lookup.Next = encodeCursor(next)
+	err = s.loadDepositETAs(r, deposits)
+	if err != nil {
+		s.logger.Error("failed to get ETA model", "error", err)
+		http.Error(w, "Failed to get ETA model", http.StatusInternalServerError)
+		return
+	}
+
+	s.renderLookup(w, r, http.StatusOK, lookup)
+}
+
+// loadDepositETAs estimates the arrival of the pending deposits
+func (s *Server) loadDepositETAs(r *http.Request, deposits []Deposit) error {
+	model, err := GetETAModel(r.Context(), s.db, time.Now())
+	if err != nil {
+		return err
+	}
+	model.LoadDepositETAs(deposits)
+	return nil
+}
+
+func (s *Server) renderLookup(w http.ResponseWriter, r *http.Request, status int, lookup DepositLookup) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	err := DepositLookupPage(lookup, s.pathPrefix).Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render deposit lookup", "error", err)
+	}
+}
@@ -0,0 +1,36 @@
+package webui_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRedirects(t *testing.T) {
+	handler := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search?q="+l1TxHash.Hex(), nil))
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+	require.Equal(t, "/deposit/"+l1TxHash.Hex(), rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search?q=not-a-hash", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestLookupPages(t *testing.T) {
+	handler := newTestServer(t)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/deposit/"+l2TxHash.Hex(), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), l1TxHash.Hex())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/address/"+sender.Hex(), nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), l2TxHash.Hex())
+}
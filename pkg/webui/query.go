@@ -4,17 +4,60 @@ import (
 	"context"
 	"database/sql"
 	"encoding/hex"
+	"math/big"
 	"time"
 
 	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// DepositFilter narrows down deposit lists. Zero values mean no filtering.
+type DepositFilter struct {
+	// Address matches either the sender or the recipient
+	Address []byte
+	// Since and Until bound the L1 block timestamp to [Since, Until)
+	Since *time.Time
+	Until *time.Time
+	// MinAmountWei and MaxAmountWei bound the amount, inclusive
+	MinAmountWei *big.Int
+	MaxAmountWei *big.Int
+	// MinConfirmationSeconds and MaxConfirmationSeconds bound the time
+	// between the L1 and L2 events, inclusive. Matched deposits only.
+	MinConfirmationSeconds *int64
+	MaxConfirmationSeconds *int64
+}
+
+// amountOrNil encodes an amount the way it is stored in the amount_wei columns
+func amountOrNil(amount *big.Int) []byte {
+	if amount == nil {
+		return nil
+	}
+	return common.LeftPadBytes(amount.Bytes(), 32)
+}
+
+// secondsOrNil passes an optional number of seconds as a query argument
+func secondsOrNil(seconds *int64) any {
+	if seconds == nil {
+		return nil
+	}
+	return *seconds
+}
+
+func unixOrNil(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	unix := t.Unix()
+	return &unix
+}
+
 // DepositPair represents a matched pair of L1 and L2 deposit events
 type DepositPair struct {
 	ID              int64
 	FromAddress     string
 	ToAddress       string
 	Amount          float64
+	AmountWei       string
 	L1BlockNumber   int64
 	L2BlockNumber   int64
 	L1Timestamp     time.Time
@@ -30,24 +73,234 @@ type UnmatchedDeposit struct {
 	FromAddress      string
 	ToAddress        string
 	Amount           float64
+	AmountWei        string
 	L1BlockNumber    int64
 	L1Timestamp      time.Time
 	TimeSinceSeconds int64
 	TxHashL1         string
+	// ETA is nil when there is no confirmation history to estimate from
+	ETA *DepositETA
 }
 
-// GetMatchedDeposits returns a list of matched deposit pairs with time difference information
-func GetMatchedDeposits(ctx context.Context, db *sql.DB, limit, offset int) ([]DepositPair, error) {
-	queries := sqlitestore.New(db)
+// OrphanedFinalization represents an L2 deposit finalization without a known L1 deposit
+type OrphanedFinalization struct {
+	ID               int64
+	FromAddress      string
+	ToAddress        string
+	Amount           float64
+	AmountWei        string
+	L2BlockNumber    int64
+	L2Timestamp      time.Time
+	TimeSinceSeconds int64
+	TxHashL2         string
+}
 
-	rows, err := queries.GetMatchedDeposits(ctx, sqlitestore.GetMatchedDepositsParams{
-		Limit:  int64(limit),
-		Offset: int64(offset),
+// Deposit represents an L1 deposit together with its L2 confirmation, if matched
+type Deposit struct {
+	ID            int64
+	FromAddress   string
+	ToAddress     string
+	Amount        float64
+	AmountWei     string
+	L1BlockNumber int64
+	L1Timestamp   time.Time
+	TxHashL1      string
+	// L2 is nil while the deposit is waiting for its L2 confirmation
+	L2 *L2Confirmation
+	// Latency is only loaded for single deposit lookups
+	Latency *DepositLatency
+	// ETA is only loaded for pending deposits in lookups
+	ETA *DepositETA
+}
+
+// L2Confirmation represents the L2 side of a matched deposit
+type L2Confirmation struct {
+	ID              int64
+	BlockNumber     int64
+	Timestamp       time.Time
+	TxHash          string
+	TimeDiffSeconds int64
+}
+
+// BlockPointer represents the indexing progress stored under a pointer name
+type BlockPointer struct {
+	Name        string
+	BlockNumber *int64
+	BlockTime   *time.Time
+}
+
+// DepositTiming holds the L1 and L2 times of a deposit, used to compute time series
+type DepositTiming struct {
+	L1Timestamp int64
+	// L2Timestamp is nil while the deposit is pending
+	L2Timestamp *int64
+	Amount      float64
+}
+
+// hexString encodes bytes as a 0x-prefixed hex string
+func hexString(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// weiString decodes a 32-byte big-endian amount into a decimal string
+func weiString(b []byte) string {
+	return new(big.Int).SetBytes(b).String()
+}
+
+// newDeposit builds a Deposit from the columns shared by the single deposit queries
+func newDeposit(id int64, from, to []byte, amount float64, amountWei []byte, l1BlockNumber, l1Timestamp int64, txHashL1 []byte, l2ID, l2BlockNumber, l2Timestamp *int64, txHashL2 []byte) Deposit {
+	deposit := Deposit{
+		ID:            id,
+		FromAddress:   hexString(from),
+		ToAddress:     hexString(to),
+		Amount:        amount,
+		AmountWei:     weiString(amountWei),
+		L1BlockNumber: l1BlockNumber,
+		L1Timestamp:   time.Unix(l1Timestamp, 0),
+		TxHashL1:      hexString(txHashL1),
+	}
+	if l2ID != nil && l2BlockNumber != nil && l2Timestamp != nil {
+		deposit.L2 = &L2Confirmation{
+			ID:              *l2ID,
+			BlockNumber:     *l2BlockNumber,
+			Timestamp:       time.Unix(*l2Timestamp, 0),
+			TxHash:          hexString(txHashL2),
+			TimeDiffSeconds: *l2Timestamp - l1Timestamp,
+		}
+	}
+	return deposit
+}
+
+// GetDeposit returns the deposit with the given L1 event ID, or sql.ErrNoRows
+func GetDeposit(ctx context.Context, db *sql.DB, id int64) (Deposit, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	row, err := queries.GetDepositByID(ctx, id)
+	if err != nil {
+		return Deposit{}, err
+	}
+
+	return newDeposit(row.ID, row.FromAddress, row.ToAddress, row.Amount, row.AmountWei, row.L1BlockNumber, row.L1Timestamp, row.TxHashL1, row.L2ID, row.L2BlockNumber, row.L2Timestamp, row.TxHashL2), nil
+}
+
+// GetDepositsByTxHash returns the deposits initiated or finalized in the given L1 or L2 transaction
+func GetDepositsByTxHash(ctx context.Context, db *sql.DB, txHash []byte) ([]Deposit, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	rows, err := queries.GetDepositsByTxHash(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	deposits := make([]Deposit, 0, len(rows))
+	for _, row := range rows {
+		deposits = append(deposits, newDeposit(row.ID, row.FromAddress, row.ToAddress, row.Amount, row.AmountWei, row.L1BlockNumber, row.L1Timestamp, row.TxHashL1, row.L2ID, row.L2BlockNumber, row.L2Timestamp, row.TxHashL2))
+	}
+
+	return deposits, nil
+}
+
+// GetDepositsByAddress returns a page of the deposits sent from or to the
+// given address, newest first, and the cursor of the next page or nil if this
+// is the last one
+func GetDepositsByAddress(ctx context.Context, db *sql.DB, address []byte, page PageRequest) ([]Deposit, *Cursor, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	cursorID, cursorKey := page.cursorArgs()
+	rows, err := queries.GetDepositsByAddress(ctx, sqlitestore.GetDepositsByAddressParams{
+		Address:   address,
+		CursorID:  cursorID,
+		CursorKey: cursorKey,
+		// Fetch one extra row to find out whether there is a next page
+		Limit: int64(page.Limit) + 1,
 	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{Sort: page.Sort, Key: last.L1Timestamp, ID: last.ID}
+	}
+
+	deposits := make([]Deposit, 0, len(rows))
+	for _, row := range rows {
+		deposits = append(deposits, newDeposit(row.ID, row.FromAddress, row.ToAddress, row.Amount, row.AmountWei, row.L1BlockNumber, row.L1Timestamp, row.TxHashL1, row.L2ID, row.L2BlockNumber, row.L2Timestamp, row.TxHashL2))
+	}
+
+	return deposits, next, nil
+}
+
+// GetTotalDepositsByAddress returns the number of deposits sent from or to the given address
+func GetTotalDepositsByAddress(ctx context.Context, db *sql.DB, address []byte) (int, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	count, err := queries.GetTotalDepositsByAddress(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// GetBlockPointers returns all indexer block pointers
+func GetBlockPointers(ctx context.Context, db *sql.DB) ([]BlockPointer, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	rows, err := queries.ListBlockPointers(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	pointers := make([]BlockPointer, 0, len(rows))
+	for _, row := range rows {
+		pointer := BlockPointer{
+			Name:        row.Name,
+			BlockNumber: row.BlockNumber,
+		}
+		if row.BlockTime != nil {
+			blockTime := time.Unix(*row.BlockTime, 0)
+			pointer.BlockTime = &blockTime
+		}
+		pointers = append(pointers, pointer)
+	}
+
+	return pointers, nil
+}
+
+// GetMatchedDeposits returns a page of matched deposit pairs with time difference information,
+// and the cursor of the next page or nil if this is the last one
+func GetMatchedDeposits(ctx context.Context, db *sql.DB, filter DepositFilter, page PageRequest) ([]DepositPair, *Cursor, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	cursorID, cursorKey := page.cursorArgs()
+	rows, err := queries.GetMatchedDeposits(ctx, sqlitestore.GetMatchedDepositsParams{
+		Sort:         string(page.Sort),
+		Address:      filter.Address,
+		Since:        unixOrNil(filter.Since),
+		Until:        unixOrNil(filter.Until),
+		MinAmountWei: amountOrNil(filter.MinAmountWei),
+		MaxAmountWei: amountOrNil(filter.MaxAmountWei),
+		MinTimeDiff:  secondsOrNil(filter.MinConfirmationSeconds),
+		MaxTimeDiff:  secondsOrNil(filter.MaxConfirmationSeconds),
+		CursorID:     cursorID,
+		CursorKey:    cursorKey,
+		// Fetch one extra row to find out whether there is a next page
+		Limit: int64(page.Limit) + 1,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{Sort: page.Sort, Key: last.SortKey, ID: last.ID}
+	}
+
 	var deposits []DepositPair
 	for _, row := range rows {
 		// Convert TimeDiffSeconds from interface{} to int64
@@ -63,28 +316,37 @@ func GetMatchedDeposits(ctx context.Context, db *sql.DB, limit, offset int) ([]D
 
 		deposit := DepositPair{
 			ID:              row.ID,
-			FromAddress:     "0x" + hex.EncodeToString(row.FromAddress),
-			ToAddress:       "0x" + hex.EncodeToString(row.ToAddress),
+			FromAddress:     hexString(row.FromAddress),
+			ToAddress:       hexString(row.ToAddress),
 			Amount:          row.Amount,
+			AmountWei:       weiString(row.AmountWei),
 			L1BlockNumber:   row.L1BlockNumber,
 			L2BlockNumber:   row.L2BlockNumber,
 			L1Timestamp:     time.Unix(row.L1Timestamp, 0),
 			L2Timestamp:     time.Unix(row.L2Timestamp, 0),
 			TimeDiffSeconds: timeDiff,
-			TxHashL1:        "0x" + hex.EncodeToString(row.TxHashL1),
-			TxHashL2:        "0x" + hex.EncodeToString(row.TxHashL2),
+			TxHashL1:        hexString(row.TxHashL1),
+			TxHashL2:        hexString(row.TxHashL2),
 		}
 		deposits = append(deposits, deposit)
 	}
 
-	return deposits, nil
+	return deposits, next, nil
 }
 
 // GetTotalMatchedDeposits returns the total number of matched deposits
-func GetTotalMatchedDeposits(ctx context.Context, db *sql.DB) (int, error) {
-	queries := sqlitestore.New(db)
-
-	count, err := queries.GetTotalMatchedDeposits(ctx)
+func GetTotalMatchedDeposits(ctx context.Context, db *sql.DB, filter DepositFilter) (int, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	count, err := queries.GetTotalMatchedDeposits(ctx, sqlitestore.GetTotalMatchedDepositsParams{
+		Address:      filter.Address,
+		Since:        unixOrNil(filter.Since),
+		Until:        unixOrNil(filter.Until),
+		MinAmountWei: amountOrNil(filter.MinAmountWei),
+		MaxAmountWei: amountOrNil(filter.MaxAmountWei),
+		MinTimeDiff:  secondsOrNil(filter.MinConfirmationSeconds),
+		MaxTimeDiff:  secondsOrNil(filter.MaxConfirmationSeconds),
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -92,16 +354,34 @@ func GetTotalMatchedDeposits(ctx context.Context, db *sql.DB) (int, error) {
 	return int(count), nil
 }
 
-// GetUnmatchedDeposits returns a list of unmatched L1 deposit events
-func GetUnmatchedDeposits(ctx context.Context, db *sql.DB, limit, offset int) ([]UnmatchedDeposit, error) {
-	queries := sqlitestore.New(db)
+// GetUnmatchedDeposits returns a page of unmatched L1 deposit events, and the
+// cursor of the next page or nil if this is the last one. The confirmation
+// time bounds of the filter are ignored.
+func GetUnmatchedDeposits(ctx context.Context, db *sql.DB, filter DepositFilter, page PageRequest) ([]UnmatchedDeposit, *Cursor, error) {
+	queries := sqlitestore.NewTraced(db)
 
+	cursorID, cursorKey := page.cursorArgs()
 	rows, err := queries.GetUnmatchedDeposits(ctx, sqlitestore.GetUnmatchedDepositsParams{
-		Limit:  int64(limit),
-		Offset: int64(offset),
+		Sort:         string(page.Sort),
+		Address:      filter.Address,
+		Since:        unixOrNil(filter.Since),
+		Until:        unixOrNil(filter.Until),
+		MinAmountWei: amountOrNil(filter.MinAmountWei),
+		MaxAmountWei: amountOrNil(filter.MaxAmountWei),
+		CursorID:     cursorID,
+		CursorKey:    cursorKey,
+		// Fetch one extra row to find out whether there is a next page
+		Limit: int64(page.Limit) + 1,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{Sort: page.Sort, Key: last.SortKey, ID: last.ID}
 	}
 
 	var deposits []UnmatchedDeposit
@@ -119,25 +399,106 @@ func GetUnmatchedDeposits(ctx context.Context, db *sql.DB, limit, offset int) ([
 
 		deposit := UnmatchedDeposit{
 			ID:               row.ID,
-			FromAddress:      "0x" + hex.EncodeToString(row.FromAddress),
-			ToAddress:        "0x" + hex.EncodeToString(row.ToAddress),
+			FromAddress:      hexString(row.FromAddress),
+			ToAddress:        hexString(row.ToAddress),
 			Amount:           row.Amount,
+			AmountWei:        weiString(row.AmountWei),
 			L1BlockNumber:    row.L1BlockNumber,
 			L1Timestamp:      time.Unix(row.L1Timestamp, 0),
 			TimeSinceSeconds: timeSince,
-			TxHashL1:         "0x" + hex.EncodeToString(row.TxHashL1),
+			TxHashL1:         hexString(row.TxHashL1),
 		}
 		deposits = append(deposits, deposit)
 	}
 
-	return deposits, nil
+	return deposits, next, nil
 }
 
 // GetTotalUnmatchedDeposits returns the total number of unmatched deposits
-func GetTotalUnmatchedDeposits(ctx context.Context, db *sql.DB) (int, error) {
-	queries := sqlitestore.New(db)
+func GetTotalUnmatchedDeposits(ctx context.Context, db *sql.DB, filter DepositFilter) (int, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	count, err := queries.GetTotalUnmatchedDeposits(ctx, sqlitestore.GetTotalUnmatchedDepositsParams{
+		Address:      filter.Address,
+		Since:        unixOrNil(filter.Since),
+		Until:        unixOrNil(filter.Until),
+		MinAmountWei: amountOrNil(filter.MinAmountWei),
+		MaxAmountWei: amountOrNil(filter.MaxAmountWei),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// GetOrphanedFinalizations returns a page of L2 deposit finalizations without
+// a matching L1 deposit, and the cursor of the next page or nil if this is the
+// last one. The confirmation time bounds of the filter are ignored.
+func GetOrphanedFinalizations(ctx context.Context, db *sql.DB, filter DepositFilter, page PageRequest) ([]OrphanedFinalization, *Cursor, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	cursorID, cursorKey := page.cursorArgs()
+	rows, err := queries.GetOrphanedFinalizations(ctx, sqlitestore.GetOrphanedFinalizationsParams{
+		Sort:         string(page.Sort),
+		Address:      filter.Address,
+		Since:        unixOrNil(filter.Since),
+		Until:        unixOrNil(filter.Until),
+		MinAmountWei: amountOrNil(filter.MinAmountWei),
+		MaxAmountWei: amountOrNil(filter.MaxAmountWei),
+		CursorID:     cursorID,
+		CursorKey:    cursorKey,
+		// Fetch one extra row to find out whether there is a next page
+		Limit: int64(page.Limit) + 1,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	count, err := queries.GetTotalUnmatchedDeposits(ctx)
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{Sort: page.Sort, Key: last.SortKey, ID: last.ID}
+	}
+
+	var finalizations []OrphanedFinalization
+	for _, row := range rows {
+		var timeSince int64
+		switch v := row.TimeSinceSeconds.(type) {
+		case int64:
+			timeSince = v
+		case float64:
+			timeSince = int64(v)
+		}
+
+		finalizations = append(finalizations, OrphanedFinalization{
+			ID:               row.ID,
+			FromAddress:      hexString(row.FromAddress),
+			ToAddress:        hexString(row.ToAddress),
+			Amount:           row.Amount,
+			AmountWei:        weiString(row.AmountWei),
+			L2BlockNumber:    row.L2BlockNumber,
+			L2Timestamp:      time.Unix(row.L2Timestamp, 0),
+			TimeSinceSeconds: timeSince,
+			TxHashL2:         hexString(row.TxHashL2),
+		})
+	}
+
+	return finalizations, next, nil
+}
+
+// GetTotalOrphanedFinalizations returns the number of L2 deposit finalizations without a matching L1 deposit
+func GetTotalOrphanedFinalizations(ctx context.Context, db *sql.DB, filter DepositFilter) (int, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	count, err := queries.GetTotalOrphanedFinalizations(ctx, sqlitestore.GetTotalOrphanedFinalizationsParams{
+		Address:      filter.Address,
+		Since:        unixOrNil(filter.Since),
+		Until:        unixOrNil(filter.Until),
+		MinAmountWei: amountOrNil(filter.MinAmountWei),
+		MaxAmountWei: amountOrNil(filter.MaxAmountWei),
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -145,20 +506,34 @@ func GetTotalUnmatchedDeposits(ctx context.Context, db *sql.DB) (int, error) {
 	return int(count), nil
 }
 
+// BridgeStats holds aggregate statistics about the bridge
+type BridgeStats struct {
+	TotalMatched              int     `json:"total_matched"`
+	PendingDeposits           int     `json:"pending_deposits"`
+	TotalBridgedETH           float64 `json:"-"`
+	AvgConfirmationSeconds    float64 `json:"avg_confirmation_seconds"`
+	MinConfirmationSeconds    float64 `json:"min_confirmation_seconds"`
+	MaxConfirmationSeconds    float64 `json:"max_confirmation_seconds"`
+	LatestL1Block             int     `json:"latest_l1_block"`
+	LatestL2Block             int     `json:"latest_l2_block"`
+	SecondsSinceLatestL1Block float64 `json:"seconds_since_latest_l1_block"`
+	SecondsSinceLatestL2Block float64 `json:"seconds_since_latest_l2_block"`
+}
+
 // GetBridgeStats returns statistics about the bridge
-func GetBridgeStats(ctx context.Context, db *sql.DB) (map[string]interface{}, error) {
-	queries := sqlitestore.New(db)
+func GetBridgeStats(ctx context.Context, db *sql.DB) (BridgeStats, error) {
+	queries := sqlitestore.NewTraced(db)
 
 	// Get main bridge stats
 	stats, err := queries.GetBridgeStats(ctx)
 	if err != nil {
-		return nil, err
+		return BridgeStats{}, err
 	}
 
 	// Get pending deposits count
 	pendingDeposits, err := queries.GetPendingDeposits(ctx)
 	if err != nil {
-		return nil, err
+		return BridgeStats{}, err
 	}
 
 	// Get latest L1 block info
@@ -169,7 +544,7 @@ func GetBridgeStats(ctx context.Context, db *sql.DB) (map[string]interface{}, er
 			// Create a zero struct
 			latestL1Block = sqlitestore.GetLatestL1BlockRow{}
 		} else {
-			return nil, err
+			return BridgeStats{}, err
 		}
 	}
 
@@ -181,7 +556,7 @@ func GetBridgeStats(ctx context.Context, db *sql.DB) (map[string]interface{}, er
 			// Create a zero struct
 			latestL2Block = sqlitestore.GetLatestL2BlockRow{}
 		} else {
-			return nil, err
+			return BridgeStats{}, err
 		}
 	}
 
@@ -236,19 +611,131 @@ func GetBridgeStats(ctx context.Context, db *sql.DB) (map[string]interface{}, er
 		l2BlockNum = int(*latestL2Block.BlockNumber)
 	}
 
-	// Create a map with the results
-	result := map[string]interface{}{
-		"total_matched":     int(stats.TotalMatched),
-		"avg_time_diff":     avgTimeDiff,
-		"min_time_diff":     minTimeDiff,
-		"max_time_diff":     maxTimeDiff,
-		"total_bridged_eth": totalBridgedEth,
-		"pending_deposits":  int(pendingDeposits),
-		"latest_l1_block":   l1BlockNum,
-		"latest_l2_block":   l2BlockNum,
-		"l1_time_since":     l1TimeSince,
-		"l2_time_since":     l2TimeSince,
+	return BridgeStats{
+		TotalMatched:              int(stats.TotalMatched),
+		PendingDeposits:           int(pendingDeposits),
+		TotalBridgedETH:           totalBridgedEth,
+		AvgConfirmationSeconds:    avgTimeDiff,
+		MinConfirmationSeconds:    minTimeDiff,
+		MaxConfirmationSeconds:    maxTimeDiff,
+		LatestL1Block:             l1BlockNum,
+		LatestL2Block:             l2BlockNum,
+		SecondsSinceLatestL1Block: l1TimeSince,
+		SecondsSinceLatestL2Block: l2TimeSince,
+	}, nil
+}
+
+// GetTotalBridgedWei returns the exact sum of all matched deposit amounts in wei
+func GetTotalBridgedWei(ctx context.Context, db *sql.DB) (*big.Int, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	amounts, err := queries.GetMatchedAmountsWei(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	total := new(big.Int)
+	for _, amount := range amounts {
+		total.Add(total, new(big.Int).SetBytes(amount))
+	}
+
+	return total, nil
+}
+
+// GetDepositTimings returns every deposit initiated before until that was
+// not yet finalized at since, which covers all deposits initiated or pending
+// in [since, until)
+func GetDepositTimings(ctx context.Context, db *sql.DB, since, until time.Time) ([]DepositTiming, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	rows, err := queries.GetDepositTimings(ctx, sqlitestore.GetDepositTimingsParams{
+		Since: since.Unix(),
+		Until: until.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timings := make([]DepositTiming, 0, len(rows))
+	for _, row := range rows {
+		timings = append(timings, DepositTiming{
+			L1Timestamp: row.L1Timestamp,
+			L2Timestamp: row.L2Timestamp,
+			Amount:      row.Amount,
+		})
+	}
+
+	return timings, nil
+}
+
+// AmbiguousGroup is a set of L1 deposits and L2 finalizations sharing the same
+// matching hash, with more than one event on either side
+type AmbiguousGroup struct {
+	MatchingHash   string
+	FromAddress    string
+	Amount         float64
+	AmountWei      string
+	L1Count        int
+	L1Unmatched    int
+	L2Count        int
+	L2Unmatched    int
+	FirstTimestamp time.Time
+	LastTimestamp  time.Time
+	// MinConfidence is the lowest confidence of the group matches, nil when none is matched
+	MinConfidence *float64
+}
+
+// GetAmbiguousGroups returns a page of the ambiguous matching groups, most
+// recent first, and the cursor of the next page or nil if this is the last one
+func GetAmbiguousGroups(ctx context.Context, db *sql.DB, page PageRequest) ([]AmbiguousGroup, *Cursor, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	cursorID, cursorKey := page.cursorArgs()
+	rows, err := queries.GetAmbiguousGroups(ctx, sqlitestore.GetAmbiguousGroupsParams{
+		CursorID:  cursorID,
+		CursorKey: cursorKey,
+		// Fetch one extra row to find out whether there is a next page
+		Limit: int64(page.Limit) + 1,
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return result, nil
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{Sort: page.Sort, Key: last.LastTimestamp, ID: last.ID}
+	}
+
+	groups := make([]AmbiguousGroup, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, AmbiguousGroup{
+			MatchingHash:   hexString(row.MatchingHash),
+			FromAddress:    hexString(row.FromAddress),
+			Amount:         row.Amount,
+			AmountWei:      weiString(row.AmountWei),
+			L1Count:        int(row.L1Count),
+			L1Unmatched:    int(row.L1Unmatched),
+			L2Count:        int(row.L2Count),
+			L2Unmatched:    int(row.L2Unmatched),
+			FirstTimestamp: time.Unix(row.FirstTimestamp, 0),
+			LastTimestamp:  time.Unix(row.LastTimestamp, 0),
+			MinConfidence:  row.MinConfidence,
+		})
+	}
+
+	return groups, next, nil
+}
+
+// GetTotalAmbiguousGroups returns the number of ambiguous matching groups
+func GetTotalAmbiguousGroups(ctx context.Context, db *sql.DB) (int, error) {
+	queries := sqlitestore.NewTraced(db)
+
+	count, err := queries.GetTotalAmbiguousGroups(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
 }
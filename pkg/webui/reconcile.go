@@ -0,0 +1,189 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+)
+
+const (
+	// DefaultReconciliationWindows is the number of windows shown when no range is given
+	DefaultReconciliationWindows = 14
+	// MaxReconciliationWindows bounds the number of windows of a reconciliation
+	MaxReconciliationWindows = 1000
+)
+
+// reconciliationWindows are the accepted window sizes
+var reconciliationWindows = map[string]time.Duration{
+	"hour": time.Hour,
+	"day":  24 * time.Hour,
+}
+
+// ValueTotal is the number and exact value of a set of bridge events
+type ValueTotal struct {
+	Count int
+	Wei   *big.Int
+}
+
+func (v *ValueTotal) add(amountWei []byte) {
+	v.Count++
+	v.Wei.Add(v.Wei, new(big.Int).SetBytes(amountWei))
+}
+
+// ReconciliationWindow compares the value initiated on L1 with the value
+// finalized on L2 during a time window. Deposits initiated near the end of a
+// window are usually finalized in the next one, so the two totals rarely
+// match exactly. The value still in flight explains the L1 side, the orphaned
+// finalizations are L2 value without a visible L1 lock.
+type ReconciliationWindow struct {
+	Start time.Time
+	End   time.Time
+	// Initiated are the L1 deposits of the window
+	Initiated ValueTotal
+	// Finalized are the L2 finalizations of the window
+	Finalized ValueTotal
+	// InFlight are the L1 deposits of the window not finalized on L2 yet
+	InFlight ValueTotal
+	// Orphaned are the L2 finalizations of the window without a matching L1 deposit
+	Orphaned ValueTotal
+}
+
+func newReconciliationWindow(start, end time.Time) ReconciliationWindow {
+	return ReconciliationWindow{
+		Start:     start,
+		End:       end,
+		Initiated: ValueTotal{Wei: new(big.Int)},
+		Finalized: ValueTotal{Wei: new(big.Int)},
+		InFlight:  ValueTotal{Wei: new(big.Int)},
+		Orphaned:  ValueTotal{Wei: new(big.Int)},
+	}
+}
+
+// DifferenceWei is the value initiated on L1 minus the value finalized on L2
+func (w ReconciliationWindow) DifferenceWei() *big.Int {
+	return new(big.Int).Sub(w.Initiated.Wei, w.Finalized.Wei)
+}
+
+// Discrepancy reports whether value was finalized on L2 without a matching L1 deposit
+func (w ReconciliationWindow) Discrepancy() bool {
+	return w.Orphaned.Count > 0
+}
+
+// Reconciliation holds the reconciliation windows of a time range, newest first,
+// and their sum
+type Reconciliation struct {
+	Window  string
+	Windows []ReconciliationWindow
+	Total   ReconciliationWindow
+}
+
+// ReconciliationQuery is a parsed reconciliation request
+type ReconciliationQuery struct {
+	// Window is the name of the window size, hour or day
+	Window string
+	Since  time.Time
+	Until  time.Time
+}
+
+// ParseReconciliationQuery reads the window, since and until parameters.
+// Since is rounded down to a window boundary and defaults to
+// DefaultReconciliationWindows windows before until, which defaults to now.
+func ParseReconciliationQuery(r *http.Request, now time.Time) (ReconciliationQuery, error) {
+	values := r.URL.Query()
+	q := ReconciliationQuery{Window: "day", Until: now}
+
+	if v := values.Get("window"); v != "" {
+		if _, ok := reconciliationWindows[v]; !ok {
+			return q, fmt.Errorf("window must be hour or day")
+		}
+		q.Window = v
+	}
+	size := reconciliationWindows[q.Window]
+
+	until, err := parseTimeParam(values.Get("until"), "until")
+	if err != nil {
+		return q, err
+	}
+	if until != nil {
+		q.Until = *until
+	}
+
+	since, err := parseTimeParam(values.Get("since"), "since")
+	if err != nil {
+		return q, err
+	}
+	if since != nil {
+		q.Since = since.Truncate(size)
+	} else {
+		q.Since = q.Until.Add(-time.Duration(DefaultReconciliationWindows-1) * size).Truncate(size)
+	}
+
+	if !q.Since.Before(q.Until) {
+		return q, fmt.Errorf("since must be before until")
+	}
+	if q.Until.Sub(q.Since) > MaxReconciliationWindows*size {
+		return q, fmt.Errorf("the range must span at most %d windows", MaxReconciliationWindows)
+	}
+	return q, nil
+}
+
+// GetReconciliation sums the value initiated on L1 and finalized on L2 per
+// window in [since, until). Amounts are summed exactly in wei.
+func GetReconciliation(ctx context.Context, db *sql.DB, q ReconciliationQuery) (Reconciliation, error) {
+	queries := sqlitestore.NewTraced(db)
+	size := reconciliationWindows[q.Window]
+
+	result := Reconciliation{Window: q.Window, Total: newReconciliationWindow(q.Since, q.Until)}
+	for start := q.Since; start.Before(q.Until); start = start.Add(size) {
+		end := start.Add(size)
+		if end.After(q.Until) {
+			end = q.Until
+		}
+		result.Windows = append(result.Windows, newReconciliationWindow(start, end))
+	}
+	window := func(timestamp int64) *ReconciliationWindow {
+		return &result.Windows[int(time.Unix(timestamp, 0).Sub(q.Since)/size)]
+	}
+
+	l1Rows, err := queries.ListL1AmountsInRange(ctx, sqlitestore.ListL1AmountsInRangeParams{
+		Since: q.Since.Unix(),
+		Until: q.Until.Unix(),
+	})
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	for _, row := range l1Rows {
+		for _, w := range []*ReconciliationWindow{window(row.BlockTimestamp), &result.Total} {
+			w.Initiated.add(row.AmountWei)
+			if !row.Matched {
+				w.InFlight.add(row.AmountWei)
+			}
+		}
+	}
+
+	l2Rows, err := queries.ListL2AmountsInRange(ctx, sqlitestore.ListL2AmountsInRangeParams{
+		Since: q.Since.Unix(),
+		Until: q.Until.Unix(),
+	})
+	if err != nil {
+		return Reconciliation{}, err
+	}
+	for _, row := range l2Rows {
+		for _, w := range []*ReconciliationWindow{window(row.BlockTimestamp), &result.Total} {
+			w.Finalized.add(row.AmountWei)
+			if !row.Matched {
+				w.Orphaned.add(row.AmountWei)
+			}
+		}
+	}
+
+	// Newest first, like the deposit lists
+	slices.Reverse(result.Windows)
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/security"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RecentSecurityFindings is the number of findings listed on the dashboard
+const RecentSecurityFindings = 20
+
+// securityKindNames are the labels of the finding kinds
+var securityKindNames = map[string]string{
+	security.Upgraded:             "Upgraded",
+	security.AdminChanged:         "Admin changed",
+	security.OwnershipTransferred: "Ownership transferred",
+	security.Paused:               "Paused",
+	security.Unpaused:             "Unpaused",
+	security.ConfigUpdate:         "Config update",
+	security.Implementation:       "Implementation",
+	security.Admin:                "Proxy admin",
+}
+
+// SecurityFinding is an entry of the audit trail of the bridge contracts on L1
+type SecurityFinding struct {
+	ID           int64
+	ObservedAt   time.Time
+	BlockNumber  int64
+	Contract     string
+	ContractName string
+	Kind         string
+	KindName     string
+	Severity     string
+	Detail       string
+	// TxHash is empty for findings read from storage or paused()
+	TxHash string
+	// Value is the new implementation, admin or owner, empty when there is none
+	Value string
+}
+
+func newSecurityFinding(row sqlitestore.SecurityFinding) SecurityFinding {
+	finding := SecurityFinding{
+		ID:           row.ID,
+		ObservedAt:   time.Unix(row.ObservedAt, 0),
+		BlockNumber:  row.BlockNumber,
+		Contract:     common.BytesToAddress(row.Contract).Hex(),
+		ContractName: row.ContractName,
+		Kind:         row.Kind,
+		KindName:     securityKindNames[row.Kind],
+		Severity:     row.Severity,
+		Detail:       row.Detail,
+	}
+	if row.TxHash != nil {
+		finding.TxHash = common.BytesToHash(row.TxHash).Hex()
+	}
+	if row.Value != nil {
+		finding.Value = common.BytesToAddress(row.Value).Hex()
+	}
+	return finding
+}
+
+// GetPause returns the finding that paused the bridge, or nil when the bridge
+// is not paused
+func GetPause(ctx context.Context, db *sql.DB) (*SecurityFinding, error) {
+	row, err := sqlitestore.NewTraced(db).GetLatestPauseFinding(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if row.Kind != security.Paused {
+		return nil, nil
+	}
+	finding := newSecurityFinding(row)
+	return &finding, nil
+}
+
+// GetSecurityFindings returns a page of the audit trail, newest first, and
+// the cursor of the next page or nil if this is the last one
+func GetSecurityFindings(ctx context.Context, db *sql.DB, page PageRequest) ([]SecurityFinding, *Cursor, error) {
+	cursorID, cursorKey := page.cursorArgs()
+	rows, err := sqlitestore.NewTraced(db).ListSecurityFindings(ctx, sqlitestore.ListSecurityFindingsParams{
+		CursorID:  cursorID,
+		CursorKey: cursorKey,
+		// Fetch one extra row to find out whether there is a next page
+		Limit: int64(page.Limit) + 1,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		last := rows[len(rows)-1]
+		next = &Cursor{Sort: page.Sort, Key: last.ObservedAt, ID: last.ID}
+	}
+
+	findings := make([]SecurityFinding, 0, len(rows))
+	for _, row := range rows {
+		findings = append(findings, newSecurityFinding(row))
+	}
+	return findings, next, nil
+}
+
+// CountSecurityFindings returns the length of the audit trail
+func CountSecurityFindings(ctx context.Context, db *sql.DB) (int, error) {
+	count, err := sqlitestore.NewTraced(db).CountSecurityFindings(ctx)
+	return int(count), err
+}
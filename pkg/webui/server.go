@@ -9,32 +9,42 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
-	"math"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Golem-Base/bridgette/pkg/events"
 	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 const (
-	// ItemsPerPage defines how many deposits to show per page
+	// ItemsPerPage defines how many deposits to show per page by default
 	ItemsPerPage = 10
+	// MaxItemsPerPage is the largest page size of the dashboard sections
+	MaxItemsPerPage = 100
 )
 
+// PageSizes are the page sizes offered in the dashboard sections
+var PageSizes = []int{ItemsPerPage, 25, 50, MaxItemsPerPage}
+
 // Server represents the web UI server
 type Server struct {
 	db         *sql.DB
+	bus        *events.Bus
 	logger     *slog.Logger
 	addr       string
 	pathPrefix string
 }
 
-// NewServer creates a new web UI server
-func NewServer(db *sql.DB, logger *slog.Logger, addr string, pathPrefix string) *Server {
+// NewServer creates a new web UI server. Indexer events published on bus are
+// pushed to the dashboard over server-sent events.
+func NewServer(db *sql.DB, bus *events.Bus, logger *slog.Logger, addr string, pathPrefix string) *Server {
 	return &Server{
 		db:         db,
+		bus:        bus,
 		logger:     logger,
 		addr:       addr,
 		pathPrefix: pathPrefix,
@@ -66,29 +76,93 @@ func (s *Server) prefixPath(path string) string {
 	return prefix + path
 }
 
-// Start starts the web UI server
-func (s *Server) Start(ctx context.Context) error {
+// handle registers a handler for a "METHOD /path" pattern, applying the path
+// prefix and wrapping the handler in a span named after the route
+func (s *Server) handle(mux *http.ServeMux, pattern string, handler http.HandlerFunc) {
+	method, path, _ := strings.Cut(pattern, " ")
+	mux.Handle(method+" "+s.prefixPath(path), otelhttp.NewHandler(handler, pattern))
+}
+
+// Handler returns the HTTP handler serving the web UI and the API
+func (s *Server) Handler() http.Handler {
 	mux := http.NewServeMux()
 
 	// Register handlers
-	mux.HandleFunc("GET "+s.prefixPath("/"), s.handleIndex)
+	s.handle(mux, "GET /", s.handleIndex)
 
 	// Dashboard component handlers
-	mux.HandleFunc("GET "+s.prefixPath("/dashboard/metrics"), s.handleDashboardMetrics)
-	mux.HandleFunc("GET "+s.prefixPath("/dashboard/performance"), s.handleBridgePerformance)
-	mux.HandleFunc("GET "+s.prefixPath("/dashboard/unmatched"), s.handleUnmatchedDepositsSection)
-	mux.HandleFunc("GET "+s.prefixPath("/dashboard/timeline"), s.handleDepositsTimelineSection)
+	s.handle(mux, "GET /dashboard/metrics", s.handleDashboardMetrics)
+	s.handle(mux, "GET /dashboard/performance", s.handleBridgePerformance)
+	s.handle(mux, "GET /dashboard/unmatched", s.handleUnmatchedDepositsSection)
+	s.handle(mux, "GET /dashboard/timeline", s.handleDepositsTimelineSection)
+	s.handle(mux, "GET /dashboard/orphaned", s.handleOrphanedFinalizationsSection)
+	s.handle(mux, "GET /dashboard/reconciliation", s.handleReconciliationSection)
+	s.handle(mux, "GET /dashboard/solvency", s.handleSolvencySection)
+	s.handle(mux, "GET /dashboard/latency", s.handleLatencySection)
+	s.handle(mux, "GET /dashboard/incidents", s.handleIncidentsSection)
+	s.handle(mux, "GET /dashboard/batcher", s.handleBatcherSection)
+	s.handle(mux, "GET /dashboard/dispute-games", s.handleDisputeGamesSection)
+	s.handle(mux, "GET /dashboard/security", s.handleSecuritySection)
+	s.handle(mux, "GET /dashboard/pause-banner", s.handlePauseBanner)
+
+	// Server-sent events stream. It is not traced as the request lasts as
+	// long as the browser tab stays open.
+	mux.Handle("GET "+s.prefixPath("/events"), http.HandlerFunc(s.handleEvents))
+
+	// Deposit lookup pages
+	s.handle(mux, "GET /search", s.handleSearch)
+	s.handle(mux, "GET /deposit/{txhash}", s.handleDepositLookup)
+	s.handle(mux, "GET /address/{addr}", s.handleAddressLookup)
+	s.handle(mux, "GET /incident/{id}", s.handleIncidentLookup)
+
+	// Matching review
+	s.handle(mux, "GET /matches/ambiguous", s.handleAmbiguousGroups)
 
 	// API endpoints
-	mux.HandleFunc("GET "+s.prefixPath("/api/chart-data"), s.handleTimeSeriesData)
+	s.handle(mux, "GET /api/chart-data", s.handleTimeSeriesData)
+
+	// Versioned JSON API
+	s.handle(mux, "GET /api/v1/deposits/matched", s.handleAPIMatchedDeposits)
+	s.handle(mux, "GET /api/v1/deposits/unmatched", s.handleAPIUnmatchedDeposits)
+	s.handle(mux, "GET /api/v1/deposits/{id}", s.handleAPIDeposit)
+	s.handle(mux, "GET /api/v1/deposits/by-tx/{hash}", s.handleAPIDepositsByTxHash)
+	s.handle(mux, "GET /api/v1/finalizations/orphaned", s.handleAPIOrphanedFinalizations)
+	s.handle(mux, "GET /api/v1/reconciliation", s.handleAPIReconciliation)
+	s.handle(mux, "GET /api/v1/solvency", s.handleAPISolvency)
+	s.handle(mux, "GET /api/v1/latency", s.handleAPILatency)
+	s.handle(mux, "GET /api/v1/incidents", s.handleAPIIncidents)
+	s.handle(mux, "GET /api/v1/incidents/{id}/deposits", s.handleAPIIncidentDeposits)
+	s.handle(mux, "GET /api/v1/batcher", s.handleAPIBatcher)
+	s.handle(mux, "GET /api/v1/dispute-games", s.handleAPIDisputeGames)
+	s.handle(mux, "GET /api/v1/security-events", s.handleAPISecurityEvents)
+	s.handle(mux, "GET /api/v1/events", s.handleAPIEventSources)
+	s.handle(mux, "GET /api/v1/events/{source}", s.handleAPIIndexedEvents)
+	s.handle(mux, "GET /api/v1/stats", s.handleAPIStats)
+	s.handle(mux, "GET /api/v1/status", s.handleAPIStatus)
+	s.handle(mux, "GET /api/v1/export", s.handleAPIExport)
+	s.handle(mux, "GET /api/v1/matches/ambiguous", s.handleAPIAmbiguousGroups)
+
+	// Grafana JSON datasource
+	s.handle(mux, "GET /grafana/{$}", s.handleGrafanaTest)
+	s.handle(mux, "POST /grafana/search", s.handleGrafanaSearch)
+	s.handle(mux, "POST /grafana/query", s.handleGrafanaQuery)
+	s.handle(mux, "POST /grafana/annotations", s.handleGrafanaAnnotations)
 
 	// Static files
 	staticPath := s.prefixPath("/static/")
 	mux.Handle("GET "+staticPath, http.StripPrefix(staticPath, createStaticHandler()))
 
+	return mux
+}
+
+// Start starts the web UI server
+func (s *Server) Start(ctx context.Context) error {
 	server := &http.Server{
 		Addr:    s.addr,
-		Handler: mux,
+		Handler: s.Handler(),
+		// Derive request contexts from ctx so that open event streams end
+		// on shutdown instead of blocking it
+		BaseContext: func(net.Listener) context.Context { return ctx },
 	}
 
 	s.logger.Info("starting web UI server", "addr", s.addr, "pathPrefix", s.pathPrefix)
@@ -167,7 +241,7 @@ func (s *Server) handleTimeSeriesData(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Create a queries object using the sqlitestore package
-	queries := sqlitestore.New(s.db)
+	queries := sqlitestore.NewTraced(s.db)
 
 	// Get the time series chart data
 	data, err := queries.GetTimeSeriesChartData(r.Context(), int64(limit))
@@ -222,34 +296,35 @@ func (s *Server) handleTimeSeriesData(w http.ResponseWriter, r *http.Request) {
 
 // handleUnmatchedDepositsSection handles the unmatched deposits section component
 func (s *Server) handleUnmatchedDepositsSection(w http.ResponseWriter, r *http.Request) {
-	page := 1
-	pageStr := r.URL.Query().Get("page")
-	if pageStr != "" {
-		parsedPage, err := strconv.Atoi(pageStr)
-		if err == nil && parsedPage > 0 {
-			page = parsedPage
-		}
+	q, err := ParseListQuery(r, ItemsPerPage, MaxItemsPerPage)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	offset := (page - 1) * ItemsPerPage
-
-	deposits, err := GetUnmatchedDeposits(r.Context(), s.db, ItemsPerPage, offset)
+	deposits, next, err := GetUnmatchedDeposits(r.Context(), s.db, q.Filter, q.Page)
 	if err != nil {
 		s.logger.Error("failed to get unmatched deposits", "error", err)
 		http.Error(w, "Failed to get unmatched deposits", http.StatusInternalServerError)
 		return
 	}
 
-	totalCount, err := GetTotalUnmatchedDeposits(r.Context(), s.db)
+	totalCount, err := GetTotalUnmatchedDeposits(r.Context(), s.db, q.Filter)
 	if err != nil {
 		s.logger.Error("failed to get total unmatched count", "error", err)
 		http.Error(w, "Failed to get total unmatched count", http.StatusInternalServerError)
 		return
 	}
 
-	totalPages := int(math.Ceil(float64(totalCount) / float64(ItemsPerPage)))
+	model, err := GetETAModel(r.Context(), s.db, time.Now())
+	if err != nil {
+		s.logger.Error("failed to get ETA model", "error", err)
+		http.Error(w, "Failed to get ETA model", http.StatusInternalServerError)
+		return
+	}
+	model.LoadUnmatchedDepositETAs(deposits)
 
-	component := UnmatchedDepositsSection(deposits, page, totalPages, s.pathPrefix)
+	component := UnmatchedDepositsSection(deposits, totalCount, q, encodeCursor(next), s.pathPrefix)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		s.logger.Error("failed to render unmatched deposits section", "error", err)
@@ -258,36 +333,230 @@ func (s *Server) handleUnmatchedDepositsSection(w http.ResponseWriter, r *http.R
 	}
 }
 
+// handleOrphanedFinalizationsSection handles the orphaned L2 finalizations section component
+func (s *Server) handleOrphanedFinalizationsSection(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseListQuery(r, ItemsPerPage, MaxItemsPerPage)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	finalizations, next, err := GetOrphanedFinalizations(r.Context(), s.db, q.Filter, q.Page)
+	if err != nil {
+		s.logger.Error("failed to get orphaned finalizations", "error", err)
+		http.Error(w, "Failed to get orphaned finalizations", http.StatusInternalServerError)
+		return
+	}
+
+	totalCount, err := GetTotalOrphanedFinalizations(r.Context(), s.db, q.Filter)
+	if err != nil {
+		s.logger.Error("failed to get total orphaned count", "error", err)
+		http.Error(w, "Failed to get total orphaned count", http.StatusInternalServerError)
+		return
+	}
+
+	component := OrphanedFinalizationsSection(finalizations, totalCount, q, encodeCursor(next), s.pathPrefix)
+	err = component.Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render orphaned finalizations section", "error", err)
+		http.Error(w, "Failed to render orphaned finalizations section", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleReconciliationSection handles the value reconciliation section component
+func (s *Server) handleReconciliationSection(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseReconciliationQuery(r, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	reconciliation, err := GetReconciliation(r.Context(), s.db, q)
+	if err != nil {
+		s.logger.Error("failed to get reconciliation", "error", err)
+		http.Error(w, "Failed to get reconciliation", http.StatusInternalServerError)
+		return
+	}
+
+	component := ReconciliationSection(reconciliation, s.pathPrefix)
+	err = component.Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render reconciliation section", "error", err)
+		http.Error(w, "Failed to render reconciliation section", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleSolvencySection handles the bridge solvency section component
+func (s *Server) handleSolvencySection(w http.ResponseWriter, r *http.Request) {
+	latest, err := GetLatestSolvencySample(r.Context(), s.db)
+	if err != nil {
+		s.logger.Error("failed to get latest solvency sample", "error", err)
+		http.Error(w, "Failed to get latest solvency sample", http.StatusInternalServerError)
+		return
+	}
+
+	component := SolvencySection(latest, s.pathPrefix)
+	err = component.Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render solvency section", "error", err)
+		http.Error(w, "Failed to render solvency section", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleLatencySection handles the latency by phase section component
+func (s *Server) handleLatencySection(w http.ResponseWriter, r *http.Request) {
+	since, until, err := ParseLatencyRange(r, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := GetLatencyReport(r.Context(), s.db, since, until)
+	if err != nil {
+		s.logger.Error("failed to get latency report", "error", err)
+		http.Error(w, "Failed to get latency report", http.StatusInternalServerError)
+		return
+	}
+
+	component := LatencySection(report, s.pathPrefix)
+	err = component.Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render latency section", "error", err)
+		http.Error(w, "Failed to render latency section", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleIncidentsSection handles the liveness incident timeline section component
+func (s *Server) handleIncidentsSection(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	since, until, err := ParseIncidentRange(r, now)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	timeline, err := GetIncidentTimeline(r.Context(), s.db, since, until, now)
+	if err != nil {
+		s.logger.Error("failed to get incidents", "error", err)
+		http.Error(w, "Failed to get incidents", http.StatusInternalServerError)
+		return
+	}
+
+	component := IncidentsSection(timeline, now, s.pathPrefix)
+	err = component.Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render incidents section", "error", err)
+		http.Error(w, "Failed to render incidents section", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleBatcherSection handles the batcher and data availability section component
+func (s *Server) handleBatcherSection(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	report, err := GetBatcherReport(r.Context(), s.db, now.Add(-DefaultBatcherRange), now)
+	if err != nil {
+		s.logger.Error("failed to get batcher report", "error", err)
+		http.Error(w, "Failed to get batcher report", http.StatusInternalServerError)
+		return
+	}
+
+	component := BatcherSection(report, now, s.pathPrefix)
+	err = component.Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render batcher section", "error", err)
+		http.Error(w, "Failed to render batcher section", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleDisputeGamesSection handles the dispute games section component
+func (s *Server) handleDisputeGamesSection(w http.ResponseWriter, r *http.Request) {
+	summary, err := GetDisputeGameSummary(r.Context(), s.db)
+	if err != nil {
+		s.logger.Error("failed to get dispute games", "error", err)
+		http.Error(w, "Failed to get dispute games", http.StatusInternalServerError)
+		return
+	}
+
+	component := DisputeGamesSection(summary, s.pathPrefix)
+	err = component.Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render dispute games section", "error", err)
+		http.Error(w, "Failed to render dispute games section", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleSecuritySection handles the security events section component
+func (s *Server) handleSecuritySection(w http.ResponseWriter, r *http.Request) {
+	total, err := CountSecurityFindings(r.Context(), s.db)
+	if err != nil {
+		s.logger.Error("failed to count security events", "error", err)
+		http.Error(w, "Failed to get security events", http.StatusInternalServerError)
+		return
+	}
+	findings, _, err := GetSecurityFindings(r.Context(), s.db, PageRequest{Sort: SortNewest, Limit: RecentSecurityFindings})
+	if err != nil {
+		s.logger.Error("failed to get security events", "error", err)
+		http.Error(w, "Failed to get security events", http.StatusInternalServerError)
+		return
+	}
+
+	component := SecuritySection(findings, total, s.pathPrefix)
+	err = component.Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render security section", "error", err)
+		http.Error(w, "Failed to render security section", http.StatusInternalServerError)
+		return
+	}
+}
+
+// handlePauseBanner handles the banner shown while the bridge is paused
+func (s *Server) handlePauseBanner(w http.ResponseWriter, r *http.Request) {
+	pause, err := GetPause(r.Context(), s.db)
+	if err != nil {
+		s.logger.Error("failed to get pause state", "error", err)
+		http.Error(w, "Failed to get pause state", http.StatusInternalServerError)
+		return
+	}
+
+	component := PauseBanner(pause, s.pathPrefix)
+	err = component.Render(r.Context(), w)
+	if err != nil {
+		s.logger.Error("failed to render pause banner", "error", err)
+		http.Error(w, "Failed to render pause banner", http.StatusInternalServerError)
+		return
+	}
+}
+
 // handleDepositsTimelineSection handles the deposits timeline section component
 func (s *Server) handleDepositsTimelineSection(w http.ResponseWriter, r *http.Request) {
-	page := 1
-	pageStr := r.URL.Query().Get("page")
-	if pageStr != "" {
-		parsedPage, err := strconv.Atoi(pageStr)
-		if err == nil && parsedPage > 0 {
-			page = parsedPage
-		}
+	q, err := ParseListQuery(r, ItemsPerPage, MaxItemsPerPage)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	offset := (page - 1) * ItemsPerPage
-
-	deposits, err := GetMatchedDeposits(r.Context(), s.db, ItemsPerPage, offset)
+	deposits, next, err := GetMatchedDeposits(r.Context(), s.db, q.Filter, q.Page)
 	if err != nil {
 		s.logger.Error("failed to get deposits", "error", err)
 		http.Error(w, "Failed to get deposits", http.StatusInternalServerError)
 		return
 	}
 
-	totalCount, err := GetTotalMatchedDeposits(r.Context(), s.db)
+	totalCount, err := GetTotalMatchedDeposits(r.Context(), s.db, q.Filter)
 	if err != nil {
 		s.logger.Error("failed to get total count", "error", err)
 		http.Error(w, "Failed to get total count", http.StatusInternalServerError)
 		return
 	}
 
-	totalPages := int(math.Ceil(float64(totalCount) / float64(ItemsPerPage)))
-
-	component := DepositsTimelineSection(deposits, page, totalPages, s.pathPrefix)
+	component := DepositsTimelineSection(deposits, totalCount, q, encodeCursor(next), s.pathPrefix)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		s.logger.Error("failed to render deposits timeline section", "error", err)
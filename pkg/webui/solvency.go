@@ -0,0 +1,125 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+)
+
+const (
+	// DefaultSolvencyRange is the range of samples shown when no since is given
+	DefaultSolvencyRange = 7 * 24 * time.Hour
+	// MaxSolvencySamples bounds the number of samples returned, the newest are kept
+	MaxSolvencySamples = 10000
+)
+
+// SolvencySample compares the ETH held by the bridge contracts on L1 with
+// the deposits not withdrawn yet, at an L1 block
+type SolvencySample struct {
+	BlockNumber  int64
+	BlockTime    time.Time
+	BalanceWei   *big.Int
+	DepositedWei *big.Int
+	WithdrawnWei *big.Int
+	GapEth       float64
+	// Alerting is set when the shortfall exceeded the monitor's threshold
+	Alerting bool
+}
+
+// ExpectedWei is the balance the bridge should hold: the deposits minus the withdrawals
+func (s SolvencySample) ExpectedWei() *big.Int {
+	return new(big.Int).Sub(s.DepositedWei, s.WithdrawnWei)
+}
+
+// GapWei is the balance minus the expected balance, negative when the bridge is short
+func (s SolvencySample) GapWei() *big.Int {
+	return new(big.Int).Sub(s.BalanceWei, s.ExpectedWei())
+}
+
+// BalanceEth is the balance in ETH, for charts
+func (s SolvencySample) BalanceEth() float64 {
+	eth, _ := new(big.Float).Quo(new(big.Float).SetInt(s.BalanceWei), big.NewFloat(1e18)).Float64()
+	return eth
+}
+
+func newSolvencySample(blockNumber, blockTime int64, balance, deposited, withdrawn []byte, gapEth float64, alerting bool) SolvencySample {
+	return SolvencySample{
+		BlockNumber:  blockNumber,
+		BlockTime:    time.Unix(blockTime, 0),
+		BalanceWei:   new(big.Int).SetBytes(balance),
+		DepositedWei: new(big.Int).SetBytes(deposited),
+		WithdrawnWei: new(big.Int).SetBytes(withdrawn),
+		GapEth:       gapEth,
+		Alerting:     alerting,
+	}
+}
+
+// ParseSolvencyRange reads the since and until parameters. Until defaults to
+// now and since to DefaultSolvencyRange before until.
+func ParseSolvencyRange(r *http.Request, now time.Time) (time.Time, time.Time, error) {
+	values := r.URL.Query()
+
+	until := now
+	t, err := parseTimeParam(values.Get("until"), "until")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if t != nil {
+		until = *t
+	}
+
+	since := until.Add(-DefaultSolvencyRange)
+	t, err = parseTimeParam(values.Get("since"), "since")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if t != nil {
+		since = *t
+	}
+
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("since must be before until")
+	}
+	return since, until, nil
+}
+
+// GetSolvencySamples returns the samples taken in [since, until), oldest
+// first. At most MaxSolvencySamples of the newest samples are returned.
+func GetSolvencySamples(ctx context.Context, db *sql.DB, since, until time.Time) ([]SolvencySample, error) {
+	rows, err := sqlitestore.NewTraced(db).ListSolvencySamples(ctx, sqlitestore.ListSolvencySamplesParams{
+		Since: since.Unix(),
+		Until: until.Unix(),
+		Limit: MaxSolvencySamples,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]SolvencySample, 0, len(rows))
+	for _, row := range rows {
+		samples = append(samples, newSolvencySample(row.BlockNumber, row.BlockTime, row.BalanceWei, row.DepositedWei, row.WithdrawnWei, row.GapEth, row.Alerting))
+	}
+	slices.Reverse(samples)
+	return samples, nil
+}
+
+// GetLatestSolvencySample returns the newest sample, or nil when the monitor
+// has not recorded any
+func GetLatestSolvencySample(ctx context.Context, db *sql.DB) (*SolvencySample, error) {
+	row, err := sqlitestore.NewTraced(db).GetLatestSolvencySample(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sample := newSolvencySample(row.BlockNumber, row.BlockTime, row.BalanceWei, row.DepositedWei, row.WithdrawnWei, row.GapEth, row.Alerting)
+	return &sample, nil
+}
@@ -0,0 +1,28 @@
+// Subscribes to the server-sent events stream and re-dispatches indexer events
+// as "bridgette:deposit", "bridgette:match", "bridgette:pointer",
+// "bridgette:solvency", "bridgette:incident", "bridgette:batcher",
+// "bridgette:dispute_game" and "bridgette:security" htmx triggers on the body. While the stream is
+// connected window.bridgetteLive is true, which pauses the polling triggers
+// kept as a fallback.
+window.bridgetteLive = false;
+
+function bridgetteLiveUpdates(url) {
+    if (!window.EventSource) {
+        return;
+    }
+
+    const source = new EventSource(url);
+    source.onopen = function () {
+        window.bridgetteLive = true;
+    };
+    source.onerror = function () {
+        // EventSource reconnects on its own, poll until it does
+        window.bridgetteLive = false;
+    };
+
+    ['deposit', 'match', 'pointer', 'solvency', 'incident', 'batcher', 'dispute_game', 'security'].forEach(function (type) {
+        source.addEventListener(type, function () {
+            htmx.trigger(document.body, 'bridgette:' + type);
+        });
+    });
+}
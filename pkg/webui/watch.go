@@ -0,0 +1,109 @@
+package webui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+)
+
+// WatchDatabase polls the database for changes committed by an indexer
+// running in another process and publishes them on the server's bus, so the
+// dashboard live updates keep working when the web UI runs on its own
+func (s *Server) WatchDatabase(ctx context.Context, interval time.Duration) error {
+	// Polling is not traced, it would produce a trace every interval
+	store := sqlitestore.New(s.db)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *sqlitestore.GetDepositCountsRow
+	pointers := make(map[string]int64)
+	// marks holds what identifies the latest rows of each monitor, the event
+	// is published when they changed since the previous poll
+	marks := make(map[events.Type]any)
+	changed := func(event events.Event, mark any) {
+		previous, ok := marks[event.Type]
+		marks[event.Type] = mark
+		if ok && previous != mark {
+			s.bus.Publish(event)
+		}
+	}
+
+	for {
+		counts, err := store.GetDepositCounts(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get deposit counts: %w", err)
+		}
+
+		blockPointers, err := store.ListBlockPointers(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list block pointers: %w", err)
+		}
+
+		// The first poll only records the current state
+		initialized := last != nil
+		if initialized {
+			if n := counts.L1Deposits - last.L1Deposits; n > 0 {
+				s.bus.Publish(events.Event{Type: events.Deposit, Chain: "l1", Count: int(n)})
+			}
+			if n := counts.MatchedDeposits - last.MatchedDeposits; n > 0 {
+				s.bus.Publish(events.Event{Type: events.Match, Count: int(n)})
+			}
+		}
+		last = &counts
+
+		for _, p := range blockPointers {
+			if p.BlockNumber == nil || pointers[p.Name] == *p.BlockNumber {
+				continue
+			}
+			pointers[p.Name] = *p.BlockNumber
+			if !initialized {
+				continue
+			}
+			chain, _, _ := strings.Cut(p.Name, "_")
+			s.bus.Publish(events.Event{Type: events.Pointer, Chain: chain, BlockNumber: uint64(*p.BlockNumber)})
+		}
+
+		sample, err := store.GetLatestSolvencySample(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to get latest solvency sample: %w", err)
+		}
+		changed(events.Event{Type: events.Solvency, Chain: "l1", BlockNumber: uint64(sample.BlockNumber)}, sample.BlockNumber)
+
+		incidents, err := store.CountLivenessIncidents(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count liveness incidents: %w", err)
+		}
+		changed(events.Event{Type: events.Incident, Chain: "l2"}, incidents)
+
+		batcherTx, err := store.GetLatestBatcherTransaction(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to get latest batcher transaction: %w", err)
+		}
+		changed(events.Event{Type: events.Batcher, Chain: "l1", BlockNumber: uint64(batcherTx.BlockNumber)}, batcherTx.ID)
+
+		games, err := store.CountDisputeGames(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count dispute games: %w", err)
+		}
+		changed(events.Event{Type: events.DisputeGame, Chain: "l1"}, games)
+
+		findings, err := store.CountSecurityFindings(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to count security findings: %w", err)
+		}
+		changed(events.Event{Type: events.Security, Chain: "l1"}, findings)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
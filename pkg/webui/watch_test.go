@@ -0,0 +1,126 @@
+package webui_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore"
+	"github.com/Golem-Base/bridgette/pkg/sqlitestore/sqlitetest"
+	"github.com/Golem-Base/bridgette/pkg/webui"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchDatabase(t *testing.T) {
+	db := sqlitetest.Open(t)
+	queries := sqlitestore.New(db)
+	bus := events.NewBus()
+	server := webui.NewServer(db, bus, slog.New(slog.NewTextHandler(io.Discard, nil)), "", "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	ch, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+	go server.WatchDatabase(ctx, 5*time.Millisecond)
+
+	// await returns the next event of a type, moving the pointer until then
+	// so that events published before the watcher recorded the initial
+	// state are not waited for
+	var block int64
+	await := func(eventType events.Type, change func()) events.Event {
+		t.Helper()
+		change()
+		for {
+			select {
+			case event := <-ch:
+				if event.Type == eventType {
+					return event
+				}
+			case <-time.After(20 * time.Millisecond):
+				block++
+				require.NoError(t, queries.UpdateBlockPointer(ctx, sqlitestore.UpdateBlockPointerParams{
+					BlockNumber: &block,
+					Name:        "l1_standard_bridge_eth_deposit_initiated_last_processed_block",
+				}))
+			case <-ctx.Done():
+				t.Fatalf("no %s event", eventType)
+			}
+		}
+	}
+	await(events.Pointer, func() {})
+
+	var l1ID int64
+	event := await(events.Deposit, func() {
+		id, err := queries.InsertL1StandardBridgeETHDepositInitiated(ctx, sqlitestore.InsertL1StandardBridgeETHDepositInitiatedParams{
+			TxHash:       []byte{1},
+			FromAddress:  []byte{1},
+			ToAddress:    []byte{1},
+			AmountWei:    make([]byte, 32),
+			Event:        []byte("{}"),
+			MatchingHash: []byte{1},
+		})
+		require.NoError(t, err)
+		l1ID = id
+	})
+	require.Equal(t, "l1", event.Chain)
+
+	// Matches involve both chains
+	event = await(events.Match, func() {
+		l2ID, err := queries.InsertL2StandardBridgeDepositFinalized(ctx, sqlitestore.InsertL2StandardBridgeDepositFinalizedParams{
+			TxHash:       []byte{2},
+			FromAddress:  []byte{1},
+			ToAddress:    []byte{1},
+			L1Token:      []byte{0},
+			AmountWei:    make([]byte, 32),
+			Event:        []byte("{}"),
+			MatchingHash: []byte{1},
+		})
+		require.NoError(t, err)
+		require.NoError(t, queries.UpdateL1DepositWithMatch(ctx, sqlitestore.UpdateL1DepositWithMatchParams{MatchedL2StandardBridgeDepositFinalizedID: &l2ID, ID: l1ID}))
+	})
+	require.Empty(t, event.Chain)
+	require.Equal(t, 1, event.Count)
+
+	event = await(events.Solvency, func() {
+		require.NoError(t, queries.InsertSolvencySample(ctx, sqlitestore.InsertSolvencySampleParams{
+			BlockNumber:  42,
+			BalanceWei:   make([]byte, 32),
+			DepositedWei: make([]byte, 32),
+			WithdrawnWei: make([]byte, 32),
+		}))
+	})
+	require.Equal(t, uint64(42), event.BlockNumber)
+
+	var incident int64
+	await(events.Incident, func() {
+		id, err := queries.InsertLivenessIncident(ctx, sqlitestore.InsertLivenessIncidentParams{Kind: "stall", StartedAt: 1})
+		require.NoError(t, err)
+		incident = id
+	})
+	// Ending an incident is published too
+	await(events.Incident, func() {
+		endedAt := int64(2)
+		require.NoError(t, queries.CloseLivenessIncident(ctx, sqlitestore.CloseLivenessIncidentParams{EndedAt: &endedAt, ID: incident}))
+	})
+
+	event = await(events.Batcher, func() {
+		require.NoError(t, queries.InsertBatcherTransaction(ctx, sqlitestore.InsertBatcherTransactionParams{BlockNumber: 43, TxHash: []byte{1}}))
+	})
+	require.Equal(t, uint64(43), event.BlockNumber)
+
+	await(events.DisputeGame, func() {
+		require.NoError(t, queries.InsertDisputeGame(ctx, sqlitestore.InsertDisputeGameParams{GameAddress: []byte{1}, RootClaim: []byte{2}, TxHash: []byte{3}}))
+	})
+	// Verifying a claim is published too
+	await(events.DisputeGame, func() {
+		valid := true
+		require.NoError(t, queries.UpdateDisputeGameOutputRoot(ctx, sqlitestore.UpdateDisputeGameOutputRootParams{ExpectedRoot: []byte{2}, Valid: &valid, ID: 1}))
+	})
+
+	await(events.Security, func() {
+		require.NoError(t, queries.InsertSecurityFinding(ctx, sqlitestore.InsertSecurityFindingParams{TxHash: []byte{1}, Contract: []byte{2}, Kind: "paused", Severity: "critical"}))
+	})
+}
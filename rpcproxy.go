@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/rpcproxy"
+	"github.com/urfave/cli/v2"
+)
+
+// rpcProxyCommand serves a JSON-RPC proxy in front of an execution endpoint
+// that injects faults, to exercise the indexer against a misbehaving node
+func rpcProxyCommand(log *slog.Logger) *cli.Command {
+	var target, addr string
+	var seed int64
+	var methods cli.StringSlice
+	var faults rpcproxy.Faults
+
+	return &cli.Command{
+		Name:  "rpc-proxy",
+		Usage: "Serve a JSON-RPC proxy in front of an execution endpoint that injects latency, errors, rate limits, truncated results, stale heads and reorgs",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "target",
+				Usage:       "The URL of the execution endpoint the calls are forwarded to",
+				EnvVars:     []string{"RPC_PROXY_TARGET"},
+				Required:    true,
+				Destination: &target,
+			},
+			&cli.StringFlag{
+				Name:        "addr",
+				Usage:       "The address the proxy listens on",
+				Value:       ":8645",
+				EnvVars:     []string{"RPC_PROXY_ADDR"},
+				Destination: &addr,
+			},
+			&cli.Int64Flag{
+				Name:        "seed",
+				Usage:       "The seed of the random source the faults are drawn from, the same calls see the same faults",
+				Value:       1,
+				EnvVars:     []string{"RPC_PROXY_SEED"},
+				Destination: &seed,
+			},
+			&cli.StringSliceFlag{
+				Name:        "methods",
+				Usage:       "The JSON-RPC methods faults are injected into (all methods when unset)",
+				EnvVars:     []string{"RPC_PROXY_METHODS"},
+				Destination: &methods,
+			},
+			&cli.DurationFlag{
+				Name:        "latency",
+				Usage:       "The delay added to every call",
+				Destination: &faults.Latency,
+			},
+			&cli.DurationFlag{
+				Name:        "latency-jitter",
+				Usage:       "The maximum random delay added on top of --latency",
+				Destination: &faults.LatencyJitter,
+			},
+			&cli.Float64Flag{
+				Name:        "error-rate",
+				Usage:       "The share of calls failing with a JSON-RPC error",
+				Destination: &faults.ErrorRate,
+			},
+			&cli.Float64Flag{
+				Name:        "rate-limit-rate",
+				Usage:       "The share of calls answered with HTTP 429 Too Many Requests",
+				Destination: &faults.RateLimitRate,
+			},
+			&cli.IntFlag{
+				Name:        "max-logs",
+				Usage:       "Reject eth_getLogs calls returning more logs than this, like providers limiting their results (disabled when 0)",
+				Destination: &faults.MaxLogs,
+			},
+			&cli.Float64Flag{
+				Name:        "truncate-rate",
+				Usage:       "The share of eth_getLogs calls silently returning only the first half of the logs",
+				Destination: &faults.TruncateRate,
+			},
+			&cli.Float64Flag{
+				Name:        "stale-head-rate",
+				Usage:       "The share of eth_blockNumber calls answered with a head --stale-head-blocks behind",
+				Destination: &faults.StaleHeadRate,
+			},
+			&cli.Uint64Flag{
+				Name:        "stale-head-blocks",
+				Usage:       "How far behind stale heads are",
+				Value:       5,
+				Destination: &faults.StaleHeadBlocks,
+			},
+			&cli.Float64Flag{
+				Name:        "reorg-rate",
+				Usage:       "The share of eth_getLogs calls answered from a fork without the logs of the latest --reorg-depth blocks",
+				Destination: &faults.ReorgRate,
+			},
+			&cli.Uint64Flag{
+				Name:        "reorg-depth",
+				Usage:       "How many of the latest blocks a reorg replaces",
+				Value:       3,
+				Destination: &faults.ReorgDepth,
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			faults.Methods = methods.Value()
+			proxy := rpcproxy.New(target, faults, seed, log.With("component", "rpc-proxy"))
+
+			server := &http.Server{Addr: addr, Handler: proxy}
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				server.Shutdown(shutdownCtx)
+			}()
+
+			log.Info("serving rpc proxy", "addr", addr, "target", target, "seed", seed)
+			err := server.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("failed to serve rpc proxy: %w", err)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/security"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// securityStartBlocks starts a fresh database about a day of L1 blocks back
+const securityStartBlocks = 24 * 60 * 60 / 12
+
+// securityMonitor returns the security monitor configured by the security
+// flags, or nil when it is disabled. It watches the bridge and every other
+// contract whose address is given.
+func (cfg *config) securityMonitor(db *sql.DB, l1Client *tracing.EthClient, bus *events.Bus, log *slog.Logger) (*security.Monitor, error) {
+	if cfg.securityInterval <= 0 {
+		return nil, nil
+	}
+
+	contracts := []security.Contract{{Name: "L1StandardBridge", Address: common.HexToAddress(cfg.l1BridgeAddress)}}
+	for _, c := range []struct {
+		name, flag, address string
+	}{
+		{"L1CrossDomainMessenger", "--l1-messenger-address", cfg.l1MessengerAddr},
+		{"OptimismPortal", "--l1-portal-address", cfg.l1PortalAddress},
+		{"SystemConfig", "--l1-system-config-address", cfg.l1SystemConfigAddr},
+		{"SuperchainConfig", "--l1-superchain-config-address", cfg.l1SuperchainConfig},
+		{"DisputeGameFactory", "--l1-dispute-game-factory-address", cfg.l1DisputeGameFactory},
+	} {
+		if c.address == "" {
+			continue
+		}
+		if !common.IsHexAddress(c.address) {
+			return nil, fmt.Errorf("invalid %s: %q", c.flag, c.address)
+		}
+		contracts = append(contracts, security.Contract{Name: c.name, Address: common.HexToAddress(c.address)})
+	}
+
+	// The portal checks the SuperchainConfig, so its paused() is the one that
+	// stops withdrawals of this chain
+	var pausable common.Address
+	switch {
+	case cfg.l1PortalAddress != "":
+		pausable = common.HexToAddress(cfg.l1PortalAddress)
+	case cfg.l1SuperchainConfig != "":
+		pausable = common.HexToAddress(cfg.l1SuperchainConfig)
+	}
+
+	return security.New(db, l1Client, security.Config{
+		Contracts:   contracts,
+		Pausable:    pausable,
+		BatchSize:   cfg.backfillingBatchSize,
+		StartBlocks: securityStartBlocks,
+	}, bus, log.With("component", "security")), nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/Golem-Base/bridgette/pkg/events"
+	"github.com/Golem-Base/bridgette/pkg/solvency"
+	"github.com/Golem-Base/bridgette/pkg/tracing"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ethToWei parses an ETH amount with at most 18 decimals
+func ethToWei(s string) (*big.Int, error) {
+	eth, ok := new(big.Rat).SetString(s)
+	if !ok || eth.Sign() < 0 {
+		return nil, fmt.Errorf("%q is not a non-negative ETH amount", s)
+	}
+	wei := eth.Mul(eth, new(big.Rat).SetInt64(params.Ether))
+	if !wei.IsInt() {
+		return nil, fmt.Errorf("%q has more than 18 decimals", s)
+	}
+	return wei.Num(), nil
+}
+
+// solvencyMonitor returns the solvency monitor configured by the solvency
+// flags, or nil when it is disabled
+func (cfg *config) solvencyMonitor(ix *bridgeIndexer, l1Client *tracing.EthClient, bus *events.Bus, log *slog.Logger) (*solvency.Monitor, error) {
+	if cfg.solvencyInterval <= 0 {
+		return nil, nil
+	}
+	if !common.IsHexAddress(cfg.l1PortalAddress) {
+		return nil, fmt.Errorf("--l1-portal-address is required by the solvency monitor")
+	}
+	threshold, err := ethToWei(cfg.solvencyThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --solvency-threshold: %w", err)
+	}
+
+	bridge := common.HexToAddress(cfg.l1BridgeAddress)
+	return solvency.New(ix.db, l1Client, solvency.Config{
+		Bridge:       bridge,
+		Holders:      []common.Address{common.HexToAddress(cfg.l1PortalAddress), bridge},
+		ThresholdWei: threshold,
+		BatchSize:    cfg.backfillingBatchSize,
+		LowPointer:   ix.l1.LowPointer,
+		LastPointer:  ix.l1.LastPointer,
+		StartBlock:   ix.l1.StartBlock,
+	}, bus, log.With("component", "solvency")), nil
+}
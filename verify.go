@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/Golem-Base/bridgette/pkg/indexer"
+	"github.com/Golem-Base/bridgette/pkg/monitor"
+	"github.com/Golem-Base/bridgette/pkg/verify"
+	"github.com/urfave/cli/v2"
+)
+
+// verifyChain returns the verification settings of an indexed chain
+func verifyChain(c *indexer.Chain) verify.Chain {
+	return verify.Chain{
+		Layer:       verify.Layer(c.Name),
+		History:     c.Contracts,
+		LowPointer:  c.LowPointer,
+		LastPointer: c.LastPointer,
+		Client:      c.Source,
+	}
+}
+
+// verifyCommand audits the database against the chain
+func verifyCommand(log *slog.Logger) *cli.Command {
+	cfg := &config{}
+	var chainName, output string
+	var fromBlock, toBlock uint64
+	var samples int
+
+	return &cli.Command{
+		Name:  "verify",
+		Usage: "Verify the stored events against the chain and print a JSON report of missing, extra and inconsistent records",
+		Flags: append(flags(cfg.dbFlags(), cfg.chainFlags()),
+			cfg.backfillingBatchSizeFlag(),
+			&cli.StringFlag{
+				Name:        "chain",
+				Usage:       "Only verify the logs of this chain: l1 or l2 (default: both)",
+				Destination: &chainName,
+			},
+			&cli.Uint64Flag{
+				Name:        "from",
+				Usage:       "The first block to verify, requires --chain",
+				Destination: &fromBlock,
+			},
+			&cli.Uint64Flag{
+				Name:        "to",
+				Usage:       "The last block to verify, requires --chain",
+				Destination: &toBlock,
+			},
+			&cli.IntFlag{
+				Name:        "samples",
+				Usage:       "The number of randomly picked backfilling batches to verify per chain, 0 verifies every indexed block",
+				Destination: &samples,
+			},
+			&cli.StringFlag{
+				Name:        "output",
+				Aliases:     []string{"o"},
+				Usage:       "The file to write the report to, - for stdout",
+				Value:       "-",
+				Destination: &output,
+			},
+		),
+		Action: func(c *cli.Context) error {
+			if (c.IsSet("from") || c.IsSet("to")) && chainName == "" {
+				return fmt.Errorf("--from and --to require --chain")
+			}
+
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, os.Kill)
+			defer stop()
+
+			db, err := cfg.openDB()
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			// Logs are only compared on the chains whose URL is given, the
+			// block pointers and matches are always checked
+			ix, err := newBridgeIndexer(cfg, db, nil, nil, nil, log)
+			if err != nil {
+				return err
+			}
+			if cfg.l1ExecutionURL != "" {
+				client, closeClient, err := cfg.dialL1()
+				if err != nil {
+					return err
+				}
+				defer closeClient()
+				ix.l1.Source = client
+			}
+			if cfg.l2ExecutionURL != "" {
+				client, closeClient, err := cfg.dialL2()
+				if err != nil {
+					return err
+				}
+				defer closeClient()
+				ix.l2.Source = client
+			}
+
+			chains := []*indexer.Chain{ix.l1, ix.l2}
+			if chainName != "" {
+				ch, err := ix.chain(chainName)
+				if err != nil {
+					return err
+				}
+				chains = []*indexer.Chain{ch}
+			}
+
+			opts := verify.Options{BatchSize: cfg.backfillingBatchSize, Samples: samples}
+			if c.IsSet("from") {
+				opts.FromBlock = &fromBlock
+			}
+			if c.IsSet("to") {
+				opts.ToBlock = &toBlock
+			}
+
+			report, err := runVerification(ctx, db, chains, opts)
+			if err != nil {
+				return err
+			}
+
+			out := c.App.Writer
+			if output != "-" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			enc := json.NewEncoder(out)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(report)
+			if err != nil {
+				return fmt.Errorf("failed to write report: %w", err)
+			}
+
+			if !report.OK() {
+				return cli.Exit(fmt.Sprintf("verification found %d problems", report.Problems()), 1)
+			}
+			return nil
+		},
+	}
+}
+
+func runVerification(ctx context.Context, db *sql.DB, chains []*indexer.Chain, opts verify.Options) (*verify.Report, error) {
+	vcs := make([]verify.Chain, 0, len(chains))
+	for _, c := range chains {
+		vcs = append(vcs, verifyChain(c))
+	}
+	report, err := verify.Run(ctx, db, vcs, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify database: %w", err)
+	}
+	return report, nil
+}
+
+// verifyPeriodically verifies a random sample of the indexed blocks of both
+// chains every interval and logs the report when it finds problems. Failed
+// runs are logged and do not stop the indexer.
+func (ix *bridgeIndexer) verifyPeriodically(ctx context.Context, interval time.Duration, samples int) error {
+	log := ix.log.With("component", "verify")
+
+	return monitor.Every(ctx, interval, log, func(ctx context.Context) error {
+		report, err := runVerification(ctx, ix.db, []*indexer.Chain{ix.l1, ix.l2}, verify.Options{
+			BatchSize: ix.backfillingBatchSize,
+			Samples:   samples,
+		})
+		if err != nil {
+			return err
+		}
+
+		if report.OK() {
+			log.Info("verification passed", "duration", report.FinishedAt.Sub(report.StartedAt))
+			return nil
+		}
+		log.Warn("verification found problems",
+			"missing", len(report.Missing),
+			"extra", len(report.Extra),
+			"inconsistent", len(report.Inconsistent),
+			slog.Any("report", report))
+		return nil
+	})
+}